@@ -0,0 +1,99 @@
+package rest
+
+import (
+	"net/http"
+
+	"github.com/sr-codefreak/user-group/db/mongodb/access"
+	"github.com/sr-codefreak/user-group/myerrors"
+)
+
+type rolesBody struct {
+	Roles []string `json:"roles"`
+}
+
+func (s *Server) routeAccess(w http.ResponseWriter, r *http.Request, id string, segments []string) {
+	oid, err := objectID(myerrors.KindUserGroup, id)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	if len(segments) == 0 {
+		if r.Method != http.MethodGet {
+			writeError(w, errMethodNotAllowed)
+			return
+		}
+		role := r.URL.Query().Get("role")
+		if role == "" {
+			writeError(w, myerrors.New(myerrors.InvalidArgument, myerrors.KindAccess, "", errRoleRequired))
+			return
+		}
+		userIds, err := s.backend.Access().ListUsersWithRoleInGroup(r.Context(), oid, role)
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		writeJSON(w, http.StatusOK, userIds)
+		return
+	}
+	if len(segments) > 2 || len(segments) == 2 && segments[1] != "permissions" {
+		writeError(w, errNotFound)
+		return
+	}
+
+	uid, err := objectID(myerrors.KindUser, segments[0])
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	if len(segments) == 2 {
+		if r.Method != http.MethodGet {
+			writeError(w, errMethodNotAllowed)
+			return
+		}
+		permissions, err := s.backend.Access().EffectivePermissions(r.Context(), uid, oid)
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		writeJSON(w, http.StatusOK, permissions)
+		return
+	}
+	store := s.backend.Access()
+	switch r.Method {
+	case http.MethodGet:
+		accesses, err := store.ListRolesForUser(r.Context(), uid)
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		a := access.Access{UserId: uid.Hex(), UserGroupId: oid.Hex(), Roles: []string{}}
+		for _, ac := range accesses {
+			if ac.UserGroupId == oid.Hex() {
+				a = ac
+			}
+		}
+		writeJSON(w, http.StatusOK, a)
+		return
+	case http.MethodPost, http.MethodPut:
+		body := rolesBody{}
+		if err := readJSON(w, r, &body); err != nil {
+			writeError(w, err)
+			return
+		}
+		if r.Method == http.MethodPost {
+			err = store.Grant(r.Context(), uid, oid, body.Roles...)
+		} else {
+			err = store.SetRoles(r.Context(), uid, oid, body.Roles)
+		}
+	case http.MethodDelete:
+		err = store.Revoke(r.Context(), uid, oid, r.URL.Query()["role"]...)
+	default:
+		writeError(w, errMethodNotAllowed)
+		return
+	}
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusNoContent, nil)
+}
@@ -0,0 +1,49 @@
+package rest
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/sr-codefreak/user-group/db/mongodb/audit"
+	"github.com/sr-codefreak/user-group/myerrors"
+)
+
+// listAudit returns the audit records selected by the query
+//
+//	kind=, entityId=  the entity, e.g. kind=userGroup&entityId=...
+//	from=, to=        RFC 3339 time range, from inclusive and to exclusive
+//	limit=50          maximum number of records, newest first
+func (s *Server) listAudit(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	q := audit.Query{
+		Kind:     query.Get("kind"),
+		EntityId: query.Get("entityId"),
+	}
+	for param, t := range map[string]*time.Time{"from": &q.From, "to": &q.To} {
+		v := query.Get(param)
+		if v == "" {
+			continue
+		}
+		parsed, err := time.Parse(time.RFC3339Nano, v)
+		if err != nil {
+			writeError(w, myerrors.Invalid(err))
+			return
+		}
+		*t = parsed
+	}
+	if limit := query.Get("limit"); limit != "" {
+		n, err := strconv.Atoi(limit)
+		if err != nil {
+			writeError(w, myerrors.Invalid(err))
+			return
+		}
+		q.Limit = n
+	}
+	records, err := s.backend.Audit().List(r.Context(), q)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, records)
+}
@@ -0,0 +1,90 @@
+package rest
+
+import (
+	"net/http"
+
+	"github.com/sr-codefreak/user-group/authorizer"
+	"github.com/sr-codefreak/user-group/myerrors"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func (s *Server) routeAuthorize(w http.ResponseWriter, r *http.Request, segments []string) {
+	if len(segments) > 1 || len(segments) == 1 && segments[0] != "filter" {
+		writeError(w, errNotFound)
+		return
+	}
+	if r.Method != http.MethodPost {
+		writeError(w, errMethodNotAllowed)
+		return
+	}
+	if len(segments) == 1 {
+		s.filterAuthorized(w, r)
+		return
+	}
+	s.authorize(w, r)
+}
+
+func (s *Server) authorize(w http.ResponseWriter, r *http.Request) {
+	body := struct {
+		UserId     string `json:"userId"`
+		Permission string `json:"permission"`
+		GroupId    string `json:"groupId"`
+	}{}
+	if err := readJSON(w, r, &body); err != nil {
+		writeError(w, err)
+		return
+	}
+	uid, err := objectID(myerrors.KindUser, body.UserId)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	oid, err := objectID(myerrors.KindUserGroup, body.GroupId)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	ok, reason, err := authorizer.New(s.backend).Can(r.Context(), uid, body.Permission, oid)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, struct {
+		Allowed bool              `json:"allowed"`
+		Reason  authorizer.Reason `json:"reason"`
+	}{ok, reason})
+}
+
+func (s *Server) filterAuthorized(w http.ResponseWriter, r *http.Request) {
+	body := struct {
+		UserId     string   `json:"userId"`
+		Permission string   `json:"permission"`
+		GroupIds   []string `json:"groupIds"`
+	}{}
+	if err := readJSON(w, r, &body); err != nil {
+		writeError(w, err)
+		return
+	}
+	uid, err := objectID(myerrors.KindUser, body.UserId)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	oids := make([]primitive.ObjectID, 0, len(body.GroupIds))
+	for _, id := range body.GroupIds {
+		oid, err := objectID(myerrors.KindUserGroup, id)
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		oids = append(oids, oid)
+	}
+	allowed, err := authorizer.New(s.backend).Filter(r.Context(), uid, body.Permission, oids)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, struct {
+		GroupIds []primitive.ObjectID `json:"groupIds"`
+	}{allowed})
+}
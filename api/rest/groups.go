@@ -0,0 +1,308 @@
+package rest
+
+import (
+	"net/http"
+
+	"github.com/sr-codefreak/user-group/db/dynamic"
+	"github.com/sr-codefreak/user-group/db/mongodb/usergroup"
+	"github.com/sr-codefreak/user-group/myerrors"
+)
+
+// groupBody is the body creating a user group, the members and children are
+// added afterwards
+type groupBody struct {
+	Name     string         `json:"name"`
+	MetaData map[string]any `json:"metaData"`
+	Rule     string         `json:"rule"`
+}
+
+func (s *Server) routeGroups(w http.ResponseWriter, r *http.Request, segments []string) {
+	switch {
+	case len(segments) == 0:
+		switch r.Method {
+		case http.MethodGet:
+			s.listGroups(w, r)
+		case http.MethodPost:
+			s.createGroup(w, r)
+		default:
+			writeError(w, errMethodNotAllowed)
+		}
+	case len(segments) == 1:
+		switch r.Method {
+		case http.MethodGet:
+			s.getGroup(w, r, segments[0])
+		case http.MethodPatch:
+			s.renameGroup(w, r, segments[0])
+		case http.MethodDelete:
+			s.deleteGroup(w, r, segments[0])
+		default:
+			writeError(w, errMethodNotAllowed)
+		}
+	case segments[1] == "members":
+		s.routeMembers(w, r, segments[0], segments[2:])
+	case segments[1] == "access":
+		s.routeAccess(w, r, segments[0], segments[2:])
+	case segments[1] == "rule" && len(segments) == 2:
+		if r.Method != http.MethodPut {
+			writeError(w, errMethodNotAllowed)
+			return
+		}
+		s.setRule(w, r, segments[0])
+	case segments[1] == "children" && len(segments) == 3:
+		switch r.Method {
+		case http.MethodPut:
+			s.addChild(w, r, segments[0], segments[2])
+		case http.MethodDelete:
+			s.removeChild(w, r, segments[0], segments[2])
+		default:
+			writeError(w, errMethodNotAllowed)
+		}
+	default:
+		writeError(w, errNotFound)
+	}
+}
+
+func (s *Server) routeMembers(w http.ResponseWriter, r *http.Request, id string, segments []string) {
+	switch {
+	case len(segments) == 0:
+		switch r.Method {
+		case http.MethodGet:
+			s.listMembers(w, r, id)
+		case http.MethodPost:
+			body := struct {
+				UserId string `json:"userId"`
+			}{}
+			if err := readJSON(w, r, &body); err != nil {
+				writeError(w, err)
+				return
+			}
+			s.addMember(w, r, id, body.UserId)
+		default:
+			writeError(w, errMethodNotAllowed)
+		}
+	case len(segments) == 1:
+		switch r.Method {
+		case http.MethodPut:
+			s.addMember(w, r, id, segments[0])
+		case http.MethodDelete:
+			s.removeMember(w, r, id, segments[0])
+		default:
+			writeError(w, errMethodNotAllowed)
+		}
+	default:
+		writeError(w, errNotFound)
+	}
+}
+
+func (s *Server) listGroups(w http.ResponseWriter, r *http.Request) {
+	opts, err := listOptions(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	groups, next, err := s.backend.UserGroups().List(r.Context(), opts)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, struct {
+		Groups        []usergroup.UserGroup `json:"groups"`
+		NextPageToken string                `json:"nextPageToken,omitempty"`
+	}{groups, next})
+}
+
+func (s *Server) createGroup(w http.ResponseWriter, r *http.Request) {
+	body := groupBody{}
+	if err := readJSON(w, r, &body); err != nil {
+		writeError(w, err)
+		return
+	}
+	ug := &usergroup.UserGroup{Name: body.Name, MetaData: body.MetaData, Rule: body.Rule}
+	if err := s.backend.UserGroups().Create(r.Context(), ug); err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, ug)
+}
+
+func (s *Server) getGroup(w http.ResponseWriter, r *http.Request, id string) {
+	ug, err := s.backend.UserGroups().GetById(r.Context(), id)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, ug)
+}
+
+func (s *Server) renameGroup(w http.ResponseWriter, r *http.Request, id string) {
+	oid, err := objectID(myerrors.KindUserGroup, id)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	body := struct {
+		Name string `json:"name"`
+	}{}
+	if err := readJSON(w, r, &body); err != nil {
+		writeError(w, err)
+		return
+	}
+	if err := s.backend.UserGroups().UpdateName(r.Context(), oid, body.Name); err != nil {
+		writeError(w, err)
+		return
+	}
+	s.getGroup(w, r, id)
+}
+
+func (s *Server) deleteGroup(w http.ResponseWriter, r *http.Request, id string) {
+	oid, err := objectID(myerrors.KindUserGroup, id)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	if err := s.backend.UserGroups().DeleteById(r.Context(), oid); err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusNoContent, nil)
+}
+
+func (s *Server) listMembers(w http.ResponseWriter, r *http.Request, id string) {
+	if r.URL.Query().Get("transitive") == "true" {
+		s.resolveMembers(w, r, id)
+		return
+	}
+	ug, err := s.backend.UserGroups().GetById(r.Context(), id)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	members := ug.Users
+	if members == nil {
+		members = []usergroup.UserRef{}
+	}
+	writeJSON(w, http.StatusOK, members)
+}
+
+func (s *Server) addMember(w http.ResponseWriter, r *http.Request, id string, userId string) {
+	oid, err := objectID(myerrors.KindUserGroup, id)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	uid, err := objectID(myerrors.KindUser, userId)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	if err := s.backend.UserGroups().AddUser(r.Context(), oid, uid); err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusNoContent, nil)
+}
+
+func (s *Server) removeMember(w http.ResponseWriter, r *http.Request, id string, userId string) {
+	oid, err := objectID(myerrors.KindUserGroup, id)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	uid, err := objectID(myerrors.KindUser, userId)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	if err := s.backend.UserGroups().RemoveUser(r.Context(), oid, uid); err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusNoContent, nil)
+}
+
+func (s *Server) resolveMembers(w http.ResponseWriter, r *http.Request, id string) {
+	oid, err := objectID(myerrors.KindUserGroup, id)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	members, err := s.backend.UserGroups().ResolveMembers(r.Context(), oid)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, members)
+}
+
+func (s *Server) addChild(w http.ResponseWriter, r *http.Request, id string, childId string) {
+	oid, err := objectID(myerrors.KindUserGroup, id)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	cid, err := objectID(myerrors.KindUserGroup, childId)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	if err := s.backend.UserGroups().AddChild(r.Context(), oid, cid); err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusNoContent, nil)
+}
+
+func (s *Server) removeChild(w http.ResponseWriter, r *http.Request, id string, childId string) {
+	oid, err := objectID(myerrors.KindUserGroup, id)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	cid, err := objectID(myerrors.KindUserGroup, childId)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	if err := s.backend.UserGroups().RemoveChild(r.Context(), oid, cid); err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusNoContent, nil)
+}
+
+func (s *Server) setRule(w http.ResponseWriter, r *http.Request, id string) {
+	oid, err := objectID(myerrors.KindUserGroup, id)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	body := struct {
+		Rule string `json:"rule"`
+	}{}
+	if err := readJSON(w, r, &body); err != nil {
+		writeError(w, err)
+		return
+	}
+	if err := s.backend.UserGroups().SetRule(r.Context(), oid, body.Rule); err != nil {
+		writeError(w, err)
+		return
+	}
+	s.getGroup(w, r, id)
+}
+
+func (s *Server) previewRule(w http.ResponseWriter, r *http.Request) {
+	body := struct {
+		Rule  string `json:"rule"`
+		Limit int    `json:"limit"`
+	}{}
+	if err := readJSON(w, r, &body); err != nil {
+		writeError(w, err)
+		return
+	}
+	users, err := dynamic.Preview(r.Context(), s.backend.Users(), body.Rule, body.Limit)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, users)
+}
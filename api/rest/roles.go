@@ -0,0 +1,93 @@
+package rest
+
+import (
+	"net/http"
+
+	"github.com/sr-codefreak/user-group/db/mongodb/access"
+	"github.com/sr-codefreak/user-group/myerrors"
+)
+
+func (s *Server) routeRoles(w http.ResponseWriter, r *http.Request, segments []string) {
+	switch {
+	case len(segments) == 0:
+		switch r.Method {
+		case http.MethodGet:
+			s.listRoles(w, r)
+		case http.MethodPost:
+			s.createRole(w, r)
+		default:
+			writeError(w, errMethodNotAllowed)
+		}
+	case len(segments) == 1:
+		switch r.Method {
+		case http.MethodGet:
+			s.getRole(w, r, segments[0])
+		case http.MethodPut:
+			s.updateRole(w, r, segments[0])
+		case http.MethodDelete:
+			s.deleteRole(w, r, segments[0])
+		default:
+			writeError(w, errMethodNotAllowed)
+		}
+	default:
+		writeError(w, errNotFound)
+	}
+}
+
+func (s *Server) listRoles(w http.ResponseWriter, r *http.Request) {
+	roles, err := s.backend.Roles().List(r.Context())
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, roles)
+}
+
+func (s *Server) createRole(w http.ResponseWriter, r *http.Request) {
+	role := &access.Role{}
+	if err := readJSON(w, r, role); err != nil {
+		writeError(w, err)
+		return
+	}
+	if err := s.backend.Roles().Create(r.Context(), role); err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, role)
+}
+
+func (s *Server) getRole(w http.ResponseWriter, r *http.Request, name string) {
+	role, err := s.backend.Roles().Get(r.Context(), name)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, role)
+}
+
+// updateRole replaces the permissions and inherited roles, the name is taken from the path
+func (s *Server) updateRole(w http.ResponseWriter, r *http.Request, name string) {
+	role := &access.Role{}
+	if err := readJSON(w, r, role); err != nil {
+		writeError(w, err)
+		return
+	}
+	if role.Name != "" && role.Name != name {
+		writeError(w, myerrors.New(myerrors.InvalidArgument, myerrors.KindRole, name, errRoleRenamed))
+		return
+	}
+	role.Name = name
+	if err := s.backend.Roles().Update(r.Context(), role); err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, role)
+}
+
+func (s *Server) deleteRole(w http.ResponseWriter, r *http.Request, name string) {
+	if err := s.backend.Roles().Delete(r.Context(), name); err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusNoContent, nil)
+}
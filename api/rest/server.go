@@ -0,0 +1,276 @@
+// Package rest serves the users, user groups, access, roles, audit log and webhook
+// subscriptions of a db.Backend as a versioned JSON API over net/http.
+//
+//	GET    /v1/users                              list users, see listOptions
+//	POST   /v1/users                              create a user
+//	GET    /v1/users/{id}                         get a user
+//	PATCH  /v1/users/{id}                         update name, email and phone, replace metaData when given
+//	DELETE /v1/users/{id}                         delete a user
+//	GET    /v1/users/{id}/access                  roles of the user per group
+//	GET    /v1/users/{id}/groups                  groups of the user, including the parents of its groups
+//	GET    /v1/groups                             list user groups, see listOptions
+//	POST   /v1/groups                             create a user group
+//	GET    /v1/groups/{id}                        get a user group
+//	PATCH  /v1/groups/{id}                        rename a user group
+//	PUT    /v1/groups/{id}/rule                   set the membership rule {"rule": ...}, "" for a static group
+//	DELETE /v1/groups/{id}                        delete a user group
+//	GET    /v1/groups/{id}/members?transitive=    members of the user group, with those of its subgroups when transitive=true
+//	POST   /v1/groups/{id}/members                add the member {"userId": ...}
+//	PUT    /v1/groups/{id}/members/{userId}       add the member
+//	DELETE /v1/groups/{id}/members/{userId}       remove the member
+//	PUT    /v1/groups/{id}/children/{childId}     make childId a subgroup
+//	DELETE /v1/groups/{id}/children/{childId}     remove the subgroup
+//	POST   /v1/rules/preview                      users matching {"rule": ..., "limit": ...}
+//	GET    /v1/groups/{id}/access?role=           users having the role
+//	GET    /v1/groups/{id}/access/{userId}        roles of the user
+//	POST   /v1/groups/{id}/access/{userId}        grant {"roles": [...]}
+//	PUT    /v1/groups/{id}/access/{userId}        replace the roles with {"roles": [...]}
+//	DELETE /v1/groups/{id}/access/{userId}?role=  revoke the roles, all without role
+//	GET    /v1/groups/{id}/access/{userId}/permissions  permissions of the user including inherited ones
+//	GET    /v1/roles                              list roles
+//	POST   /v1/roles                              define a role {"name", "permissions", "inherits"}
+//	GET    /v1/roles/{name}                       get a role
+//	PUT    /v1/roles/{name}                       replace permissions and inherited roles
+//	DELETE /v1/roles/{name}                       delete a role and revoke it from all users
+//	POST   /v1/authorize                          decide {"userId", "permission", "groupId"}, see package authorizer
+//	POST   /v1/authorize/filter                   groups of {"userId", "permission", "groupIds"} the user holds the permission in
+//	GET    /v1/audit?kind=&entityId=&from=&to=    audit records, see listAudit
+//	GET    /v1/webhooks                           list webhook subscriptions
+//	POST   /v1/webhooks                           subscribe {"url", "userGroupId", "types", "secret"}, see package webhooks
+//	GET    /v1/webhooks/{id}                      get a subscription, without its secret
+//	PUT    /v1/webhooks/{id}                      replace url, userGroupId, types, disabled and the secret when given
+//	DELETE /v1/webhooks/{id}                      delete a subscription and its deliveries
+//	GET    /v1/webhooks/{id}/deliveries?status=   delivery history, dead letters with status=dead
+//
+// The X-Actor header names the author of the changes made by the request in
+// the audit log, see package audited. The X-Tenant header names the tenant
+// whose data the request is on, which selects the database of the mongo
+// backend, see mongodb.Naming. Requests naming a tenant the backend does not
+// serve fail with 400.
+//
+// Errors are returned as {"error": {"code", "message", "kind", "id"}} with
+// the status derived from the myerrors code.
+package rest
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/sr-codefreak/user-group/db"
+	"github.com/sr-codefreak/user-group/db/mongodb"
+	"github.com/sr-codefreak/user-group/db/mongodb/audit"
+	"github.com/sr-codefreak/user-group/myerrors"
+	"github.com/sr-codefreak/user-group/utils/logger"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+var log = logger.GetLogger()
+
+// maxBodyBytes limits the size of request bodies
+const maxBodyBytes = 1 << 20
+
+// ActorHeader is the request header naming the actor recorded in the audit log
+const ActorHeader = "X-Actor"
+
+// TenantHeader is the request header naming the tenant, see mongodb.WithTenant
+const TenantHeader = "X-Tenant"
+
+// Server is the http.Handler of the API
+type Server struct {
+	backend db.Backend
+}
+
+// NewServer returns the API server on backend
+func NewServer(backend db.Backend) *Server {
+	return &Server{backend: backend}
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	path, ok := strings.CutPrefix(r.URL.Path, "/v1/")
+	if !ok {
+		writeError(w, errNotFound)
+		return
+	}
+	if actor := r.Header.Get(ActorHeader); actor != "" {
+		r = r.WithContext(audit.WithActor(r.Context(), actor))
+	}
+	if tenant := r.Header.Get(TenantHeader); tenant != "" {
+		if err := db.CheckTenant(s.backend, tenant); err != nil {
+			writeError(w, err)
+			return
+		}
+		r = r.WithContext(mongodb.WithTenant(r.Context(), tenant))
+	}
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	switch segments[0] {
+	case "users":
+		s.routeUsers(w, r, segments[1:])
+	case "groups":
+		s.routeGroups(w, r, segments[1:])
+	case "roles":
+		s.routeRoles(w, r, segments[1:])
+	case "authorize":
+		s.routeAuthorize(w, r, segments[1:])
+	case "webhooks":
+		s.routeWebhooks(w, r, segments[1:])
+	case "audit":
+		if len(segments) != 1 {
+			writeError(w, errNotFound)
+			return
+		}
+		if r.Method != http.MethodGet {
+			writeError(w, errMethodNotAllowed)
+			return
+		}
+		s.listAudit(w, r)
+	case "rules":
+		if len(segments) != 2 || segments[1] != "preview" {
+			writeError(w, errNotFound)
+			return
+		}
+		if r.Method != http.MethodPost {
+			writeError(w, errMethodNotAllowed)
+			return
+		}
+		s.previewRule(w, r)
+	default:
+		writeError(w, errNotFound)
+	}
+}
+
+var (
+	errNotFound         = myerrors.New(myerrors.NotFound, "", "", errors.New("no such route"))
+	errMethodNotAllowed = errors.New("method not allowed")
+)
+
+type errorBody struct {
+	Error errorDetail `json:"error"`
+}
+
+type errorDetail struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+	Kind    string `json:"kind,omitempty"`
+	ID      string `json:"id,omitempty"`
+}
+
+var codeNames = map[myerrors.Code]string{
+	myerrors.Unknown:         "internal",
+	myerrors.NotFound:        "not_found",
+	myerrors.AlreadyExists:   "already_exists",
+	myerrors.InvalidArgument: "invalid_argument",
+	myerrors.Unavailable:     "unavailable",
+	myerrors.Conflict:        "conflict",
+}
+
+var codeStatus = map[myerrors.Code]int{
+	myerrors.Unknown:         http.StatusInternalServerError,
+	myerrors.NotFound:        http.StatusNotFound,
+	myerrors.AlreadyExists:   http.StatusConflict,
+	myerrors.InvalidArgument: http.StatusBadRequest,
+	myerrors.Unavailable:     http.StatusServiceUnavailable,
+	myerrors.Conflict:        http.StatusConflict,
+}
+
+// writeError writes err as JSON error body with the status of its code
+func writeError(w http.ResponseWriter, err error) {
+	if errors.Is(err, errMethodNotAllowed) {
+		writeJSON(w, http.StatusMethodNotAllowed, errorBody{Error: errorDetail{
+			Code:    "method_not_allowed",
+			Message: err.Error(),
+		}})
+		return
+	}
+	detail := errorDetail{Message: err.Error()}
+	code := myerrors.CodeOf(err)
+	var e *myerrors.Error
+	if errors.As(err, &e) {
+		detail.Kind = string(e.Kind)
+		detail.ID = e.ID
+	}
+	if code == myerrors.Unknown {
+		log.Errorf("rest: %s", err)
+		detail.Message = "internal error"
+	}
+	detail.Code = codeNames[code]
+	writeJSON(w, codeStatus[code], errorBody{Error: detail})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if v == nil {
+		return
+	}
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		log.Warnf("rest: writing response: %s", err)
+	}
+}
+
+// readJSON decodes the request body into v
+func readJSON(w http.ResponseWriter, r *http.Request, v any) error {
+	dec := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxBodyBytes))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(v); err != nil {
+		return myerrors.Invalid(err)
+	}
+	return nil
+}
+
+// objectID parses a path segment as id of kind
+func objectID(kind myerrors.Kind, id string) (primitive.ObjectID, error) {
+	oid, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return oid, myerrors.New(myerrors.InvalidArgument, kind, id, err)
+	}
+	return oid, nil
+}
+
+var (
+	errRoleRequired = errors.New("query parameter role is required")
+	errRoleRenamed  = errors.New("the name of a role cannot be changed")
+)
+
+// listOptions reads the options of a list request from the query:
+//
+//	pageSize=50            number of entries per page
+//	pageToken=...          nextPageToken of the previous page
+//	sort=name,-email       sort keys, descending when prefixed with -
+//	name=, email=, phone=  prefix of the value
+//	metaData.<key>=        string value of the metaData key
+func listOptions(r *http.Request) (mongodb.ListOptions, error) {
+	query := r.URL.Query()
+	opts := mongodb.ListOptions{
+		PageToken: query.Get("pageToken"),
+		Filter: mongodb.ListFilter{
+			Name:  query.Get("name"),
+			Email: query.Get("email"),
+			Phone: query.Get("phone"),
+		},
+	}
+	if size := query.Get("pageSize"); size != "" {
+		n, err := strconv.Atoi(size)
+		if err != nil {
+			return opts, myerrors.Invalid(err)
+		}
+		opts.PageSize = n
+	}
+	if sort := query.Get("sort"); sort != "" {
+		for _, key := range strings.Split(sort, ",") {
+			key, desc := strings.CutPrefix(key, "-")
+			opts.Sort = append(opts.Sort, mongodb.SortField{Key: key, Desc: desc})
+		}
+	}
+	for param, values := range query {
+		if key, ok := strings.CutPrefix(param, "metaData."); ok {
+			if opts.Filter.MetaData == nil {
+				opts.Filter.MetaData = map[string]any{}
+			}
+			opts.Filter.MetaData[key] = values[0]
+		}
+	}
+	return opts, nil
+}
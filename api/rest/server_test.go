@@ -0,0 +1,202 @@
+package rest_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/sr-codefreak/user-group/api/rest"
+	"github.com/sr-codefreak/user-group/db"
+	"github.com/sr-codefreak/user-group/db/memory"
+	"github.com/sr-codefreak/user-group/db/mongodb/user"
+	"github.com/sr-codefreak/user-group/myerrors"
+)
+
+type errorBody struct {
+	Error struct {
+		Code    string `json:"code"`
+		Message string `json:"message"`
+		Kind    string `json:"kind"`
+		ID      string `json:"id"`
+	} `json:"error"`
+}
+
+// call sends the request to h and decodes the response body into v unless v is nil
+func call(t *testing.T, h http.Handler, method string, path string, body string, v any, header ...string) int {
+	t.Helper()
+	r := httptest.NewRequest(method, path, strings.NewReader(body))
+	for i := 0; i+1 < len(header); i += 2 {
+		r.Header.Set(header[i], header[i+1])
+	}
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	if v != nil {
+		if ct := w.Header().Get("Content-Type"); ct != "application/json" {
+			t.Errorf("%s %s: content type %q", method, path, ct)
+		}
+		if err := json.Unmarshal(w.Body.Bytes(), v); err != nil {
+			t.Fatalf("%s %s: %s: %s", method, path, err, w.Body)
+		}
+	}
+	return w.Code
+}
+
+func TestRoutes(t *testing.T) {
+	h := rest.NewServer(memory.New())
+
+	ann := struct {
+		ID   string `json:"_id"`
+		Name string `json:"name"`
+	}{}
+	if status := call(t, h, "POST", "/v1/users", `{"name": "ann", "email": "ann@example.com"}`, &ann); status != http.StatusCreated || ann.Name != "ann" {
+		t.Fatalf("create user: %d %+v", status, ann)
+	}
+	eng := struct {
+		ID   string `json:"_id"`
+		Name string `json:"name"`
+	}{}
+	if status := call(t, h, "POST", "/v1/groups", `{"name": "eng"}`, &eng); status != http.StatusCreated {
+		t.Fatalf("create group: %d", status)
+	}
+	if status := call(t, h, "PATCH", "/v1/groups/"+eng.ID, `{"name": "engineering"}`, &eng); status != http.StatusOK || eng.Name != "engineering" {
+		t.Errorf("rename group: %d %+v", status, eng)
+	}
+	if status := call(t, h, "PUT", "/v1/groups/"+eng.ID+"/members/"+ann.ID, "", nil); status != http.StatusNoContent {
+		t.Errorf("add member: %d", status)
+	}
+	var members []struct {
+		ID   string `json:"_id"`
+		Name string `json:"name"`
+	}
+	if status := call(t, h, "GET", "/v1/groups/"+eng.ID+"/members", "", &members); status != http.StatusOK || len(members) != 1 || members[0].ID != ann.ID {
+		t.Errorf("members: %d %+v", status, members)
+	}
+	if status := call(t, h, "POST", "/v1/roles", `{"name": "editor", "permissions": ["groups:*"]}`, nil); status != http.StatusCreated {
+		t.Errorf("create role: %d", status)
+	}
+	if status := call(t, h, "POST", "/v1/groups/"+eng.ID+"/access/"+ann.ID, `{"roles": ["editor"]}`, nil); status != http.StatusNoContent {
+		t.Errorf("grant: %d", status)
+	}
+	var permissions []string
+	if status := call(t, h, "GET", "/v1/groups/"+eng.ID+"/access/"+ann.ID+"/permissions", "", &permissions); status != http.StatusOK || !reflect.DeepEqual(permissions, []string{"groups:*"}) {
+		t.Errorf("permissions: %d %v", status, permissions)
+	}
+	decision := struct {
+		Allowed bool `json:"allowed"`
+	}{}
+	body := `{"userId": "` + ann.ID + `", "permission": "groups:write", "groupId": "` + eng.ID + `"}`
+	if status := call(t, h, "POST", "/v1/authorize", body, &decision); status != http.StatusOK || !decision.Allowed {
+		t.Errorf("authorize: %d %+v", status, decision)
+	}
+	if status := call(t, h, "DELETE", "/v1/users/"+ann.ID, "", nil); status != http.StatusNoContent {
+		t.Errorf("delete user: %d", status)
+	}
+
+	e := errorBody{}
+	if status := call(t, h, "GET", "/v1/users/"+ann.ID, "", &e); status != http.StatusNotFound {
+		t.Errorf("deleted user: %d", status)
+	}
+	if e.Error.Code != "not_found" || e.Error.Kind != string(myerrors.KindUser) || e.Error.ID != ann.ID {
+		t.Errorf("deleted user: %+v", e.Error)
+	}
+}
+
+func TestRouteErrors(t *testing.T) {
+	h := rest.NewServer(memory.New())
+	if status := call(t, h, "POST", "/v1/roles", `{"name": "viewer"}`, nil); status != http.StatusCreated {
+		t.Fatalf("create role: %d", status)
+	}
+	for _, c := range []struct {
+		method string
+		path   string
+		body   string
+		status int
+		code   string
+		header []string
+	}{
+		{"GET", "/users", "", http.StatusNotFound, "not_found", nil},
+		{"GET", "/v1/nothing", "", http.StatusNotFound, "not_found", nil},
+		{"GET", "/v1/users/1/2/3", "", http.StatusNotFound, "not_found", nil},
+		{"PUT", "/v1/users", "", http.StatusMethodNotAllowed, "method_not_allowed", nil},
+		{"POST", "/v1/audit", "", http.StatusMethodNotAllowed, "method_not_allowed", nil},
+		{"DELETE", "/v1/users/zzz", "", http.StatusBadRequest, "invalid_argument", nil},
+		{"POST", "/v1/users", `{"name": "ann", "age": 3}`, http.StatusBadRequest, "invalid_argument", nil},
+		{"POST", "/v1/users", `{"name":`, http.StatusBadRequest, "invalid_argument", nil},
+		{"POST", "/v1/roles", `{"name": "viewer"}`, http.StatusConflict, "already_exists", nil},
+		{"GET", "/v1/roles/nobody", "", http.StatusNotFound, "not_found", nil},
+		{"GET", "/v1/groups/" + "0123456789abcdef01234567" + "/access", "", http.StatusBadRequest, "invalid_argument", nil},
+		{"GET", "/v1/users", "", http.StatusBadRequest, "invalid_argument", []string{rest.TenantHeader, "acme"}},
+	} {
+		e := errorBody{}
+		status := call(t, h, c.method, c.path, c.body, &e, c.header...)
+		if status != c.status || e.Error.Code != c.code || e.Error.Message == "" {
+			t.Errorf("%s %s: %d %+v, want %d %s", c.method, c.path, status, e.Error, c.status, c.code)
+		}
+	}
+}
+
+// failing is a backend whose users cannot be read
+type failing struct {
+	db.Backend
+	err error
+}
+
+func (b failing) Users() user.UserStore {
+	return failingUsers{UserStore: b.Backend.Users(), err: b.err}
+}
+
+type failingUsers struct {
+	user.UserStore
+	err error
+}
+
+func (s failingUsers) GetById(ctx context.Context, id string) (*user.User, error) {
+	return nil, s.err
+}
+
+func TestErrorStatus(t *testing.T) {
+	for _, c := range []struct {
+		err     error
+		status  int
+		code    string
+		message string
+	}{
+		{myerrors.New(myerrors.NotFound, myerrors.KindUser, "u1", errors.New("gone")), http.StatusNotFound, "not_found", "gone"},
+		{myerrors.New(myerrors.AlreadyExists, myerrors.KindUser, "u1", errors.New("taken")), http.StatusConflict, "already_exists", "taken"},
+		{myerrors.New(myerrors.InvalidArgument, myerrors.KindUser, "u1", errors.New("bad")), http.StatusBadRequest, "invalid_argument", "bad"},
+		{myerrors.New(myerrors.Unavailable, myerrors.KindUser, "u1", errors.New("down")), http.StatusServiceUnavailable, "unavailable", "down"},
+		{myerrors.New(myerrors.Conflict, myerrors.KindUser, "u1", errors.New("changed")), http.StatusConflict, "conflict", "changed"},
+		// the message of unknown errors is not shown to the client
+		{errors.New("secret detail"), http.StatusInternalServerError, "internal", "internal error"},
+	} {
+		h := rest.NewServer(failing{Backend: memory.New(), err: c.err})
+		e := errorBody{}
+		status := call(t, h, "GET", "/v1/users/u1", "", &e)
+		if status != c.status || e.Error.Code != c.code || !strings.Contains(e.Error.Message, c.message) {
+			t.Errorf("%v: %d %+v, want %d %s", c.err, status, e.Error, c.status, c.code)
+		}
+		if c.code != "internal" && (e.Error.Kind != string(myerrors.KindUser) || e.Error.ID != "u1") {
+			t.Errorf("%v: kind %q id %q", c.err, e.Error.Kind, e.Error.ID)
+		}
+	}
+}
+
+func TestBodyLimit(t *testing.T) {
+	h := rest.NewServer(memory.New())
+	padding := strings.Repeat("x", 1<<20)
+
+	e := errorBody{}
+	status := call(t, h, "POST", "/v1/users", `{"name": "ann", "metaData": {"note": "`+padding+`"}}`, &e)
+	if status != http.StatusBadRequest || e.Error.Code != "invalid_argument" || !strings.Contains(e.Error.Message, "too large") {
+		t.Errorf("large body: %d %+v", status, e.Error)
+	}
+	status = call(t, h, "POST", "/v1/users", `{"name": "ann", "metaData": {"note": "`+padding[:1<<19]+`"}}`, nil)
+	if status != http.StatusCreated {
+		t.Errorf("body within the limit: %d", status)
+	}
+}
@@ -0,0 +1,156 @@
+package rest
+
+import (
+	"net/http"
+
+	"github.com/sr-codefreak/user-group/db/mongodb/user"
+	"github.com/sr-codefreak/user-group/myerrors"
+)
+
+// userBody is the body creating a user, the memberships are changed through
+// the members of the user groups
+type userBody struct {
+	Name     string         `json:"name"`
+	Email    string         `json:"email"`
+	Phone    string         `json:"phone"`
+	MetaData map[string]any `json:"metaData"`
+}
+
+func (s *Server) routeUsers(w http.ResponseWriter, r *http.Request, segments []string) {
+	switch {
+	case len(segments) == 0:
+		switch r.Method {
+		case http.MethodGet:
+			s.listUsers(w, r)
+		case http.MethodPost:
+			s.createUser(w, r)
+		default:
+			writeError(w, errMethodNotAllowed)
+		}
+	case len(segments) == 1:
+		switch r.Method {
+		case http.MethodGet:
+			s.getUser(w, r, segments[0])
+		case http.MethodPatch:
+			s.updateUser(w, r, segments[0])
+		case http.MethodDelete:
+			s.deleteUser(w, r, segments[0])
+		default:
+			writeError(w, errMethodNotAllowed)
+		}
+	case len(segments) == 2 && segments[1] == "access":
+		if r.Method != http.MethodGet {
+			writeError(w, errMethodNotAllowed)
+			return
+		}
+		s.listUserAccess(w, r, segments[0])
+	case len(segments) == 2 && segments[1] == "groups":
+		if r.Method != http.MethodGet {
+			writeError(w, errMethodNotAllowed)
+			return
+		}
+		s.listUserGroups(w, r, segments[0])
+	default:
+		writeError(w, errNotFound)
+	}
+}
+
+func (s *Server) listUsers(w http.ResponseWriter, r *http.Request) {
+	opts, err := listOptions(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	users, next, err := s.backend.Users().List(r.Context(), opts)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, struct {
+		Users         []user.User `json:"users"`
+		NextPageToken string      `json:"nextPageToken,omitempty"`
+	}{users, next})
+}
+
+func (s *Server) createUser(w http.ResponseWriter, r *http.Request) {
+	body := userBody{}
+	if err := readJSON(w, r, &body); err != nil {
+		writeError(w, err)
+		return
+	}
+	u := &user.User{Name: body.Name, Email: body.Email, Phone: body.Phone, MetaData: body.MetaData}
+	if err := s.backend.Users().Create(r.Context(), u); err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, u)
+}
+
+func (s *Server) getUser(w http.ResponseWriter, r *http.Request, id string) {
+	u, err := s.backend.Users().GetById(r.Context(), id)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, u)
+}
+
+func (s *Server) updateUser(w http.ResponseWriter, r *http.Request, id string) {
+	oid, err := objectID(myerrors.KindUser, id)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	u := &user.User{}
+	if err := readJSON(w, r, u); err != nil {
+		writeError(w, err)
+		return
+	}
+	u.ID = oid
+	if err := s.backend.Users().Update(r.Context(), u); err != nil {
+		writeError(w, err)
+		return
+	}
+	s.getUser(w, r, id)
+}
+
+func (s *Server) deleteUser(w http.ResponseWriter, r *http.Request, id string) {
+	oid, err := objectID(myerrors.KindUser, id)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	if err := s.backend.Users().Delete(r.Context(), oid); err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusNoContent, nil)
+}
+
+func (s *Server) listUserAccess(w http.ResponseWriter, r *http.Request, id string) {
+	oid, err := objectID(myerrors.KindUser, id)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	accesses, err := s.backend.Access().ListRolesForUser(r.Context(), oid)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, accesses)
+}
+
+func (s *Server) listUserGroups(w http.ResponseWriter, r *http.Request, id string) {
+	oid, err := objectID(myerrors.KindUser, id)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	groups, err := s.backend.UserGroups().ResolveGroupsForUser(r.Context(), oid)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, groups)
+}
@@ -0,0 +1,159 @@
+package rest
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/sr-codefreak/user-group/db/mongodb/webhook"
+	"github.com/sr-codefreak/user-group/myerrors"
+	"github.com/sr-codefreak/user-group/webhooks"
+)
+
+func (s *Server) routeWebhooks(w http.ResponseWriter, r *http.Request, segments []string) {
+	switch {
+	case len(segments) == 0:
+		switch r.Method {
+		case http.MethodGet:
+			s.listWebhooks(w, r)
+		case http.MethodPost:
+			s.createWebhook(w, r)
+		default:
+			writeError(w, errMethodNotAllowed)
+		}
+	case len(segments) == 1:
+		switch r.Method {
+		case http.MethodGet:
+			s.getWebhook(w, r, segments[0])
+		case http.MethodPut:
+			s.updateWebhook(w, r, segments[0])
+		case http.MethodDelete:
+			s.deleteWebhook(w, r, segments[0])
+		default:
+			writeError(w, errMethodNotAllowed)
+		}
+	case len(segments) == 2 && segments[1] == "deliveries":
+		if r.Method != http.MethodGet {
+			writeError(w, errMethodNotAllowed)
+			return
+		}
+		s.listDeliveries(w, r, segments[0])
+	default:
+		writeError(w, errNotFound)
+	}
+}
+
+// redact clears the secret, it is only returned on creation
+func redact(sub *webhook.Subscription) *webhook.Subscription {
+	sub.Secret = ""
+	return sub
+}
+
+func (s *Server) listWebhooks(w http.ResponseWriter, r *http.Request) {
+	subs, err := s.backend.Webhooks().List(r.Context())
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	for i := range subs {
+		redact(&subs[i])
+	}
+	writeJSON(w, http.StatusOK, subs)
+}
+
+// createWebhook subscribes {"url", "userGroupId", "types", "secret"}, a
+// random secret is generated when none is given and returned once
+func (s *Server) createWebhook(w http.ResponseWriter, r *http.Request) {
+	sub := &webhook.Subscription{}
+	if err := readJSON(w, r, sub); err != nil {
+		writeError(w, err)
+		return
+	}
+	if sub.Secret == "" {
+		secret, err := webhooks.NewSecret()
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		sub.Secret = secret
+	}
+	if err := s.backend.Webhooks().Create(r.Context(), sub); err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, sub)
+}
+
+func (s *Server) getWebhook(w http.ResponseWriter, r *http.Request, id string) {
+	sub, err := s.backend.Webhooks().Get(r.Context(), id)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, redact(sub))
+}
+
+// updateWebhook replaces the url, user group, types and disabled flag, and
+// the secret when one is given
+func (s *Server) updateWebhook(w http.ResponseWriter, r *http.Request, id string) {
+	oid, err := objectID(myerrors.KindWebhook, id)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	sub := &webhook.Subscription{}
+	if err := readJSON(w, r, sub); err != nil {
+		writeError(w, err)
+		return
+	}
+	sub.ID = oid
+	if err := s.backend.Webhooks().Update(r.Context(), sub); err != nil {
+		writeError(w, err)
+		return
+	}
+	updated, err := s.backend.Webhooks().Get(r.Context(), id)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, redact(updated))
+}
+
+func (s *Server) deleteWebhook(w http.ResponseWriter, r *http.Request, id string) {
+	oid, err := objectID(myerrors.KindWebhook, id)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	if err := s.backend.Webhooks().Delete(r.Context(), oid); err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusNoContent, nil)
+}
+
+// listDeliveries returns the deliveries of the subscription, newest first
+//
+//	status=dead  pending, delivered or dead, all when empty
+//	limit=50     maximum number of deliveries
+func (s *Server) listDeliveries(w http.ResponseWriter, r *http.Request, id string) {
+	if _, err := s.backend.Webhooks().Get(r.Context(), id); err != nil {
+		writeError(w, err)
+		return
+	}
+	query := r.URL.Query()
+	q := webhook.DeliveryQuery{SubscriptionId: id, Status: webhook.Status(query.Get("status"))}
+	if limit := query.Get("limit"); limit != "" {
+		n, err := strconv.Atoi(limit)
+		if err != nil {
+			writeError(w, myerrors.Invalid(err))
+			return
+		}
+		q.Limit = n
+	}
+	deliveries, err := s.backend.Webhooks().ListDeliveries(r.Context(), q)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, deliveries)
+}
@@ -0,0 +1,169 @@
+package rpc
+
+import (
+	"context"
+
+	"github.com/sr-codefreak/user-group/api/rpc/pb"
+	"github.com/sr-codefreak/user-group/authorizer"
+	"github.com/sr-codefreak/user-group/db/mongodb/access"
+	"github.com/sr-codefreak/user-group/myerrors"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+type accessService struct {
+	pb.UnimplementedAccessServiceServer
+	*Server
+}
+
+func toAccess(a access.Access) *pb.Access {
+	return &pb.Access{UserId: a.UserId, UserGroupId: a.UserGroupId, Roles: a.Roles}
+}
+
+// accessIDs parses the user and user group id of a request
+func accessIDs(userId string, userGroupId string) (primitive.ObjectID, primitive.ObjectID, error) {
+	uid, err := objectID(myerrors.KindUser, userId)
+	if err != nil {
+		return uid, primitive.NilObjectID, err
+	}
+	oid, err := objectID(myerrors.KindUserGroup, userGroupId)
+	return uid, oid, err
+}
+
+func (s accessService) Grant(ctx context.Context, req *pb.RolesRequest) (*emptypb.Empty, error) {
+	uid, oid, err := accessIDs(req.GetUserId(), req.GetUserGroupId())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	if err := s.backend.Access().Grant(ctx, uid, oid, req.GetRoles()...); err != nil {
+		return nil, toStatus(err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s accessService) Revoke(ctx context.Context, req *pb.RolesRequest) (*emptypb.Empty, error) {
+	uid, oid, err := accessIDs(req.GetUserId(), req.GetUserGroupId())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	if err := s.backend.Access().Revoke(ctx, uid, oid, req.GetRoles()...); err != nil {
+		return nil, toStatus(err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s accessService) SetRoles(ctx context.Context, req *pb.RolesRequest) (*emptypb.Empty, error) {
+	uid, oid, err := accessIDs(req.GetUserId(), req.GetUserGroupId())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	if err := s.backend.Access().SetRoles(ctx, uid, oid, req.GetRoles()); err != nil {
+		return nil, toStatus(err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s accessService) GetAccess(ctx context.Context, req *pb.GetAccessRequest) (*pb.Access, error) {
+	uid, oid, err := accessIDs(req.GetUserId(), req.GetUserGroupId())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	accesses, err := s.backend.Access().ListRolesForUser(ctx, uid)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	a := access.Access{UserId: uid.Hex(), UserGroupId: oid.Hex()}
+	for _, ac := range accesses {
+		if ac.UserGroupId == oid.Hex() {
+			a = ac
+		}
+	}
+	return toAccess(a), nil
+}
+
+func (s accessService) HasRole(ctx context.Context, req *pb.HasRoleRequest) (*pb.HasRoleResponse, error) {
+	uid, oid, err := accessIDs(req.GetUserId(), req.GetUserGroupId())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	if req.GetRole() == "" {
+		return nil, toStatus(myerrors.New(myerrors.InvalidArgument, myerrors.KindAccess, "", errRoleRequired))
+	}
+	ok, err := s.backend.Access().HasRole(ctx, uid, oid, req.GetRole())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &pb.HasRoleResponse{HasRole: ok}, nil
+}
+
+func (s accessService) ListUsersWithRole(req *pb.ListUsersWithRoleRequest, stream pb.AccessService_ListUsersWithRoleServer) error {
+	oid, err := objectID(myerrors.KindUserGroup, req.GetUserGroupId())
+	if err != nil {
+		return toStatus(err)
+	}
+	if req.GetRole() == "" {
+		return toStatus(myerrors.New(myerrors.InvalidArgument, myerrors.KindAccess, "", errRoleRequired))
+	}
+	userIds, err := s.backend.Access().ListUsersWithRoleInGroup(stream.Context(), oid, req.GetRole())
+	if err != nil {
+		return toStatus(err)
+	}
+	for _, userId := range userIds {
+		if err := stream.Send(&pb.ListUsersWithRoleResponse{UserId: userId}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s accessService) EffectivePermissions(ctx context.Context, req *pb.GetAccessRequest) (*pb.EffectivePermissionsResponse, error) {
+	uid, oid, err := accessIDs(req.GetUserId(), req.GetUserGroupId())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	permissions, err := s.backend.Access().EffectivePermissions(ctx, uid, oid)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &pb.EffectivePermissionsResponse{Permissions: permissions}, nil
+}
+
+func (s accessService) Can(ctx context.Context, req *pb.CanRequest) (*pb.CanResponse, error) {
+	uid, oid, err := accessIDs(req.GetUserId(), req.GetUserGroupId())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	ok, reason, err := authorizer.New(s.backend).Can(ctx, uid, req.GetPermission(), oid)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	resp := &pb.CanResponse{Allowed: ok, Trace: reason.Trace}
+	if g := reason.Grant; g != nil {
+		resp.Grant = &pb.Grant{UserGroupId: g.UserGroupId, Role: g.Role, Path: g.Path, Permission: g.Permission}
+	}
+	return resp, nil
+}
+
+func (s accessService) FilterAuthorized(ctx context.Context, req *pb.FilterAuthorizedRequest) (*pb.FilterAuthorizedResponse, error) {
+	uid, err := objectID(myerrors.KindUser, req.GetUserId())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	oids := make([]primitive.ObjectID, 0, len(req.GetUserGroupIds()))
+	for _, id := range req.GetUserGroupIds() {
+		oid, err := objectID(myerrors.KindUserGroup, id)
+		if err != nil {
+			return nil, toStatus(err)
+		}
+		oids = append(oids, oid)
+	}
+	allowed, err := authorizer.New(s.backend).Filter(ctx, uid, req.GetPermission(), oids)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	resp := &pb.FilterAuthorizedResponse{UserGroupIds: make([]string, 0, len(allowed))}
+	for _, oid := range allowed {
+		resp.UserGroupIds = append(resp.UserGroupIds, oid.Hex())
+	}
+	return resp, nil
+}
@@ -0,0 +1,120 @@
+package rpc
+
+import (
+	"context"
+
+	"github.com/sr-codefreak/user-group/api/rpc/pb"
+	"github.com/sr-codefreak/user-group/db"
+	"github.com/sr-codefreak/user-group/db/mongodb"
+	"github.com/sr-codefreak/user-group/db/mongodb/audit"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type auditService struct {
+	pb.UnimplementedAuditServiceServer
+	*Server
+}
+
+func toAuditRecord(r *audit.Record) (*pb.AuditRecord, error) {
+	before, err := toStruct(r.Before)
+	if err != nil {
+		return nil, err
+	}
+	after, err := toStruct(r.After)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.AuditRecord{
+		Id:       r.ID.Hex(),
+		Time:     timestamppb.New(r.Time),
+		Actor:    r.Actor,
+		Action:   r.Action,
+		Kind:     r.Kind,
+		EntityId: r.EntityId,
+		Before:   before,
+		After:    after,
+	}, nil
+}
+
+func (s auditService) ListAudit(req *pb.ListAuditRequest, stream pb.AuditService_ListAuditServer) error {
+	q := audit.Query{
+		Kind:     req.GetKind(),
+		EntityId: req.GetEntityId(),
+		Limit:    int(req.GetLimit()),
+	}
+	if req.GetFrom() != nil {
+		q.From = req.GetFrom().AsTime()
+	}
+	if req.GetTo() != nil {
+		q.To = req.GetTo().AsTime()
+	}
+	records, err := s.backend.Audit().List(stream.Context(), q)
+	if err != nil {
+		return toStatus(err)
+	}
+	for i := range records {
+		r, err := toAuditRecord(&records[i])
+		if err != nil {
+			return toStatus(err)
+		}
+		if err := stream.Send(r); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ActorMetadataKey is the metadata key naming the actor recorded in the audit log
+const ActorMetadataKey = "x-actor"
+
+// TenantMetadataKey is the metadata key naming the tenant, see mongodb.WithTenant
+const TenantMetadataKey = "x-tenant"
+
+// withMetadata returns ctx carrying the actor and the tenant of the incoming
+// metadata, it fails when backend does not serve the tenant
+func withMetadata(ctx context.Context, backend db.Backend) (context.Context, error) {
+	if actors := metadata.ValueFromIncomingContext(ctx, ActorMetadataKey); len(actors) > 0 && actors[0] != "" {
+		ctx = audit.WithActor(ctx, actors[0])
+	}
+	if tenants := metadata.ValueFromIncomingContext(ctx, TenantMetadataKey); len(tenants) > 0 && tenants[0] != "" {
+		if err := db.CheckTenant(backend, tenants[0]); err != nil {
+			return nil, toStatus(err)
+		}
+		ctx = mongodb.WithTenant(ctx, tenants[0])
+	}
+	return ctx, nil
+}
+
+type metadataStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s metadataStream) Context() context.Context {
+	return s.ctx
+}
+
+// ServerOptions returns the interceptors passing the actor of the
+// ActorMetadataKey metadata on to the audit log and the tenant of the
+// TenantMetadataKey metadata on to the stores, use them with grpc.NewServer.
+// Calls naming a tenant backend does not serve fail with InvalidArgument.
+func ServerOptions(backend db.Backend) []grpc.ServerOption {
+	return []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+			ctx, err := withMetadata(ctx, backend)
+			if err != nil {
+				return nil, err
+			}
+			return handler(ctx, req)
+		}),
+		grpc.ChainStreamInterceptor(func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+			ctx, err := withMetadata(ss.Context(), backend)
+			if err != nil {
+				return err
+			}
+			return handler(srv, metadataStream{ServerStream: ss, ctx: ctx})
+		}),
+	}
+}
@@ -0,0 +1,232 @@
+package rpc
+
+import (
+	"context"
+
+	"github.com/sr-codefreak/user-group/api/rpc/pb"
+	"github.com/sr-codefreak/user-group/db/dynamic"
+	"github.com/sr-codefreak/user-group/db/mongodb/usergroup"
+	"github.com/sr-codefreak/user-group/myerrors"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+type userGroupService struct {
+	pb.UnimplementedUserGroupServiceServer
+	*Server
+}
+
+func toUserGroup(ug *usergroup.UserGroup) (*pb.UserGroup, error) {
+	metaData, err := toStruct(ug.MetaData)
+	if err != nil {
+		return nil, err
+	}
+	parentIds := make([]string, 0, len(ug.ParentIds))
+	for _, id := range ug.ParentIds {
+		parentIds = append(parentIds, id.Hex())
+	}
+	return &pb.UserGroup{
+		Id:          ug.ID.Hex(),
+		Name:        ug.Name,
+		MetaData:    metaData,
+		MemberCount: int32(len(ug.Users)),
+		ParentIds:   parentIds,
+		Rule:        ug.Rule,
+	}, nil
+}
+
+func (s userGroupService) ListUserGroups(ctx context.Context, req *pb.ListRequest) (*pb.ListUserGroupsResponse, error) {
+	groups, next, err := s.backend.UserGroups().List(ctx, toListOptions(req))
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	resp := &pb.ListUserGroupsResponse{NextPageToken: next}
+	for i := range groups {
+		pug, err := toUserGroup(&groups[i])
+		if err != nil {
+			return nil, toStatus(err)
+		}
+		resp.UserGroups = append(resp.UserGroups, pug)
+	}
+	return resp, nil
+}
+
+func (s userGroupService) CreateUserGroup(ctx context.Context, req *pb.CreateUserGroupRequest) (*pb.UserGroup, error) {
+	ug := &usergroup.UserGroup{
+		Name:     req.GetUserGroup().GetName(),
+		MetaData: fromStruct(req.GetUserGroup().GetMetaData()),
+		Rule:     req.GetUserGroup().GetRule(),
+	}
+	if err := s.backend.UserGroups().Create(ctx, ug); err != nil {
+		return nil, toStatus(err)
+	}
+	pug, err := toUserGroup(ug)
+	return pug, toStatus(err)
+}
+
+func (s userGroupService) GetUserGroup(ctx context.Context, req *pb.GetUserGroupRequest) (*pb.UserGroup, error) {
+	ug, err := s.backend.UserGroups().GetById(ctx, req.GetId())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	pug, err := toUserGroup(ug)
+	return pug, toStatus(err)
+}
+
+func (s userGroupService) RenameUserGroup(ctx context.Context, req *pb.RenameUserGroupRequest) (*pb.UserGroup, error) {
+	oid, err := objectID(myerrors.KindUserGroup, req.GetId())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	if err := s.backend.UserGroups().UpdateName(ctx, oid, req.GetName()); err != nil {
+		return nil, toStatus(err)
+	}
+	return s.GetUserGroup(ctx, &pb.GetUserGroupRequest{Id: oid.Hex()})
+}
+
+func (s userGroupService) DeleteUserGroup(ctx context.Context, req *pb.DeleteUserGroupRequest) (*emptypb.Empty, error) {
+	oid, err := objectID(myerrors.KindUserGroup, req.GetId())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	if err := s.backend.UserGroups().DeleteById(ctx, oid); err != nil {
+		return nil, toStatus(err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s userGroupService) AddMember(ctx context.Context, req *pb.MemberRequest) (*emptypb.Empty, error) {
+	oid, err := objectID(myerrors.KindUserGroup, req.GetUserGroupId())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	uid, err := objectID(myerrors.KindUser, req.GetUserId())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	if err := s.backend.UserGroups().AddUser(ctx, oid, uid); err != nil {
+		return nil, toStatus(err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s userGroupService) RemoveMember(ctx context.Context, req *pb.MemberRequest) (*emptypb.Empty, error) {
+	oid, err := objectID(myerrors.KindUserGroup, req.GetUserGroupId())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	uid, err := objectID(myerrors.KindUser, req.GetUserId())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	if err := s.backend.UserGroups().RemoveUser(ctx, oid, uid); err != nil {
+		return nil, toStatus(err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s userGroupService) ListMembers(req *pb.ListMembersRequest, stream pb.UserGroupService_ListMembersServer) error {
+	ug, err := s.backend.UserGroups().GetById(stream.Context(), req.GetUserGroupId())
+	if err != nil {
+		return toStatus(err)
+	}
+	for _, ref := range ug.Users {
+		err := stream.Send(&pb.UserRef{Id: ref.ID, Name: ref.Name, Email: ref.Email, Phone: ref.Phone})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s userGroupService) AddChildGroup(ctx context.Context, req *pb.ChildGroupRequest) (*emptypb.Empty, error) {
+	oid, err := objectID(myerrors.KindUserGroup, req.GetUserGroupId())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	cid, err := objectID(myerrors.KindUserGroup, req.GetChildId())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	if err := s.backend.UserGroups().AddChild(ctx, oid, cid); err != nil {
+		return nil, toStatus(err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s userGroupService) RemoveChildGroup(ctx context.Context, req *pb.ChildGroupRequest) (*emptypb.Empty, error) {
+	oid, err := objectID(myerrors.KindUserGroup, req.GetUserGroupId())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	cid, err := objectID(myerrors.KindUserGroup, req.GetChildId())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	if err := s.backend.UserGroups().RemoveChild(ctx, oid, cid); err != nil {
+		return nil, toStatus(err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s userGroupService) ResolveMembers(req *pb.ListMembersRequest, stream pb.UserGroupService_ResolveMembersServer) error {
+	oid, err := objectID(myerrors.KindUserGroup, req.GetUserGroupId())
+	if err != nil {
+		return toStatus(err)
+	}
+	members, err := s.backend.UserGroups().ResolveMembers(stream.Context(), oid)
+	if err != nil {
+		return toStatus(err)
+	}
+	for _, ref := range members {
+		err := stream.Send(&pb.UserRef{Id: ref.ID, Name: ref.Name, Email: ref.Email, Phone: ref.Phone})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s userGroupService) ResolveGroupsForUser(req *pb.ResolveGroupsForUserRequest, stream pb.UserGroupService_ResolveGroupsForUserServer) error {
+	uid, err := objectID(myerrors.KindUser, req.GetUserId())
+	if err != nil {
+		return toStatus(err)
+	}
+	groups, err := s.backend.UserGroups().ResolveGroupsForUser(stream.Context(), uid)
+	if err != nil {
+		return toStatus(err)
+	}
+	for _, ref := range groups {
+		if err := stream.Send(&pb.UserGroupRef{Id: ref.ID, Name: ref.Name}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s userGroupService) SetRule(ctx context.Context, req *pb.SetRuleRequest) (*pb.UserGroup, error) {
+	oid, err := objectID(myerrors.KindUserGroup, req.GetUserGroupId())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	if err := s.backend.UserGroups().SetRule(ctx, oid, req.GetRule()); err != nil {
+		return nil, toStatus(err)
+	}
+	return s.GetUserGroup(ctx, &pb.GetUserGroupRequest{Id: oid.Hex()})
+}
+
+func (s userGroupService) PreviewRule(req *pb.PreviewRuleRequest, stream pb.UserGroupService_PreviewRuleServer) error {
+	users, err := dynamic.Preview(stream.Context(), s.backend.Users(), req.GetRule(), int(req.GetLimit()))
+	if err != nil {
+		return toStatus(err)
+	}
+	for i := range users {
+		pu, err := toUser(&users[i])
+		if err != nil {
+			return toStatus(err)
+		}
+		if err := stream.Send(pu); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,6 @@
+// Package pb holds the protobuf messages and gRPC services generated from
+// usergroup.proto. Regenerate them with buf and the protoc-gen-go and
+// protoc-gen-go-grpc plugins after changing the proto file.
+package pb
+
+//go:generate buf generate --template buf.gen.yaml
@@ -0,0 +1,2113 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             (unknown)
+// source: usergroup.proto
+
+package pb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	UserService_ListUsers_FullMethodName      = "/usergroup.v1.UserService/ListUsers"
+	UserService_CreateUser_FullMethodName     = "/usergroup.v1.UserService/CreateUser"
+	UserService_GetUser_FullMethodName        = "/usergroup.v1.UserService/GetUser"
+	UserService_UpdateUser_FullMethodName     = "/usergroup.v1.UserService/UpdateUser"
+	UserService_DeleteUser_FullMethodName     = "/usergroup.v1.UserService/DeleteUser"
+	UserService_ListUserAccess_FullMethodName = "/usergroup.v1.UserService/ListUserAccess"
+)
+
+// UserServiceClient is the client API for UserService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type UserServiceClient interface {
+	ListUsers(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (*ListUsersResponse, error)
+	CreateUser(ctx context.Context, in *CreateUserRequest, opts ...grpc.CallOption) (*User, error)
+	GetUser(ctx context.Context, in *GetUserRequest, opts ...grpc.CallOption) (*User, error)
+	// UpdateUser updates the non empty name, email and phone and replaces the meta_data when set
+	UpdateUser(ctx context.Context, in *UpdateUserRequest, opts ...grpc.CallOption) (*User, error)
+	DeleteUser(ctx context.Context, in *DeleteUserRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	ListUserAccess(ctx context.Context, in *ListUserAccessRequest, opts ...grpc.CallOption) (UserService_ListUserAccessClient, error)
+}
+
+type userServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewUserServiceClient(cc grpc.ClientConnInterface) UserServiceClient {
+	return &userServiceClient{cc}
+}
+
+func (c *userServiceClient) ListUsers(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (*ListUsersResponse, error) {
+	out := new(ListUsersResponse)
+	err := c.cc.Invoke(ctx, UserService_ListUsers_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) CreateUser(ctx context.Context, in *CreateUserRequest, opts ...grpc.CallOption) (*User, error) {
+	out := new(User)
+	err := c.cc.Invoke(ctx, UserService_CreateUser_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) GetUser(ctx context.Context, in *GetUserRequest, opts ...grpc.CallOption) (*User, error) {
+	out := new(User)
+	err := c.cc.Invoke(ctx, UserService_GetUser_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) UpdateUser(ctx context.Context, in *UpdateUserRequest, opts ...grpc.CallOption) (*User, error) {
+	out := new(User)
+	err := c.cc.Invoke(ctx, UserService_UpdateUser_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) DeleteUser(ctx context.Context, in *DeleteUserRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, UserService_DeleteUser_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) ListUserAccess(ctx context.Context, in *ListUserAccessRequest, opts ...grpc.CallOption) (UserService_ListUserAccessClient, error) {
+	stream, err := c.cc.NewStream(ctx, &UserService_ServiceDesc.Streams[0], UserService_ListUserAccess_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &userServiceListUserAccessClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type UserService_ListUserAccessClient interface {
+	Recv() (*Access, error)
+	grpc.ClientStream
+}
+
+type userServiceListUserAccessClient struct {
+	grpc.ClientStream
+}
+
+func (x *userServiceListUserAccessClient) Recv() (*Access, error) {
+	m := new(Access)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// UserServiceServer is the server API for UserService service.
+// All implementations must embed UnimplementedUserServiceServer
+// for forward compatibility
+type UserServiceServer interface {
+	ListUsers(context.Context, *ListRequest) (*ListUsersResponse, error)
+	CreateUser(context.Context, *CreateUserRequest) (*User, error)
+	GetUser(context.Context, *GetUserRequest) (*User, error)
+	// UpdateUser updates the non empty name, email and phone and replaces the meta_data when set
+	UpdateUser(context.Context, *UpdateUserRequest) (*User, error)
+	DeleteUser(context.Context, *DeleteUserRequest) (*emptypb.Empty, error)
+	ListUserAccess(*ListUserAccessRequest, UserService_ListUserAccessServer) error
+	mustEmbedUnimplementedUserServiceServer()
+}
+
+// UnimplementedUserServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedUserServiceServer struct {
+}
+
+func (UnimplementedUserServiceServer) ListUsers(context.Context, *ListRequest) (*ListUsersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListUsers not implemented")
+}
+func (UnimplementedUserServiceServer) CreateUser(context.Context, *CreateUserRequest) (*User, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateUser not implemented")
+}
+func (UnimplementedUserServiceServer) GetUser(context.Context, *GetUserRequest) (*User, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetUser not implemented")
+}
+func (UnimplementedUserServiceServer) UpdateUser(context.Context, *UpdateUserRequest) (*User, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateUser not implemented")
+}
+func (UnimplementedUserServiceServer) DeleteUser(context.Context, *DeleteUserRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteUser not implemented")
+}
+func (UnimplementedUserServiceServer) ListUserAccess(*ListUserAccessRequest, UserService_ListUserAccessServer) error {
+	return status.Errorf(codes.Unimplemented, "method ListUserAccess not implemented")
+}
+func (UnimplementedUserServiceServer) mustEmbedUnimplementedUserServiceServer() {}
+
+// UnsafeUserServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to UserServiceServer will
+// result in compilation errors.
+type UnsafeUserServiceServer interface {
+	mustEmbedUnimplementedUserServiceServer()
+}
+
+func RegisterUserServiceServer(s grpc.ServiceRegistrar, srv UserServiceServer) {
+	s.RegisterService(&UserService_ServiceDesc, srv)
+}
+
+func _UserService_ListUsers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).ListUsers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_ListUsers_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).ListUsers(ctx, req.(*ListRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_CreateUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateUserRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).CreateUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_CreateUser_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).CreateUser(ctx, req.(*CreateUserRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_GetUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetUserRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).GetUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_GetUser_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).GetUser(ctx, req.(*GetUserRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_UpdateUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateUserRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).UpdateUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_UpdateUser_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).UpdateUser(ctx, req.(*UpdateUserRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_DeleteUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteUserRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).DeleteUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_DeleteUser_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).DeleteUser(ctx, req.(*DeleteUserRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_ListUserAccess_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ListUserAccessRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(UserServiceServer).ListUserAccess(m, &userServiceListUserAccessServer{stream})
+}
+
+type UserService_ListUserAccessServer interface {
+	Send(*Access) error
+	grpc.ServerStream
+}
+
+type userServiceListUserAccessServer struct {
+	grpc.ServerStream
+}
+
+func (x *userServiceListUserAccessServer) Send(m *Access) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+// UserService_ServiceDesc is the grpc.ServiceDesc for UserService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var UserService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "usergroup.v1.UserService",
+	HandlerType: (*UserServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListUsers",
+			Handler:    _UserService_ListUsers_Handler,
+		},
+		{
+			MethodName: "CreateUser",
+			Handler:    _UserService_CreateUser_Handler,
+		},
+		{
+			MethodName: "GetUser",
+			Handler:    _UserService_GetUser_Handler,
+		},
+		{
+			MethodName: "UpdateUser",
+			Handler:    _UserService_UpdateUser_Handler,
+		},
+		{
+			MethodName: "DeleteUser",
+			Handler:    _UserService_DeleteUser_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ListUserAccess",
+			Handler:       _UserService_ListUserAccess_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "usergroup.proto",
+}
+
+const (
+	UserGroupService_ListUserGroups_FullMethodName       = "/usergroup.v1.UserGroupService/ListUserGroups"
+	UserGroupService_CreateUserGroup_FullMethodName      = "/usergroup.v1.UserGroupService/CreateUserGroup"
+	UserGroupService_GetUserGroup_FullMethodName         = "/usergroup.v1.UserGroupService/GetUserGroup"
+	UserGroupService_RenameUserGroup_FullMethodName      = "/usergroup.v1.UserGroupService/RenameUserGroup"
+	UserGroupService_DeleteUserGroup_FullMethodName      = "/usergroup.v1.UserGroupService/DeleteUserGroup"
+	UserGroupService_AddMember_FullMethodName            = "/usergroup.v1.UserGroupService/AddMember"
+	UserGroupService_RemoveMember_FullMethodName         = "/usergroup.v1.UserGroupService/RemoveMember"
+	UserGroupService_ListMembers_FullMethodName          = "/usergroup.v1.UserGroupService/ListMembers"
+	UserGroupService_AddChildGroup_FullMethodName        = "/usergroup.v1.UserGroupService/AddChildGroup"
+	UserGroupService_RemoveChildGroup_FullMethodName     = "/usergroup.v1.UserGroupService/RemoveChildGroup"
+	UserGroupService_ResolveMembers_FullMethodName       = "/usergroup.v1.UserGroupService/ResolveMembers"
+	UserGroupService_ResolveGroupsForUser_FullMethodName = "/usergroup.v1.UserGroupService/ResolveGroupsForUser"
+	UserGroupService_SetRule_FullMethodName              = "/usergroup.v1.UserGroupService/SetRule"
+	UserGroupService_PreviewRule_FullMethodName          = "/usergroup.v1.UserGroupService/PreviewRule"
+)
+
+// UserGroupServiceClient is the client API for UserGroupService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type UserGroupServiceClient interface {
+	ListUserGroups(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (*ListUserGroupsResponse, error)
+	CreateUserGroup(ctx context.Context, in *CreateUserGroupRequest, opts ...grpc.CallOption) (*UserGroup, error)
+	GetUserGroup(ctx context.Context, in *GetUserGroupRequest, opts ...grpc.CallOption) (*UserGroup, error)
+	RenameUserGroup(ctx context.Context, in *RenameUserGroupRequest, opts ...grpc.CallOption) (*UserGroup, error)
+	DeleteUserGroup(ctx context.Context, in *DeleteUserGroupRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	AddMember(ctx context.Context, in *MemberRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	RemoveMember(ctx context.Context, in *MemberRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// ListMembers streams the members of the user group one by one
+	ListMembers(ctx context.Context, in *ListMembersRequest, opts ...grpc.CallOption) (UserGroupService_ListMembersClient, error)
+	// AddChildGroup makes child_id a subgroup, failing with InvalidArgument on cycles
+	AddChildGroup(ctx context.Context, in *ChildGroupRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	RemoveChildGroup(ctx context.Context, in *ChildGroupRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// ResolveMembers streams the members of the user group and of all its subgroups
+	ResolveMembers(ctx context.Context, in *ListMembersRequest, opts ...grpc.CallOption) (UserGroupService_ResolveMembersClient, error)
+	// ResolveGroupsForUser streams the user groups of the user and their parents
+	ResolveGroupsForUser(ctx context.Context, in *ResolveGroupsForUserRequest, opts ...grpc.CallOption) (UserGroupService_ResolveGroupsForUserClient, error)
+	// SetRule sets the membership rule, an empty rule makes the user group static
+	SetRule(ctx context.Context, in *SetRuleRequest, opts ...grpc.CallOption) (*UserGroup, error)
+	// PreviewRule streams up to limit users matching the rule without saving it
+	PreviewRule(ctx context.Context, in *PreviewRuleRequest, opts ...grpc.CallOption) (UserGroupService_PreviewRuleClient, error)
+}
+
+type userGroupServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewUserGroupServiceClient(cc grpc.ClientConnInterface) UserGroupServiceClient {
+	return &userGroupServiceClient{cc}
+}
+
+func (c *userGroupServiceClient) ListUserGroups(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (*ListUserGroupsResponse, error) {
+	out := new(ListUserGroupsResponse)
+	err := c.cc.Invoke(ctx, UserGroupService_ListUserGroups_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userGroupServiceClient) CreateUserGroup(ctx context.Context, in *CreateUserGroupRequest, opts ...grpc.CallOption) (*UserGroup, error) {
+	out := new(UserGroup)
+	err := c.cc.Invoke(ctx, UserGroupService_CreateUserGroup_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userGroupServiceClient) GetUserGroup(ctx context.Context, in *GetUserGroupRequest, opts ...grpc.CallOption) (*UserGroup, error) {
+	out := new(UserGroup)
+	err := c.cc.Invoke(ctx, UserGroupService_GetUserGroup_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userGroupServiceClient) RenameUserGroup(ctx context.Context, in *RenameUserGroupRequest, opts ...grpc.CallOption) (*UserGroup, error) {
+	out := new(UserGroup)
+	err := c.cc.Invoke(ctx, UserGroupService_RenameUserGroup_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userGroupServiceClient) DeleteUserGroup(ctx context.Context, in *DeleteUserGroupRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, UserGroupService_DeleteUserGroup_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userGroupServiceClient) AddMember(ctx context.Context, in *MemberRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, UserGroupService_AddMember_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userGroupServiceClient) RemoveMember(ctx context.Context, in *MemberRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, UserGroupService_RemoveMember_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userGroupServiceClient) ListMembers(ctx context.Context, in *ListMembersRequest, opts ...grpc.CallOption) (UserGroupService_ListMembersClient, error) {
+	stream, err := c.cc.NewStream(ctx, &UserGroupService_ServiceDesc.Streams[0], UserGroupService_ListMembers_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &userGroupServiceListMembersClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type UserGroupService_ListMembersClient interface {
+	Recv() (*UserRef, error)
+	grpc.ClientStream
+}
+
+type userGroupServiceListMembersClient struct {
+	grpc.ClientStream
+}
+
+func (x *userGroupServiceListMembersClient) Recv() (*UserRef, error) {
+	m := new(UserRef)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *userGroupServiceClient) AddChildGroup(ctx context.Context, in *ChildGroupRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, UserGroupService_AddChildGroup_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userGroupServiceClient) RemoveChildGroup(ctx context.Context, in *ChildGroupRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, UserGroupService_RemoveChildGroup_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userGroupServiceClient) ResolveMembers(ctx context.Context, in *ListMembersRequest, opts ...grpc.CallOption) (UserGroupService_ResolveMembersClient, error) {
+	stream, err := c.cc.NewStream(ctx, &UserGroupService_ServiceDesc.Streams[1], UserGroupService_ResolveMembers_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &userGroupServiceResolveMembersClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type UserGroupService_ResolveMembersClient interface {
+	Recv() (*UserRef, error)
+	grpc.ClientStream
+}
+
+type userGroupServiceResolveMembersClient struct {
+	grpc.ClientStream
+}
+
+func (x *userGroupServiceResolveMembersClient) Recv() (*UserRef, error) {
+	m := new(UserRef)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *userGroupServiceClient) ResolveGroupsForUser(ctx context.Context, in *ResolveGroupsForUserRequest, opts ...grpc.CallOption) (UserGroupService_ResolveGroupsForUserClient, error) {
+	stream, err := c.cc.NewStream(ctx, &UserGroupService_ServiceDesc.Streams[2], UserGroupService_ResolveGroupsForUser_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &userGroupServiceResolveGroupsForUserClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type UserGroupService_ResolveGroupsForUserClient interface {
+	Recv() (*UserGroupRef, error)
+	grpc.ClientStream
+}
+
+type userGroupServiceResolveGroupsForUserClient struct {
+	grpc.ClientStream
+}
+
+func (x *userGroupServiceResolveGroupsForUserClient) Recv() (*UserGroupRef, error) {
+	m := new(UserGroupRef)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *userGroupServiceClient) SetRule(ctx context.Context, in *SetRuleRequest, opts ...grpc.CallOption) (*UserGroup, error) {
+	out := new(UserGroup)
+	err := c.cc.Invoke(ctx, UserGroupService_SetRule_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userGroupServiceClient) PreviewRule(ctx context.Context, in *PreviewRuleRequest, opts ...grpc.CallOption) (UserGroupService_PreviewRuleClient, error) {
+	stream, err := c.cc.NewStream(ctx, &UserGroupService_ServiceDesc.Streams[3], UserGroupService_PreviewRule_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &userGroupServicePreviewRuleClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type UserGroupService_PreviewRuleClient interface {
+	Recv() (*User, error)
+	grpc.ClientStream
+}
+
+type userGroupServicePreviewRuleClient struct {
+	grpc.ClientStream
+}
+
+func (x *userGroupServicePreviewRuleClient) Recv() (*User, error) {
+	m := new(User)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// UserGroupServiceServer is the server API for UserGroupService service.
+// All implementations must embed UnimplementedUserGroupServiceServer
+// for forward compatibility
+type UserGroupServiceServer interface {
+	ListUserGroups(context.Context, *ListRequest) (*ListUserGroupsResponse, error)
+	CreateUserGroup(context.Context, *CreateUserGroupRequest) (*UserGroup, error)
+	GetUserGroup(context.Context, *GetUserGroupRequest) (*UserGroup, error)
+	RenameUserGroup(context.Context, *RenameUserGroupRequest) (*UserGroup, error)
+	DeleteUserGroup(context.Context, *DeleteUserGroupRequest) (*emptypb.Empty, error)
+	AddMember(context.Context, *MemberRequest) (*emptypb.Empty, error)
+	RemoveMember(context.Context, *MemberRequest) (*emptypb.Empty, error)
+	// ListMembers streams the members of the user group one by one
+	ListMembers(*ListMembersRequest, UserGroupService_ListMembersServer) error
+	// AddChildGroup makes child_id a subgroup, failing with InvalidArgument on cycles
+	AddChildGroup(context.Context, *ChildGroupRequest) (*emptypb.Empty, error)
+	RemoveChildGroup(context.Context, *ChildGroupRequest) (*emptypb.Empty, error)
+	// ResolveMembers streams the members of the user group and of all its subgroups
+	ResolveMembers(*ListMembersRequest, UserGroupService_ResolveMembersServer) error
+	// ResolveGroupsForUser streams the user groups of the user and their parents
+	ResolveGroupsForUser(*ResolveGroupsForUserRequest, UserGroupService_ResolveGroupsForUserServer) error
+	// SetRule sets the membership rule, an empty rule makes the user group static
+	SetRule(context.Context, *SetRuleRequest) (*UserGroup, error)
+	// PreviewRule streams up to limit users matching the rule without saving it
+	PreviewRule(*PreviewRuleRequest, UserGroupService_PreviewRuleServer) error
+	mustEmbedUnimplementedUserGroupServiceServer()
+}
+
+// UnimplementedUserGroupServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedUserGroupServiceServer struct {
+}
+
+func (UnimplementedUserGroupServiceServer) ListUserGroups(context.Context, *ListRequest) (*ListUserGroupsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListUserGroups not implemented")
+}
+func (UnimplementedUserGroupServiceServer) CreateUserGroup(context.Context, *CreateUserGroupRequest) (*UserGroup, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateUserGroup not implemented")
+}
+func (UnimplementedUserGroupServiceServer) GetUserGroup(context.Context, *GetUserGroupRequest) (*UserGroup, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetUserGroup not implemented")
+}
+func (UnimplementedUserGroupServiceServer) RenameUserGroup(context.Context, *RenameUserGroupRequest) (*UserGroup, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RenameUserGroup not implemented")
+}
+func (UnimplementedUserGroupServiceServer) DeleteUserGroup(context.Context, *DeleteUserGroupRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteUserGroup not implemented")
+}
+func (UnimplementedUserGroupServiceServer) AddMember(context.Context, *MemberRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddMember not implemented")
+}
+func (UnimplementedUserGroupServiceServer) RemoveMember(context.Context, *MemberRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveMember not implemented")
+}
+func (UnimplementedUserGroupServiceServer) ListMembers(*ListMembersRequest, UserGroupService_ListMembersServer) error {
+	return status.Errorf(codes.Unimplemented, "method ListMembers not implemented")
+}
+func (UnimplementedUserGroupServiceServer) AddChildGroup(context.Context, *ChildGroupRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddChildGroup not implemented")
+}
+func (UnimplementedUserGroupServiceServer) RemoveChildGroup(context.Context, *ChildGroupRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveChildGroup not implemented")
+}
+func (UnimplementedUserGroupServiceServer) ResolveMembers(*ListMembersRequest, UserGroupService_ResolveMembersServer) error {
+	return status.Errorf(codes.Unimplemented, "method ResolveMembers not implemented")
+}
+func (UnimplementedUserGroupServiceServer) ResolveGroupsForUser(*ResolveGroupsForUserRequest, UserGroupService_ResolveGroupsForUserServer) error {
+	return status.Errorf(codes.Unimplemented, "method ResolveGroupsForUser not implemented")
+}
+func (UnimplementedUserGroupServiceServer) SetRule(context.Context, *SetRuleRequest) (*UserGroup, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetRule not implemented")
+}
+func (UnimplementedUserGroupServiceServer) PreviewRule(*PreviewRuleRequest, UserGroupService_PreviewRuleServer) error {
+	return status.Errorf(codes.Unimplemented, "method PreviewRule not implemented")
+}
+func (UnimplementedUserGroupServiceServer) mustEmbedUnimplementedUserGroupServiceServer() {}
+
+// UnsafeUserGroupServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to UserGroupServiceServer will
+// result in compilation errors.
+type UnsafeUserGroupServiceServer interface {
+	mustEmbedUnimplementedUserGroupServiceServer()
+}
+
+func RegisterUserGroupServiceServer(s grpc.ServiceRegistrar, srv UserGroupServiceServer) {
+	s.RegisterService(&UserGroupService_ServiceDesc, srv)
+}
+
+func _UserGroupService_ListUserGroups_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserGroupServiceServer).ListUserGroups(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserGroupService_ListUserGroups_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserGroupServiceServer).ListUserGroups(ctx, req.(*ListRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserGroupService_CreateUserGroup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateUserGroupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserGroupServiceServer).CreateUserGroup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserGroupService_CreateUserGroup_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserGroupServiceServer).CreateUserGroup(ctx, req.(*CreateUserGroupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserGroupService_GetUserGroup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetUserGroupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserGroupServiceServer).GetUserGroup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserGroupService_GetUserGroup_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserGroupServiceServer).GetUserGroup(ctx, req.(*GetUserGroupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserGroupService_RenameUserGroup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RenameUserGroupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserGroupServiceServer).RenameUserGroup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserGroupService_RenameUserGroup_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserGroupServiceServer).RenameUserGroup(ctx, req.(*RenameUserGroupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserGroupService_DeleteUserGroup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteUserGroupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserGroupServiceServer).DeleteUserGroup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserGroupService_DeleteUserGroup_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserGroupServiceServer).DeleteUserGroup(ctx, req.(*DeleteUserGroupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserGroupService_AddMember_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MemberRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserGroupServiceServer).AddMember(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserGroupService_AddMember_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserGroupServiceServer).AddMember(ctx, req.(*MemberRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserGroupService_RemoveMember_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MemberRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserGroupServiceServer).RemoveMember(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserGroupService_RemoveMember_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserGroupServiceServer).RemoveMember(ctx, req.(*MemberRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserGroupService_ListMembers_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ListMembersRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(UserGroupServiceServer).ListMembers(m, &userGroupServiceListMembersServer{stream})
+}
+
+type UserGroupService_ListMembersServer interface {
+	Send(*UserRef) error
+	grpc.ServerStream
+}
+
+type userGroupServiceListMembersServer struct {
+	grpc.ServerStream
+}
+
+func (x *userGroupServiceListMembersServer) Send(m *UserRef) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _UserGroupService_AddChildGroup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ChildGroupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserGroupServiceServer).AddChildGroup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserGroupService_AddChildGroup_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserGroupServiceServer).AddChildGroup(ctx, req.(*ChildGroupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserGroupService_RemoveChildGroup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ChildGroupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserGroupServiceServer).RemoveChildGroup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserGroupService_RemoveChildGroup_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserGroupServiceServer).RemoveChildGroup(ctx, req.(*ChildGroupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserGroupService_ResolveMembers_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ListMembersRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(UserGroupServiceServer).ResolveMembers(m, &userGroupServiceResolveMembersServer{stream})
+}
+
+type UserGroupService_ResolveMembersServer interface {
+	Send(*UserRef) error
+	grpc.ServerStream
+}
+
+type userGroupServiceResolveMembersServer struct {
+	grpc.ServerStream
+}
+
+func (x *userGroupServiceResolveMembersServer) Send(m *UserRef) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _UserGroupService_ResolveGroupsForUser_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ResolveGroupsForUserRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(UserGroupServiceServer).ResolveGroupsForUser(m, &userGroupServiceResolveGroupsForUserServer{stream})
+}
+
+type UserGroupService_ResolveGroupsForUserServer interface {
+	Send(*UserGroupRef) error
+	grpc.ServerStream
+}
+
+type userGroupServiceResolveGroupsForUserServer struct {
+	grpc.ServerStream
+}
+
+func (x *userGroupServiceResolveGroupsForUserServer) Send(m *UserGroupRef) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _UserGroupService_SetRule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetRuleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserGroupServiceServer).SetRule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserGroupService_SetRule_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserGroupServiceServer).SetRule(ctx, req.(*SetRuleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserGroupService_PreviewRule_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(PreviewRuleRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(UserGroupServiceServer).PreviewRule(m, &userGroupServicePreviewRuleServer{stream})
+}
+
+type UserGroupService_PreviewRuleServer interface {
+	Send(*User) error
+	grpc.ServerStream
+}
+
+type userGroupServicePreviewRuleServer struct {
+	grpc.ServerStream
+}
+
+func (x *userGroupServicePreviewRuleServer) Send(m *User) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+// UserGroupService_ServiceDesc is the grpc.ServiceDesc for UserGroupService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var UserGroupService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "usergroup.v1.UserGroupService",
+	HandlerType: (*UserGroupServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListUserGroups",
+			Handler:    _UserGroupService_ListUserGroups_Handler,
+		},
+		{
+			MethodName: "CreateUserGroup",
+			Handler:    _UserGroupService_CreateUserGroup_Handler,
+		},
+		{
+			MethodName: "GetUserGroup",
+			Handler:    _UserGroupService_GetUserGroup_Handler,
+		},
+		{
+			MethodName: "RenameUserGroup",
+			Handler:    _UserGroupService_RenameUserGroup_Handler,
+		},
+		{
+			MethodName: "DeleteUserGroup",
+			Handler:    _UserGroupService_DeleteUserGroup_Handler,
+		},
+		{
+			MethodName: "AddMember",
+			Handler:    _UserGroupService_AddMember_Handler,
+		},
+		{
+			MethodName: "RemoveMember",
+			Handler:    _UserGroupService_RemoveMember_Handler,
+		},
+		{
+			MethodName: "AddChildGroup",
+			Handler:    _UserGroupService_AddChildGroup_Handler,
+		},
+		{
+			MethodName: "RemoveChildGroup",
+			Handler:    _UserGroupService_RemoveChildGroup_Handler,
+		},
+		{
+			MethodName: "SetRule",
+			Handler:    _UserGroupService_SetRule_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ListMembers",
+			Handler:       _UserGroupService_ListMembers_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ResolveMembers",
+			Handler:       _UserGroupService_ResolveMembers_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ResolveGroupsForUser",
+			Handler:       _UserGroupService_ResolveGroupsForUser_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "PreviewRule",
+			Handler:       _UserGroupService_PreviewRule_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "usergroup.proto",
+}
+
+const (
+	AccessService_Grant_FullMethodName                = "/usergroup.v1.AccessService/Grant"
+	AccessService_Revoke_FullMethodName               = "/usergroup.v1.AccessService/Revoke"
+	AccessService_SetRoles_FullMethodName             = "/usergroup.v1.AccessService/SetRoles"
+	AccessService_GetAccess_FullMethodName            = "/usergroup.v1.AccessService/GetAccess"
+	AccessService_HasRole_FullMethodName              = "/usergroup.v1.AccessService/HasRole"
+	AccessService_ListUsersWithRole_FullMethodName    = "/usergroup.v1.AccessService/ListUsersWithRole"
+	AccessService_EffectivePermissions_FullMethodName = "/usergroup.v1.AccessService/EffectivePermissions"
+	AccessService_Can_FullMethodName                  = "/usergroup.v1.AccessService/Can"
+	AccessService_FilterAuthorized_FullMethodName     = "/usergroup.v1.AccessService/FilterAuthorized"
+)
+
+// AccessServiceClient is the client API for AccessService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type AccessServiceClient interface {
+	Grant(ctx context.Context, in *RolesRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// Revoke revokes the roles, all roles when none is given
+	Revoke(ctx context.Context, in *RolesRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	SetRoles(ctx context.Context, in *RolesRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	GetAccess(ctx context.Context, in *GetAccessRequest, opts ...grpc.CallOption) (*Access, error)
+	HasRole(ctx context.Context, in *HasRoleRequest, opts ...grpc.CallOption) (*HasRoleResponse, error)
+	// ListUsersWithRole streams the ids of the users having the role in the user group
+	ListUsersWithRole(ctx context.Context, in *ListUsersWithRoleRequest, opts ...grpc.CallOption) (AccessService_ListUsersWithRoleClient, error)
+	// EffectivePermissions returns the permissions of the roles of the user including inherited ones
+	EffectivePermissions(ctx context.Context, in *GetAccessRequest, opts ...grpc.CallOption) (*EffectivePermissionsResponse, error)
+	// Can decides whether the user holds the permission in the user group, see package authorizer
+	Can(ctx context.Context, in *CanRequest, opts ...grpc.CallOption) (*CanResponse, error)
+	// FilterAuthorized returns the user groups the user holds the permission in
+	FilterAuthorized(ctx context.Context, in *FilterAuthorizedRequest, opts ...grpc.CallOption) (*FilterAuthorizedResponse, error)
+}
+
+type accessServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewAccessServiceClient(cc grpc.ClientConnInterface) AccessServiceClient {
+	return &accessServiceClient{cc}
+}
+
+func (c *accessServiceClient) Grant(ctx context.Context, in *RolesRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, AccessService_Grant_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *accessServiceClient) Revoke(ctx context.Context, in *RolesRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, AccessService_Revoke_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *accessServiceClient) SetRoles(ctx context.Context, in *RolesRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, AccessService_SetRoles_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *accessServiceClient) GetAccess(ctx context.Context, in *GetAccessRequest, opts ...grpc.CallOption) (*Access, error) {
+	out := new(Access)
+	err := c.cc.Invoke(ctx, AccessService_GetAccess_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *accessServiceClient) HasRole(ctx context.Context, in *HasRoleRequest, opts ...grpc.CallOption) (*HasRoleResponse, error) {
+	out := new(HasRoleResponse)
+	err := c.cc.Invoke(ctx, AccessService_HasRole_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *accessServiceClient) ListUsersWithRole(ctx context.Context, in *ListUsersWithRoleRequest, opts ...grpc.CallOption) (AccessService_ListUsersWithRoleClient, error) {
+	stream, err := c.cc.NewStream(ctx, &AccessService_ServiceDesc.Streams[0], AccessService_ListUsersWithRole_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &accessServiceListUsersWithRoleClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type AccessService_ListUsersWithRoleClient interface {
+	Recv() (*ListUsersWithRoleResponse, error)
+	grpc.ClientStream
+}
+
+type accessServiceListUsersWithRoleClient struct {
+	grpc.ClientStream
+}
+
+func (x *accessServiceListUsersWithRoleClient) Recv() (*ListUsersWithRoleResponse, error) {
+	m := new(ListUsersWithRoleResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *accessServiceClient) EffectivePermissions(ctx context.Context, in *GetAccessRequest, opts ...grpc.CallOption) (*EffectivePermissionsResponse, error) {
+	out := new(EffectivePermissionsResponse)
+	err := c.cc.Invoke(ctx, AccessService_EffectivePermissions_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *accessServiceClient) Can(ctx context.Context, in *CanRequest, opts ...grpc.CallOption) (*CanResponse, error) {
+	out := new(CanResponse)
+	err := c.cc.Invoke(ctx, AccessService_Can_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *accessServiceClient) FilterAuthorized(ctx context.Context, in *FilterAuthorizedRequest, opts ...grpc.CallOption) (*FilterAuthorizedResponse, error) {
+	out := new(FilterAuthorizedResponse)
+	err := c.cc.Invoke(ctx, AccessService_FilterAuthorized_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// AccessServiceServer is the server API for AccessService service.
+// All implementations must embed UnimplementedAccessServiceServer
+// for forward compatibility
+type AccessServiceServer interface {
+	Grant(context.Context, *RolesRequest) (*emptypb.Empty, error)
+	// Revoke revokes the roles, all roles when none is given
+	Revoke(context.Context, *RolesRequest) (*emptypb.Empty, error)
+	SetRoles(context.Context, *RolesRequest) (*emptypb.Empty, error)
+	GetAccess(context.Context, *GetAccessRequest) (*Access, error)
+	HasRole(context.Context, *HasRoleRequest) (*HasRoleResponse, error)
+	// ListUsersWithRole streams the ids of the users having the role in the user group
+	ListUsersWithRole(*ListUsersWithRoleRequest, AccessService_ListUsersWithRoleServer) error
+	// EffectivePermissions returns the permissions of the roles of the user including inherited ones
+	EffectivePermissions(context.Context, *GetAccessRequest) (*EffectivePermissionsResponse, error)
+	// Can decides whether the user holds the permission in the user group, see package authorizer
+	Can(context.Context, *CanRequest) (*CanResponse, error)
+	// FilterAuthorized returns the user groups the user holds the permission in
+	FilterAuthorized(context.Context, *FilterAuthorizedRequest) (*FilterAuthorizedResponse, error)
+	mustEmbedUnimplementedAccessServiceServer()
+}
+
+// UnimplementedAccessServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedAccessServiceServer struct {
+}
+
+func (UnimplementedAccessServiceServer) Grant(context.Context, *RolesRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Grant not implemented")
+}
+func (UnimplementedAccessServiceServer) Revoke(context.Context, *RolesRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Revoke not implemented")
+}
+func (UnimplementedAccessServiceServer) SetRoles(context.Context, *RolesRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetRoles not implemented")
+}
+func (UnimplementedAccessServiceServer) GetAccess(context.Context, *GetAccessRequest) (*Access, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAccess not implemented")
+}
+func (UnimplementedAccessServiceServer) HasRole(context.Context, *HasRoleRequest) (*HasRoleResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method HasRole not implemented")
+}
+func (UnimplementedAccessServiceServer) ListUsersWithRole(*ListUsersWithRoleRequest, AccessService_ListUsersWithRoleServer) error {
+	return status.Errorf(codes.Unimplemented, "method ListUsersWithRole not implemented")
+}
+func (UnimplementedAccessServiceServer) EffectivePermissions(context.Context, *GetAccessRequest) (*EffectivePermissionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method EffectivePermissions not implemented")
+}
+func (UnimplementedAccessServiceServer) Can(context.Context, *CanRequest) (*CanResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Can not implemented")
+}
+func (UnimplementedAccessServiceServer) FilterAuthorized(context.Context, *FilterAuthorizedRequest) (*FilterAuthorizedResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FilterAuthorized not implemented")
+}
+func (UnimplementedAccessServiceServer) mustEmbedUnimplementedAccessServiceServer() {}
+
+// UnsafeAccessServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to AccessServiceServer will
+// result in compilation errors.
+type UnsafeAccessServiceServer interface {
+	mustEmbedUnimplementedAccessServiceServer()
+}
+
+func RegisterAccessServiceServer(s grpc.ServiceRegistrar, srv AccessServiceServer) {
+	s.RegisterService(&AccessService_ServiceDesc, srv)
+}
+
+func _AccessService_Grant_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RolesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccessServiceServer).Grant(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AccessService_Grant_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccessServiceServer).Grant(ctx, req.(*RolesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AccessService_Revoke_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RolesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccessServiceServer).Revoke(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AccessService_Revoke_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccessServiceServer).Revoke(ctx, req.(*RolesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AccessService_SetRoles_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RolesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccessServiceServer).SetRoles(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AccessService_SetRoles_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccessServiceServer).SetRoles(ctx, req.(*RolesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AccessService_GetAccess_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAccessRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccessServiceServer).GetAccess(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AccessService_GetAccess_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccessServiceServer).GetAccess(ctx, req.(*GetAccessRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AccessService_HasRole_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HasRoleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccessServiceServer).HasRole(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AccessService_HasRole_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccessServiceServer).HasRole(ctx, req.(*HasRoleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AccessService_ListUsersWithRole_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ListUsersWithRoleRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(AccessServiceServer).ListUsersWithRole(m, &accessServiceListUsersWithRoleServer{stream})
+}
+
+type AccessService_ListUsersWithRoleServer interface {
+	Send(*ListUsersWithRoleResponse) error
+	grpc.ServerStream
+}
+
+type accessServiceListUsersWithRoleServer struct {
+	grpc.ServerStream
+}
+
+func (x *accessServiceListUsersWithRoleServer) Send(m *ListUsersWithRoleResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _AccessService_EffectivePermissions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAccessRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccessServiceServer).EffectivePermissions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AccessService_EffectivePermissions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccessServiceServer).EffectivePermissions(ctx, req.(*GetAccessRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AccessService_Can_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CanRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccessServiceServer).Can(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AccessService_Can_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccessServiceServer).Can(ctx, req.(*CanRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AccessService_FilterAuthorized_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FilterAuthorizedRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccessServiceServer).FilterAuthorized(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AccessService_FilterAuthorized_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccessServiceServer).FilterAuthorized(ctx, req.(*FilterAuthorizedRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// AccessService_ServiceDesc is the grpc.ServiceDesc for AccessService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var AccessService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "usergroup.v1.AccessService",
+	HandlerType: (*AccessServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Grant",
+			Handler:    _AccessService_Grant_Handler,
+		},
+		{
+			MethodName: "Revoke",
+			Handler:    _AccessService_Revoke_Handler,
+		},
+		{
+			MethodName: "SetRoles",
+			Handler:    _AccessService_SetRoles_Handler,
+		},
+		{
+			MethodName: "GetAccess",
+			Handler:    _AccessService_GetAccess_Handler,
+		},
+		{
+			MethodName: "HasRole",
+			Handler:    _AccessService_HasRole_Handler,
+		},
+		{
+			MethodName: "EffectivePermissions",
+			Handler:    _AccessService_EffectivePermissions_Handler,
+		},
+		{
+			MethodName: "Can",
+			Handler:    _AccessService_Can_Handler,
+		},
+		{
+			MethodName: "FilterAuthorized",
+			Handler:    _AccessService_FilterAuthorized_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ListUsersWithRole",
+			Handler:       _AccessService_ListUsersWithRole_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "usergroup.proto",
+}
+
+const (
+	RoleService_ListRoles_FullMethodName  = "/usergroup.v1.RoleService/ListRoles"
+	RoleService_CreateRole_FullMethodName = "/usergroup.v1.RoleService/CreateRole"
+	RoleService_GetRole_FullMethodName    = "/usergroup.v1.RoleService/GetRole"
+	RoleService_UpdateRole_FullMethodName = "/usergroup.v1.RoleService/UpdateRole"
+	RoleService_DeleteRole_FullMethodName = "/usergroup.v1.RoleService/DeleteRole"
+)
+
+// RoleServiceClient is the client API for RoleService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type RoleServiceClient interface {
+	ListRoles(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ListRolesResponse, error)
+	CreateRole(ctx context.Context, in *CreateRoleRequest, opts ...grpc.CallOption) (*Role, error)
+	GetRole(ctx context.Context, in *GetRoleRequest, opts ...grpc.CallOption) (*Role, error)
+	// UpdateRole replaces the permissions and inherited roles of the role with the name
+	UpdateRole(ctx context.Context, in *UpdateRoleRequest, opts ...grpc.CallOption) (*Role, error)
+	// DeleteRole deletes the role and revokes it from all users
+	DeleteRole(ctx context.Context, in *DeleteRoleRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+}
+
+type roleServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewRoleServiceClient(cc grpc.ClientConnInterface) RoleServiceClient {
+	return &roleServiceClient{cc}
+}
+
+func (c *roleServiceClient) ListRoles(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ListRolesResponse, error) {
+	out := new(ListRolesResponse)
+	err := c.cc.Invoke(ctx, RoleService_ListRoles_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *roleServiceClient) CreateRole(ctx context.Context, in *CreateRoleRequest, opts ...grpc.CallOption) (*Role, error) {
+	out := new(Role)
+	err := c.cc.Invoke(ctx, RoleService_CreateRole_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *roleServiceClient) GetRole(ctx context.Context, in *GetRoleRequest, opts ...grpc.CallOption) (*Role, error) {
+	out := new(Role)
+	err := c.cc.Invoke(ctx, RoleService_GetRole_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *roleServiceClient) UpdateRole(ctx context.Context, in *UpdateRoleRequest, opts ...grpc.CallOption) (*Role, error) {
+	out := new(Role)
+	err := c.cc.Invoke(ctx, RoleService_UpdateRole_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *roleServiceClient) DeleteRole(ctx context.Context, in *DeleteRoleRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, RoleService_DeleteRole_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// RoleServiceServer is the server API for RoleService service.
+// All implementations must embed UnimplementedRoleServiceServer
+// for forward compatibility
+type RoleServiceServer interface {
+	ListRoles(context.Context, *emptypb.Empty) (*ListRolesResponse, error)
+	CreateRole(context.Context, *CreateRoleRequest) (*Role, error)
+	GetRole(context.Context, *GetRoleRequest) (*Role, error)
+	// UpdateRole replaces the permissions and inherited roles of the role with the name
+	UpdateRole(context.Context, *UpdateRoleRequest) (*Role, error)
+	// DeleteRole deletes the role and revokes it from all users
+	DeleteRole(context.Context, *DeleteRoleRequest) (*emptypb.Empty, error)
+	mustEmbedUnimplementedRoleServiceServer()
+}
+
+// UnimplementedRoleServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedRoleServiceServer struct {
+}
+
+func (UnimplementedRoleServiceServer) ListRoles(context.Context, *emptypb.Empty) (*ListRolesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListRoles not implemented")
+}
+func (UnimplementedRoleServiceServer) CreateRole(context.Context, *CreateRoleRequest) (*Role, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateRole not implemented")
+}
+func (UnimplementedRoleServiceServer) GetRole(context.Context, *GetRoleRequest) (*Role, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRole not implemented")
+}
+func (UnimplementedRoleServiceServer) UpdateRole(context.Context, *UpdateRoleRequest) (*Role, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateRole not implemented")
+}
+func (UnimplementedRoleServiceServer) DeleteRole(context.Context, *DeleteRoleRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteRole not implemented")
+}
+func (UnimplementedRoleServiceServer) mustEmbedUnimplementedRoleServiceServer() {}
+
+// UnsafeRoleServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to RoleServiceServer will
+// result in compilation errors.
+type UnsafeRoleServiceServer interface {
+	mustEmbedUnimplementedRoleServiceServer()
+}
+
+func RegisterRoleServiceServer(s grpc.ServiceRegistrar, srv RoleServiceServer) {
+	s.RegisterService(&RoleService_ServiceDesc, srv)
+}
+
+func _RoleService_ListRoles_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RoleServiceServer).ListRoles(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RoleService_ListRoles_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RoleServiceServer).ListRoles(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RoleService_CreateRole_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateRoleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RoleServiceServer).CreateRole(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RoleService_CreateRole_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RoleServiceServer).CreateRole(ctx, req.(*CreateRoleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RoleService_GetRole_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRoleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RoleServiceServer).GetRole(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RoleService_GetRole_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RoleServiceServer).GetRole(ctx, req.(*GetRoleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RoleService_UpdateRole_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateRoleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RoleServiceServer).UpdateRole(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RoleService_UpdateRole_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RoleServiceServer).UpdateRole(ctx, req.(*UpdateRoleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RoleService_DeleteRole_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteRoleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RoleServiceServer).DeleteRole(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RoleService_DeleteRole_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RoleServiceServer).DeleteRole(ctx, req.(*DeleteRoleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// RoleService_ServiceDesc is the grpc.ServiceDesc for RoleService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var RoleService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "usergroup.v1.RoleService",
+	HandlerType: (*RoleServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListRoles",
+			Handler:    _RoleService_ListRoles_Handler,
+		},
+		{
+			MethodName: "CreateRole",
+			Handler:    _RoleService_CreateRole_Handler,
+		},
+		{
+			MethodName: "GetRole",
+			Handler:    _RoleService_GetRole_Handler,
+		},
+		{
+			MethodName: "UpdateRole",
+			Handler:    _RoleService_UpdateRole_Handler,
+		},
+		{
+			MethodName: "DeleteRole",
+			Handler:    _RoleService_DeleteRole_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "usergroup.proto",
+}
+
+const (
+	AuditService_ListAudit_FullMethodName = "/usergroup.v1.AuditService/ListAudit"
+)
+
+// AuditServiceClient is the client API for AuditService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type AuditServiceClient interface {
+	// ListAudit streams the records of an entity in a time range, newest first
+	ListAudit(ctx context.Context, in *ListAuditRequest, opts ...grpc.CallOption) (AuditService_ListAuditClient, error)
+}
+
+type auditServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewAuditServiceClient(cc grpc.ClientConnInterface) AuditServiceClient {
+	return &auditServiceClient{cc}
+}
+
+func (c *auditServiceClient) ListAudit(ctx context.Context, in *ListAuditRequest, opts ...grpc.CallOption) (AuditService_ListAuditClient, error) {
+	stream, err := c.cc.NewStream(ctx, &AuditService_ServiceDesc.Streams[0], AuditService_ListAudit_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &auditServiceListAuditClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type AuditService_ListAuditClient interface {
+	Recv() (*AuditRecord, error)
+	grpc.ClientStream
+}
+
+type auditServiceListAuditClient struct {
+	grpc.ClientStream
+}
+
+func (x *auditServiceListAuditClient) Recv() (*AuditRecord, error) {
+	m := new(AuditRecord)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// AuditServiceServer is the server API for AuditService service.
+// All implementations must embed UnimplementedAuditServiceServer
+// for forward compatibility
+type AuditServiceServer interface {
+	// ListAudit streams the records of an entity in a time range, newest first
+	ListAudit(*ListAuditRequest, AuditService_ListAuditServer) error
+	mustEmbedUnimplementedAuditServiceServer()
+}
+
+// UnimplementedAuditServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedAuditServiceServer struct {
+}
+
+func (UnimplementedAuditServiceServer) ListAudit(*ListAuditRequest, AuditService_ListAuditServer) error {
+	return status.Errorf(codes.Unimplemented, "method ListAudit not implemented")
+}
+func (UnimplementedAuditServiceServer) mustEmbedUnimplementedAuditServiceServer() {}
+
+// UnsafeAuditServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to AuditServiceServer will
+// result in compilation errors.
+type UnsafeAuditServiceServer interface {
+	mustEmbedUnimplementedAuditServiceServer()
+}
+
+func RegisterAuditServiceServer(s grpc.ServiceRegistrar, srv AuditServiceServer) {
+	s.RegisterService(&AuditService_ServiceDesc, srv)
+}
+
+func _AuditService_ListAudit_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ListAuditRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(AuditServiceServer).ListAudit(m, &auditServiceListAuditServer{stream})
+}
+
+type AuditService_ListAuditServer interface {
+	Send(*AuditRecord) error
+	grpc.ServerStream
+}
+
+type auditServiceListAuditServer struct {
+	grpc.ServerStream
+}
+
+func (x *auditServiceListAuditServer) Send(m *AuditRecord) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+// AuditService_ServiceDesc is the grpc.ServiceDesc for AuditService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var AuditService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "usergroup.v1.AuditService",
+	HandlerType: (*AuditServiceServer)(nil),
+	Methods:     []grpc.MethodDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ListAudit",
+			Handler:       _AuditService_ListAudit_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "usergroup.proto",
+}
+
+const (
+	WebhookService_ListWebhooks_FullMethodName   = "/usergroup.v1.WebhookService/ListWebhooks"
+	WebhookService_CreateWebhook_FullMethodName  = "/usergroup.v1.WebhookService/CreateWebhook"
+	WebhookService_GetWebhook_FullMethodName     = "/usergroup.v1.WebhookService/GetWebhook"
+	WebhookService_UpdateWebhook_FullMethodName  = "/usergroup.v1.WebhookService/UpdateWebhook"
+	WebhookService_DeleteWebhook_FullMethodName  = "/usergroup.v1.WebhookService/DeleteWebhook"
+	WebhookService_ListDeliveries_FullMethodName = "/usergroup.v1.WebhookService/ListDeliveries"
+)
+
+// WebhookServiceClient is the client API for WebhookService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type WebhookServiceClient interface {
+	ListWebhooks(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ListWebhooksResponse, error)
+	// CreateWebhook subscribes, a random secret is generated when none is given
+	CreateWebhook(ctx context.Context, in *CreateWebhookRequest, opts ...grpc.CallOption) (*WebhookSubscription, error)
+	GetWebhook(ctx context.Context, in *GetWebhookRequest, opts ...grpc.CallOption) (*WebhookSubscription, error)
+	// UpdateWebhook replaces url, user group, types and disabled, and the secret when given
+	UpdateWebhook(ctx context.Context, in *UpdateWebhookRequest, opts ...grpc.CallOption) (*WebhookSubscription, error)
+	// DeleteWebhook deletes the subscription and its deliveries
+	DeleteWebhook(ctx context.Context, in *DeleteWebhookRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// ListDeliveries streams the deliveries of a subscription, newest first
+	ListDeliveries(ctx context.Context, in *ListDeliveriesRequest, opts ...grpc.CallOption) (WebhookService_ListDeliveriesClient, error)
+}
+
+type webhookServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewWebhookServiceClient(cc grpc.ClientConnInterface) WebhookServiceClient {
+	return &webhookServiceClient{cc}
+}
+
+func (c *webhookServiceClient) ListWebhooks(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ListWebhooksResponse, error) {
+	out := new(ListWebhooksResponse)
+	err := c.cc.Invoke(ctx, WebhookService_ListWebhooks_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *webhookServiceClient) CreateWebhook(ctx context.Context, in *CreateWebhookRequest, opts ...grpc.CallOption) (*WebhookSubscription, error) {
+	out := new(WebhookSubscription)
+	err := c.cc.Invoke(ctx, WebhookService_CreateWebhook_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *webhookServiceClient) GetWebhook(ctx context.Context, in *GetWebhookRequest, opts ...grpc.CallOption) (*WebhookSubscription, error) {
+	out := new(WebhookSubscription)
+	err := c.cc.Invoke(ctx, WebhookService_GetWebhook_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *webhookServiceClient) UpdateWebhook(ctx context.Context, in *UpdateWebhookRequest, opts ...grpc.CallOption) (*WebhookSubscription, error) {
+	out := new(WebhookSubscription)
+	err := c.cc.Invoke(ctx, WebhookService_UpdateWebhook_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *webhookServiceClient) DeleteWebhook(ctx context.Context, in *DeleteWebhookRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, WebhookService_DeleteWebhook_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *webhookServiceClient) ListDeliveries(ctx context.Context, in *ListDeliveriesRequest, opts ...grpc.CallOption) (WebhookService_ListDeliveriesClient, error) {
+	stream, err := c.cc.NewStream(ctx, &WebhookService_ServiceDesc.Streams[0], WebhookService_ListDeliveries_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &webhookServiceListDeliveriesClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type WebhookService_ListDeliveriesClient interface {
+	Recv() (*WebhookDelivery, error)
+	grpc.ClientStream
+}
+
+type webhookServiceListDeliveriesClient struct {
+	grpc.ClientStream
+}
+
+func (x *webhookServiceListDeliveriesClient) Recv() (*WebhookDelivery, error) {
+	m := new(WebhookDelivery)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// WebhookServiceServer is the server API for WebhookService service.
+// All implementations must embed UnimplementedWebhookServiceServer
+// for forward compatibility
+type WebhookServiceServer interface {
+	ListWebhooks(context.Context, *emptypb.Empty) (*ListWebhooksResponse, error)
+	// CreateWebhook subscribes, a random secret is generated when none is given
+	CreateWebhook(context.Context, *CreateWebhookRequest) (*WebhookSubscription, error)
+	GetWebhook(context.Context, *GetWebhookRequest) (*WebhookSubscription, error)
+	// UpdateWebhook replaces url, user group, types and disabled, and the secret when given
+	UpdateWebhook(context.Context, *UpdateWebhookRequest) (*WebhookSubscription, error)
+	// DeleteWebhook deletes the subscription and its deliveries
+	DeleteWebhook(context.Context, *DeleteWebhookRequest) (*emptypb.Empty, error)
+	// ListDeliveries streams the deliveries of a subscription, newest first
+	ListDeliveries(*ListDeliveriesRequest, WebhookService_ListDeliveriesServer) error
+	mustEmbedUnimplementedWebhookServiceServer()
+}
+
+// UnimplementedWebhookServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedWebhookServiceServer struct {
+}
+
+func (UnimplementedWebhookServiceServer) ListWebhooks(context.Context, *emptypb.Empty) (*ListWebhooksResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListWebhooks not implemented")
+}
+func (UnimplementedWebhookServiceServer) CreateWebhook(context.Context, *CreateWebhookRequest) (*WebhookSubscription, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateWebhook not implemented")
+}
+func (UnimplementedWebhookServiceServer) GetWebhook(context.Context, *GetWebhookRequest) (*WebhookSubscription, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetWebhook not implemented")
+}
+func (UnimplementedWebhookServiceServer) UpdateWebhook(context.Context, *UpdateWebhookRequest) (*WebhookSubscription, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateWebhook not implemented")
+}
+func (UnimplementedWebhookServiceServer) DeleteWebhook(context.Context, *DeleteWebhookRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteWebhook not implemented")
+}
+func (UnimplementedWebhookServiceServer) ListDeliveries(*ListDeliveriesRequest, WebhookService_ListDeliveriesServer) error {
+	return status.Errorf(codes.Unimplemented, "method ListDeliveries not implemented")
+}
+func (UnimplementedWebhookServiceServer) mustEmbedUnimplementedWebhookServiceServer() {}
+
+// UnsafeWebhookServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to WebhookServiceServer will
+// result in compilation errors.
+type UnsafeWebhookServiceServer interface {
+	mustEmbedUnimplementedWebhookServiceServer()
+}
+
+func RegisterWebhookServiceServer(s grpc.ServiceRegistrar, srv WebhookServiceServer) {
+	s.RegisterService(&WebhookService_ServiceDesc, srv)
+}
+
+func _WebhookService_ListWebhooks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WebhookServiceServer).ListWebhooks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WebhookService_ListWebhooks_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WebhookServiceServer).ListWebhooks(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WebhookService_CreateWebhook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateWebhookRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WebhookServiceServer).CreateWebhook(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WebhookService_CreateWebhook_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WebhookServiceServer).CreateWebhook(ctx, req.(*CreateWebhookRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WebhookService_GetWebhook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetWebhookRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WebhookServiceServer).GetWebhook(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WebhookService_GetWebhook_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WebhookServiceServer).GetWebhook(ctx, req.(*GetWebhookRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WebhookService_UpdateWebhook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateWebhookRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WebhookServiceServer).UpdateWebhook(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WebhookService_UpdateWebhook_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WebhookServiceServer).UpdateWebhook(ctx, req.(*UpdateWebhookRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WebhookService_DeleteWebhook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteWebhookRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WebhookServiceServer).DeleteWebhook(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WebhookService_DeleteWebhook_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WebhookServiceServer).DeleteWebhook(ctx, req.(*DeleteWebhookRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WebhookService_ListDeliveries_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ListDeliveriesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(WebhookServiceServer).ListDeliveries(m, &webhookServiceListDeliveriesServer{stream})
+}
+
+type WebhookService_ListDeliveriesServer interface {
+	Send(*WebhookDelivery) error
+	grpc.ServerStream
+}
+
+type webhookServiceListDeliveriesServer struct {
+	grpc.ServerStream
+}
+
+func (x *webhookServiceListDeliveriesServer) Send(m *WebhookDelivery) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+// WebhookService_ServiceDesc is the grpc.ServiceDesc for WebhookService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var WebhookService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "usergroup.v1.WebhookService",
+	HandlerType: (*WebhookServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListWebhooks",
+			Handler:    _WebhookService_ListWebhooks_Handler,
+		},
+		{
+			MethodName: "CreateWebhook",
+			Handler:    _WebhookService_CreateWebhook_Handler,
+		},
+		{
+			MethodName: "GetWebhook",
+			Handler:    _WebhookService_GetWebhook_Handler,
+		},
+		{
+			MethodName: "UpdateWebhook",
+			Handler:    _WebhookService_UpdateWebhook_Handler,
+		},
+		{
+			MethodName: "DeleteWebhook",
+			Handler:    _WebhookService_DeleteWebhook_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ListDeliveries",
+			Handler:       _WebhookService_ListDeliveries_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "usergroup.proto",
+}
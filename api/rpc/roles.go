@@ -0,0 +1,74 @@
+package rpc
+
+import (
+	"context"
+
+	"github.com/sr-codefreak/user-group/api/rpc/pb"
+	"github.com/sr-codefreak/user-group/db/mongodb/access"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+type roleService struct {
+	pb.UnimplementedRoleServiceServer
+	*Server
+}
+
+func toRole(r *access.Role) *pb.Role {
+	return &pb.Role{
+		Id:          r.ID.Hex(),
+		Name:        r.Name,
+		Permissions: r.Permissions,
+		Inherits:    r.Inherits,
+	}
+}
+
+func fromRole(r *pb.Role) *access.Role {
+	return &access.Role{
+		Name:        r.GetName(),
+		Permissions: r.GetPermissions(),
+		Inherits:    r.GetInherits(),
+	}
+}
+
+func (s roleService) ListRoles(ctx context.Context, req *emptypb.Empty) (*pb.ListRolesResponse, error) {
+	roles, err := s.backend.Roles().List(ctx)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	resp := &pb.ListRolesResponse{Roles: make([]*pb.Role, 0, len(roles))}
+	for i := range roles {
+		resp.Roles = append(resp.Roles, toRole(&roles[i]))
+	}
+	return resp, nil
+}
+
+func (s roleService) CreateRole(ctx context.Context, req *pb.CreateRoleRequest) (*pb.Role, error) {
+	r := fromRole(req.GetRole())
+	if err := s.backend.Roles().Create(ctx, r); err != nil {
+		return nil, toStatus(err)
+	}
+	return toRole(r), nil
+}
+
+func (s roleService) GetRole(ctx context.Context, req *pb.GetRoleRequest) (*pb.Role, error) {
+	r, err := s.backend.Roles().Get(ctx, req.GetName())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return toRole(r), nil
+}
+
+func (s roleService) UpdateRole(ctx context.Context, req *pb.UpdateRoleRequest) (*pb.Role, error) {
+	r := fromRole(req.GetRole())
+	if err := s.backend.Roles().Update(ctx, r); err != nil {
+		return nil, toStatus(err)
+	}
+	return toRole(r), nil
+}
+
+func (s roleService) DeleteRole(ctx context.Context, req *pb.DeleteRoleRequest) (*emptypb.Empty, error) {
+	if err := s.backend.Roles().Delete(ctx, req.GetName()); err != nil {
+		return nil, toStatus(err)
+	}
+	return &emptypb.Empty{}, nil
+}
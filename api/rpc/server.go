@@ -0,0 +1,144 @@
+// Package rpc serves the users, user groups, access, roles, audit log and
+// webhook subscriptions of a db.Backend as the gRPC services UserService,
+// UserGroupService, AccessService, RoleService, AuditService and
+// WebhookService of package pb.
+//
+// Errors are returned as gRPC status with the code derived from the myerrors
+// code. Register the services on any grpc.Server, e.g. one listening on a
+// bufconn.Listener to run the API in-process:
+//
+//	lis := bufconn.Listen(1 << 20)
+//	s := grpc.NewServer()
+//	rpc.NewServer(memory.New()).Register(s)
+//	go s.Serve(lis)
+//	conn, _ := grpc.DialContext(ctx, "bufnet",
+//		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+//			return lis.DialContext(ctx)
+//		}),
+//		grpc.WithTransportCredentials(insecure.NewCredentials()))
+//	users := pb.NewUserServiceClient(conn)
+//
+// The x-actor and x-tenant metadata select the actor and the tenant of a
+// call when the grpc.Server is created with ServerOptions, else every call
+// is on the shared data.
+package rpc
+
+import (
+	"encoding/json"
+	"errors"
+
+	"github.com/sr-codefreak/user-group/api/rpc/pb"
+	"github.com/sr-codefreak/user-group/db"
+	"github.com/sr-codefreak/user-group/db/mongodb"
+	"github.com/sr-codefreak/user-group/myerrors"
+	"github.com/sr-codefreak/user-group/utils/logger"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+var log = logger.GetLogger()
+
+// Server implements the gRPC services on a backend
+type Server struct {
+	backend db.Backend
+}
+
+// NewServer returns the gRPC server on backend
+func NewServer(backend db.Backend) *Server {
+	return &Server{backend: backend}
+}
+
+// Register registers the user, user group, access, role, audit and webhook services on s
+func (s *Server) Register(r grpc.ServiceRegistrar) {
+	pb.RegisterUserServiceServer(r, userService{Server: s})
+	pb.RegisterUserGroupServiceServer(r, userGroupService{Server: s})
+	pb.RegisterAccessServiceServer(r, accessService{Server: s})
+	pb.RegisterRoleServiceServer(r, roleService{Server: s})
+	pb.RegisterAuditServiceServer(r, auditService{Server: s})
+	pb.RegisterWebhookServiceServer(r, webhookService{Server: s})
+}
+
+var codeStatus = map[myerrors.Code]codes.Code{
+	myerrors.Unknown:         codes.Internal,
+	myerrors.NotFound:        codes.NotFound,
+	myerrors.AlreadyExists:   codes.AlreadyExists,
+	myerrors.InvalidArgument: codes.InvalidArgument,
+	myerrors.Unavailable:     codes.Unavailable,
+	myerrors.Conflict:        codes.Aborted,
+}
+
+// toStatus converts err to a gRPC status error with the code of its myerrors code.
+// Context errors keep their own code and unknown errors are logged and hidden.
+func toStatus(err error) error {
+	if err == nil {
+		return nil
+	}
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+	code := myerrors.CodeOf(err)
+	if code == myerrors.Unknown {
+		if c := status.FromContextError(err).Code(); c != codes.Unknown {
+			return status.Error(c, err.Error())
+		}
+		log.Errorf("rpc: %s", err)
+		return status.Error(codes.Internal, "internal error")
+	}
+	return status.Error(codeStatus[code], err.Error())
+}
+
+// objectID parses a request field as id of kind
+func objectID(kind myerrors.Kind, id string) (primitive.ObjectID, error) {
+	oid, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return oid, myerrors.New(myerrors.InvalidArgument, kind, id, err)
+	}
+	return oid, nil
+}
+
+var errRoleRequired = errors.New("role is required")
+
+// toStruct converts the metadata of an entity, nil stays nil.
+// The metadata goes through JSON as mongo decodes arrays as primitive.A.
+func toStruct(m map[string]any) (*structpb.Struct, error) {
+	if m == nil {
+		return nil, nil
+	}
+	b, err := json.Marshal(m)
+	if err != nil {
+		return nil, err
+	}
+	st := &structpb.Struct{}
+	if err := protojson.Unmarshal(b, st); err != nil {
+		return nil, err
+	}
+	return st, nil
+}
+
+func fromStruct(st *structpb.Struct) map[string]any {
+	if st == nil {
+		return nil
+	}
+	return st.AsMap()
+}
+
+func toListOptions(req *pb.ListRequest) mongodb.ListOptions {
+	opts := mongodb.ListOptions{
+		PageSize:  int(req.GetPageSize()),
+		PageToken: req.GetPageToken(),
+		Filter: mongodb.ListFilter{
+			Name:     req.GetName(),
+			Email:    req.GetEmail(),
+			Phone:    req.GetPhone(),
+			MetaData: fromStruct(req.GetMetaData()),
+		},
+	}
+	for _, f := range req.GetSort() {
+		opts.Sort = append(opts.Sort, mongodb.SortField{Key: f.GetKey(), Desc: f.GetDesc()})
+	}
+	return opts
+}
@@ -0,0 +1,169 @@
+package rpc_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"testing"
+
+	"github.com/sr-codefreak/user-group/api/rpc"
+	"github.com/sr-codefreak/user-group/api/rpc/pb"
+	"github.com/sr-codefreak/user-group/db/memory"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// dial serves the services on a memory backend over a bufconn listener and
+// returns a connection to them
+func dial(t *testing.T) *grpc.ClientConn {
+	lis := bufconn.Listen(1 << 20)
+	backend := memory.New()
+	s := grpc.NewServer(rpc.ServerOptions(backend)...)
+	rpc.NewServer(backend).Register(s)
+	go s.Serve(lis)
+	t.Cleanup(s.Stop)
+
+	conn, err := grpc.DialContext(context.Background(), "bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return conn
+}
+
+// memberStream is a server stream of the members of a user group
+type memberStream interface {
+	Recv() (*pb.UserRef, error)
+}
+
+func recvNames(t *testing.T, stream memberStream) []string {
+	t.Helper()
+	var names []string
+	for {
+		u, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return names
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		names = append(names, u.Name)
+	}
+}
+
+func wantCode(t *testing.T, err error, code codes.Code) {
+	t.Helper()
+	if status.Code(err) != code {
+		t.Errorf("error %v, want code %s", err, code)
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	conn := dial(t)
+	users := pb.NewUserServiceClient(conn)
+	groups := pb.NewUserGroupServiceClient(conn)
+
+	metaData, err := structpb.NewStruct(map[string]any{"dept": "eng"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ann, err := users.CreateUser(ctx, &pb.CreateUserRequest{User: &pb.User{Name: "ann", Email: "ann@example.com", MetaData: metaData}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := users.GetUser(ctx, &pb.GetUserRequest{Id: ann.Id})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Name != "ann" || got.Email != "ann@example.com" || got.MetaData.AsMap()["dept"] != "eng" {
+		t.Errorf("GetUser = %v", got)
+	}
+
+	org, err := groups.CreateUserGroup(ctx, &pb.CreateUserGroupRequest{UserGroup: &pb.UserGroup{Name: "org"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	team, err := groups.CreateUserGroup(ctx, &pb.CreateUserGroupRequest{UserGroup: &pb.UserGroup{Name: "team"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := groups.AddMember(ctx, &pb.MemberRequest{UserGroupId: team.Id, UserId: ann.Id}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := groups.AddChildGroup(ctx, &pb.ChildGroupRequest{UserGroupId: org.Id, ChildId: team.Id}); err != nil {
+		t.Fatal(err)
+	}
+
+	members, err := groups.ListMembers(ctx, &pb.ListMembersRequest{UserGroupId: team.Id})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if names := recvNames(t, members); len(names) != 1 || names[0] != "ann" {
+		t.Errorf("members of team = %v", names)
+	}
+	resolved, err := groups.ResolveMembers(ctx, &pb.ListMembersRequest{UserGroupId: org.Id})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if names := recvNames(t, resolved); len(names) != 1 || names[0] != "ann" {
+		t.Errorf("transitive members of org = %v", names)
+	}
+	g, err := groups.GetUserGroup(ctx, &pb.GetUserGroupRequest{Id: team.Id})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if g.MemberCount != 1 || len(g.ParentIds) != 1 || g.ParentIds[0] != org.Id {
+		t.Errorf("GetUserGroup = %v", g)
+	}
+
+	if _, err := users.DeleteUser(ctx, &pb.DeleteUserRequest{Id: ann.Id}); err != nil {
+		t.Fatal(err)
+	}
+	_, err = users.GetUser(ctx, &pb.GetUserRequest{Id: ann.Id})
+	wantCode(t, err, codes.NotFound)
+}
+
+func TestErrorCodes(t *testing.T) {
+	ctx := context.Background()
+	conn := dial(t)
+	users := pb.NewUserServiceClient(conn)
+	groups := pb.NewUserGroupServiceClient(conn)
+
+	_, err := users.GetUser(ctx, &pb.GetUserRequest{Id: "not an id"})
+	wantCode(t, err, codes.InvalidArgument)
+	_, err = users.GetUser(ctx, &pb.GetUserRequest{Id: "5f1d7f1c2a3b4c5d6e7f8091"})
+	wantCode(t, err, codes.NotFound)
+
+	ug, err := groups.CreateUserGroup(ctx, &pb.CreateUserGroupRequest{UserGroup: &pb.UserGroup{Name: "eng"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = groups.AddChildGroup(ctx, &pb.ChildGroupRequest{UserGroupId: ug.Id, ChildId: ug.Id})
+	wantCode(t, err, codes.InvalidArgument)
+}
+
+func TestUnknownTenant(t *testing.T) {
+	ctx := metadata.AppendToOutgoingContext(context.Background(), rpc.TenantMetadataKey, "acme")
+	conn := dial(t)
+	users := pb.NewUserServiceClient(conn)
+	groups := pb.NewUserGroupServiceClient(conn)
+
+	_, err := users.GetUser(ctx, &pb.GetUserRequest{Id: "5f1d7f1c2a3b4c5d6e7f8091"})
+	wantCode(t, err, codes.InvalidArgument)
+	stream, err := groups.ListMembers(ctx, &pb.ListMembersRequest{UserGroupId: "5f1d7f1c2a3b4c5d6e7f8091"})
+	if err == nil {
+		_, err = stream.Recv()
+	}
+	wantCode(t, err, codes.InvalidArgument)
+}
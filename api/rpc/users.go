@@ -0,0 +1,119 @@
+package rpc
+
+import (
+	"context"
+
+	"github.com/sr-codefreak/user-group/api/rpc/pb"
+	"github.com/sr-codefreak/user-group/db/mongodb/user"
+	"github.com/sr-codefreak/user-group/myerrors"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+type userService struct {
+	pb.UnimplementedUserServiceServer
+	*Server
+}
+
+func toUser(u *user.User) (*pb.User, error) {
+	metaData, err := toStruct(u.MetaData)
+	if err != nil {
+		return nil, err
+	}
+	refs := make([]*pb.UserGroupRef, 0, len(u.UsersGroups))
+	for _, ref := range u.UsersGroups {
+		refs = append(refs, &pb.UserGroupRef{Id: ref.ID, Name: ref.Name})
+	}
+	return &pb.User{
+		Id:         u.ID.Hex(),
+		Name:       u.Name,
+		Email:      u.Email,
+		Phone:      u.Phone,
+		MetaData:   metaData,
+		UserGroups: refs,
+	}, nil
+}
+
+func (s userService) ListUsers(ctx context.Context, req *pb.ListRequest) (*pb.ListUsersResponse, error) {
+	users, next, err := s.backend.Users().List(ctx, toListOptions(req))
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	resp := &pb.ListUsersResponse{NextPageToken: next}
+	for i := range users {
+		pu, err := toUser(&users[i])
+		if err != nil {
+			return nil, toStatus(err)
+		}
+		resp.Users = append(resp.Users, pu)
+	}
+	return resp, nil
+}
+
+func (s userService) CreateUser(ctx context.Context, req *pb.CreateUserRequest) (*pb.User, error) {
+	u := &user.User{
+		Name:     req.GetUser().GetName(),
+		Email:    req.GetUser().GetEmail(),
+		Phone:    req.GetUser().GetPhone(),
+		MetaData: fromStruct(req.GetUser().GetMetaData()),
+	}
+	if err := s.backend.Users().Create(ctx, u); err != nil {
+		return nil, toStatus(err)
+	}
+	pu, err := toUser(u)
+	return pu, toStatus(err)
+}
+
+func (s userService) GetUser(ctx context.Context, req *pb.GetUserRequest) (*pb.User, error) {
+	u, err := s.backend.Users().GetById(ctx, req.GetId())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	pu, err := toUser(u)
+	return pu, toStatus(err)
+}
+
+func (s userService) UpdateUser(ctx context.Context, req *pb.UpdateUserRequest) (*pb.User, error) {
+	oid, err := objectID(myerrors.KindUser, req.GetUser().GetId())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	u := &user.User{
+		ID:       oid,
+		Name:     req.GetUser().GetName(),
+		Email:    req.GetUser().GetEmail(),
+		Phone:    req.GetUser().GetPhone(),
+		MetaData: fromStruct(req.GetUser().GetMetaData()),
+	}
+	if err := s.backend.Users().Update(ctx, u); err != nil {
+		return nil, toStatus(err)
+	}
+	return s.GetUser(ctx, &pb.GetUserRequest{Id: oid.Hex()})
+}
+
+func (s userService) DeleteUser(ctx context.Context, req *pb.DeleteUserRequest) (*emptypb.Empty, error) {
+	oid, err := objectID(myerrors.KindUser, req.GetId())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	if err := s.backend.Users().Delete(ctx, oid); err != nil {
+		return nil, toStatus(err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s userService) ListUserAccess(req *pb.ListUserAccessRequest, stream pb.UserService_ListUserAccessServer) error {
+	oid, err := objectID(myerrors.KindUser, req.GetUserId())
+	if err != nil {
+		return toStatus(err)
+	}
+	accesses, err := s.backend.Access().ListRolesForUser(stream.Context(), oid)
+	if err != nil {
+		return toStatus(err)
+	}
+	for _, a := range accesses {
+		if err := stream.Send(toAccess(a)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,152 @@
+package rpc
+
+import (
+	"context"
+
+	"github.com/sr-codefreak/user-group/api/rpc/pb"
+	"github.com/sr-codefreak/user-group/db/mongodb/event"
+	"github.com/sr-codefreak/user-group/db/mongodb/webhook"
+	"github.com/sr-codefreak/user-group/myerrors"
+	"github.com/sr-codefreak/user-group/webhooks"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type webhookService struct {
+	pb.UnimplementedWebhookServiceServer
+	*Server
+}
+
+func toWebhook(s *webhook.Subscription) *pb.WebhookSubscription {
+	types := make([]string, 0, len(s.Types))
+	for _, t := range s.Types {
+		types = append(types, string(t))
+	}
+	return &pb.WebhookSubscription{
+		Id:          s.ID.Hex(),
+		Url:         s.URL,
+		Secret:      s.Secret,
+		UserGroupId: s.UserGroupId,
+		Types:       types,
+		Disabled:    s.Disabled,
+		Created:     timestamppb.New(s.Created),
+	}
+}
+
+func fromWebhook(s *pb.WebhookSubscription) *webhook.Subscription {
+	var types []event.Type
+	for _, t := range s.GetTypes() {
+		types = append(types, event.Type(t))
+	}
+	return &webhook.Subscription{
+		URL:         s.GetUrl(),
+		Secret:      s.GetSecret(),
+		UserGroupId: s.GetUserGroupId(),
+		Types:       types,
+		Disabled:    s.GetDisabled(),
+	}
+}
+
+func toDelivery(d *webhook.Delivery) *pb.WebhookDelivery {
+	attempts := make([]*pb.WebhookAttempt, 0, len(d.Attempts))
+	for _, a := range d.Attempts {
+		attempts = append(attempts, &pb.WebhookAttempt{
+			Time:       timestamppb.New(a.Time),
+			StatusCode: int32(a.StatusCode),
+			Error:      a.Error,
+		})
+	}
+	return &pb.WebhookDelivery{
+		Id:          d.ID.Hex(),
+		WebhookId:   d.SubscriptionId,
+		EventId:     d.EventId,
+		EventType:   string(d.EventType),
+		Payload:     d.Payload,
+		Status:      string(d.Status),
+		Attempts:    attempts,
+		NextAttempt: timestamppb.New(d.NextAttempt),
+		Created:     timestamppb.New(d.Created),
+	}
+}
+
+func (s webhookService) ListWebhooks(ctx context.Context, req *emptypb.Empty) (*pb.ListWebhooksResponse, error) {
+	subs, err := s.backend.Webhooks().List(ctx)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	resp := &pb.ListWebhooksResponse{Webhooks: make([]*pb.WebhookSubscription, 0, len(subs))}
+	for i := range subs {
+		subs[i].Secret = ""
+		resp.Webhooks = append(resp.Webhooks, toWebhook(&subs[i]))
+	}
+	return resp, nil
+}
+
+func (s webhookService) CreateWebhook(ctx context.Context, req *pb.CreateWebhookRequest) (*pb.WebhookSubscription, error) {
+	sub := fromWebhook(req.GetWebhook())
+	if sub.Secret == "" {
+		secret, err := webhooks.NewSecret()
+		if err != nil {
+			return nil, toStatus(err)
+		}
+		sub.Secret = secret
+	}
+	if err := s.backend.Webhooks().Create(ctx, sub); err != nil {
+		return nil, toStatus(err)
+	}
+	return toWebhook(sub), nil
+}
+
+func (s webhookService) GetWebhook(ctx context.Context, req *pb.GetWebhookRequest) (*pb.WebhookSubscription, error) {
+	sub, err := s.backend.Webhooks().Get(ctx, req.GetId())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	sub.Secret = ""
+	return toWebhook(sub), nil
+}
+
+func (s webhookService) UpdateWebhook(ctx context.Context, req *pb.UpdateWebhookRequest) (*pb.WebhookSubscription, error) {
+	id, err := objectID(myerrors.KindWebhook, req.GetWebhook().GetId())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	sub := fromWebhook(req.GetWebhook())
+	sub.ID = id
+	if err := s.backend.Webhooks().Update(ctx, sub); err != nil {
+		return nil, toStatus(err)
+	}
+	return s.GetWebhook(ctx, &pb.GetWebhookRequest{Id: id.Hex()})
+}
+
+func (s webhookService) DeleteWebhook(ctx context.Context, req *pb.DeleteWebhookRequest) (*emptypb.Empty, error) {
+	id, err := objectID(myerrors.KindWebhook, req.GetId())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	if err := s.backend.Webhooks().Delete(ctx, id); err != nil {
+		return nil, toStatus(err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s webhookService) ListDeliveries(req *pb.ListDeliveriesRequest, stream pb.WebhookService_ListDeliveriesServer) error {
+	ctx := stream.Context()
+	if _, err := s.backend.Webhooks().Get(ctx, req.GetWebhookId()); err != nil {
+		return toStatus(err)
+	}
+	deliveries, err := s.backend.Webhooks().ListDeliveries(ctx, webhook.DeliveryQuery{
+		SubscriptionId: req.GetWebhookId(),
+		Status:         webhook.Status(req.GetStatus()),
+		Limit:          int(req.GetLimit()),
+	})
+	if err != nil {
+		return toStatus(err)
+	}
+	for i := range deliveries {
+		if err := stream.Send(toDelivery(&deliveries[i])); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,221 @@
+package scim
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/sr-codefreak/user-group/db/mongodb"
+)
+
+type supported struct {
+	Supported bool `json:"supported"`
+}
+
+type filterSupported struct {
+	Supported  bool `json:"supported"`
+	MaxResults int  `json:"maxResults"`
+}
+
+type bulkSupported struct {
+	Supported      bool `json:"supported"`
+	MaxOperations  int  `json:"maxOperations"`
+	MaxPayloadSize int  `json:"maxPayloadSize"`
+}
+
+type authenticationScheme struct {
+	Type        string `json:"type"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+type serviceProviderConfig struct {
+	Schemas               []string               `json:"schemas"`
+	Patch                 supported              `json:"patch"`
+	Bulk                  bulkSupported          `json:"bulk"`
+	Filter                filterSupported        `json:"filter"`
+	ChangePassword        supported              `json:"changePassword"`
+	Sort                  supported              `json:"sort"`
+	ETag                  supported              `json:"etag"`
+	AuthenticationSchemes []authenticationScheme `json:"authenticationSchemes"`
+	Meta                  meta                   `json:"meta"`
+}
+
+func (s *Server) serviceProviderConfig(r *http.Request) (any, error) {
+	return serviceProviderConfig{
+		Schemas: []string{serviceProviderSchema},
+		Patch:   supported{true},
+		Bulk:    bulkSupported{},
+		Filter:  filterSupported{Supported: true, MaxResults: mongodb.MaxPageSize},
+		// authentication is left to the proxy in front of the server
+		AuthenticationSchemes: []authenticationScheme{},
+		Meta: meta{
+			ResourceType: "ServiceProviderConfig",
+			Location:     s.location(r, "ServiceProviderConfig"),
+		},
+	}, nil
+}
+
+type schemaExtension struct {
+	Schema   string `json:"schema"`
+	Required bool   `json:"required"`
+}
+
+type resourceTypeDoc struct {
+	Schemas          []string          `json:"schemas"`
+	ID               string            `json:"id"`
+	Name             string            `json:"name"`
+	Endpoint         string            `json:"endpoint"`
+	Description      string            `json:"description"`
+	Schema           string            `json:"schema"`
+	SchemaExtensions []schemaExtension `json:"schemaExtensions"`
+	Meta             meta              `json:"meta"`
+}
+
+func (s *Server) resourceTypes(r *http.Request, segments []string) (any, error) {
+	types := []resourceTypeDoc{{
+		ID:               "User",
+		Name:             "User",
+		Endpoint:         "/Users",
+		Description:      "User",
+		Schema:           UserSchema,
+		SchemaExtensions: []schemaExtension{{Schema: UserExtension}},
+	}, {
+		ID:               "Group",
+		Name:             "Group",
+		Endpoint:         "/Groups",
+		Description:      "User group",
+		Schema:           GroupSchema,
+		SchemaExtensions: []schemaExtension{{Schema: GroupExtension}},
+	}}
+	resources := make([]any, len(types))
+	for i := range types {
+		types[i].Schemas = []string{resourceTypeSchema}
+		types[i].Meta = meta{ResourceType: "ResourceType", Location: s.location(r, "ResourceTypes/"+types[i].ID)}
+		if len(segments) == 1 && segments[0] == types[i].ID {
+			return types[i], nil
+		}
+		resources[i] = types[i]
+	}
+	if len(segments) == 1 {
+		return nil, errNotFound
+	}
+	return listOf(resources), nil
+}
+
+// schemaAttribute describes an attribute, RFC 7643 section 7
+type schemaAttribute struct {
+	Name          string            `json:"name"`
+	Type          string            `json:"type"`
+	MultiValued   bool              `json:"multiValued"`
+	Description   string            `json:"description,omitempty"`
+	Required      bool              `json:"required"`
+	CaseExact     bool              `json:"caseExact"`
+	Mutability    string            `json:"mutability"`
+	Returned      string            `json:"returned"`
+	Uniqueness    string            `json:"uniqueness"`
+	SubAttributes []schemaAttribute `json:"subAttributes,omitempty"`
+}
+
+type schemaDoc struct {
+	Schemas     []string          `json:"schemas"`
+	ID          string            `json:"id"`
+	Name        string            `json:"name"`
+	Description string            `json:"description"`
+	Attributes  []schemaAttribute `json:"attributes"`
+	Meta        meta              `json:"meta"`
+}
+
+// attribute returns a readWrite attribute returned by default
+func attribute(name, typ, description string) schemaAttribute {
+	return schemaAttribute{
+		Name:        name,
+		Type:        typ,
+		Description: description,
+		Mutability:  "readWrite",
+		Returned:    "default",
+		Uniqueness:  "none",
+	}
+}
+
+// multiValuedAttribute returns a multi-valued complex attribute with value and display
+func multiValuedAttribute(name, description, mutability string) schemaAttribute {
+	a := attribute(name, "complex", description)
+	a.MultiValued = true
+	a.Mutability = mutability
+	value := attribute("value", "string", "")
+	value.Mutability = mutability
+	display := attribute("display", "string", "")
+	display.Mutability = "readOnly"
+	a.SubAttributes = []schemaAttribute{value, display}
+	return a
+}
+
+func schemaDocs() []schemaDoc {
+	userName := attribute("userName", "string", "Unique name of the user, its name")
+	userName.Required = true
+	userName.Uniqueness = "server"
+	externalId := attribute("externalId", "string", "Identifier of the user in the provisioning client")
+	externalId.CaseExact = true
+	active := attribute("active", "boolean", "Whether the user is active, true when never set")
+	emails := multiValuedAttribute("emails", "The primary or first email is stored", "readWrite")
+	phoneNumbers := multiValuedAttribute("phoneNumbers", "The primary or first phone number is stored", "readWrite")
+	for _, a := range []*schemaAttribute{&emails, &phoneNumbers} {
+		a.SubAttributes = append(a.SubAttributes[:1], attribute("primary", "boolean", ""), attribute("type", "string", ""))
+	}
+	groups := multiValuedAttribute("groups", "User groups of the user", "readOnly")
+	groupExternalId := externalId
+	groupExternalId.Description = "Identifier of the user group in the provisioning client, set on creation"
+	groupExternalId.Mutability = "immutable"
+	displayName := attribute("displayName", "string", "Name of the user group")
+	displayName.Required = true
+	members := multiValuedAttribute("members", "Users of the user group", "readWrite")
+	return []schemaDoc{{
+		ID:          UserSchema,
+		Name:        "User",
+		Description: "User",
+		Attributes:  []schemaAttribute{userName, externalId, active, emails, phoneNumbers, groups},
+	}, {
+		ID:          GroupSchema,
+		Name:        "Group",
+		Description: "User group",
+		Attributes:  []schemaAttribute{displayName, groupExternalId, members},
+	}, {
+		ID:          UserExtension,
+		Name:        "UserMetaData",
+		Description: "Any attributes, stored in the metaData of the user",
+		Attributes:  []schemaAttribute{},
+	}, {
+		ID:          GroupExtension,
+		Name:        "GroupMetaData",
+		Description: "Any attributes, stored in the metaData of the user group and set on creation",
+		Attributes:  []schemaAttribute{},
+	}}
+}
+
+func (s *Server) schemas(r *http.Request, segments []string) (any, error) {
+	docs := schemaDocs()
+	resources := make([]any, len(docs))
+	for i := range docs {
+		docs[i].Schemas = []string{schemaSchema}
+		docs[i].Meta = meta{ResourceType: "Schema", Location: s.location(r, "Schemas/"+docs[i].ID)}
+		if len(segments) == 1 && strings.EqualFold(segments[0], docs[i].ID) {
+			return docs[i], nil
+		}
+		resources[i] = docs[i]
+	}
+	if len(segments) == 1 {
+		return nil, errNotFound
+	}
+	return listOf(resources), nil
+}
+
+// listOf returns the list response holding all the resources
+func listOf(resources []any) listResponse {
+	return listResponse{
+		Schemas:      []string{listResponseSchema},
+		TotalResults: len(resources),
+		StartIndex:   1,
+		ItemsPerPage: len(resources),
+		Resources:    resources,
+	}
+}
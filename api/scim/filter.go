@@ -0,0 +1,478 @@
+package scim
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// filter is a parsed SCIM filter, RFC 7644 section 3.4.2.2, evaluated
+// against the JSON document of a resource
+type filter interface {
+	match(doc map[string]any) bool
+}
+
+type andFilter struct{ left, right filter }
+
+func (f andFilter) match(doc map[string]any) bool { return f.left.match(doc) && f.right.match(doc) }
+
+type orFilter struct{ left, right filter }
+
+func (f orFilter) match(doc map[string]any) bool { return f.left.match(doc) || f.right.match(doc) }
+
+type notFilter struct{ f filter }
+
+func (f notFilter) match(doc map[string]any) bool { return !f.f.match(doc) }
+
+// attrPath is an attribute of a resource: the schema URN of an extension,
+// the attribute and its sub-attribute
+type attrPath struct {
+	urn  string
+	attr string
+	sub  string
+}
+
+func parseAttrPath(s string) (attrPath, error) {
+	var p attrPath
+	if strings.HasPrefix(strings.ToLower(s), "urn:") {
+		i := strings.LastIndex(s, ":")
+		p.urn, s = s[:i], s[i+1:]
+		if strings.EqualFold(p.urn, UserSchema) || strings.EqualFold(p.urn, GroupSchema) {
+			p.urn = ""
+		}
+	}
+	p.attr, p.sub, _ = strings.Cut(s, ".")
+	if p.attr == "" || strings.Contains(p.sub, ".") {
+		return p, fmt.Errorf("invalid attribute path %q", s)
+	}
+	return p, nil
+}
+
+func (p attrPath) String() string {
+	s := p.attr
+	if p.sub != "" {
+		s += "." + p.sub
+	}
+	if p.urn != "" {
+		s = p.urn + ":" + s
+	}
+	return s
+}
+
+// caseExact reports whether the strings of the attribute compare case sensitively
+func (p attrPath) caseExact() bool {
+	return p.urn == "" && p.sub == "" && (strings.EqualFold(p.attr, "id") || strings.EqualFold(p.attr, "externalId"))
+}
+
+// lookup returns the value of key in doc, keys are case insensitive
+func lookup(doc map[string]any, key string) (any, bool) {
+	if v, ok := doc[key]; ok {
+		return v, true
+	}
+	for k, v := range doc {
+		if strings.EqualFold(k, key) {
+			return v, true
+		}
+	}
+	return nil, false
+}
+
+// values returns the values of the attribute in doc, one per element of a
+// multi-valued attribute. Complex values without sub-attribute yield their
+// "value" sub-attribute.
+func (p attrPath) values(doc map[string]any) []any {
+	v, ok := lookup(p.container(doc, false), p.attr)
+	if !ok || v == nil {
+		return nil
+	}
+	elems, multi := v.([]any)
+	if !multi {
+		elems = []any{v}
+	}
+	values := []any{}
+	for _, e := range elems {
+		m, complex := e.(map[string]any)
+		switch {
+		case p.sub != "" && complex:
+			if sv, ok := lookup(m, p.sub); ok && sv != nil {
+				values = append(values, sv)
+			}
+		case p.sub != "":
+		case complex:
+			if sv, ok := lookup(m, "value"); ok && sv != nil {
+				values = append(values, sv)
+			}
+		default:
+			values = append(values, e)
+		}
+	}
+	return values
+}
+
+type presentFilter struct{ path attrPath }
+
+func (f presentFilter) match(doc map[string]any) bool {
+	for _, v := range f.path.values(doc) {
+		if s, ok := v.(string); !ok || s != "" {
+			return true
+		}
+	}
+	return false
+}
+
+type compareFilter struct {
+	path  attrPath
+	op    string
+	value any
+}
+
+func (f compareFilter) match(doc map[string]any) bool {
+	values := f.path.values(doc)
+	if f.op == "ne" {
+		for _, v := range values {
+			if compareValues(v, f.value, "eq", f.path.caseExact()) {
+				return false
+			}
+		}
+		return true
+	}
+	for _, v := range values {
+		if compareValues(v, f.value, f.op, f.path.caseExact()) {
+			return true
+		}
+	}
+	return false
+}
+
+// compareValues applies op to the attribute value a and the filter value b
+func compareValues(a any, b any, op string, caseExact bool) bool {
+	switch x := a.(type) {
+	case string:
+		y, ok := b.(string)
+		if !ok {
+			return false
+		}
+		if !caseExact {
+			x, y = strings.ToLower(x), strings.ToLower(y)
+		}
+		switch op {
+		case "eq":
+			return x == y
+		case "co":
+			return strings.Contains(x, y)
+		case "sw":
+			return strings.HasPrefix(x, y)
+		case "ew":
+			return strings.HasSuffix(x, y)
+		case "gt":
+			return x > y
+		case "ge":
+			return x >= y
+		case "lt":
+			return x < y
+		case "le":
+			return x <= y
+		}
+	case float64:
+		y, ok := b.(float64)
+		if !ok {
+			return false
+		}
+		switch op {
+		case "eq":
+			return x == y
+		case "gt":
+			return x > y
+		case "ge":
+			return x >= y
+		case "lt":
+			return x < y
+		case "le":
+			return x <= y
+		}
+	case bool:
+		y, ok := b.(bool)
+		return ok && op == "eq" && x == y
+	}
+	return false
+}
+
+// valuePathFilter matches the resources having an element of the
+// multi-valued attribute matching the filter, e.g. emails[type eq "work"]
+type valuePathFilter struct {
+	path attrPath
+	f    filter
+}
+
+func (f valuePathFilter) match(doc map[string]any) bool {
+	for _, e := range f.path.elements(doc) {
+		if m, ok := e.(map[string]any); ok && f.f.match(m) {
+			return true
+		}
+	}
+	return false
+}
+
+// elements returns the elements of the multi-valued attribute in doc
+func (p attrPath) elements(doc map[string]any) []any {
+	v, _ := lookup(p.container(doc, false), p.attr)
+	elems, ok := v.([]any)
+	if !ok && v != nil {
+		elems = []any{v}
+	}
+	return elems
+}
+
+type filterTokenKind int
+
+const (
+	ftEOF filterTokenKind = iota
+	ftWord
+	ftString
+	ftNumber
+	ftLParen
+	ftRParen
+	ftLBracket
+	ftRBracket
+)
+
+type filterToken struct {
+	kind filterTokenKind
+	text string
+	pos  int
+}
+
+func (t filterToken) String() string {
+	if t.kind == ftEOF {
+		return "end of filter"
+	}
+	return fmt.Sprintf("%q at %d", t.text, t.pos)
+}
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || strings.ContainsRune("_-.:$", r)
+}
+
+// filterParser is a recursive descent parser of
+//
+//	or        = and { "or" and }
+//	and       = not { "and" not }
+//	not       = "not" "(" or ")" | "(" or ")" | attrExp
+//	attrExp   = attrPath "pr" | attrPath compareOp value | attrPath "[" or "]"
+type filterParser struct {
+	src string
+	pos int
+	tok filterToken
+	err error
+}
+
+func (p *filterParser) next() {
+	if p.err != nil {
+		return
+	}
+	for p.pos < len(p.src) && p.src[p.pos] == ' ' {
+		p.pos++
+	}
+	start := p.pos
+	if p.pos >= len(p.src) {
+		p.tok = filterToken{kind: ftEOF, pos: start}
+		return
+	}
+	c := p.src[p.pos]
+	single := map[byte]filterTokenKind{'(': ftLParen, ')': ftRParen, '[': ftLBracket, ']': ftRBracket}
+	switch kind, ok := single[c]; {
+	case ok:
+		p.pos++
+		p.tok = filterToken{kind: kind, text: string(c), pos: start}
+	case c == '"':
+		p.pos++
+		for p.pos < len(p.src) && p.src[p.pos] != '"' {
+			if p.src[p.pos] == '\\' {
+				p.pos++
+			}
+			p.pos++
+		}
+		if p.pos >= len(p.src) {
+			p.err = fmt.Errorf("unterminated string at %d", start)
+			return
+		}
+		p.pos++
+		var s string
+		if err := json.Unmarshal([]byte(p.src[start:p.pos]), &s); err != nil {
+			p.err = fmt.Errorf("invalid string at %d: %w", start, err)
+			return
+		}
+		p.tok = filterToken{kind: ftString, text: s, pos: start}
+	case c == '-' || (c >= '0' && c <= '9'):
+		p.pos++
+		for p.pos < len(p.src) && strings.ContainsRune("0123456789.eE+-", rune(p.src[p.pos])) {
+			p.pos++
+		}
+		p.tok = filterToken{kind: ftNumber, text: p.src[start:p.pos], pos: start}
+	case isWordRune(rune(c)):
+		for p.pos < len(p.src) && isWordRune(rune(p.src[p.pos])) {
+			p.pos++
+		}
+		p.tok = filterToken{kind: ftWord, text: p.src[start:p.pos], pos: start}
+	default:
+		p.err = fmt.Errorf("unexpected %q at %d", c, start)
+	}
+}
+
+func (p *filterParser) errorf(format string, args ...any) error {
+	if p.err != nil {
+		return p.err
+	}
+	return fmt.Errorf(format, args...)
+}
+
+func (p *filterParser) keyword(kw string) bool {
+	return p.err == nil && p.tok.kind == ftWord && strings.EqualFold(p.tok.text, kw)
+}
+
+func (p *filterParser) parseOr() (filter, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.keyword("or") {
+		p.next()
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = orFilter{left, right}
+	}
+	return left, p.err
+}
+
+func (p *filterParser) parseAnd() (filter, error) {
+	left, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+	for p.keyword("and") {
+		p.next()
+		right, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		left = andFilter{left, right}
+	}
+	return left, p.err
+}
+
+func (p *filterParser) parseNot() (filter, error) {
+	negate := p.keyword("not")
+	if negate {
+		p.next()
+		if p.err == nil && p.tok.kind != ftLParen {
+			return nil, p.errorf("expected ( instead of %s", p.tok)
+		}
+	}
+	if p.err == nil && p.tok.kind == ftLParen {
+		p.next()
+		f, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if p.tok.kind != ftRParen {
+			return nil, p.errorf("expected ) instead of %s", p.tok)
+		}
+		p.next()
+		if negate {
+			f = notFilter{f}
+		}
+		return f, p.err
+	}
+	return p.parseAttrExp()
+}
+
+var compareOps = map[string]bool{
+	"eq": true, "ne": true, "co": true, "sw": true, "ew": true,
+	"gt": true, "ge": true, "lt": true, "le": true,
+}
+
+func (p *filterParser) parseAttrExp() (filter, error) {
+	if p.err != nil {
+		return nil, p.err
+	}
+	if p.tok.kind != ftWord {
+		return nil, p.errorf("expected an attribute instead of %s", p.tok)
+	}
+	path, err := parseAttrPath(p.tok.text)
+	if err != nil {
+		return nil, err
+	}
+	p.next()
+	if p.err == nil && p.tok.kind == ftLBracket {
+		if path.sub != "" {
+			return nil, fmt.Errorf("invalid value path %q", path)
+		}
+		p.next()
+		f, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if p.tok.kind != ftRBracket {
+			return nil, p.errorf("expected ] instead of %s", p.tok)
+		}
+		p.next()
+		return valuePathFilter{path: path, f: f}, p.err
+	}
+	if p.keyword("pr") {
+		p.next()
+		return presentFilter{path: path}, p.err
+	}
+	if p.err != nil || p.tok.kind != ftWord || !compareOps[strings.ToLower(p.tok.text)] {
+		return nil, p.errorf("expected an operator instead of %s", p.tok)
+	}
+	op := strings.ToLower(p.tok.text)
+	p.next()
+	value, err := p.parseValue()
+	if err != nil {
+		return nil, err
+	}
+	return compareFilter{path: path, op: op, value: value}, nil
+}
+
+func (p *filterParser) parseValue() (any, error) {
+	if p.err != nil {
+		return nil, p.err
+	}
+	tok := p.tok
+	p.next()
+	switch {
+	case tok.kind == ftString:
+		return tok.text, p.err
+	case tok.kind == ftNumber:
+		f, err := strconv.ParseFloat(tok.text, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid number %s", tok)
+		}
+		return f, p.err
+	case tok.kind == ftWord && strings.EqualFold(tok.text, "true"):
+		return true, p.err
+	case tok.kind == ftWord && strings.EqualFold(tok.text, "false"):
+		return false, p.err
+	case tok.kind == ftWord && strings.EqualFold(tok.text, "null"):
+		return nil, p.err
+	}
+	return nil, fmt.Errorf("expected a value instead of %s", tok)
+}
+
+// parseFilter parses src, errors are reported with scimType invalidFilter
+func parseFilter(src string) (filter, error) {
+	p := &filterParser{src: src}
+	p.next()
+	f, err := p.parseOr()
+	if err == nil && p.tok.kind != ftEOF {
+		err = p.errorf("unexpected %s", p.tok)
+	}
+	if err != nil {
+		return nil, &Error{Status: 400, ScimType: "invalidFilter", Detail: fmt.Sprintf("filter %q: %s", src, err)}
+	}
+	return f, nil
+}
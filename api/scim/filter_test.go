@@ -0,0 +1,102 @@
+package scim
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+// jsonDoc decodes the JSON object s as the document of a resource
+func jsonDoc(t *testing.T, s string) map[string]any {
+	t.Helper()
+	doc := map[string]any{}
+	if err := json.Unmarshal([]byte(s), &doc); err != nil {
+		t.Fatal(err)
+	}
+	return doc
+}
+
+func TestParseFilter(t *testing.T) {
+	doc := jsonDoc(t, `{
+		"id": "64a1",
+		"externalId": "Ext-1",
+		"userName": "Ann.Lee",
+		"active": true,
+		"emails": [{"value": "ann@work.example", "type": "work"}, {"value": "ann@home.example", "type": "home"}],
+		"meta": {"created": "2023-07-01T00:00:00Z"},
+		"`+UserExtension+`": {"level": 3, "team": "eng"}
+	}`)
+	for _, c := range []struct {
+		src  string
+		want bool
+	}{
+		{`userName eq "ann.lee"`, true},
+		{`USERNAME EQ "ANN.LEE"`, true},
+		{`userName ne "ann.lee"`, false},
+		{`userName ne "bob"`, true},
+		{`userName co "n.L"`, true},
+		{`userName sw "ann"`, true},
+		{`userName ew "lee"`, true},
+		{`userName gt "ann"`, true},
+		{`userName lt "ann"`, false},
+		{`externalId eq "ext-1"`, false},
+		{`externalId eq "Ext-1"`, true},
+		{`id eq "64A1"`, false},
+		{`active eq true`, true},
+		{`active eq false`, false},
+		{`active eq "true"`, false},
+		{`phoneNumbers pr`, false},
+		{`emails pr`, true},
+		{`emails eq "ann@home.example"`, true},
+		{`emails.type eq "work"`, true},
+		{`emails[type eq "work" and value ew "work.example"]`, true},
+		{`emails[type eq "work" and value ew "home.example"]`, false},
+		{`meta.created ge "2023-01-01"`, true},
+		{UserSchema + `:userName eq "ann.lee"`, true},
+		{UserExtension + `:level gt 2`, true},
+		{UserExtension + `:level le 2.5`, false},
+		{UserExtension + `:team eq "eng"`, true},
+		{`missing eq null`, false},
+		{`userName eq "bob" or active eq true`, true},
+		{`userName eq "bob" or active eq false and userName pr`, false},
+		{`(userName eq "bob" or active eq true) and emails pr`, true},
+		{`not (userName eq "bob")`, true},
+		{`not (userName eq "ann.lee") or not (active eq false)`, true},
+		{`userName eq "a\"b"`, false},
+	} {
+		f, err := parseFilter(c.src)
+		if err != nil {
+			t.Errorf("%s: %s", c.src, err)
+			continue
+		}
+		if got := f.match(doc); got != c.want {
+			t.Errorf("%s: match = %v, want %v", c.src, got, c.want)
+		}
+	}
+}
+
+func TestParseFilterErrors(t *testing.T) {
+	for _, src := range []string{
+		``,
+		`userName`,
+		`userName eq`,
+		`userName is "ann"`,
+		`userName eq "ann`,
+		`userName eq ann`,
+		`userName eq "ann" and`,
+		`(userName eq "ann"`,
+		`userName eq "ann")`,
+		`not userName eq "ann"`,
+		`emails[type eq "work"`,
+		`emails.type[value pr]`,
+		`a.b.c pr`,
+		`userName eq "ann" # comment`,
+		`userName eq 1e`,
+	} {
+		_, err := parseFilter(src)
+		var e *Error
+		if !errors.As(err, &e) || e.Status != 400 || e.ScimType != "invalidFilter" {
+			t.Errorf("%q: %v, want an invalidFilter error", src, err)
+		}
+	}
+}
@@ -0,0 +1,219 @@
+package scim
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/sr-codefreak/user-group/db/mongodb"
+)
+
+// defaultCount is the page size of a list request without count
+const defaultCount = 100
+
+type listResponse struct {
+	Schemas      []string `json:"schemas"`
+	TotalResults int      `json:"totalResults"`
+	StartIndex   int      `json:"startIndex"`
+	ItemsPerPage int      `json:"itemsPerPage"`
+	Resources    []any    `json:"Resources"`
+}
+
+// list answers a query, RFC 7644 section 3.4.2:
+//
+//	filter=userName eq "x"  resources matching the filter, see parseFilter
+//	startIndex=1            1-based index of the first resource of the page
+//	count=100               size of the page, at most mongodb.MaxPageSize
+//	attributes=             attributes returned, see project
+//	excludedAttributes=     attributes left out
+//
+// The filter is evaluated on every resource of the backend.
+func (s *Server) list(r *http.Request, rt resourceType) (any, error) {
+	query := r.URL.Query()
+	var f filter
+	if src := query.Get("filter"); src != "" {
+		var err error
+		if f, err = parseFilter(src); err != nil {
+			return nil, err
+		}
+	}
+	startIndex, err := intParam(query.Get("startIndex"), 1)
+	if err != nil {
+		return nil, err
+	}
+	if startIndex < 1 {
+		startIndex = 1
+	}
+	count, err := intParam(query.Get("count"), defaultCount)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case count < 0:
+		count = 0
+	case count > mongodb.MaxPageSize:
+		count = mongodb.MaxPageSize
+	}
+	p := projectionOf(query)
+	res := listResponse{
+		Schemas:    []string{listResponseSchema},
+		StartIndex: startIndex,
+		Resources:  []any{},
+	}
+	err = rt.scan(r, func(doc map[string]any) {
+		if f != nil && !f.match(doc) {
+			return
+		}
+		res.TotalResults++
+		if res.TotalResults >= startIndex && len(res.Resources) < count {
+			res.Resources = append(res.Resources, p.project(doc))
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	res.ItemsPerPage = len(res.Resources)
+	return res, nil
+}
+
+func intParam(s string, def int) (int, error) {
+	if s == "" {
+		return def, nil
+	}
+	n, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, invalidValue("%s", err)
+	}
+	return n, nil
+}
+
+// projection selects the attributes of the returned resources with the
+// attributes and excludedAttributes parameters. Both name top-level
+// attributes, extension attributes are named with the URN of their schema.
+// schemas, id and meta are always returned.
+type projection struct {
+	attributes []attrPath
+	excluded   []attrPath
+}
+
+func projectionOf(query map[string][]string) projection {
+	var p projection
+	for _, name := range splitList(first(query["attributes"])) {
+		p.attributes = append(p.attributes, projectionPath(name))
+	}
+	for _, name := range splitList(first(query["excludedAttributes"])) {
+		p.excluded = append(p.excluded, projectionPath(name))
+	}
+	return p
+}
+
+func first(values []string) string {
+	if len(values) == 0 {
+		return ""
+	}
+	return values[0]
+}
+
+func splitList(s string) []string {
+	var names []string
+	for _, name := range strings.Split(s, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// projectionPath returns the attribute named by name, an extension URN names the whole extension
+func projectionPath(name string) attrPath {
+	if isExtension(name) {
+		return attrPath{attr: name}
+	}
+	p, err := parseAttrPath(name)
+	if err != nil {
+		return attrPath{attr: name}
+	}
+	p.sub = ""
+	return p
+}
+
+var alwaysReturned = []string{"schemas", "id", "meta"}
+
+// project returns the attributes of doc selected by p
+func (p projection) project(doc map[string]any) map[string]any {
+	if len(p.attributes) == 0 && len(p.excluded) == 0 {
+		return doc
+	}
+	out := doc
+	if len(p.attributes) > 0 {
+		out = map[string]any{}
+		for _, key := range alwaysReturned {
+			if v, ok := lookup(doc, key); ok {
+				out[key] = v
+			}
+		}
+		for _, a := range p.attributes {
+			copyAttr(out, doc, a)
+		}
+	} else {
+		out = copyDoc(doc)
+	}
+	for _, a := range p.excluded {
+		if a.urn == "" && containsFold(alwaysReturned, a.attr) {
+			continue
+		}
+		if target := a.container(out, false); target != nil {
+			deleteAttr(target, a.attr)
+		}
+	}
+	return out
+}
+
+// copyAttr copies the attribute a of src to dst
+func copyAttr(dst, src map[string]any, a attrPath) {
+	from := a.container(src, false)
+	for k, v := range from {
+		if !strings.EqualFold(k, a.attr) {
+			continue
+		}
+		if a.urn == "" {
+			dst[k] = v
+			continue
+		}
+		to := a.container(dst, true)
+		to[k] = v
+	}
+}
+
+func containsFold(values []string, s string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, s) {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *Server) get(r *http.Request, rt resourceType, id string) (any, error) {
+	doc, err := rt.get(r, id)
+	if err != nil {
+		return nil, err
+	}
+	return projectionOf(r.URL.Query()).project(doc), nil
+}
+
+func (s *Server) create(w http.ResponseWriter, r *http.Request, rt resourceType) (any, error) {
+	doc := map[string]any{}
+	if err := readJSON(w, r, &doc); err != nil {
+		return nil, err
+	}
+	return rt.create(r, doc)
+}
+
+func (s *Server) replace(w http.ResponseWriter, r *http.Request, rt resourceType, id string) (any, error) {
+	doc := map[string]any{}
+	if err := readJSON(w, r, &doc); err != nil {
+		return nil, err
+	}
+	return rt.replace(r, id, doc)
+}
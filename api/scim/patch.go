@@ -0,0 +1,357 @@
+package scim
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+)
+
+// patchRequest is the body of a PATCH request, RFC 7644 section 3.5.2
+type patchRequest struct {
+	Schemas    []string         `json:"schemas"`
+	Operations []patchOperation `json:"Operations"`
+}
+
+type patchOperation struct {
+	Op    string `json:"op"`
+	Path  string `json:"path"`
+	Value any    `json:"value"`
+}
+
+// patchPath is the target of a patch operation: an attribute, optionally
+// the elements of a multi-valued attribute matching a filter, and a
+// sub-attribute, e.g. members[value eq "..."].display
+type patchPath struct {
+	attrPath
+	filter filter
+}
+
+func parsePatchPath(s string) (patchPath, error) {
+	var p patchPath
+	attr, rest, valuePath := strings.Cut(s, "[")
+	if valuePath {
+		i := strings.LastIndex(rest, "]")
+		if i < 0 {
+			return p, invalidPath("missing ] in %q", s)
+		}
+		f, err := parseFilter(rest[:i])
+		if err != nil {
+			return p, invalidPath("%s", err)
+		}
+		p.filter = f
+		sub, ok := strings.CutPrefix(rest[i+1:], ".")
+		if rest[i+1:] != "" && !ok {
+			return p, invalidPath("unexpected %q after ] in %q", rest[i+1:], s)
+		}
+		attr += "." + sub
+		attr = strings.TrimSuffix(attr, ".")
+	}
+	ap, err := parseAttrPath(attr)
+	if err != nil {
+		return p, invalidPath("%s", err)
+	}
+	p.attrPath = ap
+	return p, nil
+}
+
+func invalidPath(format string, args ...any) *Error {
+	return &Error{Status: http.StatusBadRequest, ScimType: "invalidPath", Detail: fmt.Sprintf(format, args...)}
+}
+
+func noTarget(format string, args ...any) *Error {
+	return &Error{Status: http.StatusBadRequest, ScimType: "noTarget", Detail: fmt.Sprintf(format, args...)}
+}
+
+// copyDoc returns a deep copy of the JSON document
+func copyDoc(doc map[string]any) map[string]any {
+	c := make(map[string]any, len(doc))
+	for k, v := range doc {
+		c[k] = copyValue(v)
+	}
+	return c
+}
+
+func copyValue(v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		return copyDoc(v)
+	case []any:
+		c := make([]any, len(v))
+		for i, e := range v {
+			c[i] = copyValue(e)
+		}
+		return c
+	}
+	return v
+}
+
+// setAttr sets the attribute of doc, replacing it when it is present with another case
+func setAttr(doc map[string]any, key string, v any) {
+	deleteAttr(doc, key)
+	doc[key] = v
+}
+
+func deleteAttr(doc map[string]any, key string) {
+	for k := range doc {
+		if strings.EqualFold(k, key) {
+			delete(doc, k)
+		}
+	}
+}
+
+// container returns the map holding the attribute of p, the extension
+// of its URN or doc itself, creating the extension when create is set
+func (p attrPath) container(doc map[string]any, create bool) map[string]any {
+	if p.urn == "" {
+		return doc
+	}
+	ext, _ := lookup(doc, p.urn)
+	m, ok := ext.(map[string]any)
+	if !ok && create {
+		m = map[string]any{}
+		setAttr(doc, p.urn, m)
+	}
+	return m
+}
+
+// applyPatch applies the operations to a copy of doc and returns it.
+// Operations without path add or replace the attributes of their value,
+// extensions given by their URN are merged. The members of a remove
+// operation without filter whose value lists elements are only removed.
+func applyPatch(doc map[string]any, ops []patchOperation) (map[string]any, error) {
+	doc = copyDoc(doc)
+	for _, op := range ops {
+		var err error
+		switch strings.ToLower(op.Op) {
+		case "add", "replace":
+			err = patchSet(doc, op)
+		case "remove":
+			err = patchRemove(doc, op)
+		default:
+			err = invalidValue("unknown patch op %q", op.Op)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return doc, nil
+}
+
+func patchSet(doc map[string]any, op patchOperation) error {
+	add := strings.EqualFold(op.Op, "add")
+	if op.Path == "" {
+		value, ok := op.Value.(map[string]any)
+		if !ok {
+			return invalidValue("the value of a %s without path must be an object", op.Op)
+		}
+		for k, v := range value {
+			if ext, isExt := v.(map[string]any); isExt && strings.HasPrefix(strings.ToLower(k), "urn:") {
+				target := attrPath{urn: k}.container(doc, true)
+				for sk, sv := range ext {
+					setAttr(target, sk, sv)
+				}
+				continue
+			}
+			ap, err := parseAttrPath(k)
+			if err != nil {
+				return invalidPath("%s", err)
+			}
+			if err := setValue(doc, patchPath{attrPath: ap}, v, add); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if isExtension(op.Path) {
+		value, ok := op.Value.(map[string]any)
+		if !ok {
+			return invalidValue("the value of %s must be an object", op.Path)
+		}
+		target := attrPath{urn: op.Path}.container(doc, true)
+		for k, v := range value {
+			setAttr(target, k, v)
+		}
+		return nil
+	}
+	p, err := parsePatchPath(op.Path)
+	if err != nil {
+		return err
+	}
+	return setValue(doc, p, op.Value, add)
+}
+
+// setValue sets the attribute of p to v, add appends v to multi-valued attributes
+func setValue(doc map[string]any, p patchPath, v any, add bool) error {
+	target := p.container(doc, true)
+	current, _ := lookup(target, p.attr)
+	if p.filter != nil {
+		elems, _ := current.([]any)
+		matched := false
+		for _, e := range elems {
+			m, ok := e.(map[string]any)
+			if !ok || !p.filter.match(m) {
+				continue
+			}
+			matched = true
+			if p.sub != "" {
+				setAttr(m, p.sub, v)
+				continue
+			}
+			value, ok := v.(map[string]any)
+			if !ok {
+				return invalidValue("the value of %s must be an object", p)
+			}
+			for k, sv := range value {
+				setAttr(m, k, sv)
+			}
+		}
+		if !matched {
+			return noTarget("no element of %s matches the filter", p.attr)
+		}
+		return nil
+	}
+	if p.sub != "" {
+		switch c := current.(type) {
+		case map[string]any:
+			setAttr(c, p.sub, v)
+		case []any:
+			for _, e := range c {
+				if m, ok := e.(map[string]any); ok {
+					setAttr(m, p.sub, v)
+				}
+			}
+		default:
+			setAttr(target, p.attr, map[string]any{p.sub: v})
+		}
+		return nil
+	}
+	elems, multi := current.([]any)
+	values, isArray := v.([]any)
+	if add && multi && isArray {
+		for _, value := range values {
+			if !containsValue(elems, value) {
+				elems = append(elems, value)
+			}
+		}
+		v = elems
+	}
+	setAttr(target, p.attr, v)
+	return nil
+}
+
+// containsValue reports whether the elements hold an element with the value of e
+func containsValue(elems []any, e any) bool {
+	m, ok := e.(map[string]any)
+	if !ok {
+		return false
+	}
+	value, ok := lookup(m, "value")
+	if !ok {
+		return false
+	}
+	for _, elem := range elems {
+		if em, ok := elem.(map[string]any); ok {
+			if ev, ok := lookup(em, "value"); ok && reflect.DeepEqual(ev, value) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func patchRemove(doc map[string]any, op patchOperation) error {
+	if op.Path == "" {
+		return noTarget("remove requires a path")
+	}
+	if isExtension(op.Path) {
+		deleteAttr(doc, op.Path)
+		return nil
+	}
+	p, err := parsePatchPath(op.Path)
+	if err != nil {
+		return err
+	}
+	target := p.container(doc, false)
+	if target == nil {
+		return nil
+	}
+	current, ok := lookup(target, p.attr)
+	if !ok {
+		return nil
+	}
+	elems, multi := current.([]any)
+	switch {
+	case p.filter != nil:
+		kept := []any{}
+		for _, e := range elems {
+			m, ok := e.(map[string]any)
+			if !ok || !p.filter.match(m) {
+				kept = append(kept, e)
+				continue
+			}
+			if p.sub != "" {
+				deleteAttr(m, p.sub)
+				kept = append(kept, m)
+			}
+		}
+		setAttr(target, p.attr, kept)
+	case p.sub != "":
+		if m, ok := current.(map[string]any); ok {
+			deleteAttr(m, p.sub)
+		}
+		for _, e := range elems {
+			if m, ok := e.(map[string]any); ok {
+				deleteAttr(m, p.sub)
+			}
+		}
+	case multi && op.Value != nil:
+		values, ok := op.Value.([]any)
+		if !ok {
+			values = []any{op.Value}
+		}
+		kept := []any{}
+		for _, e := range elems {
+			if !containsValue(values, e) {
+				kept = append(kept, e)
+			}
+		}
+		setAttr(target, p.attr, kept)
+	default:
+		deleteAttr(target, p.attr)
+	}
+	return nil
+}
+
+// isExtension reports whether path is the URN of an extension schema
+func isExtension(path string) bool {
+	return strings.EqualFold(path, UserExtension) || strings.EqualFold(path, GroupExtension)
+}
+
+func (s *Server) patch(w http.ResponseWriter, r *http.Request, rt resourceType, id string) (any, error) {
+	req := patchRequest{}
+	if err := readJSON(w, r, &req); err != nil {
+		return nil, err
+	}
+	if !hasSchema(req.Schemas, patchOpSchema) {
+		return nil, invalidValue("schemas must contain %s", patchOpSchema)
+	}
+	doc, err := rt.get(r, id)
+	if err != nil {
+		return nil, err
+	}
+	doc, err = applyPatch(doc, req.Operations)
+	if err != nil {
+		return nil, err
+	}
+	return rt.replace(r, id, doc)
+}
+
+func hasSchema(schemas []string, schema string) bool {
+	for _, s := range schemas {
+		if strings.EqualFold(s, schema) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,125 @@
+package scim
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestApplyPatch(t *testing.T) {
+	doc := `{
+		"userName": "ann",
+		"active": true,
+		"emails": [{"value": "ann@work.example", "type": "work"}, {"value": "ann@home.example", "type": "home"}],
+		"members": [{"value": "u1"}, {"value": "u2"}],
+		"` + UserExtension + `": {"team": "eng"}
+	}`
+	for _, c := range []struct {
+		name string
+		ops  string
+		want string
+	}{{
+		name: "replace an attribute",
+		ops:  `[{"op": "Replace", "path": "active", "value": false}]`,
+		want: `{"userName": "ann", "active": false}`,
+	}, {
+		name: "attribute names ignore case",
+		ops:  `[{"op": "replace", "path": "USERNAME", "value": "bob"}]`,
+		want: `{"USERNAME": "bob"}`,
+	}, {
+		name: "without path",
+		ops:  `[{"op": "replace", "value": {"active": false, "` + UserExtension + `": {"level": 2}}}]`,
+		want: `{"active": false, "` + UserExtension + `": {"team": "eng", "level": 2}}`,
+	}, {
+		name: "add appends the new elements",
+		ops:  `[{"op": "add", "path": "members", "value": [{"value": "u2"}, {"value": "u3"}]}]`,
+		want: `{"members": [{"value": "u1"}, {"value": "u2"}, {"value": "u3"}]}`,
+	}, {
+		name: "replace sets all elements",
+		ops:  `[{"op": "replace", "path": "members", "value": [{"value": "u3"}]}]`,
+		want: `{"members": [{"value": "u3"}]}`,
+	}, {
+		name: "sub-attribute of the elements matching the filter",
+		ops:  `[{"op": "replace", "path": "emails[type eq \"work\"].value", "value": "ann@new.example"}]`,
+		want: `{"emails": [{"value": "ann@new.example", "type": "work"}, {"value": "ann@home.example", "type": "home"}]}`,
+	}, {
+		name: "extension attribute",
+		ops:  `[{"op": "add", "path": "` + UserExtension + `:level", "value": 2}]`,
+		want: `{"` + UserExtension + `": {"team": "eng", "level": 2}}`,
+	}, {
+		name: "remove an attribute",
+		ops:  `[{"op": "remove", "path": "active"}]`,
+		want: `{"active": null}`,
+	}, {
+		name: "remove the elements matching the filter",
+		ops:  `[{"op": "remove", "path": "members[value eq \"u1\"]"}]`,
+		want: `{"members": [{"value": "u2"}]}`,
+	}, {
+		name: "remove the elements of the value",
+		ops:  `[{"op": "remove", "path": "members", "value": [{"value": "u2"}]}]`,
+		want: `{"members": [{"value": "u1"}]}`,
+	}, {
+		name: "remove a missing attribute",
+		ops:  `[{"op": "remove", "path": "nickName"}]`,
+		want: `{}`,
+	}, {
+		name: "operations in order",
+		ops:  `[{"op": "remove", "path": "members"}, {"op": "add", "path": "members", "value": [{"value": "u3"}]}]`,
+		want: `{"members": [{"value": "u3"}]}`,
+	}} {
+		var ops []patchOperation
+		if err := json.Unmarshal([]byte(c.ops), &ops); err != nil {
+			t.Fatal(err)
+		}
+		before := jsonDoc(t, doc)
+		got, err := applyPatch(before, ops)
+		if err != nil {
+			t.Errorf("%s: %s", c.name, err)
+			continue
+		}
+		// want holds the attributes that change, null for the removed ones
+		want := jsonDoc(t, doc)
+		for k, v := range jsonDoc(t, c.want) {
+			deleteAttr(want, k)
+			if v != nil {
+				want[k] = v
+			}
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("%s: got %v, want %v", c.name, got, want)
+		}
+		if !reflect.DeepEqual(before, jsonDoc(t, doc)) {
+			t.Errorf("%s: the document was changed", c.name)
+		}
+	}
+}
+
+func TestApplyPatchErrors(t *testing.T) {
+	doc := `{"userName": "ann", "members": [{"value": "u1"}]}`
+	for _, c := range []struct {
+		ops      string
+		scimType string
+	}{
+		{`[{"op": "move", "path": "userName"}]`, "invalidValue"},
+		{`[{"op": "replace", "value": "ann"}]`, "invalidValue"},
+		{`[{"op": "remove"}]`, "noTarget"},
+		{`[{"op": "replace", "path": "members[value eq \"u9\"].display", "value": "x"}]`, "noTarget"},
+		{`[{"op": "replace", "path": "members[value eq \"u1\"]", "value": "x"}]`, "invalidValue"},
+		{`[{"op": "replace", "path": "members[value eq \"u1\"", "value": "x"}]`, "invalidPath"},
+		{`[{"op": "replace", "path": "members[value]", "value": "x"}]`, "invalidPath"},
+		{`[{"op": "replace", "path": "members[value pr]x", "value": "x"}]`, "invalidPath"},
+		{`[{"op": "replace", "path": "a.b.c", "value": "x"}]`, "invalidPath"},
+		{`[{"op": "replace", "path": "` + UserExtension + `", "value": "x"}]`, "invalidValue"},
+	} {
+		var ops []patchOperation
+		if err := json.Unmarshal([]byte(c.ops), &ops); err != nil {
+			t.Fatal(err)
+		}
+		_, err := applyPatch(jsonDoc(t, doc), ops)
+		var e *Error
+		if !errors.As(err, &e) || e.Status != 400 || e.ScimType != c.scimType {
+			t.Errorf("%s: %v, want %s", c.ops, err, c.scimType)
+		}
+	}
+}
@@ -0,0 +1,550 @@
+package scim
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sr-codefreak/user-group/db/mongodb"
+	"github.com/sr-codefreak/user-group/db/mongodb/user"
+	"github.com/sr-codefreak/user-group/db/mongodb/usergroup"
+	"github.com/sr-codefreak/user-group/myerrors"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// resourceType stores one kind of resource in the backend, resources are
+// passed around as their JSON documents so filters and patches apply to them
+type resourceType interface {
+	// scan calls fn with the document of every resource
+	scan(r *http.Request, fn func(doc map[string]any)) error
+	get(r *http.Request, id string) (map[string]any, error)
+	create(r *http.Request, doc map[string]any) (map[string]any, error)
+	replace(r *http.Request, id string, doc map[string]any) (map[string]any, error)
+	delete(r *http.Request, id string) error
+}
+
+// externalIdKey is the metaData key of the externalId attribute, active is
+// stored under user.ActiveKey
+const externalIdKey = "externalId"
+
+type meta struct {
+	ResourceType string `json:"resourceType"`
+	Created      string `json:"created,omitempty"`
+	Location     string `json:"location"`
+}
+
+// multiValued is an element of a multi-valued attribute
+type multiValued struct {
+	Value   string `json:"value"`
+	Display string `json:"display,omitempty"`
+	Type    string `json:"type,omitempty"`
+	Primary bool   `json:"primary,omitempty"`
+	Ref     string `json:"$ref,omitempty"`
+}
+
+type userResource struct {
+	Schemas      []string       `json:"schemas"`
+	ID           string         `json:"id,omitempty"`
+	ExternalID   string         `json:"externalId,omitempty"`
+	UserName     string         `json:"userName"`
+	Active       *bool          `json:"active,omitempty"`
+	Emails       []multiValued  `json:"emails,omitempty"`
+	PhoneNumbers []multiValued  `json:"phoneNumbers,omitempty"`
+	Groups       []multiValued  `json:"groups,omitempty"`
+	Extension    map[string]any `json:"urn:sr-codefreak:params:scim:schemas:extension:user-group:2.0:User,omitempty"`
+	Meta         *meta          `json:"meta,omitempty"`
+}
+
+type groupResource struct {
+	Schemas     []string       `json:"schemas"`
+	ID          string         `json:"id,omitempty"`
+	ExternalID  string         `json:"externalId,omitempty"`
+	DisplayName string         `json:"displayName"`
+	Members     []multiValued  `json:"members,omitempty"`
+	Extension   map[string]any `json:"urn:sr-codefreak:params:scim:schemas:extension:user-group:2.0:Group,omitempty"`
+	Meta        *meta          `json:"meta,omitempty"`
+}
+
+// toDoc returns the JSON document of the resource v
+func toDoc(v any) (map[string]any, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	doc := map[string]any{}
+	return doc, json.Unmarshal(b, &doc)
+}
+
+// fromDoc decodes the JSON document into the resource v
+func fromDoc(doc map[string]any, v any) error {
+	b, err := json.Marshal(doc)
+	if err != nil {
+		return invalidValue("%s", err)
+	}
+	if err := json.Unmarshal(b, v); err != nil {
+		return invalidValue("%s", err)
+	}
+	return nil
+}
+
+// splitMetaData returns the externalId and the extension attributes of the metaData
+func splitMetaData(metaData map[string]any) (string, map[string]any) {
+	externalId, _ := metaData[externalIdKey].(string)
+	var ext map[string]any
+	for k, v := range metaData {
+		if k == user.ActiveKey || k == externalIdKey {
+			continue
+		}
+		if ext == nil {
+			ext = map[string]any{}
+		}
+		ext[k] = v
+	}
+	return externalId, ext
+}
+
+// joinMetaData returns the metaData storing the externalId and the extension attributes
+func joinMetaData(externalId string, ext map[string]any) (map[string]any, error) {
+	metaData := map[string]any{}
+	for k, v := range ext {
+		if k == user.ActiveKey || k == externalIdKey {
+			return nil, invalidValue("extension attribute %q is reserved", k)
+		}
+		metaData[k] = v
+	}
+	if externalId != "" {
+		metaData[externalIdKey] = externalId
+	}
+	return metaData, nil
+}
+
+// pick returns the value of the primary element of a multi-valued attribute, else of the first one
+func pick(values []multiValued) string {
+	for _, v := range values {
+		if v.Primary {
+			return v.Value
+		}
+	}
+	if len(values) == 0 {
+		return ""
+	}
+	return values[0].Value
+}
+
+func created(id primitive.ObjectID) string {
+	return id.Timestamp().UTC().Format(time.RFC3339)
+}
+
+type users struct {
+	s *Server
+}
+
+func (s *Server) users() resourceType {
+	return users{s: s}
+}
+
+func (t users) doc(r *http.Request, u *user.User) (map[string]any, error) {
+	res := userResource{
+		Schemas:  []string{UserSchema},
+		ID:       u.ID.Hex(),
+		UserName: u.Name,
+		Meta: &meta{
+			ResourceType: "User",
+			Created:      created(u.ID),
+			Location:     t.s.location(r, "Users/"+u.ID.Hex()),
+		},
+	}
+	res.ExternalID, res.Extension = splitMetaData(u.MetaData)
+	if res.Extension != nil {
+		res.Schemas = append(res.Schemas, UserExtension)
+	}
+	active := u.Active()
+	res.Active = &active
+	if u.Email != "" {
+		res.Emails = []multiValued{{Value: u.Email, Primary: true}}
+	}
+	if u.Phone != "" {
+		res.PhoneNumbers = []multiValued{{Value: u.Phone, Primary: true}}
+	}
+	for _, g := range u.UsersGroups {
+		res.Groups = append(res.Groups, multiValued{
+			Value:   g.ID,
+			Display: g.Name,
+			Type:    "direct",
+			Ref:     t.s.location(r, "Groups/"+g.ID),
+		})
+	}
+	return toDoc(res)
+}
+
+// user returns the user described by doc, the read only attributes are ignored
+func (users) user(doc map[string]any) (*user.User, error) {
+	// some identity providers send booleans as strings
+	if active, ok := lookup(doc, "active"); ok {
+		if s, ok := active.(string); ok {
+			b, err := strconv.ParseBool(s)
+			if err != nil {
+				return nil, invalidValue("active: %s", err)
+			}
+			doc = copyDoc(doc)
+			setAttr(doc, "active", b)
+		}
+	}
+	res := userResource{}
+	if err := fromDoc(doc, &res); err != nil {
+		return nil, err
+	}
+	if res.UserName == "" {
+		return nil, invalidValue("userName is required")
+	}
+	metaData, err := joinMetaData(res.ExternalID, res.Extension)
+	if err != nil {
+		return nil, err
+	}
+	if res.Active != nil {
+		metaData[user.ActiveKey] = *res.Active
+	}
+	return &user.User{
+		Name:     res.UserName,
+		Email:    pick(res.Emails),
+		Phone:    pick(res.PhoneNumbers),
+		MetaData: metaData,
+	}, nil
+}
+
+func (t users) scan(r *http.Request, fn func(doc map[string]any)) error {
+	opts := mongodb.ListOptions{PageSize: mongodb.MaxPageSize}
+	for {
+		page, next, err := t.s.backend.Users().List(r.Context(), opts)
+		if err != nil {
+			return err
+		}
+		for i := range page {
+			doc, err := t.doc(r, &page[i])
+			if err != nil {
+				return err
+			}
+			fn(doc)
+		}
+		if next == "" {
+			return nil
+		}
+		opts.PageToken = next
+	}
+}
+
+func (t users) get(r *http.Request, id string) (map[string]any, error) {
+	if _, err := objectID(myerrors.KindUser, id); err != nil {
+		return nil, err
+	}
+	u, err := t.s.backend.Users().GetById(r.Context(), id)
+	if err != nil {
+		return nil, err
+	}
+	return t.doc(r, u)
+}
+
+// checkUserName fails with scimType uniqueness when another user than id has
+// the userName, ignoring case. Two users at most are read, as one can be id.
+func (t users) checkUserName(r *http.Request, id string, userName string) error {
+	opts := mongodb.ListOptions{PageSize: 2, Filter: mongodb.ListFilter{NameFold: userName}}
+	same, _, err := t.s.backend.Users().List(r.Context(), opts)
+	if err != nil {
+		return err
+	}
+	for _, u := range same {
+		if u.ID.Hex() != id {
+			return &Error{Status: http.StatusConflict, ScimType: "uniqueness", Detail: "userName " + strconv.Quote(userName) + " is taken"}
+		}
+	}
+	return nil
+}
+
+func (t users) create(r *http.Request, doc map[string]any) (map[string]any, error) {
+	u, err := t.user(doc)
+	if err != nil {
+		return nil, err
+	}
+	if err := t.checkUserName(r, "", u.Name); err != nil {
+		return nil, err
+	}
+	if err := t.s.backend.Users().Create(r.Context(), u); err != nil {
+		return nil, err
+	}
+	return t.doc(r, u)
+}
+
+// replace updates the user, emails and phoneNumbers that are left out keep their value
+func (t users) replace(r *http.Request, id string, doc map[string]any) (map[string]any, error) {
+	oid, err := objectID(myerrors.KindUser, id)
+	if err != nil {
+		return nil, err
+	}
+	u, err := t.user(doc)
+	if err != nil {
+		return nil, err
+	}
+	current, err := t.s.backend.Users().GetById(r.Context(), id)
+	if err != nil {
+		return nil, err
+	}
+	if !strings.EqualFold(current.Name, u.Name) {
+		if err := t.checkUserName(r, id, u.Name); err != nil {
+			return nil, err
+		}
+	}
+	u.ID = oid
+	if err := t.s.backend.Users().Update(r.Context(), u); err != nil {
+		return nil, err
+	}
+	return t.get(r, id)
+}
+
+func (t users) delete(r *http.Request, id string) error {
+	oid, err := objectID(myerrors.KindUser, id)
+	if err != nil {
+		return err
+	}
+	return t.s.backend.Users().Delete(r.Context(), oid)
+}
+
+type groups struct {
+	s *Server
+}
+
+func (s *Server) groups() resourceType {
+	return groups{s: s}
+}
+
+func (t groups) doc(r *http.Request, ug *usergroup.UserGroup) (map[string]any, error) {
+	res := groupResource{
+		Schemas:     []string{GroupSchema},
+		ID:          ug.ID.Hex(),
+		DisplayName: ug.Name,
+		Meta: &meta{
+			ResourceType: "Group",
+			Created:      created(ug.ID),
+			Location:     t.s.location(r, "Groups/"+ug.ID.Hex()),
+		},
+	}
+	res.ExternalID, res.Extension = splitMetaData(ug.MetaData)
+	if res.Extension != nil {
+		res.Schemas = append(res.Schemas, GroupExtension)
+	}
+	for _, u := range ug.Users {
+		res.Members = append(res.Members, multiValued{
+			Value:   u.ID,
+			Display: u.Name,
+			Type:    "User",
+			Ref:     t.s.location(r, "Users/"+u.ID),
+		})
+	}
+	return toDoc(res)
+}
+
+// group returns the user group described by doc and the ids of its members
+func (groups) group(doc map[string]any) (*usergroup.UserGroup, []primitive.ObjectID, error) {
+	res := groupResource{}
+	if err := fromDoc(doc, &res); err != nil {
+		return nil, nil, err
+	}
+	if res.DisplayName == "" {
+		return nil, nil, invalidValue("displayName is required")
+	}
+	metaData, err := joinMetaData(res.ExternalID, res.Extension)
+	if err != nil {
+		return nil, nil, err
+	}
+	members := make([]primitive.ObjectID, 0, len(res.Members))
+	for _, m := range res.Members {
+		if m.Type != "" && !strings.EqualFold(m.Type, "User") {
+			return nil, nil, invalidValue("members of type %q are not supported, only users", m.Type)
+		}
+		oid, err := primitive.ObjectIDFromHex(m.Value)
+		if err != nil {
+			return nil, nil, invalidValue("member %q does not exist", m.Value)
+		}
+		members = append(members, oid)
+	}
+	return &usergroup.UserGroup{Name: res.DisplayName, MetaData: metaData}, members, nil
+}
+
+func (t groups) scan(r *http.Request, fn func(doc map[string]any)) error {
+	opts := mongodb.ListOptions{PageSize: mongodb.MaxPageSize}
+	for {
+		page, next, err := t.s.backend.UserGroups().List(r.Context(), opts)
+		if err != nil {
+			return err
+		}
+		for i := range page {
+			doc, err := t.doc(r, &page[i])
+			if err != nil {
+				return err
+			}
+			fn(doc)
+		}
+		if next == "" {
+			return nil
+		}
+		opts.PageToken = next
+	}
+}
+
+func (t groups) get(r *http.Request, id string) (map[string]any, error) {
+	if _, err := objectID(myerrors.KindUserGroup, id); err != nil {
+		return nil, err
+	}
+	ug, err := t.s.backend.UserGroups().GetById(r.Context(), id)
+	if err != nil {
+		return nil, err
+	}
+	return t.doc(r, ug)
+}
+
+// checkMembers fails unless all the users exist, so that no write is made
+// for a request that is rejected
+func (t groups) checkMembers(r *http.Request, members []primitive.ObjectID) error {
+	for _, userId := range members {
+		_, err := t.s.backend.Users().GetById(r.Context(), userId.Hex())
+		if errors.Is(err, myerrors.ErrNotFound) {
+			return invalidValue("member %q does not exist", userId.Hex())
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// inTransaction runs fn with a request whose context carries a transaction
+// of the backend, so that the writes of fn are kept only when it succeeds
+func (t groups) inTransaction(r *http.Request, fn func(r *http.Request) error) error {
+	return t.s.backend.WithTransaction(r.Context(), func(ctx context.Context) error {
+		return fn(r.WithContext(ctx))
+	})
+}
+
+func (t groups) create(r *http.Request, doc map[string]any) (map[string]any, error) {
+	ug, members, err := t.group(doc)
+	if err != nil {
+		return nil, err
+	}
+	if err := t.checkMembers(r, members); err != nil {
+		return nil, err
+	}
+	err = t.inTransaction(r, func(r *http.Request) error {
+		if err := t.s.backend.UserGroups().Create(r.Context(), ug); err != nil {
+			return err
+		}
+		for _, userId := range members {
+			if err := t.s.backend.UserGroups().AddUser(r.Context(), ug.ID, userId); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return t.get(r, ug.ID.Hex())
+}
+
+// replace renames the user group and adds and removes members,
+// its externalId and extension attributes cannot change
+func (t groups) replace(r *http.Request, id string, doc map[string]any) (map[string]any, error) {
+	oid, err := objectID(myerrors.KindUserGroup, id)
+	if err != nil {
+		return nil, err
+	}
+	ug, members, err := t.group(doc)
+	if err != nil {
+		return nil, err
+	}
+	current, err := t.s.backend.UserGroups().GetById(r.Context(), id)
+	if err != nil {
+		return nil, err
+	}
+	currentExternalId, currentExt := splitMetaData(current.MetaData)
+	externalId, ext := splitMetaData(ug.MetaData)
+	if externalId != currentExternalId {
+		return nil, mutability("externalId of a group cannot change")
+	}
+	if !reflect.DeepEqual(normalize(ext), normalize(currentExt)) {
+		return nil, mutability("%s attributes cannot change", GroupExtension)
+	}
+	wanted := map[string]bool{}
+	for _, userId := range members {
+		wanted[userId.Hex()] = true
+	}
+	var add, remove []primitive.ObjectID
+	for _, userId := range current.UserIds {
+		if !wanted[userId] {
+			oid, err := primitive.ObjectIDFromHex(userId)
+			if err != nil {
+				return nil, err
+			}
+			remove = append(remove, oid)
+		}
+		delete(wanted, userId)
+	}
+	for _, userId := range members {
+		if wanted[userId.Hex()] {
+			add = append(add, userId)
+			delete(wanted, userId.Hex())
+		}
+	}
+	if current.Rule != "" && len(add)+len(remove) > 0 {
+		return nil, mutability("members of a dynamic group follow its rule")
+	}
+	if err := t.checkMembers(r, add); err != nil {
+		return nil, err
+	}
+	err = t.inTransaction(r, func(r *http.Request) error {
+		if ug.Name != current.Name {
+			if err := t.s.backend.UserGroups().UpdateName(r.Context(), oid, ug.Name); err != nil {
+				return err
+			}
+		}
+		for _, userId := range add {
+			if err := t.s.backend.UserGroups().AddUser(r.Context(), oid, userId); err != nil {
+				return err
+			}
+		}
+		for _, userId := range remove {
+			if err := t.s.backend.UserGroups().RemoveUser(r.Context(), oid, userId); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return t.get(r, id)
+}
+
+func (t groups) delete(r *http.Request, id string) error {
+	oid, err := objectID(myerrors.KindUserGroup, id)
+	if err != nil {
+		return err
+	}
+	return t.s.backend.UserGroups().DeleteById(r.Context(), oid)
+}
+
+// normalize returns the extension attributes as decoded from JSON, so stored
+// and requested values compare equal
+func normalize(ext map[string]any) map[string]any {
+	if len(ext) == 0 {
+		return nil
+	}
+	doc, err := toDoc(ext)
+	if err != nil {
+		return ext
+	}
+	return doc
+}
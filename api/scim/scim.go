@@ -0,0 +1,287 @@
+// Package scim serves the users and user groups of a db.Backend as SCIM 2.0
+// resources, RFC 7643 and RFC 7644, for identity providers provisioning them.
+//
+//	GET    /Users?filter=&startIndex=&count=   list users, see Server.list
+//	POST   /Users                              create a user
+//	GET    /Users/{id}                         get a user
+//	PUT    /Users/{id}                         replace a user
+//	PATCH  /Users/{id}                         add, replace or remove attributes, see patch
+//	DELETE /Users/{id}                         delete a user
+//	GET    /Groups?filter=&startIndex=&count=  list user groups
+//	POST   /Groups                             create a user group with its members
+//	GET    /Groups/{id}                        get a user group
+//	PUT    /Groups/{id}                        rename a user group and replace its members
+//	PATCH  /Groups/{id}                        add, replace or remove members and displayName
+//	DELETE /Groups/{id}                        delete a user group
+//	GET    /ServiceProviderConfig              supported features
+//	GET    /ResourceTypes[/{name}]             the User and Group resource types
+//	GET    /Schemas[/{id}]                     the schemas of the resources
+//
+// A user is mapped as
+//
+//	userName                      name
+//	emails, the primary or first  email
+//	phoneNumbers                  phone
+//	active                        metaData.active, true when missing
+//	externalId                    metaData.externalId
+//	groups                        usersGroups, read only
+//	UserExtension                 the other metaData keys
+//
+// and a user group as
+//
+//	displayName     name
+//	members         users, only users can be members
+//	externalId      metaData.externalId, immutable
+//	GroupExtension  the other metaData keys, immutable
+//
+// The members of dynamic user groups are read only, they follow the rule.
+//
+// The X-Actor header names the author of the changes in the audit log,
+// "scim" when missing. The X-Tenant header names the tenant, as for package
+// rest.
+package scim
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/sr-codefreak/user-group/db"
+	"github.com/sr-codefreak/user-group/db/mongodb"
+	"github.com/sr-codefreak/user-group/db/mongodb/audit"
+	"github.com/sr-codefreak/user-group/myerrors"
+	"github.com/sr-codefreak/user-group/utils/logger"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+var log = logger.GetLogger()
+
+// maxBodyBytes limits the size of request bodies
+const maxBodyBytes = 1 << 20
+
+// ContentType is the media type of SCIM requests and responses
+const ContentType = "application/scim+json"
+
+const (
+	UserSchema            = "urn:ietf:params:scim:schemas:core:2.0:User"
+	GroupSchema           = "urn:ietf:params:scim:schemas:core:2.0:Group"
+	UserExtension         = "urn:sr-codefreak:params:scim:schemas:extension:user-group:2.0:User"
+	GroupExtension        = "urn:sr-codefreak:params:scim:schemas:extension:user-group:2.0:Group"
+	listResponseSchema    = "urn:ietf:params:scim:api:messages:2.0:ListResponse"
+	patchOpSchema         = "urn:ietf:params:scim:api:messages:2.0:PatchOp"
+	errorSchema           = "urn:ietf:params:scim:api:messages:2.0:Error"
+	serviceProviderSchema = "urn:ietf:params:scim:schemas:core:2.0:ServiceProviderConfig"
+	resourceTypeSchema    = "urn:ietf:params:scim:schemas:core:2.0:ResourceType"
+	schemaSchema          = "urn:ietf:params:scim:schemas:core:2.0:Schema"
+)
+
+// ActorHeader is the request header naming the actor recorded in the audit log
+const ActorHeader = "X-Actor"
+
+// TenantHeader is the request header naming the tenant, see mongodb.WithTenant
+const TenantHeader = "X-Tenant"
+
+// defaultActor is the actor of the requests without ActorHeader
+const defaultActor = "scim"
+
+// Server is the http.Handler of the SCIM endpoint
+type Server struct {
+	backend db.Backend
+	base    string
+}
+
+// NewServer returns the SCIM server on backend mounted at the path base,
+// e.g. "/scim/v2", the prefix of the resource locations
+func NewServer(backend db.Backend, base string) *Server {
+	return &Server{backend: backend, base: strings.TrimSuffix(base, "/")}
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	path, ok := strings.CutPrefix(r.URL.Path, s.base+"/")
+	if !ok {
+		writeError(w, errNotFound)
+		return
+	}
+	actor := r.Header.Get(ActorHeader)
+	if actor == "" {
+		actor = defaultActor
+	}
+	r = r.WithContext(audit.WithActor(r.Context(), actor))
+	if tenant := r.Header.Get(TenantHeader); tenant != "" {
+		if err := db.CheckTenant(s.backend, tenant); err != nil {
+			writeError(w, err)
+			return
+		}
+		r = r.WithContext(mongodb.WithTenant(r.Context(), tenant))
+	}
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	switch {
+	case segments[0] == "Users" && len(segments) <= 2:
+		s.route(w, r, s.users(), segments[1:])
+	case segments[0] == "Groups" && len(segments) <= 2:
+		s.route(w, r, s.groups(), segments[1:])
+	case segments[0] == "ServiceProviderConfig" && len(segments) == 1:
+		s.discover(w, r, s.serviceProviderConfig)
+	case segments[0] == "ResourceTypes" && len(segments) <= 2:
+		s.discover(w, r, func(r *http.Request) (any, error) { return s.resourceTypes(r, segments[1:]) })
+	case segments[0] == "Schemas" && len(segments) <= 2:
+		s.discover(w, r, func(r *http.Request) (any, error) { return s.schemas(r, segments[1:]) })
+	default:
+		writeError(w, errNotFound)
+	}
+}
+
+func (s *Server) route(w http.ResponseWriter, r *http.Request, rt resourceType, segments []string) {
+	var (
+		v      any
+		err    error
+		status = http.StatusOK
+	)
+	switch {
+	case len(segments) == 0 && r.Method == http.MethodGet:
+		v, err = s.list(r, rt)
+	case len(segments) == 0 && r.Method == http.MethodPost:
+		v, err = s.create(w, r, rt)
+		status = http.StatusCreated
+	case len(segments) == 1 && r.Method == http.MethodGet:
+		v, err = s.get(r, rt, segments[0])
+	case len(segments) == 1 && r.Method == http.MethodPut:
+		v, err = s.replace(w, r, rt, segments[0])
+	case len(segments) == 1 && r.Method == http.MethodPatch:
+		v, err = s.patch(w, r, rt, segments[0])
+	case len(segments) == 1 && r.Method == http.MethodDelete:
+		err = rt.delete(r, segments[0])
+		status = http.StatusNoContent
+	default:
+		err = errMethodNotAllowed
+	}
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	if loc, ok := v.(map[string]any); ok && status == http.StatusCreated {
+		if meta, ok := loc["meta"].(map[string]any); ok {
+			w.Header().Set("Location", fmt.Sprint(meta["location"]))
+		}
+	}
+	writeJSON(w, status, v)
+}
+
+func (s *Server) discover(w http.ResponseWriter, r *http.Request, get func(r *http.Request) (any, error)) {
+	if r.Method != http.MethodGet {
+		writeError(w, errMethodNotAllowed)
+		return
+	}
+	v, err := get(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, v)
+}
+
+// location returns the URL of the resource path below the base of the server
+func (s *Server) location(r *http.Request, path string) string {
+	scheme := "http"
+	if r.TLS != nil {
+		scheme = "https"
+	}
+	return scheme + "://" + r.Host + s.base + "/" + path
+}
+
+// Error is a SCIM error response, RFC 7644 section 3.12
+type Error struct {
+	Status int
+	// ScimType is the detail error keyword, e.g. invalidFilter or uniqueness
+	ScimType string
+	Detail   string
+}
+
+func (e *Error) Error() string {
+	if e.ScimType == "" {
+		return e.Detail
+	}
+	return e.ScimType + ": " + e.Detail
+}
+
+func (e *Error) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Schemas  []string `json:"schemas"`
+		Status   string   `json:"status"`
+		ScimType string   `json:"scimType,omitempty"`
+		Detail   string   `json:"detail,omitempty"`
+	}{[]string{errorSchema}, fmt.Sprint(e.Status), e.ScimType, e.Detail})
+}
+
+func invalidValue(format string, args ...any) *Error {
+	return &Error{Status: http.StatusBadRequest, ScimType: "invalidValue", Detail: fmt.Sprintf(format, args...)}
+}
+
+func mutability(format string, args ...any) *Error {
+	return &Error{Status: http.StatusBadRequest, ScimType: "mutability", Detail: fmt.Sprintf(format, args...)}
+}
+
+var (
+	errNotFound         = &Error{Status: http.StatusNotFound, Detail: "no such resource"}
+	errMethodNotAllowed = &Error{Status: http.StatusMethodNotAllowed, Detail: "method not allowed"}
+)
+
+var codeStatus = map[myerrors.Code]int{
+	myerrors.Unknown:         http.StatusInternalServerError,
+	myerrors.NotFound:        http.StatusNotFound,
+	myerrors.AlreadyExists:   http.StatusConflict,
+	myerrors.InvalidArgument: http.StatusBadRequest,
+	myerrors.Unavailable:     http.StatusServiceUnavailable,
+	myerrors.Conflict:        http.StatusConflict,
+}
+
+var codeScimTypes = map[myerrors.Code]string{
+	myerrors.AlreadyExists:   "uniqueness",
+	myerrors.InvalidArgument: "invalidValue",
+}
+
+// writeError writes err as SCIM error, the store errors get the status of their code
+func writeError(w http.ResponseWriter, err error) {
+	var e *Error
+	if !errors.As(err, &e) {
+		code := myerrors.CodeOf(err)
+		e = &Error{Status: codeStatus[code], ScimType: codeScimTypes[code], Detail: err.Error()}
+		if code == myerrors.Unknown {
+			log.Errorf("scim: %s", err)
+			e.Detail = "internal error"
+		}
+	}
+	writeJSON(w, e.Status, e)
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	if v == nil {
+		w.WriteHeader(status)
+		return
+	}
+	w.Header().Set("Content-Type", ContentType)
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		log.Warnf("scim: writing response: %s", err)
+	}
+}
+
+// readJSON decodes the request body into v
+func readJSON(w http.ResponseWriter, r *http.Request, v any) error {
+	dec := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxBodyBytes))
+	if err := dec.Decode(v); err != nil {
+		return &Error{Status: http.StatusBadRequest, ScimType: "invalidSyntax", Detail: err.Error()}
+	}
+	return nil
+}
+
+// objectID parses the id of a resource, ids that are no object ids are not found
+func objectID(kind myerrors.Kind, id string) (primitive.ObjectID, error) {
+	oid, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return oid, myerrors.New(myerrors.NotFound, kind, id, err)
+	}
+	return oid, nil
+}
@@ -0,0 +1,262 @@
+package scim_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/sr-codefreak/user-group/api/scim"
+	"github.com/sr-codefreak/user-group/db/memory"
+)
+
+const base = "/scim/v2"
+
+type resource struct {
+	ID          string `json:"id"`
+	UserName    string `json:"userName"`
+	DisplayName string `json:"displayName"`
+	Active      *bool  `json:"active"`
+	Members     []struct {
+		Value string `json:"value"`
+	} `json:"members"`
+	Groups []struct {
+		Value string `json:"value"`
+	} `json:"groups"`
+	Meta struct {
+		ResourceType string `json:"resourceType"`
+		Location     string `json:"location"`
+	} `json:"meta"`
+}
+
+type listResponse struct {
+	Schemas      []string   `json:"schemas"`
+	TotalResults int        `json:"totalResults"`
+	StartIndex   int        `json:"startIndex"`
+	ItemsPerPage int        `json:"itemsPerPage"`
+	Resources    []resource `json:"Resources"`
+}
+
+type scimError struct {
+	Status   string `json:"status"`
+	ScimType string `json:"scimType"`
+	Detail   string `json:"detail"`
+}
+
+// call sends the request to h below base and decodes the response body into
+// v unless v is nil
+func call(t *testing.T, h http.Handler, method string, path string, body string, v any) *httptest.ResponseRecorder {
+	t.Helper()
+	r := httptest.NewRequest(method, base+path, strings.NewReader(body))
+	r.Header.Set("Content-Type", scim.ContentType)
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	if v != nil {
+		if ct := w.Header().Get("Content-Type"); ct != scim.ContentType {
+			t.Errorf("%s %s: content type %q", method, path, ct)
+		}
+		if err := json.Unmarshal(w.Body.Bytes(), v); err != nil {
+			t.Fatalf("%s %s: %s: %s", method, path, err, w.Body)
+		}
+	}
+	return w
+}
+
+func createUser(t *testing.T, h http.Handler, userName string) resource {
+	t.Helper()
+	u := resource{}
+	body := fmt.Sprintf(`{"schemas": [%q], "userName": %q, "emails": [{"value": "%s@example.com"}]}`, scim.UserSchema, userName, userName)
+	if w := call(t, h, "POST", "/Users", body, &u); w.Code != http.StatusCreated {
+		t.Fatalf("create %s: %d %s", userName, w.Code, w.Body)
+	}
+	return u
+}
+
+func TestUsers(t *testing.T) {
+	h := scim.NewServer(memory.New(), base)
+
+	ann := resource{}
+	body := `{"schemas": ["` + scim.UserSchema + `"], "userName": "Ann", "externalId": "e1", "emails": [{"value": "ann@example.com", "primary": true}]}`
+	w := call(t, h, "POST", "/Users", body, &ann)
+	if w.Code != http.StatusCreated || ann.UserName != "Ann" || ann.Active == nil || !*ann.Active {
+		t.Fatalf("create: %d %s", w.Code, w.Body)
+	}
+	if loc := w.Header().Get("Location"); loc != "http://example.com"+base+"/Users/"+ann.ID || ann.Meta.Location != loc {
+		t.Errorf("location %q, meta %q", loc, ann.Meta.Location)
+	}
+
+	// userName is unique ignoring case
+	e := scimError{}
+	if w := call(t, h, "POST", "/Users", `{"userName": "ANN"}`, &e); w.Code != http.StatusConflict || e.ScimType != "uniqueness" {
+		t.Errorf("same userName: %d %+v", w.Code, e)
+	}
+	bob := createUser(t, h, "bob")
+	if w := call(t, h, "PUT", "/Users/"+bob.ID, `{"userName": "ann"}`, &e); w.Code != http.StatusConflict || e.ScimType != "uniqueness" {
+		t.Errorf("rename to a taken userName: %d %+v", w.Code, e)
+	}
+	got := resource{}
+	if w := call(t, h, "PUT", "/Users/"+ann.ID, `{"userName": "ANN"}`, &got); w.Code != http.StatusOK || got.UserName != "ANN" {
+		t.Errorf("change the case of the own userName: %d %s", w.Code, w.Body)
+	}
+
+	patch := `{"schemas": ["urn:ietf:params:scim:api:messages:2.0:PatchOp"], "Operations": [{"op": "replace", "path": "active", "value": "False"}]}`
+	if w := call(t, h, "PATCH", "/Users/"+ann.ID, patch, &got); w.Code != http.StatusOK || got.Active == nil || *got.Active {
+		t.Errorf("deactivate: %d %s", w.Code, w.Body)
+	}
+	list := listResponse{}
+	call(t, h, "GET", "/Users?filter="+url.QueryEscape(`userName eq "ann" and active eq false`), "", &list)
+	if list.TotalResults != 1 || len(list.Resources) != 1 || list.Resources[0].ID != ann.ID {
+		t.Errorf("filter: %+v", list)
+	}
+	if w := call(t, h, "GET", "/Users?filter="+url.QueryEscape(`userName eq`), "", &e); w.Code != http.StatusBadRequest || e.ScimType != "invalidFilter" {
+		t.Errorf("invalid filter: %d %+v", w.Code, e)
+	}
+
+	if w := call(t, h, "DELETE", "/Users/"+ann.ID, "", nil); w.Code != http.StatusNoContent {
+		t.Errorf("delete: %d %s", w.Code, w.Body)
+	}
+	for _, id := range []string{ann.ID, "not-an-id"} {
+		if w := call(t, h, "GET", "/Users/"+id, "", &e); w.Code != http.StatusNotFound || e.Status != "404" {
+			t.Errorf("get %s: %d %+v", id, w.Code, e)
+		}
+	}
+}
+
+func TestListPaging(t *testing.T) {
+	h := scim.NewServer(memory.New(), base)
+	var ids []string
+	for i := 0; i < 5; i++ {
+		ids = append(ids, createUser(t, h, fmt.Sprintf("user%d", i)).ID)
+	}
+	for _, c := range []struct {
+		query string
+		start int
+		ids   []string
+	}{
+		{"", 1, ids},
+		{"?startIndex=2&count=2", 2, ids[1:3]},
+		{"?startIndex=4&count=10", 4, ids[3:]},
+		{"?startIndex=0&count=1", 1, ids[:1]},
+		{"?startIndex=9", 9, nil},
+		{"?count=0", 1, nil},
+		{"?count=-3", 1, nil},
+	} {
+		list := listResponse{}
+		if w := call(t, h, "GET", "/Users"+c.query, "", &list); w.Code != http.StatusOK {
+			t.Errorf("%s: %d %s", c.query, w.Code, w.Body)
+			continue
+		}
+		var got []string
+		for _, u := range list.Resources {
+			got = append(got, u.ID)
+		}
+		if list.TotalResults != 5 || list.StartIndex != c.start || list.ItemsPerPage != len(c.ids) || strings.Join(got, ",") != strings.Join(c.ids, ",") {
+			t.Errorf("%s: total %d start %d items %d %v, want start %d %v", c.query, list.TotalResults, list.StartIndex, list.ItemsPerPage, got, c.start, c.ids)
+		}
+	}
+	e := scimError{}
+	if w := call(t, h, "GET", "/Users?count=many", "", &e); w.Code != http.StatusBadRequest || e.ScimType != "invalidValue" {
+		t.Errorf("invalid count: %d %+v", w.Code, e)
+	}
+}
+
+func TestGroups(t *testing.T) {
+	h := scim.NewServer(memory.New(), base)
+	ann := createUser(t, h, "ann")
+	bob := createUser(t, h, "bob")
+
+	// a missing member fails the request without creating the group
+	e := scimError{}
+	body := fmt.Sprintf(`{"displayName": "eng", "members": [{"value": %q}, {"value": "0123456789abcdef01234567"}]}`, ann.ID)
+	if w := call(t, h, "POST", "/Groups", body, &e); w.Code != http.StatusBadRequest || e.ScimType != "invalidValue" {
+		t.Errorf("missing member: %d %+v", w.Code, e)
+	}
+	list := listResponse{}
+	if call(t, h, "GET", "/Groups", "", &list); list.TotalResults != 0 {
+		t.Errorf("group created with a missing member: %+v", list)
+	}
+
+	eng := resource{}
+	body = fmt.Sprintf(`{"displayName": "eng", "members": [{"value": %q}, {"value": %q}]}`, ann.ID, bob.ID)
+	if w := call(t, h, "POST", "/Groups", body, &eng); w.Code != http.StatusCreated || len(eng.Members) != 2 {
+		t.Fatalf("create: %d %s", w.Code, w.Body)
+	}
+	u := resource{}
+	if call(t, h, "GET", "/Users/"+ann.ID, "", &u); len(u.Groups) != 1 || u.Groups[0].Value != eng.ID {
+		t.Errorf("groups of the member: %+v", u.Groups)
+	}
+
+	patch := fmt.Sprintf(`{"schemas": ["urn:ietf:params:scim:api:messages:2.0:PatchOp"], "Operations": [
+		{"op": "remove", "path": "members[value eq \"%s\"]"},
+		{"op": "replace", "path": "displayName", "value": "engineering"}]}`, ann.ID)
+	got := resource{}
+	if w := call(t, h, "PATCH", "/Groups/"+eng.ID, patch, &got); w.Code != http.StatusOK || got.DisplayName != "engineering" || len(got.Members) != 1 || got.Members[0].Value != bob.ID {
+		t.Errorf("patch: %d %s", w.Code, w.Body)
+	}
+
+	if w := call(t, h, "PATCH", "/Groups/"+eng.ID, `{"Operations": []}`, &e); w.Code != http.StatusBadRequest || e.ScimType != "invalidValue" {
+		t.Errorf("patch without schema: %d %+v", w.Code, e)
+	}
+	if w := call(t, h, "DELETE", "/Groups/"+eng.ID, "", nil); w.Code != http.StatusNoContent {
+		t.Errorf("delete: %d %s", w.Code, w.Body)
+	}
+}
+
+func TestDiscovery(t *testing.T) {
+	h := scim.NewServer(memory.New(), base)
+
+	config := struct {
+		Patch struct {
+			Supported bool `json:"supported"`
+		} `json:"patch"`
+		Filter struct {
+			Supported  bool `json:"supported"`
+			MaxResults int  `json:"maxResults"`
+		} `json:"filter"`
+	}{}
+	if w := call(t, h, "GET", "/ServiceProviderConfig", "", &config); w.Code != http.StatusOK || !config.Patch.Supported || !config.Filter.Supported || config.Filter.MaxResults == 0 {
+		t.Errorf("service provider config: %d %s", w.Code, w.Body)
+	}
+
+	list := listResponse{}
+	if call(t, h, "GET", "/ResourceTypes", "", &list); list.TotalResults != 2 || len(list.Resources) != 2 {
+		t.Errorf("resource types: %+v", list)
+	}
+	list = listResponse{}
+	if call(t, h, "GET", "/Schemas", "", &list); list.TotalResults != 4 {
+		t.Errorf("schemas: %+v", list)
+	}
+	for _, path := range []string{"/ResourceTypes/User", "/ResourceTypes/Group", "/Schemas/" + scim.UserSchema, "/Schemas/" + scim.GroupExtension} {
+		doc := struct {
+			ID   string `json:"id"`
+			Meta struct {
+				Location string `json:"location"`
+			} `json:"meta"`
+		}{}
+		w := call(t, h, "GET", path, "", &doc)
+		if w.Code != http.StatusOK || !strings.HasSuffix(path, "/"+doc.ID) || !strings.HasSuffix(doc.Meta.Location, path) {
+			t.Errorf("%s: %d %s", path, w.Code, w.Body)
+		}
+	}
+
+	for _, c := range []struct {
+		method string
+		path   string
+		status int
+	}{
+		{"GET", "/ResourceTypes/Role", http.StatusNotFound},
+		{"GET", "/Schemas/urn:nothing", http.StatusNotFound},
+		{"GET", "/Roles", http.StatusNotFound},
+		{"GET", "/Users/a/b", http.StatusNotFound},
+		{"POST", "/ServiceProviderConfig", http.StatusMethodNotAllowed},
+		{"PUT", "/Users", http.StatusMethodNotAllowed},
+	} {
+		e := scimError{}
+		if w := call(t, h, c.method, c.path, "", &e); w.Code != c.status || e.Status != fmt.Sprint(c.status) {
+			t.Errorf("%s %s: %d %+v", c.method, c.path, w.Code, e)
+		}
+	}
+}
@@ -0,0 +1,267 @@
+// Package authorizer decides whether a user holds a permission in a user group.
+//
+// A user holds a permission in a user group when one of the roles granted to
+// the user for the user group, or for one of its ancestors, has a permission
+// matching it, either directly or through the roles it inherits, and the user
+// is active, see user.User.Active. Role
+// permissions may contain * matching any run of characters, so "*" grants
+// everything and "groups:*" grants "groups:read" and "groups:write".
+//
+//	a := authorizer.New(backend)
+//	ok, reason, err := a.Can(ctx, userId, "groups:write", groupId)
+//	if err == nil && !ok {
+//		log.Infof("denied:\n%s", reason)
+//	}
+package authorizer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/sr-codefreak/user-group/db"
+	"github.com/sr-codefreak/user-group/db/mongodb/access"
+	"github.com/sr-codefreak/user-group/myerrors"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Authorizer evaluates the access, role definitions and user group nesting of a backend
+type Authorizer struct {
+	backend db.Backend
+}
+
+// New returns the authorizer of backend
+func New(backend db.Backend) *Authorizer {
+	return &Authorizer{backend: backend}
+}
+
+// Grant is the access allowing a permission
+type Grant struct {
+	// UserGroupId is the user group the role is granted for, the requested one or an ancestor
+	UserGroupId string `json:"userGroupId"`
+	Role        string `json:"role"`
+	// Path leads from Role through the inherited roles to the role defining Permission
+	Path       []string `json:"path"`
+	Permission string   `json:"permission"`
+}
+
+// Reason explains a decision, Grant is nil when the permission was denied
+type Reason struct {
+	Grant *Grant   `json:"grant,omitempty"`
+	Trace []string `json:"trace"`
+}
+
+func (r Reason) String() string {
+	return strings.Join(r.Trace, "\n")
+}
+
+var errPermissionRequired = errors.New("permission is required")
+
+// Can reports whether the user holds the permission in the user group
+func (a *Authorizer) Can(ctx context.Context, userId primitive.ObjectID, permission string, groupId primitive.ObjectID) (bool, Reason, error) {
+	e, err := a.evaluation(ctx, userId, permission)
+	if err != nil {
+		return false, Reason{}, err
+	}
+	return e.decide(ctx, groupId)
+}
+
+// Filter returns the user groups of groupIds in which the user holds the
+// permission, in the order of groupIds. User groups that do not exist are left out.
+func (a *Authorizer) Filter(ctx context.Context, userId primitive.ObjectID, permission string, groupIds []primitive.ObjectID) ([]primitive.ObjectID, error) {
+	e, err := a.evaluation(ctx, userId, permission)
+	if err != nil {
+		return nil, err
+	}
+	allowed := []primitive.ObjectID{}
+	for _, id := range groupIds {
+		ok, _, err := e.decide(ctx, id)
+		if errors.Is(err, myerrors.ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			allowed = append(allowed, id)
+		}
+	}
+	return allowed, nil
+}
+
+// evaluation holds what is loaded once for all decisions about a user and permission
+type evaluation struct {
+	a          *Authorizer
+	permission string
+	// roles of the user by user group id
+	access map[string][]string
+	// role definitions by name
+	roles map[string]access.Role
+	// parents of the user groups loaded so far
+	parents map[primitive.ObjectID][]primitive.ObjectID
+	// inactive users are denied everything, whatever their roles
+	inactive bool
+}
+
+func (a *Authorizer) evaluation(ctx context.Context, userId primitive.ObjectID, permission string) (*evaluation, error) {
+	if permission == "" {
+		return nil, myerrors.Invalid(errPermissionRequired)
+	}
+	u, err := a.backend.Users().GetById(ctx, userId.Hex())
+	if err != nil && !errors.Is(err, myerrors.ErrNotFound) {
+		return nil, err
+	}
+	accesses, err := a.backend.Access().ListRolesForUser(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+	roles, err := a.backend.Roles().List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	e := &evaluation{
+		a:          a,
+		permission: permission,
+		access:     map[string][]string{},
+		roles:      map[string]access.Role{},
+		parents:    map[primitive.ObjectID][]primitive.ObjectID{},
+		inactive:   u != nil && !u.Active(),
+	}
+	for _, ac := range accesses {
+		e.access[ac.UserGroupId] = ac.Roles
+	}
+	for _, r := range roles {
+		e.roles[r.Name] = r
+	}
+	return e, nil
+}
+
+// parentsOf returns the parents of the user group, loading it on first use
+func (e *evaluation) parentsOf(ctx context.Context, id primitive.ObjectID) ([]primitive.ObjectID, error) {
+	if parents, ok := e.parents[id]; ok {
+		return parents, nil
+	}
+	ug, err := e.a.backend.UserGroups().GetById(ctx, id.Hex())
+	if err != nil {
+		return nil, err
+	}
+	e.parents[id] = ug.ParentIds
+	return ug.ParentIds, nil
+}
+
+// decide walks the user group and its ancestors nearest first and stops at
+// the first role granting the permission
+func (e *evaluation) decide(ctx context.Context, groupId primitive.ObjectID) (bool, Reason, error) {
+	reason := Reason{Trace: []string{}}
+	tracef := func(format string, args ...any) {
+		reason.Trace = append(reason.Trace, fmt.Sprintf(format, args...))
+	}
+
+	if _, err := e.parentsOf(ctx, groupId); err != nil {
+		return false, reason, err
+	}
+	if e.inactive {
+		tracef("denied: the user is inactive")
+		return false, reason, nil
+	}
+	queue := []primitive.ObjectID{groupId}
+	via := map[primitive.ObjectID]primitive.ObjectID{}
+	visited := map[primitive.ObjectID]bool{groupId: true}
+	for len(queue) > 0 {
+		id := queue[0]
+		queue = queue[1:]
+		if child, ok := via[id]; ok {
+			tracef("user group %s, parent of %s", id.Hex(), child.Hex())
+		} else {
+			tracef("user group %s", id.Hex())
+		}
+
+		roles := e.access[id.Hex()]
+		if len(roles) == 0 {
+			tracef("  no roles granted")
+		}
+		for _, role := range roles {
+			if g := e.grant(role, tracef); g != nil {
+				g.UserGroupId = id.Hex()
+				reason.Grant = g
+				tracef("allowed: %s of role %s matches %s", g.Permission, g.Path[len(g.Path)-1], e.permission)
+				return true, reason, nil
+			}
+		}
+
+		parents, err := e.parentsOf(ctx, id)
+		if errors.Is(err, myerrors.ErrNotFound) {
+			// a parent deleted meanwhile grants nothing
+			tracef("  user group not found")
+			continue
+		}
+		if err != nil {
+			return false, reason, err
+		}
+		for _, p := range parents {
+			if !visited[p] {
+				visited[p] = true
+				via[p] = id
+				queue = append(queue, p)
+			}
+		}
+	}
+	tracef("denied: no role grants %s", e.permission)
+	return false, reason, nil
+}
+
+// grant looks for the permission in the role and the roles it inherits
+func (e *evaluation) grant(role string, tracef func(format string, args ...any)) *Grant {
+	type step struct {
+		name string
+		path []string
+	}
+	queue := []step{{name: role, path: []string{role}}}
+	visited := map[string]bool{role: true}
+	for len(queue) > 0 {
+		s := queue[0]
+		queue = queue[1:]
+		r, ok := e.roles[s.name]
+		if !ok {
+			tracef("  role %s is not defined", strings.Join(s.path, " > "))
+			continue
+		}
+		tracef("  role %s: permissions %v", strings.Join(s.path, " > "), r.Permissions)
+		for _, p := range r.Permissions {
+			if Match(p, e.permission) {
+				return &Grant{Role: role, Path: s.path, Permission: p}
+			}
+		}
+		for _, name := range r.Inherits {
+			if !visited[name] {
+				visited[name] = true
+				path := append(append([]string{}, s.path...), name)
+				queue = append(queue, step{name: name, path: path})
+			}
+		}
+	}
+	return nil
+}
+
+// Match reports whether the role permission pattern matches permission,
+// * in pattern matches any run of characters
+func Match(pattern string, permission string) bool {
+	parts := strings.Split(pattern, "*")
+	if len(parts) == 1 {
+		return pattern == permission
+	}
+	rest, ok := strings.CutPrefix(permission, parts[0])
+	if !ok {
+		return false
+	}
+	last := parts[len(parts)-1]
+	for _, part := range parts[1 : len(parts)-1] {
+		i := strings.Index(rest, part)
+		if i < 0 {
+			return false
+		}
+		rest = rest[i+len(part):]
+	}
+	return len(rest) >= len(last) && strings.HasSuffix(rest, last)
+}
@@ -0,0 +1,284 @@
+package authorizer_test
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/sr-codefreak/user-group/authorizer"
+	"github.com/sr-codefreak/user-group/db"
+	"github.com/sr-codefreak/user-group/db/memory"
+	"github.com/sr-codefreak/user-group/db/mongodb/access"
+	"github.com/sr-codefreak/user-group/db/mongodb/user"
+	"github.com/sr-codefreak/user-group/db/mongodb/usergroup"
+	"github.com/sr-codefreak/user-group/myerrors"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// fixture is a memory backend with the user ann
+type fixture struct {
+	t   *testing.T
+	ctx context.Context
+	b   *memory.Backend
+	ann *user.User
+}
+
+func newFixture(t *testing.T) *fixture {
+	f := &fixture{t: t, ctx: context.Background(), b: memory.New(), ann: &user.User{Name: "ann"}}
+	if err := f.b.Users().Create(f.ctx, f.ann); err != nil {
+		t.Fatal(err)
+	}
+	return f
+}
+
+// group creates the user group as child of the parents, in their order
+func (f *fixture) group(name string, parents ...*usergroup.UserGroup) *usergroup.UserGroup {
+	f.t.Helper()
+	ug := &usergroup.UserGroup{Name: name}
+	if err := f.b.UserGroups().Create(f.ctx, ug); err != nil {
+		f.t.Fatal(err)
+	}
+	for _, p := range parents {
+		if err := f.b.UserGroups().AddChild(f.ctx, p.ID, ug.ID); err != nil {
+			f.t.Fatal(err)
+		}
+	}
+	return ug
+}
+
+func (f *fixture) role(name string, permissions []string, inherits ...string) {
+	f.t.Helper()
+	if err := f.b.Roles().Create(f.ctx, &access.Role{Name: name, Permissions: permissions, Inherits: inherits}); err != nil {
+		f.t.Fatal(err)
+	}
+}
+
+func (f *fixture) grant(ug *usergroup.UserGroup, roles ...string) {
+	f.t.Helper()
+	if err := f.b.Access().Grant(f.ctx, f.ann.ID, ug.ID, roles...); err != nil {
+		f.t.Fatal(err)
+	}
+}
+
+func TestMatch(t *testing.T) {
+	for _, c := range []struct {
+		pattern    string
+		permission string
+		want       bool
+	}{
+		{"groups:read", "groups:read", true},
+		{"groups:read", "groups:write", false},
+		{"groups:read", "groups:readall", false},
+		{"*", "anything", true},
+		{"*", "", true},
+		{"groups:*", "groups:read", true},
+		{"groups:*", "groups:", true},
+		{"groups:*", "users:read", false},
+		{"*:read", "groups:read", true},
+		{"*:read", "groups:write", false},
+		{"groups:*:own", "groups:write:own", true},
+		{"groups:*:own", "groups:write:all", false},
+		{"groups:*:own", "groups:own", false},
+		{"*:*", "groups:read", true},
+		{"*:*", "groups", false},
+		{"a*b*c", "abc", true},
+		{"a*b*c", "axxbyyc", true},
+		{"a*b*c", "acb", false},
+		{"a*a", "a", false},
+		{"a*a", "aa", true},
+		{"**", "x", true},
+	} {
+		if got := authorizer.Match(c.pattern, c.permission); got != c.want {
+			t.Errorf("Match(%q, %q) = %v, want %v", c.pattern, c.permission, got, c.want)
+		}
+	}
+}
+
+func TestInheritedRoles(t *testing.T) {
+	f := newFixture(t)
+	eng := f.group("eng")
+	f.role("a", nil)
+	f.grant(eng, "a")
+	// the stores refuse cycles and undefined roles, a role list read while
+	// roles change may still hold them: a and b inherit each other, b
+	// inherits the editor and an undefined role
+	a := authorizer.New(stale{Backend: f.b, roles: []access.Role{
+		{Name: "a", Permissions: []string{"groups:list"}, Inherits: []string{"b"}},
+		{Name: "b", Permissions: []string{}, Inherits: []string{"a", "undefined", "editor"}},
+		{Name: "editor", Permissions: []string{"groups:write"}},
+	}})
+
+	ok, reason, err := a.Can(f.ctx, f.ann.ID, "groups:write", eng.ID)
+	if err != nil || !ok {
+		t.Fatalf("groups:write: %v %v\n%s", ok, err, reason)
+	}
+	want := &authorizer.Grant{UserGroupId: eng.ID.Hex(), Role: "a", Path: []string{"a", "b", "editor"}, Permission: "groups:write"}
+	if !reflect.DeepEqual(reason.Grant, want) {
+		t.Errorf("grant = %+v, want %+v", reason.Grant, want)
+	}
+
+	// the cycle is walked once and the undefined role grants nothing
+	ok, reason, err = a.Can(f.ctx, f.ann.ID, "groups:delete", eng.ID)
+	if err != nil || ok {
+		t.Fatalf("groups:delete: %v %v\n%s", ok, err, reason)
+	}
+	if !strings.Contains(reason.String(), "role a > b > undefined is not defined") {
+		t.Errorf("trace without the undefined role:\n%s", reason)
+	}
+	if n := strings.Count(reason.String(), "role a > b: "); n != 1 {
+		t.Errorf("role b walked %d times:\n%s", n, reason)
+	}
+	if strings.Contains(reason.String(), "role a > b > a") {
+		t.Errorf("role a walked again:\n%s", reason)
+	}
+}
+
+func TestAncestorOrder(t *testing.T) {
+	f := newFixture(t)
+	root := f.group("root")
+	left := f.group("left", root)
+	right := f.group("right")
+	team := f.group("team", left, right)
+	f.role("viewer", []string{"groups:read"})
+	f.grant(root, "viewer")
+	f.grant(right, "viewer")
+	a := authorizer.New(f.b)
+
+	// right is nearer than root, though left, the parent leading to root, comes first
+	ok, reason, err := a.Can(f.ctx, f.ann.ID, "groups:read", team.ID)
+	if err != nil || !ok {
+		t.Fatalf("%v %v\n%s", ok, err, reason)
+	}
+	if reason.Grant.UserGroupId != right.ID.Hex() {
+		t.Errorf("granted for %s, want right %s\n%s", reason.Grant.UserGroupId, right.ID.Hex(), reason)
+	}
+	var visited []string
+	for _, line := range reason.Trace {
+		if strings.HasPrefix(line, "user group ") {
+			visited = append(visited, strings.TrimSuffix(strings.Fields(line)[2], ","))
+		}
+	}
+	want := []string{team.ID.Hex(), left.ID.Hex(), right.ID.Hex()}
+	if !reflect.DeepEqual(visited, want) {
+		t.Errorf("visited %v, want %v", visited, want)
+	}
+
+	ok, reason, err = a.Can(f.ctx, f.ann.ID, "groups:read", root.ID)
+	if err != nil || !ok || reason.Grant.UserGroupId != root.ID.Hex() {
+		t.Errorf("root: %v %v\n%s", ok, err, reason)
+	}
+}
+
+// stale hides the user groups of gone, as if they were deleted while their
+// children were read, and lists roles in place of the role store
+type stale struct {
+	db.Backend
+	gone  map[string]bool
+	roles []access.Role
+}
+
+func (b stale) UserGroups() usergroup.UserGroupStore {
+	return staleGroups{UserGroupStore: b.Backend.UserGroups(), gone: b.gone}
+}
+
+func (b stale) Roles() access.RoleStore {
+	if b.roles == nil {
+		return b.Backend.Roles()
+	}
+	return staleRoles{RoleStore: b.Backend.Roles(), roles: b.roles}
+}
+
+type staleGroups struct {
+	usergroup.UserGroupStore
+	gone map[string]bool
+}
+
+func (s staleGroups) GetById(ctx context.Context, id string) (*usergroup.UserGroup, error) {
+	if s.gone[id] {
+		return nil, myerrors.Wrap(myerrors.ErrGetUserGroupById, myerrors.KindUserGroup, id, myerrors.ErrNotFound)
+	}
+	return s.UserGroupStore.GetById(ctx, id)
+}
+
+type staleRoles struct {
+	access.RoleStore
+	roles []access.Role
+}
+
+func (s staleRoles) List(ctx context.Context) ([]access.Role, error) {
+	return s.roles, nil
+}
+
+func TestMissingParent(t *testing.T) {
+	f := newFixture(t)
+	root := f.group("root")
+	left := f.group("left", root)
+	right := f.group("right")
+	team := f.group("team", left, right)
+	f.role("viewer", []string{"groups:read"})
+	f.grant(root, "viewer")
+	f.grant(right, "viewer")
+	a := authorizer.New(stale{Backend: f.b, gone: map[string]bool{left.ID.Hex(): true}})
+
+	// left and root above it are out of reach, right still grants
+	ok, reason, err := a.Can(f.ctx, f.ann.ID, "groups:read", team.ID)
+	if err != nil || !ok || reason.Grant.UserGroupId != right.ID.Hex() {
+		t.Errorf("%v %v\n%s", ok, err, reason)
+	}
+	if !strings.Contains(reason.String(), "user group not found") {
+		t.Errorf("trace without the missing parent:\n%s", reason)
+	}
+
+	// the requested user group itself must exist
+	_, _, err = a.Can(f.ctx, f.ann.ID, "groups:read", left.ID)
+	if myerrors.CodeOf(err) != myerrors.NotFound {
+		t.Errorf("missing user group: %v, want not found", err)
+	}
+}
+
+func TestFilter(t *testing.T) {
+	f := newFixture(t)
+	eng := f.group("eng")
+	ops := f.group("ops")
+	sales := f.group("sales")
+	f.role("viewer", []string{"groups:read"})
+	f.grant(eng, "viewer")
+	f.grant(sales, "viewer")
+	a := authorizer.New(f.b)
+
+	missing := primitive.NewObjectID()
+	got, err := a.Filter(f.ctx, f.ann.ID, "groups:read", []primitive.ObjectID{sales.ID, missing, ops.ID, eng.ID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []primitive.ObjectID{sales.ID, eng.ID}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Filter = %v, want %v", got, want)
+	}
+	if _, err := a.Filter(f.ctx, f.ann.ID, "", []primitive.ObjectID{eng.ID}); myerrors.CodeOf(err) != myerrors.InvalidArgument {
+		t.Errorf("empty permission: %v, want an invalid argument", err)
+	}
+}
+
+func TestInactiveUser(t *testing.T) {
+	f := newFixture(t)
+	eng := f.group("eng")
+	f.role("admin", []string{"*"})
+	f.grant(eng, "admin")
+	a := authorizer.New(f.b)
+	if ok, reason, err := a.Can(f.ctx, f.ann.ID, "groups:write", eng.ID); err != nil || !ok {
+		t.Fatalf("active user: %v %v\n%s", ok, err, reason)
+	}
+
+	f.ann.MetaData = map[string]any{user.ActiveKey: false}
+	if err := f.b.Users().Update(f.ctx, f.ann); err != nil {
+		t.Fatal(err)
+	}
+	ok, reason, err := a.Can(f.ctx, f.ann.ID, "groups:write", eng.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ok || reason.Grant != nil {
+		t.Errorf("inactive user allowed:\n%s", reason)
+	}
+}
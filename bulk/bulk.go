@@ -0,0 +1,165 @@
+// Package bulk imports and exports users, user groups and access in bulk,
+// as JSON documents of the shapes in design/user.json, design/user-group.json
+// and design/access.json or as CSV files with a header row.
+//
+// A JSON file holds an array of documents or a stream of documents. The
+// columns of the CSV files are
+//
+//	users        _id, name, email, phone, userGroupIds, metaData.<key>...
+//	user groups  _id, name, rule, userIds, parentIds, metaData.<key>...
+//	access       userId, userGroupId, roles
+//
+// where lists are separated by semicolons. Every metaData.<key> column holds
+// the string value of a metaData key, an empty cell leaves the key out. The
+// export writes the values that are not strings as JSON.
+//
+// An Importer validates every row and upserts it: users are matched by email
+// and user groups by name, the rows that match nothing are created in batches
+// with CreateMany. A user row updates the name of its user and the phone
+// when it has one, and replaces the metaData when it has some. A user group
+// row sets the rule of its user group and adds the users and parents it
+// lists; members are never removed and the metaData of an existing user
+// group is left as it is. The users of a dynamic user group are ignored,
+// its rule sets them. The usersGroups and userGroupIds of the users are
+// ignored, memberships are imported with the user groups. An access row
+// sets the roles of the user in the user group.
+//
+// Users are referenced by id or email and user groups by id or name, where
+// the ids are those of the backend or the _id of a document imported before,
+// so users are imported before user groups and user groups before access.
+// The _id of a created document is kept when it is a free ObjectID.
+//
+// Rows that are invalid or cannot be stored are rejected and the import goes
+// on; the Report lists them with the reason.
+//
+//	im := bulk.NewImporter(backend, bulk.Options{})
+//	err := im.ImportUsers(ctx, usersFile, bulk.CSV)
+//	err = im.ImportUserGroups(ctx, groupsFile, bulk.JSON)
+//	report := im.Report()
+//	report.WriteRejected(os.Stderr, bulk.CSV)
+package bulk
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/sr-codefreak/user-group/myerrors"
+)
+
+// Format is the format of a file
+type Format string
+
+const (
+	JSON Format = "json"
+	CSV  Format = "csv"
+)
+
+// FormatOf returns the format of the file from its extension
+func FormatOf(name string) (Format, error) {
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".json", ".ndjson", ".jsonl":
+		return JSON, nil
+	case ".csv":
+		return CSV, nil
+	}
+	return "", fmt.Errorf("unknown format of %s, expected .json or .csv", name)
+}
+
+// DefaultBatchSize is the number of documents created per batch by default
+const DefaultBatchSize = 500
+
+// Options are the options of an Importer
+type Options struct {
+	// BatchSize is the number of documents created per batch, defaults to DefaultBatchSize
+	BatchSize int
+}
+
+// Rejection is a row that was not imported
+type Rejection struct {
+	Kind myerrors.Kind `json:"kind"`
+	// Row is the number of the row in its file from 1, the header row of a CSV file not counted
+	Row int `json:"row"`
+	// Key is the email of a user, the name of a user group or the user and
+	// user group of an access row
+	Key    string `json:"key,omitempty"`
+	Reason string `json:"reason"`
+}
+
+func (r Rejection) String() string {
+	s := string(r.Kind) + " row " + strconv.Itoa(r.Row)
+	if r.Key != "" {
+		s += " " + r.Key
+	}
+	return s + ": " + r.Reason
+}
+
+// Counts are the numbers of rows of a kind by outcome
+type Counts struct {
+	Created   int `json:"created"`
+	Updated   int `json:"updated"`
+	Unchanged int `json:"unchanged"`
+	Rejected  int `json:"rejected"`
+}
+
+// Report is the outcome of an import
+type Report struct {
+	Users      Counts `json:"users"`
+	UserGroups Counts `json:"userGroups"`
+	Access     Counts `json:"access"`
+	// Rejected are in the order of the rows
+	Rejected []Rejection `json:"rejected"`
+}
+
+func (r *Report) counts(kind myerrors.Kind) *Counts {
+	switch kind {
+	case myerrors.KindUser:
+		return &r.Users
+	case myerrors.KindUserGroup:
+		return &r.UserGroups
+	}
+	return &r.Access
+}
+
+func (r *Report) reject(kind myerrors.Kind, row int, key string, reason string) {
+	r.counts(kind).Rejected++
+	r.Rejected = append(r.Rejected, Rejection{Kind: kind, Row: row, Key: key, Reason: reason})
+}
+
+// String returns the counts of the report, one kind per line
+func (r *Report) String() string {
+	var sb strings.Builder
+	for _, k := range []struct {
+		name string
+		c    Counts
+	}{{"users", r.Users}, {"user groups", r.UserGroups}, {"access", r.Access}} {
+		fmt.Fprintf(&sb, "%-12s %d created, %d updated, %d unchanged, %d rejected\n",
+			k.name, k.c.Created, k.c.Updated, k.c.Unchanged, k.c.Rejected)
+	}
+	return sb.String()
+}
+
+// WriteRejected writes the rejected rows, as a JSON array or as CSV with
+// the columns kind, row, key and reason
+func (r *Report) WriteRejected(w io.Writer, format Format) error {
+	if format == JSON {
+		rejected := r.Rejected
+		if rejected == nil {
+			rejected = []Rejection{}
+		}
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(rejected)
+	}
+	cw := csv.NewWriter(w)
+	cw.Write([]string{"kind", "row", "key", "reason"})
+	for _, rej := range r.Rejected {
+		cw.Write([]string{string(rej.Kind), strconv.Itoa(rej.Row), rej.Key, rej.Reason})
+	}
+	cw.Flush()
+	return cw.Error()
+}
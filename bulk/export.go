@@ -0,0 +1,159 @@
+package bulk
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/sr-codefreak/user-group/db"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// ExportUsers writes the users of the backend
+func ExportUsers(ctx context.Context, backend db.Backend, w io.Writer, format Format) error {
+	users, err := listAll(ctx, backend.Users().List)
+	if err != nil {
+		return err
+	}
+	if format == JSON {
+		return writeJSON(w, users)
+	}
+	metaData := make([]map[string]any, len(users))
+	for i, u := range users {
+		metaData[i] = u.MetaData
+	}
+	keys := metaDataKeys(metaData)
+	records := make([][]string, len(users))
+	for i, u := range users {
+		records[i] = append([]string{u.ID.Hex(), u.Name, u.Email, u.Phone, strings.Join(u.UserGroupIds, listSep)},
+			metaDataCells(u.MetaData, keys)...)
+	}
+	return writeCSV(w, userColumns[:5], keys, records)
+}
+
+// ExportUserGroups writes the user groups of the backend
+func ExportUserGroups(ctx context.Context, backend db.Backend, w io.Writer, format Format) error {
+	groups, err := listAll(ctx, backend.UserGroups().List)
+	if err != nil {
+		return err
+	}
+	if format == JSON {
+		return writeJSON(w, groups)
+	}
+	metaData := make([]map[string]any, len(groups))
+	for i, ug := range groups {
+		metaData[i] = ug.MetaData
+	}
+	keys := metaDataKeys(metaData)
+	records := make([][]string, len(groups))
+	for i, ug := range groups {
+		parentIds := make([]string, len(ug.ParentIds))
+		for j, id := range ug.ParentIds {
+			parentIds[j] = id.Hex()
+		}
+		records[i] = append([]string{ug.ID.Hex(), ug.Name, ug.Rule, strings.Join(ug.UserIds, listSep), strings.Join(parentIds, listSep)},
+			metaDataCells(ug.MetaData, keys)...)
+	}
+	return writeCSV(w, groupColumns[:5], keys, records)
+}
+
+// ExportAccess writes the roles of every user in every user group
+func ExportAccess(ctx context.Context, backend db.Backend, w io.Writer, format Format) error {
+	users, err := listAll(ctx, backend.Users().List)
+	if err != nil {
+		return err
+	}
+	docs := []accessDoc{}
+	for _, u := range users {
+		accesses, err := backend.Access().ListRolesForUser(ctx, u.ID)
+		if err != nil {
+			return err
+		}
+		for _, a := range accesses {
+			docs = append(docs, accessDoc{UserId: a.UserId, UserGroupId: a.UserGroupId, Roles: a.Roles})
+		}
+	}
+	if format == JSON {
+		return writeJSON(w, docs)
+	}
+	records := make([][]string, len(docs))
+	for i, d := range docs {
+		records[i] = []string{d.UserId, d.UserGroupId, strings.Join(d.Roles, listSep)}
+	}
+	return writeCSV(w, accessColumns, nil, records)
+}
+
+// writeJSON writes the documents as an array, one document per line
+func writeJSON[T any](w io.Writer, docs []T) error {
+	if len(docs) == 0 {
+		_, err := io.WriteString(w, "[]\n")
+		return err
+	}
+	sep := "[\n"
+	for _, d := range docs {
+		b, err := json.Marshal(d)
+		if err != nil {
+			return err
+		}
+		if _, err := io.WriteString(w, sep); err != nil {
+			return err
+		}
+		if _, err := w.Write(b); err != nil {
+			return err
+		}
+		sep = ",\n"
+	}
+	_, err := io.WriteString(w, "\n]\n")
+	return err
+}
+
+func writeCSV(w io.Writer, columns []string, metaDataKeys []string, records [][]string) error {
+	cw := csv.NewWriter(w)
+	header := append([]string(nil), columns...)
+	for _, key := range metaDataKeys {
+		header = append(header, metaDataPrefix+key)
+	}
+	cw.Write(header)
+	cw.WriteAll(records)
+	return cw.Error()
+}
+
+// metaDataKeys returns the sorted keys of the metaData
+func metaDataKeys(metaData []map[string]any) []string {
+	seen := map[string]bool{}
+	keys := []string{}
+	for _, m := range metaData {
+		for key := range m {
+			if !seen[key] {
+				seen[key] = true
+				keys = append(keys, key)
+			}
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// metaDataCells returns the cells of the metaData keys, the values that
+// are not strings as JSON
+func metaDataCells(metaData map[string]any, keys []string) []string {
+	cells := make([]string, len(keys))
+	for i, key := range keys {
+		switch v := metaData[key].(type) {
+		case nil:
+		case string:
+			cells[i] = v
+		case primitive.ObjectID:
+			cells[i] = v.Hex()
+		default:
+			b, err := json.Marshal(v)
+			if err == nil {
+				cells[i] = string(b)
+			}
+		}
+	}
+	return cells
+}
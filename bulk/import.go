@@ -0,0 +1,642 @@
+package bulk
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/sr-codefreak/user-group/db"
+	"github.com/sr-codefreak/user-group/db/mongodb"
+	"github.com/sr-codefreak/user-group/db/mongodb/user"
+	"github.com/sr-codefreak/user-group/db/mongodb/usergroup"
+	"github.com/sr-codefreak/user-group/myerrors"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Importer imports files into a backend and keeps the Report of the rows,
+// see the package documentation. An Importer is not safe for concurrent use.
+type Importer struct {
+	backend db.Backend
+	opts    Options
+	report  Report
+	loaded  bool
+	// users are the users by lower case email, nil for an email of several users
+	users map[string]*user.User
+	// groups are the user groups by name, nil for a name of several user groups
+	groups map[string]*usergroup.UserGroup
+	// userIds and groupIds map the ids of the backend and the _id of the
+	// imported documents to the ids of the backend
+	userIds  map[string]primitive.ObjectID
+	groupIds map[string]primitive.ObjectID
+	// roles are the roles of the users by user group id, read when needed
+	roles map[primitive.ObjectID]map[string][]string
+}
+
+// NewImporter returns an importer into the backend
+func NewImporter(backend db.Backend, opts Options) *Importer {
+	if opts.BatchSize <= 0 {
+		opts.BatchSize = DefaultBatchSize
+	}
+	return &Importer{
+		backend:  backend,
+		opts:     opts,
+		users:    map[string]*user.User{},
+		groups:   map[string]*usergroup.UserGroup{},
+		userIds:  map[string]primitive.ObjectID{},
+		groupIds: map[string]primitive.ObjectID{},
+		roles:    map[primitive.ObjectID]map[string][]string{},
+	}
+}
+
+// Report returns the report of the rows imported so far
+func (im *Importer) Report() *Report {
+	return &im.report
+}
+
+// load reads the users and user groups of the backend
+func (im *Importer) load(ctx context.Context) error {
+	if im.loaded {
+		return nil
+	}
+	users, err := listAll(ctx, im.backend.Users().List)
+	if err != nil {
+		return err
+	}
+	for i := range users {
+		im.indexUser(&users[i])
+	}
+	groups, err := listAll(ctx, im.backend.UserGroups().List)
+	if err != nil {
+		return err
+	}
+	for i := range groups {
+		im.indexGroup(&groups[i])
+	}
+	im.loaded = true
+	return nil
+}
+
+// listAll reads all the pages of a list
+func listAll[T any](ctx context.Context, list func(ctx context.Context, opts mongodb.ListOptions) ([]T, string, error)) ([]T, error) {
+	opts := mongodb.ListOptions{PageSize: mongodb.MaxPageSize}
+	var all []T
+	for {
+		page, next, err := list(ctx, opts)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, page...)
+		if next == "" {
+			return all, nil
+		}
+		opts.PageToken = next
+	}
+}
+
+func (im *Importer) indexUser(u *user.User) {
+	im.userIds[u.ID.Hex()] = u.ID
+	if u.Email == "" {
+		return
+	}
+	key := strings.ToLower(u.Email)
+	if _, ok := im.users[key]; ok {
+		im.users[key] = nil
+		return
+	}
+	im.users[key] = u
+}
+
+func (im *Importer) indexGroup(ug *usergroup.UserGroup) {
+	im.groupIds[ug.ID.Hex()] = ug.ID
+	if _, ok := im.groups[ug.Name]; ok {
+		im.groups[ug.Name] = nil
+		return
+	}
+	im.groups[ug.Name] = ug
+}
+
+// resolveUser returns the id of the user referenced by id or email
+func (im *Importer) resolveUser(ref string) (primitive.ObjectID, error) {
+	if id, ok := im.userIds[ref]; ok {
+		return id, nil
+	}
+	u, ok := im.users[strings.ToLower(ref)]
+	switch {
+	case !ok:
+		return primitive.NilObjectID, fmt.Errorf("unknown user %s", ref)
+	case u == nil:
+		return primitive.NilObjectID, fmt.Errorf("several users have the email %s", ref)
+	}
+	return u.ID, nil
+}
+
+// resolveGroup returns the id of the user group referenced by id or name
+func (im *Importer) resolveGroup(ref string) (primitive.ObjectID, error) {
+	if id, ok := im.groupIds[ref]; ok {
+		return id, nil
+	}
+	ug, ok := im.groups[ref]
+	switch {
+	case !ok:
+		return primitive.NilObjectID, fmt.Errorf("unknown user group %s", ref)
+	case ug == nil:
+		return primitive.NilObjectID, fmt.Errorf("several user groups are named %s", ref)
+	}
+	return ug.ID, nil
+}
+
+// newID returns the id of a document created with the _id docId, which is
+// kept when it is a free ObjectID
+func newID(ids map[string]primitive.ObjectID, docId string) (primitive.ObjectID, error) {
+	if docId == "" {
+		return primitive.NewObjectID(), nil
+	}
+	if _, ok := ids[docId]; ok {
+		return primitive.NilObjectID, fmt.Errorf("_id %s is taken", docId)
+	}
+	if id, err := primitive.ObjectIDFromHex(docId); err == nil {
+		return id, nil
+	}
+	return primitive.NewObjectID(), nil
+}
+
+// mapID maps the _id of a document to the id of the entry it matched
+func mapID(ids map[string]primitive.ObjectID, docId string, id primitive.ObjectID) error {
+	if docId == "" {
+		return nil
+	}
+	if mapped, ok := ids[docId]; ok && mapped != id {
+		return fmt.Errorf("_id %s is taken", docId)
+	}
+	ids[docId] = id
+	return nil
+}
+
+// sortRejected sorts the rejections of an import by row, from the index from
+func (im *Importer) sortRejected(from int) {
+	rejected := im.report.Rejected[from:]
+	sort.SliceStable(rejected, func(i, j int) bool { return rejected[i].Row < rejected[j].Row })
+}
+
+// sameMetaData reports whether the metaData are equal once stored
+func sameMetaData(a, b map[string]any) bool {
+	if len(a) == 0 && len(b) == 0 {
+		return true
+	}
+	ja, errA := json.Marshal(a)
+	jb, errB := json.Marshal(b)
+	return errA == nil && errB == nil && bytes.Equal(ja, jb)
+}
+
+// pendingUser is a user row waiting for its batch
+type pendingUser struct {
+	n     int
+	docId string
+	u     *user.User
+}
+
+// ImportUsers upserts the users of r by email
+func (im *Importer) ImportUsers(ctx context.Context, r io.Reader, format Format) error {
+	if err := im.load(ctx); err != nil {
+		return err
+	}
+	from := len(im.report.Rejected)
+	defer im.sortRejected(from)
+	seen := map[string]int{}
+	var batch []pendingUser
+	err := eachRow(r, format, userColumns, func(rw row) error {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		var d userDoc
+		if err := rw.decode(&d); err != nil {
+			im.report.reject(myerrors.KindUser, rw.n, "", err.Error())
+			return nil
+		}
+		if err := d.validate(); err != nil {
+			im.report.reject(myerrors.KindUser, rw.n, d.Email, err.Error())
+			return nil
+		}
+		key := strings.ToLower(d.Email)
+		if n, ok := seen[key]; ok {
+			im.report.reject(myerrors.KindUser, rw.n, d.Email, fmt.Sprintf("duplicate of row %d", n))
+			return nil
+		}
+		seen[key] = rw.n
+		if existing, ok := im.users[key]; ok {
+			if existing == nil {
+				im.report.reject(myerrors.KindUser, rw.n, d.Email, "several users have the email")
+				return nil
+			}
+			im.updateUser(ctx, rw.n, existing, &d)
+			return nil
+		}
+		id, err := newID(im.userIds, d.ID)
+		if err != nil {
+			im.report.reject(myerrors.KindUser, rw.n, d.Email, err.Error())
+			return nil
+		}
+		u := &user.User{ID: id, Name: d.Name, Email: d.Email, Phone: d.Phone, MetaData: d.MetaData}
+		batch = append(batch, pendingUser{n: rw.n, docId: d.ID, u: u})
+		if d.ID != "" {
+			im.userIds[d.ID] = id
+		}
+		if len(batch) == im.opts.BatchSize {
+			im.createUsers(ctx, batch)
+			batch = nil
+		}
+		return nil
+	})
+	im.createUsers(ctx, batch)
+	return err
+}
+
+// updateUser updates the user matched by the row when it differs. The
+// name and phone are updated when not empty, the metaData when not nil.
+func (im *Importer) updateUser(ctx context.Context, n int, existing *user.User, d *userDoc) {
+	if err := mapID(im.userIds, d.ID, existing.ID); err != nil {
+		im.report.reject(myerrors.KindUser, n, d.Email, err.Error())
+		return
+	}
+	if existing.Name == d.Name && (d.Phone == "" || existing.Phone == d.Phone) &&
+		(d.MetaData == nil || sameMetaData(existing.MetaData, d.MetaData)) {
+		im.report.Users.Unchanged++
+		return
+	}
+	u := &user.User{ID: existing.ID, Name: d.Name, Phone: d.Phone, MetaData: d.MetaData}
+	if err := im.backend.Users().Update(ctx, u); err != nil {
+		im.report.reject(myerrors.KindUser, n, d.Email, err.Error())
+		return
+	}
+	existing.Name = d.Name
+	if d.Phone != "" {
+		existing.Phone = d.Phone
+	}
+	if d.MetaData != nil {
+		existing.MetaData = d.MetaData
+	}
+	im.report.Users.Updated++
+}
+
+// createUsers creates the batch. When the batch fails the users it did
+// not insert are created one by one, so only the failing rows are rejected.
+func (im *Importer) createUsers(ctx context.Context, batch []pendingUser) {
+	if len(batch) == 0 {
+		return
+	}
+	users := make([]*user.User, len(batch))
+	for i, p := range batch {
+		users[i] = p.u
+	}
+	if err := im.backend.Users().CreateMany(ctx, users); err == nil {
+		for _, p := range batch {
+			im.indexUser(p.u)
+			im.report.Users.Created++
+		}
+		return
+	}
+	for _, p := range batch {
+		inserted, err := wasInserted(im.backend.Users().GetById(ctx, p.u.ID.Hex()))
+		if err == nil && !inserted {
+			err = im.backend.Users().Create(ctx, p.u)
+		}
+		if err != nil {
+			if p.docId != "" {
+				delete(im.userIds, p.docId)
+			}
+			im.report.reject(myerrors.KindUser, p.n, p.u.Email, err.Error())
+			continue
+		}
+		im.indexUser(p.u)
+		im.report.Users.Created++
+	}
+}
+
+// wasInserted reports whether the entry a failed batch was to insert exists,
+// from the result of reading it: the memory backend keeps the batch when a
+// reconciliation of dynamic user groups fails after it
+func wasInserted[T any](_ T, err error) (bool, error) {
+	if errors.Is(err, myerrors.ErrNotFound) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+// groupRow is a user group row, its outcome is counted once its members
+// and parents are added
+type groupRow struct {
+	n       int
+	d       *groupDoc
+	members []primitive.ObjectID
+	ug      *usergroup.UserGroup
+	created bool
+	changed bool
+	// reason is the reason the row is rejected
+	reason string
+}
+
+func (g *groupRow) fail(err error) {
+	if g.reason == "" {
+		g.reason = err.Error()
+	}
+}
+
+// ImportUserGroups upserts the user groups of r by name, adds the users
+// they list and then adds them to their parents
+func (im *Importer) ImportUserGroups(ctx context.Context, r io.Reader, format Format) error {
+	if err := im.load(ctx); err != nil {
+		return err
+	}
+	from := len(im.report.Rejected)
+	defer im.sortRejected(from)
+	seen := map[string]int{}
+	var rows, batch []*groupRow
+	err := eachRow(r, format, groupColumns, func(rw row) error {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		d := &groupDoc{}
+		if err := rw.decode(d); err != nil {
+			im.report.reject(myerrors.KindUserGroup, rw.n, "", err.Error())
+			return nil
+		}
+		if err := d.validate(); err != nil {
+			im.report.reject(myerrors.KindUserGroup, rw.n, d.Name, err.Error())
+			return nil
+		}
+		if n, ok := seen[d.Name]; ok {
+			im.report.reject(myerrors.KindUserGroup, rw.n, d.Name, fmt.Sprintf("duplicate of row %d", n))
+			return nil
+		}
+		seen[d.Name] = rw.n
+		g := &groupRow{n: rw.n, d: d}
+		// the rule sets the users of dynamic user groups
+		var members []string
+		if existing := im.groups[d.Name]; d.Rule == "" && (existing == nil || existing.Rule == "") {
+			members = d.members()
+		}
+		for _, ref := range members {
+			id, err := im.resolveUser(ref)
+			if err != nil {
+				im.report.reject(myerrors.KindUserGroup, rw.n, d.Name, err.Error())
+				return nil
+			}
+			g.members = append(g.members, id)
+		}
+		if existing, ok := im.groups[d.Name]; ok {
+			if err := im.matchGroup(g, existing); err != nil {
+				im.report.reject(myerrors.KindUserGroup, rw.n, d.Name, err.Error())
+				return nil
+			}
+			im.updateGroup(ctx, g)
+			rows = append(rows, g)
+			return nil
+		}
+		id, err := newID(im.groupIds, d.ID)
+		if err != nil {
+			im.report.reject(myerrors.KindUserGroup, rw.n, d.Name, err.Error())
+			return nil
+		}
+		g.ug = &usergroup.UserGroup{ID: id, Name: d.Name, MetaData: d.MetaData, Rule: d.Rule}
+		if d.ID != "" {
+			im.groupIds[d.ID] = id
+		}
+		rows, batch = append(rows, g), append(batch, g)
+		if len(batch) == im.opts.BatchSize {
+			im.createGroups(ctx, batch)
+			batch = nil
+		}
+		return nil
+	})
+	im.createGroups(ctx, batch)
+	if err == nil {
+		err = ctx.Err()
+	}
+	if err == nil {
+		for _, g := range rows {
+			im.addParents(ctx, g)
+		}
+	}
+	for _, g := range rows {
+		switch {
+		case g.reason != "":
+			im.report.reject(myerrors.KindUserGroup, g.n, g.d.Name, g.reason)
+		case g.created:
+			im.report.UserGroups.Created++
+		case g.changed:
+			im.report.UserGroups.Updated++
+		default:
+			im.report.UserGroups.Unchanged++
+		}
+	}
+	return err
+}
+
+// matchGroup checks the row can update the user group
+func (im *Importer) matchGroup(g *groupRow, existing *usergroup.UserGroup) error {
+	if existing == nil {
+		return fmt.Errorf("several user groups are named %s", g.d.Name)
+	}
+	g.ug = existing
+	return mapID(im.groupIds, g.d.ID, existing.ID)
+}
+
+// updateGroup sets the rule of the user group matched by the row and adds its users
+func (im *Importer) updateGroup(ctx context.Context, g *groupRow) {
+	if g.d.Rule != "" && g.d.Rule != g.ug.Rule {
+		if err := im.backend.UserGroups().SetRule(ctx, g.ug.ID, g.d.Rule); err != nil {
+			g.fail(err)
+			return
+		}
+		g.ug.Rule = g.d.Rule
+		g.changed = true
+	}
+	im.addMembers(ctx, g)
+}
+
+// createGroups creates the batch and adds the users of the created user
+// groups. When the batch fails the user groups it did not insert are
+// created one by one, so only the failing rows are rejected.
+func (im *Importer) createGroups(ctx context.Context, batch []*groupRow) {
+	if len(batch) == 0 {
+		return
+	}
+	groups := make([]*usergroup.UserGroup, len(batch))
+	for i, g := range batch {
+		groups[i] = g.ug
+	}
+	batchErr := im.backend.UserGroups().CreateMany(ctx, groups)
+	for _, g := range batch {
+		if batchErr != nil {
+			inserted, err := wasInserted(im.backend.UserGroups().GetById(ctx, g.ug.ID.Hex()))
+			if err == nil && !inserted {
+				err = im.backend.UserGroups().Create(ctx, g.ug)
+			}
+			if err != nil {
+				if g.d.ID != "" {
+					delete(im.groupIds, g.d.ID)
+				}
+				g.fail(err)
+				continue
+			}
+		}
+		im.indexGroup(g.ug)
+		g.created = true
+		im.addMembers(ctx, g)
+	}
+}
+
+// addMembers adds the users of the row the user group does not have yet
+func (im *Importer) addMembers(ctx context.Context, g *groupRow) {
+	for _, id := range g.members {
+		if contains(g.ug.UserIds, id.Hex()) {
+			continue
+		}
+		if err := im.backend.UserGroups().AddUser(ctx, g.ug.ID, id); err != nil {
+			g.fail(fmt.Errorf("adding user %s: %w", id.Hex(), err))
+			return
+		}
+		g.ug.UserIds = append(g.ug.UserIds, id.Hex())
+		g.changed = true
+	}
+}
+
+// addParents adds the user group of the row to the parents it lists and does not have yet
+func (im *Importer) addParents(ctx context.Context, g *groupRow) {
+	if g.reason != "" {
+		return
+	}
+	for _, ref := range g.d.ParentIds {
+		id, err := im.resolveGroup(ref)
+		if err != nil {
+			g.fail(err)
+			return
+		}
+		if containsID(g.ug.ParentIds, id) {
+			continue
+		}
+		if err := im.backend.UserGroups().AddChild(ctx, id, g.ug.ID); err != nil {
+			g.fail(fmt.Errorf("adding to parent %s: %w", ref, err))
+			return
+		}
+		g.ug.ParentIds = append(g.ug.ParentIds, id)
+		g.changed = true
+	}
+}
+
+// ImportAccess sets the roles of the users in the user groups of r
+func (im *Importer) ImportAccess(ctx context.Context, r io.Reader, format Format) error {
+	if err := im.load(ctx); err != nil {
+		return err
+	}
+	from := len(im.report.Rejected)
+	defer im.sortRejected(from)
+	seen := map[[2]primitive.ObjectID]int{}
+	return eachRow(r, format, accessColumns, func(rw row) error {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		var d accessDoc
+		if err := rw.decode(&d); err != nil {
+			im.report.reject(myerrors.KindAccess, rw.n, "", err.Error())
+			return nil
+		}
+		key := d.UserId + " " + d.UserGroupId
+		if err := d.validate(); err != nil {
+			im.report.reject(myerrors.KindAccess, rw.n, key, err.Error())
+			return nil
+		}
+		userId, err := im.resolveUser(d.UserId)
+		if err != nil {
+			im.report.reject(myerrors.KindAccess, rw.n, key, err.Error())
+			return nil
+		}
+		userGroupId, err := im.resolveGroup(d.UserGroupId)
+		if err != nil {
+			im.report.reject(myerrors.KindAccess, rw.n, key, err.Error())
+			return nil
+		}
+		pair := [2]primitive.ObjectID{userId, userGroupId}
+		if n, ok := seen[pair]; ok {
+			im.report.reject(myerrors.KindAccess, rw.n, key, fmt.Sprintf("duplicate of row %d", n))
+			return nil
+		}
+		seen[pair] = rw.n
+		roles, err := im.rolesOf(ctx, userId)
+		if err != nil {
+			im.report.reject(myerrors.KindAccess, rw.n, key, err.Error())
+			return nil
+		}
+		previous, ok := roles[userGroupId.Hex()]
+		if ok && sameRoles(previous, d.Roles) {
+			im.report.Access.Unchanged++
+			return nil
+		}
+		if err := im.backend.Access().SetRoles(ctx, userId, userGroupId, d.Roles); err != nil {
+			im.report.reject(myerrors.KindAccess, rw.n, key, err.Error())
+			return nil
+		}
+		roles[userGroupId.Hex()] = d.Roles
+		if ok {
+			im.report.Access.Updated++
+		} else {
+			im.report.Access.Created++
+		}
+		return nil
+	})
+}
+
+// rolesOf returns the roles of the user by user group id
+func (im *Importer) rolesOf(ctx context.Context, userId primitive.ObjectID) (map[string][]string, error) {
+	if roles, ok := im.roles[userId]; ok {
+		return roles, nil
+	}
+	accesses, err := im.backend.Access().ListRolesForUser(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+	roles := map[string][]string{}
+	for _, a := range accesses {
+		roles[a.UserGroupId] = a.Roles
+	}
+	im.roles[userId] = roles
+	return roles, nil
+}
+
+// sameRoles reports whether a and b hold the same roles in any order
+func sameRoles(a, b []string) bool {
+	for _, role := range a {
+		if !contains(b, role) {
+			return false
+		}
+	}
+	for _, role := range b {
+		if !contains(a, role) {
+			return false
+		}
+	}
+	return true
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func containsID(ids []primitive.ObjectID, id primitive.ObjectID) bool {
+	for _, i := range ids {
+		if i == id {
+			return true
+		}
+	}
+	return false
+}
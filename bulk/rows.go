@@ -0,0 +1,321 @@
+package bulk
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/mail"
+	"strings"
+
+	"github.com/sr-codefreak/user-group/db/mongodb/usergroup"
+)
+
+// listSep separates the values of a list in a CSV cell
+const listSep = ";"
+
+// metaDataPrefix starts the metaData columns of a CSV file
+const metaDataPrefix = "metaData."
+
+var (
+	userColumns   = []string{"_id", "name", "email", "phone", "userGroupIds", metaDataPrefix}
+	groupColumns  = []string{"_id", "name", "rule", "userIds", "parentIds", metaDataPrefix}
+	accessColumns = []string{"userId", "userGroupId", "roles"}
+)
+
+// row is a row of a file, a JSON document or the cells of a CSV row by column
+type row struct {
+	n      int
+	doc    json.RawMessage
+	fields map[string]string
+	// err is the reason the row cannot be read
+	err error
+}
+
+// document is a document of a file
+type document interface {
+	fromCSV(fields map[string]string)
+}
+
+func (r row) decode(d document) error {
+	if r.err != nil {
+		return r.err
+	}
+	if r.fields != nil {
+		d.fromCSV(r.fields)
+		return nil
+	}
+	return json.Unmarshal(r.doc, d)
+}
+
+// eachRow calls fn with the rows of r. A CSV file has the columns, and the
+// metaData columns when they include metaDataPrefix.
+func eachRow(r io.Reader, format Format, columns []string, fn func(r row) error) error {
+	if format == CSV {
+		return eachCSVRow(r, columns, fn)
+	}
+	return eachJSONRow(r, fn)
+}
+
+// eachJSONRow reads an array of documents or a stream of documents
+func eachJSONRow(r io.Reader, fn func(r row) error) error {
+	br := bufio.NewReader(r)
+	array, err := startsWithArray(br)
+	if err != nil {
+		return err
+	}
+	dec := json.NewDecoder(br)
+	if array {
+		if _, err := dec.Token(); err != nil {
+			return err
+		}
+	}
+	for n := 1; !array || dec.More(); n++ {
+		var doc json.RawMessage
+		if err := dec.Decode(&doc); err != nil {
+			if err == io.EOF && !array {
+				return nil
+			}
+			return fmt.Errorf("row %d: %w", n, err)
+		}
+		if err := fn(row{n: n, doc: doc}); err != nil {
+			return err
+		}
+	}
+	_, err = dec.Token()
+	return err
+}
+
+// startsWithArray reports whether the first character that is not a space is [
+func startsWithArray(br *bufio.Reader) (bool, error) {
+	for {
+		b, err := br.ReadByte()
+		if err == io.EOF {
+			return false, nil
+		}
+		if err != nil {
+			return false, err
+		}
+		switch b {
+		case ' ', '\t', '\r', '\n':
+			continue
+		}
+		return b == '[', br.UnreadByte()
+	}
+}
+
+func eachCSVRow(r io.Reader, columns []string, fn func(r row) error) error {
+	cr := csv.NewReader(r)
+	header, err := cr.Read()
+	if err == io.EOF {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	known := map[string]bool{}
+	for _, c := range columns {
+		known[c] = true
+	}
+	seen := map[string]bool{}
+	for i, c := range header {
+		c = strings.TrimSpace(strings.TrimPrefix(c, "\ufeff"))
+		switch {
+		case seen[c]:
+			return fmt.Errorf("duplicate column %q", c)
+		case known[c] && c != metaDataPrefix:
+		case known[metaDataPrefix] && strings.HasPrefix(c, metaDataPrefix) && c != metaDataPrefix:
+		default:
+			return fmt.Errorf("unknown column %q, expected %s", c, strings.Join(columns, ", "))
+		}
+		seen[c] = true
+		header[i] = c
+	}
+	for n := 1; ; n++ {
+		record, err := cr.Read()
+		if err == io.EOF {
+			return nil
+		}
+		r := row{n: n}
+		var parseErr *csv.ParseError
+		switch {
+		case errors.As(err, &parseErr) && errors.Is(err, csv.ErrFieldCount):
+			r.err = fmt.Errorf("%d cells, expected %d", len(record), len(header))
+		case err != nil:
+			return err
+		default:
+			r.fields = make(map[string]string, len(header))
+			for i, c := range header {
+				r.fields[c] = strings.TrimSpace(record[i])
+			}
+		}
+		if err := fn(r); err != nil {
+			return err
+		}
+	}
+}
+
+// splitList returns the values of a CSV list
+func splitList(s string) []string {
+	var values []string
+	for _, v := range strings.Split(s, listSep) {
+		if v = strings.TrimSpace(v); v != "" {
+			values = append(values, v)
+		}
+	}
+	return values
+}
+
+// csvMetaData returns the metaData of the CSV cells, nil when the file has no metaData columns
+func csvMetaData(fields map[string]string) map[string]any {
+	var metaData map[string]any
+	for c, v := range fields {
+		key, ok := strings.CutPrefix(c, metaDataPrefix)
+		if !ok {
+			continue
+		}
+		if metaData == nil {
+			metaData = map[string]any{}
+		}
+		if v != "" {
+			metaData[key] = v
+		}
+	}
+	return metaData
+}
+
+func validMetaData(metaData map[string]any) error {
+	for key := range metaData {
+		if strings.TrimSpace(key) == "" {
+			return errors.New("metaData has an empty key")
+		}
+	}
+	return nil
+}
+
+// userDoc is a user, see design/user.json. Its usersGroups and userGroupIds are not read.
+type userDoc struct {
+	ID       string         `json:"_id"`
+	Name     string         `json:"name"`
+	Email    string         `json:"email"`
+	Phone    string         `json:"phone"`
+	MetaData map[string]any `json:"metaData"`
+}
+
+func (d *userDoc) fromCSV(fields map[string]string) {
+	d.ID = fields["_id"]
+	d.Name = fields["name"]
+	d.Email = fields["email"]
+	d.Phone = fields["phone"]
+	d.MetaData = csvMetaData(fields)
+}
+
+func (d *userDoc) validate() error {
+	if strings.TrimSpace(d.Name) == "" {
+		return errors.New("name is required")
+	}
+	if d.Email == "" {
+		return errors.New("email is required")
+	}
+	if addr, err := mail.ParseAddress(d.Email); err != nil || addr.Address != d.Email {
+		return fmt.Errorf("invalid email %q", d.Email)
+	}
+	if d.Phone != "" && !validPhone(d.Phone) {
+		return fmt.Errorf("invalid phone %q", d.Phone)
+	}
+	return validMetaData(d.MetaData)
+}
+
+// validPhone reports whether the phone has digits, an optional leading +
+// and the separators space, -, ., ( and )
+func validPhone(phone string) bool {
+	digits := 0
+	for i, c := range phone {
+		switch {
+		case c >= '0' && c <= '9':
+			digits++
+		case c == '+' && i == 0:
+		case strings.ContainsRune(" -.()", c):
+		default:
+			return false
+		}
+	}
+	return digits >= 3
+}
+
+// groupDoc is a user group, see design/user-group.json
+type groupDoc struct {
+	ID        string              `json:"_id"`
+	Name      string              `json:"name"`
+	MetaData  map[string]any      `json:"metaData"`
+	Users     []usergroup.UserRef `json:"users"`
+	UserIds   []string            `json:"userIds"`
+	ParentIds []string            `json:"parentIds"`
+	Rule      string              `json:"rule"`
+}
+
+func (d *groupDoc) fromCSV(fields map[string]string) {
+	d.ID = fields["_id"]
+	d.Name = fields["name"]
+	d.Rule = fields["rule"]
+	d.UserIds = splitList(fields["userIds"])
+	d.ParentIds = splitList(fields["parentIds"])
+	d.MetaData = csvMetaData(fields)
+}
+
+// members returns the references of the users of the user group, ids or
+// emails. The users are referenced by email when they have one.
+func (d *groupDoc) members() []string {
+	refs := append([]string(nil), d.UserIds...)
+	for _, u := range d.Users {
+		if u.Email != "" {
+			refs = append(refs, u.Email)
+		} else if u.ID != "" {
+			refs = append(refs, u.ID)
+		}
+	}
+	return refs
+}
+
+func (d *groupDoc) validate() error {
+	if strings.TrimSpace(d.Name) == "" {
+		return errors.New("name is required")
+	}
+	if err := (usergroup.UserGroup{Rule: d.Rule}).CheckRule(); err != nil {
+		return err
+	}
+	return validMetaData(d.MetaData)
+}
+
+// accessDoc is the roles of a user in a user group, see design/access.json
+type accessDoc struct {
+	UserId      string   `json:"userId"`
+	UserGroupId string   `json:"userGroupId"`
+	Roles       []string `json:"roles"`
+}
+
+func (d *accessDoc) fromCSV(fields map[string]string) {
+	d.UserId = fields["userId"]
+	d.UserGroupId = fields["userGroupId"]
+	d.Roles = splitList(fields["roles"])
+}
+
+func (d *accessDoc) validate() error {
+	if d.UserId == "" {
+		return errors.New("userId is required")
+	}
+	if d.UserGroupId == "" {
+		return errors.New("userGroupId is required")
+	}
+	if len(d.Roles) == 0 {
+		return errors.New("roles are required")
+	}
+	for _, role := range d.Roles {
+		if strings.TrimSpace(role) == "" {
+			return errors.New("roles has an empty role")
+		}
+	}
+	return nil
+}
@@ -0,0 +1,190 @@
+// Command ugbulk imports and exports users, user groups and access as JSON
+// or CSV files, see package bulk. The format of a file is given by its
+// extension.
+//
+//	ugbulk import -users users.csv -groups groups.json -access access.csv -rejected rejected.csv
+//	ugbulk export -users users.json -groups groups.csv -access access.json
+//
+// The import reports the counts of the rows and exits with status 1 when
+// rows were rejected, the rejected rows are written to -rejected or else to
+// stderr as CSV.
+//
+// The mongo connection, database and logger are set like those of
+// usergroupd, by the -config file, the USERGROUP_* environment variables and
+// the flags, see package config. With -tenant the files are imported to or
+// exported from the database of the tenant.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/sr-codefreak/user-group/bulk"
+	"github.com/sr-codefreak/user-group/config"
+	"github.com/sr-codefreak/user-group/db"
+	"github.com/sr-codefreak/user-group/db/decorate"
+	"github.com/sr-codefreak/user-group/db/mongodb"
+	"github.com/sr-codefreak/user-group/db/mongodb/audit"
+	"github.com/sr-codefreak/user-group/utils/logger"
+)
+
+func usage() {
+	fmt.Fprintf(os.Stderr, "usage: ugbulk import|export [flags]\n")
+	os.Exit(2)
+}
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+	}
+	cmd := os.Args[1]
+	if cmd != "import" && cmd != "export" {
+		usage()
+	}
+	flags := flag.NewFlagSet("ugbulk "+cmd, flag.ExitOnError)
+	users := flags.String("users", "", "file of the users")
+	groups := flags.String("groups", "", "file of the user groups")
+	accessFile := flags.String("access", "", "file of the access")
+	rejected := flags.String("rejected", "", "file the rejected rows are written to, stderr when empty")
+	batchSize := flags.Int("batch-size", bulk.DefaultBatchSize, "number of users or user groups created per batch")
+	actor := flags.String("actor", "ugbulk", "actor the changes are audited with")
+	tenant := flags.String("tenant", "", "tenant whose database is used, the shared database when empty")
+	cfgFlags := config.NewFlags(flags, config.Default())
+	flags.Parse(os.Args[2:])
+
+	cfg, err := config.Load(cfgFlags, "USERGROUP")
+	if err == nil && !cfg.Mongo.Client().Naming.HasTenant(*tenant) {
+		err = fmt.Errorf("tenant %q has no database in mongo.tenants", *tenant)
+	}
+	if err == nil {
+		err = logger.Configure(cfg.Log.Logger())
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "config: %s\n", err)
+		os.Exit(2)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	client := mongodb.New(cfg.Mongo.Client())
+	dbChan := make(chan struct{})
+	client.Connect(dbChan)
+	select {
+	case <-dbChan:
+	case <-ctx.Done():
+		fmt.Fprintf(os.Stderr, "interrupted while connecting to mongo\n")
+		os.Exit(1)
+	}
+	backend := decorate.Backend(db.Mongo(client))
+	ctx = mongodb.WithTenant(ctx, *tenant)
+
+	type file struct {
+		path string
+		run  func(ctx context.Context, f *os.File, format bulk.Format) error
+	}
+	var files []file
+	var im *bulk.Importer
+	if cmd == "import" {
+		ctx = audit.WithActor(ctx, *actor)
+		im = bulk.NewImporter(backend, bulk.Options{BatchSize: *batchSize})
+		// users first, the user groups and access reference them
+		files = []file{
+			{*users, readWith(im.ImportUsers)},
+			{*groups, readWith(im.ImportUserGroups)},
+			{*accessFile, readWith(im.ImportAccess)},
+		}
+	} else {
+		files = []file{
+			{*users, writeWith(backend, bulk.ExportUsers)},
+			{*groups, writeWith(backend, bulk.ExportUserGroups)},
+			{*accessFile, writeWith(backend, bulk.ExportAccess)},
+		}
+	}
+	failed := false
+	for _, f := range files {
+		if f.path == "" {
+			continue
+		}
+		if err := run(ctx, cmd, f.path, f.run); err != nil {
+			fmt.Fprintf(os.Stderr, "%s %s: %s\n", cmd, f.path, err)
+			failed = true
+			break
+		}
+	}
+	if im != nil {
+		// the rows read before a failure are imported
+		report := im.Report()
+		fmt.Print(report)
+		if len(report.Rejected) > 0 {
+			if err := writeRejected(report, *rejected); err != nil {
+				fmt.Fprintf(os.Stderr, "writing %s: %s\n", *rejected, err)
+			}
+			failed = true
+		}
+	}
+	if failed {
+		os.Exit(1)
+	}
+}
+
+// run opens the file for the import or creates it for the export
+func run(ctx context.Context, cmd string, path string, fn func(ctx context.Context, f *os.File, format bulk.Format) error) error {
+	format, err := bulk.FormatOf(path)
+	if err != nil {
+		return err
+	}
+	if cmd == "import" {
+		f, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		return fn(ctx, f, format)
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := fn(ctx, f, format); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+func readWith(imp func(ctx context.Context, r io.Reader, format bulk.Format) error) func(ctx context.Context, f *os.File, format bulk.Format) error {
+	return func(ctx context.Context, f *os.File, format bulk.Format) error {
+		return imp(ctx, f, format)
+	}
+}
+
+func writeWith(backend db.Backend, exp func(ctx context.Context, backend db.Backend, w io.Writer, format bulk.Format) error) func(ctx context.Context, f *os.File, format bulk.Format) error {
+	return func(ctx context.Context, f *os.File, format bulk.Format) error {
+		return exp(ctx, backend, f, format)
+	}
+}
+
+func writeRejected(report *bulk.Report, path string) error {
+	if path == "" {
+		return report.WriteRejected(os.Stderr, bulk.CSV)
+	}
+	format, err := bulk.FormatOf(path)
+	if err != nil {
+		return err
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := report.WriteRejected(f, format); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
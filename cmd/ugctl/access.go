@@ -0,0 +1,119 @@
+package main
+
+import (
+	"context"
+	"strings"
+
+	"github.com/sr-codefreak/user-group/authorizer"
+	"github.com/sr-codefreak/user-group/myerrors"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+var accessCommands = map[string]command{
+	"grant":  {"USER_ID GROUP_ID ROLE...", "grant roles to a user for a user group", grant},
+	"revoke": {"USER_ID GROUP_ID ROLE...", "revoke roles of a user for a user group", revoke},
+	"check":  {"USER_ID GROUP_ID [PERMISSION | -role ROLE]", "check a user holds a permission or has a role in a user group", check},
+}
+
+func grant(ctx context.Context, e *env, args []string) error {
+	return changeRoles(ctx, e, "grant", args, e.backend.Access().Grant)
+}
+
+func revoke(ctx context.Context, e *env, args []string) error {
+	return changeRoles(ctx, e, "revoke", args, e.backend.Access().Revoke)
+}
+
+// changeRoles changes the roles and prints those the user has afterwards
+func changeRoles(ctx context.Context, e *env, name string, args []string, change func(ctx context.Context, userId, userGroupId primitive.ObjectID, roles ...string) error) error {
+	args, err := parse(newFlags(name), args, 3, -1)
+	if err != nil {
+		return err
+	}
+	userId, userGroupId, err := accessIDs(args)
+	if err != nil {
+		return err
+	}
+	if err := change(ctx, userId, userGroupId, args[2:]...); err != nil {
+		return err
+	}
+	accesses, err := e.backend.Access().ListRolesForUser(ctx, userId)
+	if err != nil {
+		return err
+	}
+	roles := []string{}
+	for _, a := range accesses {
+		if a.UserGroupId == userGroupId.Hex() {
+			roles = a.Roles
+		}
+	}
+	return e.out.print(struct {
+		Roles []string `json:"roles"`
+	}{roles}, nil, [][]string{{"roles", strings.Join(roles, ",")}})
+}
+
+// check returns errDenied when the user does not hold the permission or have the role
+func check(ctx context.Context, e *env, args []string) error {
+	fs := newFlags("check")
+	role := fs.String("role", "", "role to check instead of a permission")
+	args, err := parse(fs, args, 2, 3)
+	if err != nil {
+		return err
+	}
+	if (*role == "") != (len(args) == 3) {
+		return errUsage
+	}
+	userId, userGroupId, err := accessIDs(args)
+	if err != nil {
+		return err
+	}
+	if *role != "" {
+		ok, err := e.backend.Access().HasRole(ctx, userId, userGroupId, *role)
+		if err != nil {
+			return err
+		}
+		if err := e.out.print(struct {
+			HasRole bool `json:"hasRole"`
+		}{ok}, nil, [][]string{{"hasRole", yesNo(ok)}}); err != nil {
+			return err
+		}
+		return denied(ok)
+	}
+	ok, reason, err := authorizer.New(e.backend).Can(ctx, userId, args[2], userGroupId)
+	if err != nil {
+		return err
+	}
+	rows := [][]string{{"allowed", yesNo(ok)}}
+	for _, line := range reason.Trace {
+		rows = append(rows, []string{"", line})
+	}
+	if err := e.out.print(struct {
+		Allowed bool              `json:"allowed"`
+		Reason  authorizer.Reason `json:"reason"`
+	}{ok, reason}, nil, rows); err != nil {
+		return err
+	}
+	return denied(ok)
+}
+
+func accessIDs(args []string) (primitive.ObjectID, primitive.ObjectID, error) {
+	userId, err := objectID(myerrors.KindUser, args[0])
+	if err != nil {
+		return userId, primitive.NilObjectID, err
+	}
+	userGroupId, err := objectID(myerrors.KindUserGroup, args[1])
+	return userId, userGroupId, err
+}
+
+func denied(ok bool) error {
+	if ok {
+		return nil
+	}
+	return errDenied
+}
+
+func yesNo(ok bool) string {
+	if ok {
+		return "yes"
+	}
+	return "no"
+}
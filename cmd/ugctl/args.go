@@ -0,0 +1,134 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"strings"
+
+	"github.com/sr-codefreak/user-group/db/mongodb"
+	"github.com/sr-codefreak/user-group/myerrors"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// parse parses the flags, which may come before or after the positional
+// arguments, and returns between min and max positional arguments, any
+// number above min when max is negative
+func parse(fs *flag.FlagSet, args []string, min int, max int) ([]string, error) {
+	var positional []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			return nil, errUsage
+		}
+		if fs.NArg() == 0 {
+			break
+		}
+		positional = append(positional, fs.Arg(0))
+		args = fs.Args()[1:]
+	}
+	if len(positional) < min || max >= 0 && len(positional) > max {
+		return nil, errUsage
+	}
+	return positional, nil
+}
+
+func newFlags(name string) *flag.FlagSet {
+	return flag.NewFlagSet(name, flag.ContinueOnError)
+}
+
+func objectID(kind myerrors.Kind, id string) (primitive.ObjectID, error) {
+	oid, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return oid, myerrors.New(myerrors.InvalidArgument, kind, id, err)
+	}
+	return oid, nil
+}
+
+// metaDataFlag collects the KEY=VALUE flags of the metaData, the values are strings
+type metaDataFlag map[string]any
+
+func (m *metaDataFlag) String() string {
+	return ""
+}
+
+func (m *metaDataFlag) Set(s string) error {
+	key, value, ok := strings.Cut(s, "=")
+	if !ok || key == "" {
+		return errors.New("expected KEY=VALUE")
+	}
+	if *m == nil {
+		*m = metaDataFlag{}
+	}
+	(*m)[key] = value
+	return nil
+}
+
+// listFlags are the flags of the list commands, see mongodb.ListOptions
+type listFlags struct {
+	name      *string
+	email     *string
+	phone     *string
+	metaData  metaDataFlag
+	sort      *string
+	pageSize  *int
+	pageToken *string
+	all       *bool
+}
+
+func newListFlags(fs *flag.FlagSet, contacts bool) *listFlags {
+	l := &listFlags{
+		name:      fs.String("name", "", "prefix of the name"),
+		sort:      fs.String("sort", "", "sort keys separated by commas, descending when prefixed with -"),
+		pageSize:  fs.Int("page-size", 0, fmt.Sprintf("number of entries per page, at most %d", mongodb.MaxPageSize)),
+		pageToken: fs.String("page-token", "", "token of the page, printed after the previous page"),
+		all:       fs.Bool("all", false, "list all the pages"),
+	}
+	if contacts {
+		l.email = fs.String("email", "", "prefix of the email")
+		l.phone = fs.String("phone", "", "prefix of the phone")
+	}
+	fs.Var(&l.metaData, "meta", "KEY=VALUE the metaData must have, repeatable")
+	return l
+}
+
+func (l *listFlags) options() mongodb.ListOptions {
+	opts := mongodb.ListOptions{
+		PageSize:  *l.pageSize,
+		PageToken: *l.pageToken,
+		Filter:    mongodb.ListFilter{Name: *l.name, MetaData: l.metaData},
+	}
+	if l.email != nil {
+		opts.Filter.Email = *l.email
+		opts.Filter.Phone = *l.phone
+	}
+	if *l.sort != "" {
+		for _, key := range strings.Split(*l.sort, ",") {
+			key, desc := strings.CutPrefix(key, "-")
+			opts.Sort = append(opts.Sort, mongodb.SortField{Key: key, Desc: desc})
+		}
+	}
+	if *l.all && opts.PageSize == 0 {
+		opts.PageSize = mongodb.MaxPageSize
+	}
+	return opts
+}
+
+// list calls list for the page of the flags, or for all the pages with -all
+func list[T any](l *listFlags, fn func(opts mongodb.ListOptions) ([]T, string, error)) ([]T, string, error) {
+	opts := l.options()
+	var all []T
+	for {
+		page, next, err := fn(opts)
+		if err != nil {
+			return nil, "", err
+		}
+		if !*l.all {
+			return page, next, nil
+		}
+		all = append(all, page...)
+		if next == "" {
+			return all, "", nil
+		}
+		opts.PageToken = next
+	}
+}
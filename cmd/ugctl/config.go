@@ -0,0 +1,126 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/sr-codefreak/user-group/config"
+)
+
+// settings are the global settings, the settings of package config and
+// those of ugctl. They are read from the config file, then from the
+// environment and then from the flags, each overriding the previous ones:
+//
+//	{"mongo": {"uri": "mongodb://db:27017", "database": "userGroupStaging", "timeout": "10s"},
+//	 "output": "json", "actor": "ops"}
+//
+// The config file is -config, UGCTL_CONFIG or else ugctl/config.json in the
+// user config directory, which may be missing. The environment variables are
+// those of package config with the UGCTL prefix, UGCTL_MONGO_URI, and
+// UGCTL_OUTPUT, UGCTL_ACTOR and UGCTL_TENANT. The logs go to stderr at the
+// warn level.
+type settings struct {
+	config.Config `yaml:",inline"`
+	// Output is table or json
+	Output string `json:"output" yaml:"output"`
+	// Actor is the actor the changes are audited with
+	Actor string `json:"actor" yaml:"actor"`
+	// Tenant selects the database of the tenant, one of mongo.tenants, see
+	// mongodb.Naming
+	Tenant string `json:"tenant" yaml:"tenant"`
+}
+
+// settingsFlags are the global flags
+type settingsFlags struct {
+	fs     *flag.FlagSet
+	config *config.Flags
+	output *string
+	actor  *string
+	tenant *string
+}
+
+func newSettingsFlags(fs *flag.FlagSet) settingsFlags {
+	def := defaultSettings()
+	return settingsFlags{
+		fs:     fs,
+		config: config.NewFlags(fs, def.Config),
+		output: fs.String("o", def.Output, "output format, table or json"),
+		actor:  fs.String("actor", def.Actor, "actor the changes are audited with"),
+		tenant: fs.String("tenant", def.Tenant, "tenant whose database is used, the shared database when empty"),
+	}
+}
+
+func defaultSettings() settings {
+	actor := "ugctl"
+	if name := os.Getenv("USER"); name != "" {
+		actor += ":" + name
+	}
+	s := settings{
+		Config: config.Default(),
+		Output: "table",
+		Actor:  actor,
+	}
+	s.Log.Level = "warn"
+	s.Log.File = ""
+	return s
+}
+
+// loadSettings layers the config file, the environment and the flags set on the defaults
+func loadSettings(f settingsFlags) (settings, error) {
+	s := defaultSettings()
+	path, required := f.config.File(), true
+	if path == "" {
+		path = os.Getenv("UGCTL_CONFIG")
+	}
+	if path == "" {
+		dir, err := os.UserConfigDir()
+		if err == nil {
+			path, required = filepath.Join(dir, "ugctl", "config.json"), false
+		}
+	}
+	if path != "" {
+		if err := config.ReadFile(path, &s); err != nil && (required || !errors.Is(err, os.ErrNotExist)) {
+			return s, err
+		}
+	}
+	if err := config.ApplyEnv(&s.Config, "UGCTL"); err != nil {
+		return s, err
+	}
+	if v := os.Getenv("UGCTL_OUTPUT"); v != "" {
+		s.Output = v
+	}
+	if v := os.Getenv("UGCTL_ACTOR"); v != "" {
+		s.Actor = v
+	}
+	if v := os.Getenv("UGCTL_TENANT"); v != "" {
+		s.Tenant = v
+	}
+	if err := f.config.Apply(&s.Config); err != nil {
+		return s, err
+	}
+	f.fs.Visit(func(fl *flag.Flag) {
+		switch fl.Name {
+		case "o":
+			s.Output = *f.output
+		case "actor":
+			s.Actor = *f.actor
+		case "tenant":
+			s.Tenant = *f.tenant
+		}
+	})
+	return s, s.validate()
+}
+
+func (s settings) validate() error {
+	err := s.Config.Validate()
+	if s.Output != "table" && s.Output != "json" {
+		err = errors.Join(err, fmt.Errorf("unknown output %q, expected table or json", s.Output))
+	}
+	if !s.Mongo.Client().Naming.HasTenant(s.Tenant) {
+		err = errors.Join(err, fmt.Errorf("tenant %q has no database in mongo.tenants", s.Tenant))
+	}
+	return err
+}
@@ -0,0 +1,39 @@
+package main
+
+import (
+	"context"
+	"time"
+
+	"github.com/sr-codefreak/user-group/db"
+)
+
+var dbCommands = map[string]command{
+	"ping":    {"", "check the mongo server answers", ping},
+	"indexes": {"", "create the indexes of the stores", ensureIndexes},
+}
+
+func ping(ctx context.Context, e *env, args []string) error {
+	if _, err := parse(newFlags("ping"), args, 0, 0); err != nil {
+		return err
+	}
+	start := time.Now()
+	if err := e.client.Ping(ctx); err != nil {
+		return err
+	}
+	rtt := time.Since(start)
+	return e.out.print(struct {
+		RTT string `json:"rtt"`
+	}{rtt.String()}, nil, [][]string{{"rtt", rtt.String()}})
+}
+
+func ensureIndexes(ctx context.Context, e *env, args []string) error {
+	if _, err := parse(newFlags("indexes"), args, 0, 0); err != nil {
+		return err
+	}
+	if err := db.EnsureIndexes(ctx, e.client); err != nil {
+		return err
+	}
+	return e.out.print(struct {
+		Indexes string `json:"indexes"`
+	}{"ok"}, nil, [][]string{{"indexes", "ok"}})
+}
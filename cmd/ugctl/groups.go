@@ -0,0 +1,170 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/sr-codefreak/user-group/db/mongodb"
+	"github.com/sr-codefreak/user-group/db/mongodb/usergroup"
+	"github.com/sr-codefreak/user-group/myerrors"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+var groupCommands = map[string]command{
+	"create":        {"-name NAME [-rule RULE] [-meta KEY=VALUE]...", "create a user group, dynamic with -rule", createGroup},
+	"get":           {"ID", "show a user group", getGroup},
+	"list":          {"[-name PREFIX] [-meta KEY=VALUE]... [-sort KEYS] [-page-size N] [-page-token TOKEN] [-all]", "list the user groups", listGroups},
+	"rename":        {"ID NAME", "rename a user group", renameGroup},
+	"add-member":    {"ID USER_ID", "add a user to a user group", addMember},
+	"remove-member": {"ID USER_ID", "remove a user from a user group", removeMember},
+	"members":       {"ID [-transitive]", "list the users of a user group, with -transitive those of its subgroups too", listMembers},
+}
+
+func createGroup(ctx context.Context, e *env, args []string) error {
+	fs := newFlags("create")
+	name := fs.String("name", "", "name of the user group")
+	rule := fs.String("rule", "", "rule selecting the users of a dynamic user group")
+	var metaData metaDataFlag
+	fs.Var(&metaData, "meta", "KEY=VALUE of the metaData, repeatable")
+	if _, err := parse(fs, args, 0, 0); err != nil {
+		return err
+	}
+	if *name == "" {
+		return errUsage
+	}
+	ug := &usergroup.UserGroup{Name: *name, Rule: *rule, MetaData: metaData}
+	if err := e.backend.UserGroups().Create(ctx, ug); err != nil {
+		return err
+	}
+	return printGroup(ctx, e, ug.ID.Hex())
+}
+
+func getGroup(ctx context.Context, e *env, args []string) error {
+	args, err := parse(newFlags("get"), args, 1, 1)
+	if err != nil {
+		return err
+	}
+	return printGroup(ctx, e, args[0])
+}
+
+func listGroups(ctx context.Context, e *env, args []string) error {
+	fs := newFlags("list")
+	l := newListFlags(fs, false)
+	if _, err := parse(fs, args, 0, 0); err != nil {
+		return err
+	}
+	groups, next, err := list(l, func(opts mongodb.ListOptions) ([]usergroup.UserGroup, string, error) {
+		return e.backend.UserGroups().List(ctx, opts)
+	})
+	if err != nil {
+		return err
+	}
+	if groups == nil {
+		groups = []usergroup.UserGroup{}
+	}
+	rows := make([][]string, len(groups))
+	for i, ug := range groups {
+		rows[i] = []string{ug.ID.Hex(), ug.Name, strconv.Itoa(len(ug.UserIds)), ug.Rule}
+	}
+	err = e.out.print(struct {
+		Groups        []usergroup.UserGroup `json:"groups"`
+		NextPageToken string                `json:"nextPageToken,omitempty"`
+	}{groups, next}, []string{"ID", "NAME", "USERS", "RULE"}, rows)
+	if err == nil && next != "" && !e.out.json {
+		fmt.Fprintf(os.Stderr, "next page: -page-token %s\n", next)
+	}
+	return err
+}
+
+func renameGroup(ctx context.Context, e *env, args []string) error {
+	args, err := parse(newFlags("rename"), args, 2, 2)
+	if err != nil {
+		return err
+	}
+	id, err := objectID(myerrors.KindUserGroup, args[0])
+	if err != nil {
+		return err
+	}
+	if err := e.backend.UserGroups().UpdateName(ctx, id, args[1]); err != nil {
+		return err
+	}
+	return printGroup(ctx, e, args[0])
+}
+
+func addMember(ctx context.Context, e *env, args []string) error {
+	return changeMember(ctx, e, "add-member", args, e.backend.UserGroups().AddUser)
+}
+
+func removeMember(ctx context.Context, e *env, args []string) error {
+	return changeMember(ctx, e, "remove-member", args, e.backend.UserGroups().RemoveUser)
+}
+
+func changeMember(ctx context.Context, e *env, name string, args []string, change func(ctx context.Context, id, userId primitive.ObjectID) error) error {
+	args, err := parse(newFlags(name), args, 2, 2)
+	if err != nil {
+		return err
+	}
+	id, err := objectID(myerrors.KindUserGroup, args[0])
+	if err != nil {
+		return err
+	}
+	userId, err := objectID(myerrors.KindUser, args[1])
+	if err != nil {
+		return err
+	}
+	return change(ctx, id, userId)
+}
+
+func listMembers(ctx context.Context, e *env, args []string) error {
+	fs := newFlags("members")
+	transitive := fs.Bool("transitive", false, "include the users of the subgroups")
+	args, err := parse(fs, args, 1, 1)
+	if err != nil {
+		return err
+	}
+	var members []usergroup.UserRef
+	if *transitive {
+		id, err := objectID(myerrors.KindUserGroup, args[0])
+		if err != nil {
+			return err
+		}
+		if members, err = e.backend.UserGroups().ResolveMembers(ctx, id); err != nil {
+			return err
+		}
+	} else {
+		ug, err := e.backend.UserGroups().GetById(ctx, args[0])
+		if err != nil {
+			return err
+		}
+		members = ug.Users
+	}
+	if members == nil {
+		members = []usergroup.UserRef{}
+	}
+	rows := make([][]string, len(members))
+	for i, m := range members {
+		rows[i] = []string{m.ID, m.Name, m.Email, m.Phone}
+	}
+	return e.out.print(members, []string{"ID", "NAME", "EMAIL", "PHONE"}, rows)
+}
+
+func printGroup(ctx context.Context, e *env, id string) error {
+	ug, err := e.backend.UserGroups().GetById(ctx, id)
+	if err != nil {
+		return err
+	}
+	rows := [][]string{
+		{"id", ug.ID.Hex()},
+		{"name", ug.Name},
+	}
+	if ug.Rule != "" {
+		rows = append(rows, []string{"rule", ug.Rule})
+	}
+	rows = append(rows, []string{"users", strconv.Itoa(len(ug.UserIds))})
+	for _, parentId := range ug.ParentIds {
+		rows = append(rows, []string{"parent", parentId.Hex()})
+	}
+	return e.out.print(ug, nil, append(rows, metaDataRows(ug.MetaData)...))
+}
@@ -0,0 +1,169 @@
+// Command ugctl administers the users, user groups and access of a mongo
+// backend from the command line.
+//
+//	ugctl [global flags] <group> <command> [flags] [args]
+//
+//	ugctl users create -name Ann -email ann@example.com -meta dept=eng
+//	ugctl users list -email ann@ -sort name -o json
+//	ugctl groups add-member <group id> <user id>
+//	ugctl access check <user id> <group id> groups:read
+//	ugctl db ping
+//
+// Run ugctl help for the commands. The global flags are read, in order of
+// precedence, from the command line, the UGCTL_* environment variables and
+// the JSON or YAML config file, see settings. Output is a table, or with -o json the
+// JSON of the REST API. Changes are audited with the actor of -actor.
+// With -tenant the commands work on the database of the tenant.
+//
+// The exit status is 1 on errors, 2 on usage errors and 3 when access
+// check denies.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"sort"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/sr-codefreak/user-group/db"
+	"github.com/sr-codefreak/user-group/db/decorate"
+	"github.com/sr-codefreak/user-group/db/mongodb"
+	"github.com/sr-codefreak/user-group/db/mongodb/audit"
+	"github.com/sr-codefreak/user-group/utils/logger"
+)
+
+// env is what the commands run with
+type env struct {
+	client  *mongodb.Client
+	backend db.Backend
+	out     *output
+}
+
+// command is a subcommand, run with the arguments following its name
+type command struct {
+	args string
+	help string
+	run  func(ctx context.Context, e *env, args []string) error
+}
+
+var groups = map[string]map[string]command{
+	"users":  userCommands,
+	"groups": groupCommands,
+	"access": accessCommands,
+	"db":     dbCommands,
+}
+
+var (
+	// errUsage is returned for wrong arguments, the usage of the command is printed
+	errUsage = errors.New("usage")
+	// errDenied is returned by access check when the user does not have the access
+	errDenied = errors.New("denied")
+)
+
+func main() {
+	flags := newSettingsFlags(flag.CommandLine)
+	flag.Usage = usage
+	flag.Parse()
+	if flag.Arg(0) == "help" {
+		usage()
+		return
+	}
+	cmds, ok := groups[flag.Arg(0)]
+	if !ok || flag.NArg() < 2 {
+		usage()
+		os.Exit(2)
+	}
+	cmd, ok := cmds[flag.Arg(1)]
+	if !ok {
+		usage()
+		os.Exit(2)
+	}
+
+	s, err := loadSettings(flags)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "ugctl: %s\n", err)
+		os.Exit(2)
+	}
+	// keep stdout for the output
+	logConfig := s.Log.Logger()
+	logConfig.Console = os.Stderr
+	if err := logger.Configure(logConfig); err != nil {
+		fmt.Fprintf(os.Stderr, "ugctl: %s\n", err)
+		os.Exit(2)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	e, err := connect(ctx, s)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "ugctl: %s\n", err)
+		os.Exit(1)
+	}
+	ctx = mongodb.WithTenant(audit.WithActor(ctx, s.Actor), s.Tenant)
+	err = cmd.run(ctx, e, flag.Args()[2:])
+	shutdown, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	e.client.Shutdown(shutdown)
+	switch {
+	case err == nil:
+	case errors.Is(err, errUsage):
+		fmt.Fprintf(os.Stderr, "usage: ugctl %s %s %s\n", flag.Arg(0), flag.Arg(1), cmd.args)
+		os.Exit(2)
+	case errors.Is(err, errDenied):
+		os.Exit(3)
+	default:
+		fmt.Fprintf(os.Stderr, "ugctl: %s\n", err)
+		os.Exit(1)
+	}
+}
+
+// connect connects to mongo within the timeout of the settings
+func connect(ctx context.Context, s settings) (*env, error) {
+	out, err := newOutput(os.Stdout, s.Output)
+	if err != nil {
+		return nil, err
+	}
+	client := mongodb.New(s.Mongo.Client())
+	connected := make(chan struct{})
+	client.Connect(connected)
+	timer := time.NewTimer(time.Duration(s.Mongo.Timeout))
+	defer timer.Stop()
+	select {
+	case <-connected:
+	case <-timer.C:
+		return nil, fmt.Errorf("cannot connect to %s within %s", s.Mongo.URI, s.Mongo.Timeout)
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	backend := decorate.Backend(db.Mongo(client))
+	return &env{client: client, backend: backend, out: out}, nil
+}
+
+func usage() {
+	w := flag.CommandLine.Output()
+	fmt.Fprintf(w, "usage: ugctl [global flags] <group> <command> [flags] [args]\n\ncommands:\n")
+	names := make([]string, 0, len(groups))
+	for name := range groups {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		cmds := make([]string, 0, len(groups[name]))
+		for c := range groups[name] {
+			cmds = append(cmds, c)
+		}
+		sort.Strings(cmds)
+		for _, c := range cmds {
+			cmd := groups[name][c]
+			fmt.Fprintf(w, "  %s\n    \t%s\n", strings.TrimSpace(name+" "+c+" "+cmd.args), cmd.help)
+		}
+	}
+	fmt.Fprintf(w, "\nglobal flags:\n")
+	flag.PrintDefaults()
+}
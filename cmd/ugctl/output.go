@@ -0,0 +1,64 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/tabwriter"
+)
+
+// output prints the results of the commands as a table or as JSON
+type output struct {
+	w    io.Writer
+	json bool
+}
+
+func newOutput(w io.Writer, format string) (*output, error) {
+	switch format {
+	case "table":
+		return &output{w: w}, nil
+	case "json":
+		return &output{w: w, json: true}, nil
+	}
+	return nil, fmt.Errorf("unknown output %q, expected table or json", format)
+}
+
+// print writes v as JSON, or else the rows as a table below the header
+// when there is one
+func (o *output) print(v any, header []string, rows [][]string) error {
+	if o.json {
+		enc := json.NewEncoder(o.w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(v)
+	}
+	tw := tabwriter.NewWriter(o.w, 0, 4, 2, ' ', 0)
+	if header != nil {
+		fmt.Fprintln(tw, strings.Join(header, "\t"))
+	}
+	for _, row := range rows {
+		fmt.Fprintln(tw, strings.Join(row, "\t"))
+	}
+	return tw.Flush()
+}
+
+// metaDataRows returns a row per metaData key in the order of the keys,
+// the values that are not strings as JSON
+func metaDataRows(metaData map[string]any) [][]string {
+	keys := make([]string, 0, len(metaData))
+	for key := range metaData {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	rows := make([][]string, len(keys))
+	for i, key := range keys {
+		value, ok := metaData[key].(string)
+		if !ok {
+			b, _ := json.Marshal(metaData[key])
+			value = string(b)
+		}
+		rows[i] = []string{"metaData." + key, value}
+	}
+	return rows
+}
@@ -0,0 +1,136 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/sr-codefreak/user-group/db/mongodb"
+	"github.com/sr-codefreak/user-group/db/mongodb/user"
+	"github.com/sr-codefreak/user-group/myerrors"
+)
+
+var userCommands = map[string]command{
+	"create": {"-name NAME -email EMAIL [-phone PHONE] [-meta KEY=VALUE]...", "create a user", createUser},
+	"get":    {"ID", "show a user", getUser},
+	"update": {"ID [-name NAME] [-email EMAIL] [-phone PHONE] [-meta KEY=VALUE]...", "update a user, -meta replaces the metaData", updateUser},
+	"delete": {"ID", "delete a user", deleteUser},
+	"list":   {"[-name|-email|-phone PREFIX] [-meta KEY=VALUE]... [-sort KEYS] [-page-size N] [-page-token TOKEN] [-all]", "list the users", listUsers},
+}
+
+func createUser(ctx context.Context, e *env, args []string) error {
+	fs := newFlags("create")
+	name := fs.String("name", "", "name of the user")
+	email := fs.String("email", "", "email of the user")
+	phone := fs.String("phone", "", "phone of the user")
+	var metaData metaDataFlag
+	fs.Var(&metaData, "meta", "KEY=VALUE of the metaData, repeatable")
+	if _, err := parse(fs, args, 0, 0); err != nil {
+		return err
+	}
+	if *name == "" || *email == "" {
+		return errUsage
+	}
+	u := &user.User{Name: *name, Email: *email, Phone: *phone, MetaData: metaData}
+	if err := e.backend.Users().Create(ctx, u); err != nil {
+		return err
+	}
+	return printUser(ctx, e, u.ID.Hex())
+}
+
+func getUser(ctx context.Context, e *env, args []string) error {
+	args, err := parse(newFlags("get"), args, 1, 1)
+	if err != nil {
+		return err
+	}
+	return printUser(ctx, e, args[0])
+}
+
+func updateUser(ctx context.Context, e *env, args []string) error {
+	fs := newFlags("update")
+	name := fs.String("name", "", "name of the user")
+	email := fs.String("email", "", "email of the user")
+	phone := fs.String("phone", "", "phone of the user")
+	var metaData metaDataFlag
+	fs.Var(&metaData, "meta", "KEY=VALUE of the metaData, repeatable, replaces the metaData")
+	args, err := parse(fs, args, 1, 1)
+	if err != nil {
+		return err
+	}
+	id, err := objectID(myerrors.KindUser, args[0])
+	if err != nil {
+		return err
+	}
+	u := &user.User{ID: id, Name: *name, Email: *email, Phone: *phone, MetaData: metaData}
+	if err := e.backend.Users().Update(ctx, u); err != nil {
+		return err
+	}
+	return printUser(ctx, e, args[0])
+}
+
+func deleteUser(ctx context.Context, e *env, args []string) error {
+	args, err := parse(newFlags("delete"), args, 1, 1)
+	if err != nil {
+		return err
+	}
+	id, err := objectID(myerrors.KindUser, args[0])
+	if err != nil {
+		return err
+	}
+	return e.backend.Users().Delete(ctx, id)
+}
+
+func listUsers(ctx context.Context, e *env, args []string) error {
+	fs := newFlags("list")
+	l := newListFlags(fs, true)
+	if _, err := parse(fs, args, 0, 0); err != nil {
+		return err
+	}
+	users, next, err := list(l, func(opts mongodb.ListOptions) ([]user.User, string, error) {
+		return e.backend.Users().List(ctx, opts)
+	})
+	if err != nil {
+		return err
+	}
+	if users == nil {
+		users = []user.User{}
+	}
+	rows := make([][]string, len(users))
+	for i, u := range users {
+		rows[i] = []string{u.ID.Hex(), u.Name, u.Email, u.Phone, groupNames(u.UsersGroups)}
+	}
+	err = e.out.print(struct {
+		Users         []user.User `json:"users"`
+		NextPageToken string      `json:"nextPageToken,omitempty"`
+	}{users, next}, []string{"ID", "NAME", "EMAIL", "PHONE", "GROUPS"}, rows)
+	if err == nil && next != "" && !e.out.json {
+		fmt.Fprintf(os.Stderr, "next page: -page-token %s\n", next)
+	}
+	return err
+}
+
+func printUser(ctx context.Context, e *env, id string) error {
+	u, err := e.backend.Users().GetById(ctx, id)
+	if err != nil {
+		return err
+	}
+	rows := [][]string{
+		{"id", u.ID.Hex()},
+		{"name", u.Name},
+		{"email", u.Email},
+		{"phone", u.Phone},
+	}
+	for _, g := range u.UsersGroups {
+		rows = append(rows, []string{"group", g.Name + " (" + g.ID + ")"})
+	}
+	return e.out.print(u, nil, append(rows, metaDataRows(u.MetaData)...))
+}
+
+func groupNames(groups []user.UserGroupRef) string {
+	names := make([]string, len(groups))
+	for i, g := range groups {
+		names[i] = g.Name
+	}
+	return strings.Join(names, ",")
+}
@@ -0,0 +1,312 @@
+// Command usergroupd serves the REST API of package rest, the SCIM endpoint
+// of package scim below /scim/v2 and, when -grpc-addr is set, the gRPC
+// services of package rpc. With -ldap-config it syncs the users and user
+// groups with an LDAP directory, see package dirsync.
+//
+//	usergroupd -addr :8080 -grpc-addr :9090 -backend mongo -mongo-uri mongodb://localhost:27017
+//
+// The mongo connection, database and logger are set by the -config file,
+// the USERGROUP_* environment variables and the flags, see package config.
+// The tenants having their own database, see mongodb.Naming, get their
+// indexes, outbox pruning and webhook deliveries like the shared database.
+// The directory sync writes to the shared database.
+//
+// With -backend sql the models are stored in a SQL database instead, see
+// package sqlstore; the sqlite and postgres drivers are linked in:
+//
+//	usergroupd -backend sql -sql-driver postgres -sql-dsn postgres://localhost/usergroup
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"sort"
+	"syscall"
+	"time"
+
+	"github.com/sr-codefreak/user-group/api/rest"
+	"github.com/sr-codefreak/user-group/api/rpc"
+	"github.com/sr-codefreak/user-group/api/scim"
+	"github.com/sr-codefreak/user-group/config"
+	"github.com/sr-codefreak/user-group/db"
+	"github.com/sr-codefreak/user-group/db/decorate"
+	"github.com/sr-codefreak/user-group/db/memory"
+	"github.com/sr-codefreak/user-group/db/mongodb"
+	"github.com/sr-codefreak/user-group/db/mongodb/event"
+	"github.com/sr-codefreak/user-group/db/sqlstore"
+	"github.com/sr-codefreak/user-group/dirsync"
+	"github.com/sr-codefreak/user-group/events"
+	"github.com/sr-codefreak/user-group/utils/logger"
+	"github.com/sr-codefreak/user-group/webhooks"
+	"google.golang.org/grpc"
+
+	_ "github.com/lib/pq"
+	_ "modernc.org/sqlite"
+)
+
+var log = logger.GetLogger()
+
+// scimBase is the path the SCIM endpoint is mounted at
+const scimBase = "/scim/v2"
+
+func main() {
+	addr := flag.String("addr", ":8080", "address to listen on")
+	grpcAddr := flag.String("grpc-addr", "", "address to serve gRPC on, disabled when empty")
+	backendName := flag.String("backend", "mongo", "storage backend, mongo, sql or memory")
+	sqlDriver := flag.String("sql-driver", "sqlite", "database/sql driver of the sql backend, sqlite or postgres")
+	sqlDSN := flag.String("sql-dsn", "usergroup.db", "data source name of the sql backend")
+	outboxRetention := flag.Duration("outbox-retention", 7*24*time.Hour, "time events are kept in the outbox, forever when 0")
+	deliverWebhooks := flag.Bool("webhooks", true, "post the events to the webhook subscriptions")
+	ldapConfig := flag.String("ldap-config", "", "JSON file of the LDAP directory to sync, see directoryConfig; disabled when empty")
+	ldapInterval := flag.Duration("ldap-interval", 15*time.Minute, "time between two directory syncs")
+	ldapFullInterval := flag.Duration("ldap-full-interval", 24*time.Hour, "time between two full directory syncs, the others are incremental")
+	ldapDryRun := flag.Bool("ldap-dry-run", false, "print the changes a full directory sync would make and exit")
+	shutdownTimeout := flag.Duration("shutdown-timeout", 15*time.Second, "time given to in-flight requests on shutdown")
+	flags := config.NewFlags(flag.CommandLine, config.Default())
+	flag.Parse()
+
+	cfg, err := config.Load(flags, "USERGROUP")
+	if err == nil {
+		err = logger.Configure(cfg.Log.Logger())
+	}
+	if err != nil {
+		log.Errorf("config: %s", err)
+		os.Exit(2)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	var backend db.Backend
+	var client *mongodb.Client
+	var sqlBackend *sqlstore.Backend
+	switch *backendName {
+	case "memory":
+		backend = memory.New()
+	case "sql":
+		sqlBackend, err = sqlstore.Open(ctx, *sqlDriver, *sqlDSN)
+		if err != nil {
+			log.Errorf("opening the %s database: %s", *sqlDriver, err)
+			os.Exit(1)
+		}
+		backend = sqlBackend
+	case "mongo":
+		client = mongodb.New(cfg.Mongo.Client())
+		dbChan := make(chan struct{})
+		client.Connect(dbChan)
+		select {
+		case <-dbChan:
+		case <-ctx.Done():
+			log.Errorf("interrupted while connecting to mongo")
+			os.Exit(1)
+		}
+		for _, tenant := range tenants(cfg) {
+			if err := db.EnsureIndexes(mongodb.WithTenant(ctx, tenant), client); err != nil {
+				log.Errorf("ensuring indexes%s: %s", ofTenant(tenant), err)
+			}
+		}
+		backend = db.Mongo(client)
+	default:
+		log.Errorf("unknown backend %q", *backendName)
+		os.Exit(2)
+	}
+
+	// every database has its outbox and webhooks
+	databases := []string{""}
+	if client != nil {
+		databases = tenants(cfg)
+	}
+	for _, tenant := range databases {
+		ctx := mongodb.WithTenant(ctx, tenant)
+		if *outboxRetention > 0 {
+			go pruneOutbox(ctx, backend.Outbox(), *outboxRetention)
+		}
+		if *deliverWebhooks {
+			go dispatchWebhooks(ctx, backend, client)
+		}
+	}
+
+	backend = decorate.Backend(backend)
+
+	if *ldapConfig != "" {
+		syncer, err := newSyncer(*ldapConfig, backend)
+		if err != nil {
+			log.Errorf("reading %s: %s", *ldapConfig, err)
+			os.Exit(2)
+		}
+		if *ldapDryRun {
+			report, err := syncer.Sync(ctx, dirsync.Options{DryRun: true})
+			if err != nil {
+				log.Errorf("directory sync: %s", err)
+				os.Exit(1)
+			}
+			fmt.Print(report)
+			return
+		}
+		go syncer.Run(ctx, *ldapInterval, *ldapFullInterval)
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("/", rest.NewServer(backend))
+	mux.Handle(scimBase+"/", scim.NewServer(backend, scimBase))
+	srv := &http.Server{
+		Addr:              *addr,
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	errChan := make(chan error, 2)
+	go func() {
+		log.Infof("listening on %s", *addr)
+		errChan <- srv.ListenAndServe()
+	}()
+
+	var grpcSrv *grpc.Server
+	if *grpcAddr != "" {
+		lis, err := net.Listen("tcp", *grpcAddr)
+		if err != nil {
+			log.Errorf("listening on %s: %s", *grpcAddr, err)
+			os.Exit(1)
+		}
+		grpcSrv = grpc.NewServer(rpc.ServerOptions(backend)...)
+		rpc.NewServer(backend).Register(grpcSrv)
+		go func() {
+			log.Infof("serving gRPC on %s", *grpcAddr)
+			errChan <- grpcSrv.Serve(lis)
+		}()
+	}
+
+	select {
+	case err := <-errChan:
+		if err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Errorf("server: %s", err)
+		}
+	case <-ctx.Done():
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), *shutdownTimeout)
+	defer cancel()
+	if grpcSrv != nil {
+		stopped := make(chan struct{})
+		go func() {
+			grpcSrv.GracefulStop()
+			close(stopped)
+		}()
+		select {
+		case <-stopped:
+		case <-shutdownCtx.Done():
+			grpcSrv.Stop()
+		}
+	}
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		log.Errorf("shutting down server: %s", err)
+	}
+	if client != nil {
+		if err := client.Shutdown(shutdownCtx); err != nil {
+			log.Errorf("shutting down mongo client: %s", err)
+		}
+	}
+	if sqlBackend != nil {
+		if err := sqlBackend.Close(); err != nil {
+			log.Errorf("closing the %s database: %s", *sqlDriver, err)
+		}
+	}
+}
+
+// directoryConfig is the file of the -ldap-config flag, the LDAP_BIND_PASSWORD
+// environment variable overrides the bind password:
+//
+//	{"ldap": {"url": "ldaps://dc.example.com", "bindDN": "..."},
+//	 "sync": {"name": "ad", "users": {"baseDN": "...", "metaData": {"department": "department"}}, "groups": {...}}}
+type directoryConfig struct {
+	LDAP dirsync.LDAPConfig `json:"ldap"`
+	Sync dirsync.Config     `json:"sync"`
+}
+
+func newSyncer(path string, backend db.Backend) (*dirsync.Syncer, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	cfg := directoryConfig{}
+	if err := json.Unmarshal(b, &cfg); err != nil {
+		return nil, err
+	}
+	if password := os.Getenv("LDAP_BIND_PASSWORD"); password != "" {
+		cfg.LDAP.BindPassword = password
+	}
+	return dirsync.New(backend, dirsync.NewLDAP(cfg.LDAP), cfg.Sync), nil
+}
+
+// tenants returns the shared database, the empty tenant, and the tenants
+// having their own database
+func tenants(cfg config.Config) []string {
+	tenants := []string{""}
+	for tenant := range cfg.Mongo.Tenants {
+		tenants = append(tenants, tenant)
+	}
+	sort.Strings(tenants[1:])
+	return tenants
+}
+
+func ofTenant(tenant string) string {
+	if tenant == "" {
+		return ""
+	}
+	return " of tenant " + tenant
+}
+
+// pruneOutbox removes the events older than retention every hour until ctx is done
+func pruneOutbox(ctx context.Context, outbox event.OutboxStore, retention time.Duration) {
+	ticker := time.NewTicker(time.Hour)
+	defer ticker.Stop()
+	for {
+		if err := outbox.Prune(ctx, time.Now().Add(-retention)); err != nil {
+			log.Errorf("pruning outbox%s: %s", ofTenant(mongodb.TenantFrom(ctx)), err)
+		}
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// dispatchWebhooks enqueues the events of the outbox for the webhook
+// subscriptions and posts them until ctx is done. The outbox is replayed from
+// its oldest event on start, deliveries already enqueued are skipped. The
+// events of mongo, whose ids are not in commit order, are then read from a
+// change stream.
+func dispatchWebhooks(ctx context.Context, backend db.Backend, client *mongodb.Client) {
+	d := webhooks.New(backend.Webhooks(), webhooks.Config{})
+	go d.Run(ctx)
+	source := events.Poll(backend.Outbox(), time.Second)
+	if client != nil {
+		source = events.CatchUp(backend.Outbox(), events.ChangeStream(client))
+	}
+	c := events.NewConsumer(source)
+	d.Register(c)
+	token := ""
+	checkpoint := func(ctx context.Context, next string) error {
+		token = next
+		return nil
+	}
+	for {
+		err := c.Run(ctx, token, checkpoint)
+		if ctx.Err() != nil {
+			return
+		}
+		log.Errorf("consuming events for webhooks%s: %s", ofTenant(mongodb.TenantFrom(ctx)), err)
+		select {
+		case <-time.After(5 * time.Second):
+		case <-ctx.Done():
+			return
+		}
+	}
+}
@@ -0,0 +1,207 @@
+// Package config holds the settings shared by the commands: the mongo
+// connection, the names of the database and collections, and the logger.
+// The settings are layered, each source overriding the previous ones:
+//
+//  1. Default
+//  2. the config file, YAML when its extension is .yaml or .yml and JSON otherwise
+//  3. the environment variables, PREFIX_MONGO_URI sets mongo.uri
+//  4. the flags set on the command line, -mongo-uri sets mongo.uri
+//
+// A YAML config file of a staging environment:
+//
+//	mongo:
+//	  uri: mongodb://db.staging:27017
+//	  databasePrefix: staging_
+//	  timeout: 5s
+//	  tenants:
+//	    acme: acme
+//	  collections:
+//	    outbox: events
+//	log:
+//	  level: debug
+//	  file: /var/log/usergroupd.log
+//	  format: json
+//
+// The result is checked by Validate, see Load.
+package config
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/sr-codefreak/user-group/db/mongodb"
+	"github.com/sr-codefreak/user-group/utils/logger"
+	"go.mongodb.org/mongo-driver/x/mongo/driver/connstring"
+)
+
+// Config is the settings of a command
+type Config struct {
+	Mongo Mongo `json:"mongo" yaml:"mongo"`
+	Log   Log   `json:"log" yaml:"log"`
+}
+
+// Mongo is the settings of the mongo connection
+type Mongo struct {
+	// URI is the mongo connection string
+	URI string `json:"uri" yaml:"uri"`
+	// Database is the shared database of the models
+	Database string `json:"database" yaml:"database"`
+	// DatabasePrefix and DatabaseSuffix are added to the names of all the
+	// databases, to run several environments on a cluster
+	DatabasePrefix string `json:"databasePrefix,omitempty" yaml:"databasePrefix,omitempty"`
+	DatabaseSuffix string `json:"databaseSuffix,omitempty" yaml:"databaseSuffix,omitempty"`
+	// Tenants maps the tenants having their own database to its name, see
+	// mongodb.WithTenant
+	Tenants map[string]string `json:"tenants,omitempty" yaml:"tenants,omitempty"`
+	// Collections renames collections, it maps the default name of a
+	// collection (user, userGroups, access, roles, audit, outbox, webhooks
+	// or webhookDeliveries) to the name used
+	Collections map[string]string `json:"collections,omitempty" yaml:"collections,omitempty"`
+	// CollectionPrefix and CollectionSuffix are added to the names of all
+	// the collections
+	CollectionPrefix string `json:"collectionPrefix,omitempty" yaml:"collectionPrefix,omitempty"`
+	CollectionSuffix string `json:"collectionSuffix,omitempty" yaml:"collectionSuffix,omitempty"`
+	// Timeout bounds the operations whose context has no deadline
+	Timeout Duration `json:"timeout" yaml:"timeout"`
+}
+
+// Log is the settings of the logger
+type Log struct {
+	// Level is panic, fatal, error, warn, info, debug or trace
+	Level string `json:"level" yaml:"level"`
+	// File is the file the logs are appended to besides the console, none when empty
+	File string `json:"file" yaml:"file"`
+	// Format is text or json
+	Format string `json:"format" yaml:"format"`
+}
+
+// Duration is a time.Duration written like 10s or 1m30s in config files
+type Duration time.Duration
+
+func (d Duration) String() string {
+	return time.Duration(d).String()
+}
+
+func (d Duration) MarshalText() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+func (d *Duration) UnmarshalText(b []byte) error {
+	v, err := time.ParseDuration(string(b))
+	if err != nil {
+		return err
+	}
+	*d = Duration(v)
+	return nil
+}
+
+// Default returns the settings used when nothing else is set
+func Default() Config {
+	return Config{
+		Mongo: Mongo{
+			URI:      "mongodb://localhost:27017",
+			Database: mongodb.UserGroup{}.DatabaseName(),
+			Timeout:  Duration(10 * time.Second),
+		},
+		Log: Log{
+			Level:  "info",
+			File:   logger.DefaultFile,
+			Format: "text",
+		},
+	}
+}
+
+// Validate returns the errors of all the invalid settings joined
+func (c Config) Validate() error {
+	var errs []error
+	if _, err := connstring.ParseAndValidate(c.Mongo.URI); err != nil {
+		errs = append(errs, fmt.Errorf("mongo.uri: %w", err))
+	}
+	m := c.Mongo
+	if err := checkDatabase(m.Database, m.DatabasePrefix+m.Database+m.DatabaseSuffix); err != nil {
+		errs = append(errs, fmt.Errorf("mongo.database: %w", err))
+	}
+	for tenant, database := range m.Tenants {
+		if tenant == "" {
+			errs = append(errs, errors.New("mongo.tenants: the tenant is empty"))
+		}
+		if err := checkDatabase(database, m.DatabasePrefix+database+m.DatabaseSuffix); err != nil {
+			errs = append(errs, fmt.Errorf("mongo.tenants.%s: %w", tenant, err))
+		}
+	}
+	if m.CollectionPrefix != "" || m.CollectionSuffix != "" {
+		if err := checkCollection(m.CollectionPrefix + "x" + m.CollectionSuffix); err != nil {
+			errs = append(errs, fmt.Errorf("mongo.collectionPrefix, mongo.collectionSuffix: %w", err))
+		}
+	}
+	for from, to := range m.Collections {
+		if err := checkCollection(from); err != nil {
+			errs = append(errs, fmt.Errorf("mongo.collections: %q: %w", from, err))
+		}
+		if err := checkCollection(m.CollectionPrefix + to + m.CollectionSuffix); err != nil {
+			errs = append(errs, fmt.Errorf("mongo.collections.%s: %q: %w", from, to, err))
+		}
+	}
+	if c.Mongo.Timeout <= 0 {
+		errs = append(errs, errors.New("mongo.timeout: must be positive"))
+	}
+	if _, err := logrus.ParseLevel(c.Log.Level); err != nil {
+		errs = append(errs, fmt.Errorf("log.level: %w", err))
+	}
+	if c.Log.Format != "text" && c.Log.Format != "json" {
+		errs = append(errs, fmt.Errorf("log.format: unknown format %q, expected text or json", c.Log.Format))
+	}
+	return errors.Join(errs...)
+}
+
+// checkDatabase applies the naming restrictions of mongo to the name of a
+// database, which is the configured name with the prefix and suffix
+func checkDatabase(configured, name string) error {
+	switch {
+	case configured == "":
+		return errors.New("is required")
+	case len(name) >= 64:
+		return errors.New("must be shorter than 64 bytes")
+	case strings.ContainsAny(name, "/\\. \"$*<>:|?\x00"):
+		return fmt.Errorf("%q contains a character mongo does not allow", name)
+	}
+	return nil
+}
+
+// checkCollection applies the naming restrictions of mongo to a collection name
+func checkCollection(name string) error {
+	switch {
+	case name == "":
+		return errors.New("the name is empty")
+	case strings.ContainsAny(name, "$\x00"):
+		return errors.New("the name contains $ or a null character")
+	case strings.HasPrefix(name, "system."):
+		return errors.New("the system. prefix is reserved")
+	}
+	return nil
+}
+
+// Client returns the config of a mongodb.Client
+func (m Mongo) Client() mongodb.Config {
+	return mongodb.Config{
+		URI: m.URI,
+		Naming: mongodb.Naming{
+			Database:         m.Database,
+			Prefix:           m.DatabasePrefix,
+			Suffix:           m.DatabaseSuffix,
+			Tenants:          m.Tenants,
+			Collections:      m.Collections,
+			CollectionPrefix: m.CollectionPrefix,
+			CollectionSuffix: m.CollectionSuffix,
+		},
+		Timeout: time.Duration(m.Timeout),
+	}
+}
+
+// Logger returns the config of logger.Configure
+func (l Log) Logger() logger.Config {
+	return logger.Config{Level: l.Level, File: l.File, Format: l.Format}
+}
@@ -0,0 +1,260 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"unicode"
+
+	"gopkg.in/yaml.v3"
+)
+
+// setting is a setting that can be set by an environment variable and a flag
+type setting struct {
+	// name is the key in the config file, mongo.uri gives the environment
+	// variable PREFIX_MONGO_URI and the flag -mongo-uri
+	name  string
+	usage string
+	get   func(c *Config) string
+	set   func(c *Config, v string) error
+}
+
+var settings = []setting{
+	{
+		name:  "mongo.uri",
+		usage: "mongo connection string",
+		get:   func(c *Config) string { return c.Mongo.URI },
+		set:   func(c *Config, v string) error { c.Mongo.URI = v; return nil },
+	},
+	{
+		name:  "mongo.database",
+		usage: "database of the models",
+		get:   func(c *Config) string { return c.Mongo.Database },
+		set:   func(c *Config, v string) error { c.Mongo.Database = v; return nil },
+	},
+	{
+		name:  "mongo.databasePrefix",
+		usage: "prefix of the database names",
+		get:   func(c *Config) string { return c.Mongo.DatabasePrefix },
+		set:   func(c *Config, v string) error { c.Mongo.DatabasePrefix = v; return nil },
+	},
+	{
+		name:  "mongo.databaseSuffix",
+		usage: "suffix of the database names",
+		get:   func(c *Config) string { return c.Mongo.DatabaseSuffix },
+		set:   func(c *Config, v string) error { c.Mongo.DatabaseSuffix = v; return nil },
+	},
+	{
+		name:  "mongo.tenants",
+		usage: "comma separated `tenant=database` pairs of the tenants having their own database",
+		get:   func(c *Config) string { return formatPairs(c.Mongo.Tenants) },
+		set: func(c *Config, v string) (err error) {
+			c.Mongo.Tenants, err = parsePairs(v)
+			return err
+		},
+	},
+	{
+		name:  "mongo.collections",
+		usage: "comma separated `name=renamed` pairs renaming the collections",
+		get:   func(c *Config) string { return formatPairs(c.Mongo.Collections) },
+		set: func(c *Config, v string) (err error) {
+			c.Mongo.Collections, err = parsePairs(v)
+			return err
+		},
+	},
+	{
+		name:  "mongo.collectionPrefix",
+		usage: "prefix of the collection names",
+		get:   func(c *Config) string { return c.Mongo.CollectionPrefix },
+		set:   func(c *Config, v string) error { c.Mongo.CollectionPrefix = v; return nil },
+	},
+	{
+		name:  "mongo.collectionSuffix",
+		usage: "suffix of the collection names",
+		get:   func(c *Config) string { return c.Mongo.CollectionSuffix },
+		set:   func(c *Config, v string) error { c.Mongo.CollectionSuffix = v; return nil },
+	},
+	{
+		name:  "mongo.timeout",
+		usage: "timeout of the mongo operations",
+		get:   func(c *Config) string { return c.Mongo.Timeout.String() },
+		set:   func(c *Config, v string) error { return c.Mongo.Timeout.UnmarshalText([]byte(v)) },
+	},
+	{
+		name:  "log.level",
+		usage: "log level: panic, fatal, error, warn, info, debug or trace",
+		get:   func(c *Config) string { return c.Log.Level },
+		set:   func(c *Config, v string) error { c.Log.Level = v; return nil },
+	},
+	{
+		name:  "log.file",
+		usage: "file the logs are appended to, none when empty",
+		get:   func(c *Config) string { return c.Log.File },
+		set:   func(c *Config, v string) error { c.Log.File = v; return nil },
+	},
+	{
+		name:  "log.format",
+		usage: "log format, text or json",
+		get:   func(c *Config) string { return c.Log.Format },
+		set:   func(c *Config, v string) error { c.Log.Format = v; return nil },
+	},
+}
+
+func (s setting) env(prefix string) string {
+	return prefix + "_" + strings.ToUpper(strings.Join(s.words(), "_"))
+}
+
+func (s setting) flag() string {
+	return strings.Join(s.words(), "-")
+}
+
+// words splits the name at the dots and before the capitals,
+// mongo.databasePrefix gives mongo, database and prefix
+func (s setting) words() []string {
+	var words []string
+	for _, part := range strings.Split(s.name, ".") {
+		start := 0
+		for i, r := range part {
+			if unicode.IsUpper(r) {
+				words = append(words, strings.ToLower(part[start:i]))
+				start = i
+			}
+		}
+		words = append(words, strings.ToLower(part[start:]))
+	}
+	return words
+}
+
+func formatPairs(m map[string]string) string {
+	pairs := make([]string, 0, len(m))
+	for k, v := range m {
+		pairs = append(pairs, k+"="+v)
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, ",")
+}
+
+func parsePairs(v string) (map[string]string, error) {
+	if v == "" {
+		return nil, nil
+	}
+	m := map[string]string{}
+	for _, pair := range strings.Split(v, ",") {
+		k, v, ok := strings.Cut(pair, "=")
+		if !ok {
+			return nil, fmt.Errorf("%q is not key=value", pair)
+		}
+		m[strings.TrimSpace(k)] = strings.TrimSpace(v)
+	}
+	return m, nil
+}
+
+// Flags are the flags of the settings registered on a flag set
+type Flags struct {
+	fs     *flag.FlagSet
+	file   *string
+	values map[string]*string
+}
+
+// NewFlags registers -config and a flag per setting on fs, showing the
+// values of def as defaults
+func NewFlags(fs *flag.FlagSet, def Config) *Flags {
+	f := &Flags{
+		fs:     fs,
+		file:   fs.String("config", "", "JSON or YAML config file"),
+		values: map[string]*string{},
+	}
+	for _, s := range settings {
+		f.values[s.flag()] = fs.String(s.flag(), s.get(&def), s.usage)
+	}
+	return f
+}
+
+// File returns the config file given with -config
+func (f *Flags) File() string {
+	return *f.file
+}
+
+// Apply sets the settings whose flag is set on the command line, the flag
+// set must be parsed
+func (f *Flags) Apply(c *Config) error {
+	var err error
+	f.fs.Visit(func(fl *flag.Flag) {
+		for _, s := range settings {
+			if err == nil && s.flag() == fl.Name {
+				if err = s.set(c, *f.values[fl.Name]); err != nil {
+					err = fmt.Errorf("-%s: %w", fl.Name, err)
+				}
+			}
+		}
+	})
+	return err
+}
+
+// ApplyEnv sets the settings whose environment variable is set, the
+// variables are named after the setting with prefix, PREFIX_MONGO_URI
+func ApplyEnv(c *Config, prefix string) error {
+	for _, s := range settings {
+		if v := os.Getenv(s.env(prefix)); v != "" {
+			if err := s.set(c, v); err != nil {
+				return fmt.Errorf("%s: %w", s.env(prefix), err)
+			}
+		}
+	}
+	return nil
+}
+
+// ReadFile decodes the config file at path into v, which is a *Config or a
+// struct embedding Config. The file is YAML when its extension is .yaml or
+// .yml and JSON otherwise. Keys v has no field for are errors.
+func ReadFile(path string, v any) error {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		dec := yaml.NewDecoder(bytes.NewReader(b))
+		dec.KnownFields(true)
+		err = dec.Decode(v)
+	default:
+		dec := json.NewDecoder(bytes.NewReader(b))
+		dec.DisallowUnknownFields()
+		err = dec.Decode(v)
+	}
+	// an empty file sets nothing
+	if err != nil && err != io.EOF {
+		return fmt.Errorf("reading %s: %w", path, err)
+	}
+	return nil
+}
+
+// Load layers the config file, the environment variables starting with
+// prefix and the flags set on Default, and validates the result. The config
+// file is -config or else the PREFIX_CONFIG environment variable, none is
+// read when both are empty.
+func Load(f *Flags, prefix string) (Config, error) {
+	c := Default()
+	path := f.File()
+	if path == "" {
+		path = os.Getenv(prefix + "_CONFIG")
+	}
+	if path != "" {
+		if err := ReadFile(path, &c); err != nil {
+			return c, err
+		}
+	}
+	if err := ApplyEnv(&c, prefix); err != nil {
+		return c, err
+	}
+	if err := f.Apply(&c); err != nil {
+		return c, err
+	}
+	return c, c.Validate()
+}
@@ -0,0 +1,212 @@
+package config_test
+
+import (
+	"errors"
+	"flag"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sr-codefreak/user-group/config"
+)
+
+// prefix of the environment variables of the tests
+const prefix = "UGTEST"
+
+func writeFile(t *testing.T, name string, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+// load parses the arguments as command line and loads the config
+func load(t *testing.T, args ...string) (config.Config, error) {
+	t.Helper()
+	flags := flag.NewFlagSet("test", flag.ContinueOnError)
+	f := config.NewFlags(flags, config.Default())
+	if err := flags.Parse(args); err != nil {
+		t.Fatal(err)
+	}
+	return config.Load(f, prefix)
+}
+
+func TestLoadPrecedence(t *testing.T) {
+	path := writeFile(t, "config.yaml", `
+mongo:
+  uri: mongodb://file:27017
+  database: fromfile
+  databasePrefix: staging_
+  tenants:
+    acme: acme
+log:
+  level: debug
+  format: json
+`)
+	t.Setenv(prefix+"_MONGO_DATABASE", "fromenv")
+	t.Setenv(prefix+"_LOG_LEVEL", "warn")
+	t.Setenv(prefix+"_MONGO_TIMEOUT", "30s")
+
+	c, err := load(t, "-config", path, "-log-level", "error", "-mongo-collection-suffix", "_v2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := config.Default()
+	want.Mongo.URI = "mongodb://file:27017"
+	want.Mongo.Database = "fromenv"
+	want.Mongo.DatabasePrefix = "staging_"
+	want.Mongo.Tenants = map[string]string{"acme": "acme"}
+	want.Mongo.CollectionSuffix = "_v2"
+	want.Mongo.Timeout = config.Duration(30 * time.Second)
+	want.Log.Level = "error"
+	want.Log.Format = "json"
+	if !reflect.DeepEqual(c, want) {
+		t.Errorf("got %+v\nwant %+v", c, want)
+	}
+}
+
+func TestLoadConfigFile(t *testing.T) {
+	fromEnv := writeFile(t, "env.json", `{"log": {"level": "debug"}}`)
+	fromFlag := writeFile(t, "flag.yml", `log: {level: trace}`)
+	t.Setenv(prefix+"_CONFIG", fromEnv)
+
+	// -config wins over PREFIX_CONFIG
+	for _, c := range []struct {
+		args  []string
+		level string
+	}{
+		{nil, "debug"},
+		{[]string{"-config", fromFlag}, "trace"},
+	} {
+		got, err := load(t, c.args...)
+		if err != nil || got.Log.Level != c.level {
+			t.Errorf("%v: level %q, %v, want %q", c.args, got.Log.Level, err, c.level)
+		}
+	}
+
+	if _, err := load(t, "-config", filepath.Join(t.TempDir(), "missing.yaml")); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("missing file: %v", err)
+	}
+	if _, err := load(t, "-config", writeFile(t, "bad.yaml", "log: {level: loud}")); err == nil || !strings.Contains(err.Error(), "log.level") {
+		t.Errorf("invalid level in the file: %v", err)
+	}
+}
+
+func TestReadFileUnknownKeys(t *testing.T) {
+	for name, content := range map[string]string{
+		"top.yaml":    "mongo: {uri: mongodb://x}\nlogs: {level: debug}\n",
+		"nested.yml":  "mongo: {url: mongodb://x}\n",
+		"top.json":    `{"mongo": {"uri": "mongodb://x"}, "logs": {}}`,
+		"nested.json": `{"log": {"lvl": "debug"}}`,
+	} {
+		c := config.Default()
+		err := config.ReadFile(writeFile(t, name, content), &c)
+		if err == nil || !strings.Contains(err.Error(), name) {
+			t.Errorf("%s: %v, want an error about the unknown key", name, err)
+		}
+	}
+
+	// the keys of a struct embedding Config are known
+	for name, content := range map[string]string{
+		"extended.yaml": "mongo: {uri: mongodb://x}\nlisten: :8080\n",
+		"extended.json": `{"mongo": {"uri": "mongodb://x"}, "listen": ":8080"}`,
+	} {
+		v := struct {
+			config.Config `yaml:",inline"`
+			Listen        string `json:"listen" yaml:"listen"`
+		}{Config: config.Default()}
+		if err := config.ReadFile(writeFile(t, name, content), &v); err != nil || v.Listen != ":8080" || v.Mongo.URI != "mongodb://x" {
+			t.Errorf("%s: %+v %v", name, v, err)
+		}
+	}
+
+	c := config.Default()
+	if err := config.ReadFile(writeFile(t, "empty.yaml", ""), &c); err != nil || !reflect.DeepEqual(c, config.Default()) {
+		t.Errorf("empty file: %+v %v", c, err)
+	}
+}
+
+func TestPairs(t *testing.T) {
+	for _, c := range []struct {
+		value string
+		want  map[string]string
+	}{
+		{"acme=acme_db", map[string]string{"acme": "acme_db"}},
+		{"acme=a, globex = g ", map[string]string{"acme": "a", "globex": "g"}},
+		{"acme=a=b", map[string]string{"acme": "a=b"}},
+	} {
+		t.Setenv(prefix+"_MONGO_TENANTS", c.value)
+		got := config.Default()
+		if err := config.ApplyEnv(&got, prefix); err != nil || !reflect.DeepEqual(got.Mongo.Tenants, c.want) {
+			t.Errorf("%q: %v %v, want %v", c.value, got.Mongo.Tenants, err, c.want)
+		}
+	}
+
+	t.Setenv(prefix+"_MONGO_TENANTS", "acme=a,globex")
+	got := config.Default()
+	if err := config.ApplyEnv(&got, prefix); err == nil || !strings.Contains(err.Error(), prefix+"_MONGO_TENANTS") || !strings.Contains(err.Error(), `"globex"`) {
+		t.Errorf("pair without =: %v", err)
+	}
+
+	// the defaults of the flags show the pairs sorted, an empty flag clears them
+	def := config.Default()
+	def.Mongo.Collections = map[string]string{"outbox": "events", "audit": "log"}
+	flags := flag.NewFlagSet("test", flag.ContinueOnError)
+	f := config.NewFlags(flags, def)
+	if d := flags.Lookup("mongo-collections").DefValue; d != "audit=log,outbox=events" {
+		t.Errorf("default %q", d)
+	}
+	if err := flags.Parse([]string{"-mongo-collections", ""}); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.Apply(&def); err != nil || def.Mongo.Collections != nil {
+		t.Errorf("cleared: %v %v", def.Mongo.Collections, err)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	if err := config.Default().Validate(); err != nil {
+		t.Fatalf("default: %v", err)
+	}
+	for _, c := range []struct {
+		name   string
+		change func(c *config.Config)
+		want   []string
+	}{
+		{"uri", func(c *config.Config) { c.Mongo.URI = "localhost:27017" }, []string{"mongo.uri"}},
+		{"no database", func(c *config.Config) { c.Mongo.Database = "" }, []string{"mongo.database: is required"}},
+		{"dot in database", func(c *config.Config) { c.Mongo.Database = "user.group" }, []string{"mongo.database"}},
+		{"long database", func(c *config.Config) { c.Mongo.DatabasePrefix = strings.Repeat("p", 60) }, []string{"mongo.database: must be shorter"}},
+		{"empty tenant", func(c *config.Config) { c.Mongo.Tenants = map[string]string{"": "db"} }, []string{"mongo.tenants: the tenant is empty"}},
+		{"tenant database", func(c *config.Config) { c.Mongo.Tenants = map[string]string{"acme": "a/b"} }, []string{"mongo.tenants.acme"}},
+		{"collection prefix", func(c *config.Config) { c.Mongo.CollectionPrefix = "system." }, []string{"mongo.collectionPrefix"}},
+		{"renamed collection", func(c *config.Config) { c.Mongo.Collections = map[string]string{"outbox": "$out"} }, []string{"mongo.collections.outbox"}},
+		{"timeout", func(c *config.Config) { c.Mongo.Timeout = 0 }, []string{"mongo.timeout"}},
+		{"level", func(c *config.Config) { c.Log.Level = "loud" }, []string{"log.level"}},
+		{"format", func(c *config.Config) { c.Log.Format = "xml" }, []string{"log.format"}},
+		{"all joined", func(c *config.Config) {
+			c.Mongo.Timeout = -1
+			c.Log.Level = "loud"
+			c.Log.Format = "xml"
+		}, []string{"mongo.timeout", "log.level", "log.format"}},
+	} {
+		cfg := config.Default()
+		c.change(&cfg)
+		err := cfg.Validate()
+		if err == nil {
+			t.Errorf("%s: valid", c.name)
+			continue
+		}
+		for _, want := range c.want {
+			if !strings.Contains(err.Error(), want) {
+				t.Errorf("%s: %v, want %q", c.name, err, want)
+			}
+		}
+	}
+}
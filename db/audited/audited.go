@@ -0,0 +1,435 @@
+// Package audited records every mutation made through a backend in the audit
+// log of the backend, package audit of the mongodb models.
+//
+// Wrap a backend with Backend and pass the author of a change along with the
+// context of the call:
+//
+//	b := audited.Backend(backend)
+//	err := b.UserGroups().AddUser(audit.WithActor(ctx, "alice"), groupId, userId)
+//
+// Each record holds the fields of the entity the mutation changed, as they
+// were before and after it. AddUser and RemoveUser change both sides of the
+// membership and append a record for the user group and one for the user. The record is appended in the transaction of the
+// mutation, the one of b.WithTransaction: a mutation that fails is
+// not recorded, and a mutation whose record cannot be appended is rolled
+// back. Wrapping an events.Backend, its events join the same transaction:
+//
+//	b := audited.Backend(events.Backend(backend))
+//
+// The memory backend has no transactions, its records are appended after the
+// mutation.
+package audited
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"time"
+
+	"github.com/sr-codefreak/user-group/db"
+	"github.com/sr-codefreak/user-group/db/mongodb/access"
+	"github.com/sr-codefreak/user-group/db/mongodb/audit"
+	"github.com/sr-codefreak/user-group/db/mongodb/user"
+	"github.com/sr-codefreak/user-group/db/mongodb/usergroup"
+	"github.com/sr-codefreak/user-group/myerrors"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// The actions of the records
+const (
+	ActionCreateUser      = "user.create"
+	ActionUpdateUser      = "user.update"
+	ActionDeleteUser      = "user.delete"
+	ActionCreateUserGroup = "userGroup.create"
+	ActionRenameUserGroup = "userGroup.updateName"
+	ActionAddUser         = "userGroup.addUser"
+	ActionRemoveUser      = "userGroup.removeUser"
+	ActionDeleteUserGroup = "userGroup.delete"
+	ActionAddChild        = "userGroup.addChild"
+	ActionRemoveChild     = "userGroup.removeChild"
+	ActionSetRule         = "userGroup.setRule"
+	ActionGrant           = "access.grant"
+	ActionRevoke          = "access.revoke"
+	ActionSetRoles        = "access.setRoles"
+	ActionCreateRole      = "role.create"
+	ActionUpdateRole      = "role.update"
+	ActionDeleteRole      = "role.delete"
+)
+
+type backend struct {
+	db.Backend
+}
+
+// Backend wraps b so every successful mutation appends a record to b.Audit()
+func Backend(b db.Backend) db.Backend {
+	return backend{Backend: b}
+}
+
+func (b backend) Users() user.UserStore {
+	return userStore{UserStore: b.Backend.Users(), b: b.Backend}
+}
+
+func (b backend) UserGroups() usergroup.UserGroupStore {
+	return userGroupStore{UserGroupStore: b.Backend.UserGroups(), b: b.Backend}
+}
+
+func (b backend) Access() access.AccessStore {
+	return accessStore{AccessStore: b.Backend.Access(), b: b.Backend}
+}
+
+func (b backend) Roles() access.RoleStore {
+	return roleStore{RoleStore: b.Backend.Roles(), b: b.Backend}
+}
+
+// snapshot converts the entity to its JSON document, nil stays nil
+func snapshot(v any) (map[string]any, error) {
+	if v == nil {
+		return nil, nil
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	m := map[string]any{}
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// diff removes the fields that are the same before and after
+func diff(before map[string]any, after map[string]any) {
+	if before == nil || after == nil {
+		return
+	}
+	for k, v := range before {
+		if a, ok := after[k]; ok && reflect.DeepEqual(a, v) {
+			delete(before, k)
+			delete(after, k)
+		}
+	}
+}
+
+// ignoreNotFound makes a missing entity a nil snapshot
+func ignoreNotFound[T any](v *T, err error) (any, error) {
+	if errors.Is(err, myerrors.ErrNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// mutation describes a write to record
+type mutation struct {
+	action string
+	kind   myerrors.Kind
+	id     func() string
+	// load returns the entity, nil when it does not exist
+	load func(ctx context.Context) (any, error)
+	run  func(ctx context.Context) error
+}
+
+// apply runs the mutation and appends its record in one transaction
+func apply(ctx context.Context, b db.Backend, m mutation) error {
+	return b.WithTransaction(ctx, func(ctx context.Context) error {
+		var before any
+		if m.load != nil {
+			var err error
+			if before, err = m.load(ctx); err != nil {
+				return err
+			}
+		}
+		if err := m.run(ctx); err != nil {
+			return err
+		}
+		var after any
+		if m.load != nil {
+			var err error
+			if after, err = m.load(ctx); err != nil {
+				return err
+			}
+		}
+		return record(ctx, b, m.action, m.kind, m.id(), before, after)
+	})
+}
+
+// record appends the record of a mutation of the entity id
+func record(ctx context.Context, b db.Backend, action string, kind myerrors.Kind, id string, before any, after any) error {
+	r := &audit.Record{
+		Time:     time.Now().UTC(),
+		Actor:    audit.ActorFrom(ctx),
+		Action:   action,
+		Kind:     string(kind),
+		EntityId: id,
+	}
+	var err error
+	if r.Before, err = snapshot(before); err != nil {
+		return myerrors.Wrap(myerrors.ErrAppendingAudit, myerrors.KindAudit, "", err)
+	}
+	if r.After, err = snapshot(after); err != nil {
+		return myerrors.Wrap(myerrors.ErrAppendingAudit, myerrors.KindAudit, "", err)
+	}
+	diff(r.Before, r.After)
+	return b.Audit().Append(ctx, r)
+}
+
+func fixed(id string) func() string {
+	return func() string { return id }
+}
+
+type userStore struct {
+	user.UserStore
+	b db.Backend
+}
+
+func (s userStore) load(id primitive.ObjectID) func(ctx context.Context) (any, error) {
+	return func(ctx context.Context) (any, error) {
+		return ignoreNotFound(s.UserStore.GetById(ctx, id.Hex()))
+	}
+}
+
+func (s userStore) Create(ctx context.Context, u *user.User) error {
+	return apply(ctx, s.b, mutation{
+		action: ActionCreateUser,
+		kind:   myerrors.KindUser,
+		id:     func() string { return u.ID.Hex() },
+		load: func(ctx context.Context) (any, error) {
+			if u.ID.IsZero() {
+				return nil, nil
+			}
+			return s.load(u.ID)(ctx)
+		},
+		run: func(ctx context.Context) error { return s.UserStore.Create(ctx, u) },
+	})
+}
+
+// CreateMany records the users as they were given, without reading them back
+func (s userStore) CreateMany(ctx context.Context, users []*user.User) error {
+	return s.b.WithTransaction(ctx, func(ctx context.Context) error {
+		if err := s.UserStore.CreateMany(ctx, users); err != nil {
+			return err
+		}
+		for _, u := range users {
+			if err := record(ctx, s.b, ActionCreateUser, myerrors.KindUser, u.ID.Hex(), nil, u); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (s userStore) Update(ctx context.Context, u *user.User) error {
+	return apply(ctx, s.b, mutation{
+		action: ActionUpdateUser,
+		kind:   myerrors.KindUser,
+		id:     fixed(u.ID.Hex()),
+		load:   s.load(u.ID),
+		run:    func(ctx context.Context) error { return s.UserStore.Update(ctx, u) },
+	})
+}
+
+func (s userStore) Delete(ctx context.Context, id primitive.ObjectID) error {
+	return apply(ctx, s.b, mutation{
+		action: ActionDeleteUser,
+		kind:   myerrors.KindUser,
+		id:     fixed(id.Hex()),
+		load:   s.load(id),
+		run:    func(ctx context.Context) error { return s.UserStore.Delete(ctx, id) },
+	})
+}
+
+type userGroupStore struct {
+	usergroup.UserGroupStore
+	b db.Backend
+}
+
+func (s userGroupStore) load(id primitive.ObjectID) func(ctx context.Context) (any, error) {
+	return func(ctx context.Context) (any, error) {
+		return ignoreNotFound(s.UserGroupStore.GetById(ctx, id.Hex()))
+	}
+}
+
+// change records the mutation run of the user group id
+func (s userGroupStore) change(ctx context.Context, action string, id primitive.ObjectID, run func(ctx context.Context) error) error {
+	return apply(ctx, s.b, mutation{
+		action: action,
+		kind:   myerrors.KindUserGroup,
+		id:     fixed(id.Hex()),
+		load:   s.load(id),
+		run:    run,
+	})
+}
+
+func (s userGroupStore) Create(ctx context.Context, group *usergroup.UserGroup) error {
+	return apply(ctx, s.b, mutation{
+		action: ActionCreateUserGroup,
+		kind:   myerrors.KindUserGroup,
+		id:     func() string { return group.ID.Hex() },
+		load: func(ctx context.Context) (any, error) {
+			if group.ID.IsZero() {
+				return nil, nil
+			}
+			return s.load(group.ID)(ctx)
+		},
+		run: func(ctx context.Context) error { return s.UserGroupStore.Create(ctx, group) },
+	})
+}
+
+// CreateMany records the user groups as they were given, without reading them back
+func (s userGroupStore) CreateMany(ctx context.Context, groups []*usergroup.UserGroup) error {
+	return s.b.WithTransaction(ctx, func(ctx context.Context) error {
+		if err := s.UserGroupStore.CreateMany(ctx, groups); err != nil {
+			return err
+		}
+		for _, group := range groups {
+			if err := record(ctx, s.b, ActionCreateUserGroup, myerrors.KindUserGroup, group.ID.Hex(), nil, group); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (s userGroupStore) UpdateName(ctx context.Context, id primitive.ObjectID, name string) error {
+	return s.change(ctx, ActionRenameUserGroup, id, func(ctx context.Context) error {
+		return s.UserGroupStore.UpdateName(ctx, id, name)
+	})
+}
+
+// membership records the mutation run of the users of the user group id
+// twice: as a change of the user group and as one of the user groups of
+// the user userId
+func (s userGroupStore) membership(ctx context.Context, action string, id primitive.ObjectID, userId primitive.ObjectID, run func(ctx context.Context) error) error {
+	users := userStore{UserStore: s.b.Users(), b: s.b}
+	return s.change(ctx, action, id, func(ctx context.Context) error {
+		return apply(ctx, s.b, mutation{
+			action: action,
+			kind:   myerrors.KindUser,
+			id:     fixed(userId.Hex()),
+			load:   users.load(userId),
+			run:    run,
+		})
+	})
+}
+
+func (s userGroupStore) AddUser(ctx context.Context, id primitive.ObjectID, userId primitive.ObjectID) error {
+	return s.membership(ctx, ActionAddUser, id, userId, func(ctx context.Context) error {
+		return s.UserGroupStore.AddUser(ctx, id, userId)
+	})
+}
+
+func (s userGroupStore) RemoveUser(ctx context.Context, id primitive.ObjectID, userId primitive.ObjectID) error {
+	return s.membership(ctx, ActionRemoveUser, id, userId, func(ctx context.Context) error {
+		return s.UserGroupStore.RemoveUser(ctx, id, userId)
+	})
+}
+
+func (s userGroupStore) DeleteById(ctx context.Context, id primitive.ObjectID) error {
+	return s.change(ctx, ActionDeleteUserGroup, id, func(ctx context.Context) error {
+		return s.UserGroupStore.DeleteById(ctx, id)
+	})
+}
+
+// AddChild records the change of the parents of childId
+func (s userGroupStore) AddChild(ctx context.Context, id primitive.ObjectID, childId primitive.ObjectID) error {
+	return s.change(ctx, ActionAddChild, childId, func(ctx context.Context) error {
+		return s.UserGroupStore.AddChild(ctx, id, childId)
+	})
+}
+
+// RemoveChild records the change of the parents of childId
+func (s userGroupStore) RemoveChild(ctx context.Context, id primitive.ObjectID, childId primitive.ObjectID) error {
+	return s.change(ctx, ActionRemoveChild, childId, func(ctx context.Context) error {
+		return s.UserGroupStore.RemoveChild(ctx, id, childId)
+	})
+}
+
+func (s userGroupStore) SetRule(ctx context.Context, id primitive.ObjectID, rule string) error {
+	return s.change(ctx, ActionSetRule, id, func(ctx context.Context) error {
+		return s.UserGroupStore.SetRule(ctx, id, rule)
+	})
+}
+
+type accessStore struct {
+	access.AccessStore
+	b db.Backend
+}
+
+// change records the mutation run of the roles of the user for the user group
+func (s accessStore) change(ctx context.Context, action string, userId primitive.ObjectID, userGroupId primitive.ObjectID, run func(ctx context.Context) error) error {
+	return apply(ctx, s.b, mutation{
+		action: action,
+		kind:   myerrors.KindAccess,
+		id:     fixed(userId.Hex() + "/" + userGroupId.Hex()),
+		load: func(ctx context.Context) (any, error) {
+			accesses, err := s.AccessStore.ListRolesForUser(ctx, userId)
+			if err != nil {
+				return nil, err
+			}
+			for i := range accesses {
+				if accesses[i].UserGroupId == userGroupId.Hex() {
+					return &accesses[i], nil
+				}
+			}
+			return nil, nil
+		},
+		run: run,
+	})
+}
+
+func (s accessStore) Grant(ctx context.Context, userId primitive.ObjectID, userGroupId primitive.ObjectID, roles ...string) error {
+	return s.change(ctx, ActionGrant, userId, userGroupId, func(ctx context.Context) error {
+		return s.AccessStore.Grant(ctx, userId, userGroupId, roles...)
+	})
+}
+
+func (s accessStore) Revoke(ctx context.Context, userId primitive.ObjectID, userGroupId primitive.ObjectID, roles ...string) error {
+	return s.change(ctx, ActionRevoke, userId, userGroupId, func(ctx context.Context) error {
+		return s.AccessStore.Revoke(ctx, userId, userGroupId, roles...)
+	})
+}
+
+func (s accessStore) SetRoles(ctx context.Context, userId primitive.ObjectID, userGroupId primitive.ObjectID, roles []string) error {
+	return s.change(ctx, ActionSetRoles, userId, userGroupId, func(ctx context.Context) error {
+		return s.AccessStore.SetRoles(ctx, userId, userGroupId, roles)
+	})
+}
+
+type roleStore struct {
+	access.RoleStore
+	b db.Backend
+}
+
+// change records the mutation run of the role name
+func (s roleStore) change(ctx context.Context, action string, name string, run func(ctx context.Context) error) error {
+	return apply(ctx, s.b, mutation{
+		action: action,
+		kind:   myerrors.KindRole,
+		id:     fixed(name),
+		load: func(ctx context.Context) (any, error) {
+			return ignoreNotFound(s.RoleStore.Get(ctx, name))
+		},
+		run: run,
+	})
+}
+
+func (s roleStore) Create(ctx context.Context, r *access.Role) error {
+	return s.change(ctx, ActionCreateRole, r.Name, func(ctx context.Context) error {
+		return s.RoleStore.Create(ctx, r)
+	})
+}
+
+func (s roleStore) Update(ctx context.Context, r *access.Role) error {
+	return s.change(ctx, ActionUpdateRole, r.Name, func(ctx context.Context) error {
+		return s.RoleStore.Update(ctx, r)
+	})
+}
+
+func (s roleStore) Delete(ctx context.Context, name string) error {
+	return s.change(ctx, ActionDeleteRole, name, func(ctx context.Context) error {
+		return s.RoleStore.Delete(ctx, name)
+	})
+}
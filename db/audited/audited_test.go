@@ -0,0 +1,128 @@
+package audited_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/sr-codefreak/user-group/db"
+	"github.com/sr-codefreak/user-group/db/audited"
+	"github.com/sr-codefreak/user-group/db/mongodb"
+	"github.com/sr-codefreak/user-group/db/mongodb/audit"
+	"github.com/sr-codefreak/user-group/db/mongodb/user"
+	"github.com/sr-codefreak/user-group/db/mongodb/usergroup"
+	"github.com/sr-codefreak/user-group/db/sqlstore"
+	"github.com/sr-codefreak/user-group/events"
+	"github.com/sr-codefreak/user-group/myerrors"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	_ "modernc.org/sqlite"
+)
+
+var errAuditDown = errors.New("audit down")
+
+// failingAudit is a backend whose audit log rejects every record
+type failingAudit struct {
+	db.Backend
+}
+
+func (b failingAudit) Audit() audit.AuditStore {
+	return failingAuditStore{AuditStore: b.Backend.Audit()}
+}
+
+type failingAuditStore struct {
+	audit.AuditStore
+}
+
+func (failingAuditStore) Append(ctx context.Context, r *audit.Record) error {
+	return errAuditDown
+}
+
+func openSQLite(t *testing.T) *sqlstore.Backend {
+	b, err := sqlstore.Open(context.Background(), "sqlite", ":memory:")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { b.Close() })
+	return b
+}
+
+func TestRecordInTransaction(t *testing.T) {
+	ctx := audit.WithActor(context.Background(), "alice")
+	sql := openSQLite(t)
+	b := audited.Backend(events.Backend(sql))
+
+	u := &user.User{Name: "ann", Email: "ann@example.com"}
+	if err := b.Users().Create(ctx, u); err != nil {
+		t.Fatal(err)
+	}
+	records, err := sql.Audit().List(ctx, audit.Query{EntityId: u.ID.Hex()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 1 || records[0].Action != audited.ActionCreateUser || records[0].Actor != "alice" {
+		t.Errorf("records = %+v", records)
+	}
+	outbox, err := sql.Outbox().List(ctx, primitive.NilObjectID, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(outbox) != 1 || outbox[0].UserId != u.ID.Hex() {
+		t.Errorf("events = %+v", outbox)
+	}
+}
+
+func TestRollbackWithoutRecord(t *testing.T) {
+	ctx := context.Background()
+	sql := openSQLite(t)
+	b := audited.Backend(events.Backend(failingAudit{Backend: sql}))
+
+	u := &user.User{Name: "ann", Email: "ann@example.com"}
+	if err := b.Users().Create(ctx, u); !errors.Is(err, errAuditDown) {
+		t.Fatalf("Create: %v, want the audit error", err)
+	}
+	if _, err := sql.Users().GetById(ctx, u.ID.Hex()); !errors.Is(err, myerrors.ErrNotFound) {
+		t.Errorf("user whose record failed: %v, want not found", err)
+	}
+	users, _, err := sql.Users().List(ctx, mongodb.ListOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(users) != 0 {
+		t.Errorf("users = %+v", users)
+	}
+	outbox, err := sql.Outbox().List(ctx, primitive.NilObjectID, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(outbox) != 0 {
+		t.Errorf("events of the rolled back mutation = %+v", outbox)
+	}
+}
+
+func TestMembershipRecords(t *testing.T) {
+	ctx := context.Background()
+	sql := openSQLite(t)
+	b := audited.Backend(sql)
+
+	u := &user.User{Name: "ann", Email: "ann@example.com"}
+	if err := b.Users().Create(ctx, u); err != nil {
+		t.Fatal(err)
+	}
+	ug := &usergroup.UserGroup{Name: "eng"}
+	if err := b.UserGroups().Create(ctx, ug); err != nil {
+		t.Fatal(err)
+	}
+	if err := b.UserGroups().AddUser(ctx, ug.ID, u.ID); err != nil {
+		t.Fatal(err)
+	}
+	// both sides of the membership are recorded, newest first
+	for _, id := range []string{u.ID.Hex(), ug.ID.Hex()} {
+		records, err := sql.Audit().List(ctx, audit.Query{EntityId: id})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(records) != 2 || records[0].Action != audited.ActionAddUser {
+			t.Errorf("records of %s = %+v", id, records)
+		}
+	}
+}
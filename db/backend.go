@@ -0,0 +1,92 @@
+// Package db ties the stores of a storage implementation together
+// so services can be written against the store interfaces only.
+package db
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/sr-codefreak/user-group/db/mongodb"
+	"github.com/sr-codefreak/user-group/db/mongodb/access"
+	"github.com/sr-codefreak/user-group/db/mongodb/audit"
+	"github.com/sr-codefreak/user-group/db/mongodb/event"
+	"github.com/sr-codefreak/user-group/db/mongodb/user"
+	"github.com/sr-codefreak/user-group/db/mongodb/usergroup"
+	"github.com/sr-codefreak/user-group/db/mongodb/webhook"
+	"github.com/sr-codefreak/user-group/myerrors"
+)
+
+// Backend gives access to the stores of one storage implementation.
+// All stores of a backend share the same data, so membership changes made
+// through UserGroups are visible through Users and Access.
+type Backend interface {
+	Users() user.UserStore
+	UserGroups() usergroup.UserGroupStore
+	Access() access.AccessStore
+	Roles() access.RoleStore
+	Audit() audit.AuditStore
+	Outbox() event.OutboxStore
+	Webhooks() webhook.WebhookStore
+	// WithTransaction runs fn in a transaction, the stores join it when they
+	// are called with the ctx handed to fn. A transaction in ctx is joined.
+	WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+	// HasTenant reports whether the backend serves the data of tenant, see
+	// mongodb.WithTenant. The empty tenant is always served.
+	HasTenant(tenant string) bool
+}
+
+// CheckTenant fails with an InvalidArgument error unless b serves tenant
+func CheckTenant(b Backend, tenant string) error {
+	if b.HasTenant(tenant) {
+		return nil
+	}
+	return myerrors.Invalid(fmt.Errorf("unknown tenant %q", tenant))
+}
+
+type mongoBackend struct {
+	c *mongodb.Client
+}
+
+// Mongo returns the backend using the mongo client c,
+// mongodb.Default() is the client set up with mongodb.Connect
+func Mongo(c *mongodb.Client) Backend {
+	return mongoBackend{c: c}
+}
+
+func (b mongoBackend) Users() user.UserStore {
+	return user.NewStore(b.c)
+}
+
+func (b mongoBackend) UserGroups() usergroup.UserGroupStore {
+	return usergroup.NewStore(b.c)
+}
+
+func (b mongoBackend) Access() access.AccessStore {
+	return access.NewStore(b.c)
+}
+
+func (b mongoBackend) Roles() access.RoleStore {
+	return access.NewRoleStore(b.c)
+}
+
+func (b mongoBackend) Audit() audit.AuditStore {
+	return audit.NewStore(b.c)
+}
+
+func (b mongoBackend) Outbox() event.OutboxStore {
+	return event.NewStore(b.c)
+}
+
+func (b mongoBackend) Webhooks() webhook.WebhookStore {
+	return webhook.NewStore(b.c)
+}
+
+func (b mongoBackend) HasTenant(tenant string) bool {
+	return b.c.HasTenant(tenant)
+}
+
+func (b mongoBackend) WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return b.c.WithTransaction(ctx, func(ctx mongodb.SessionContext) error {
+		return fn(ctx)
+	})
+}
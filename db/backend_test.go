@@ -0,0 +1,349 @@
+package db_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/sr-codefreak/user-group/db"
+	"github.com/sr-codefreak/user-group/db/memory"
+	"github.com/sr-codefreak/user-group/db/mongodb"
+	"github.com/sr-codefreak/user-group/db/mongodb/access"
+	"github.com/sr-codefreak/user-group/db/mongodb/user"
+	"github.com/sr-codefreak/user-group/db/mongodb/usergroup"
+	"github.com/sr-codefreak/user-group/db/sqlstore"
+	"github.com/sr-codefreak/user-group/myerrors"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	_ "modernc.org/sqlite"
+)
+
+// eachBackend runs the test against every backend that needs no server, so
+// that they keep the same semantics
+func eachBackend(t *testing.T, test func(t *testing.T, ctx context.Context, b db.Backend)) {
+	backends := map[string]func(t *testing.T) db.Backend{
+		"memory": func(t *testing.T) db.Backend {
+			return memory.New()
+		},
+		"sqlite": func(t *testing.T) db.Backend {
+			b, err := sqlstore.Open(context.Background(), "sqlite", ":memory:")
+			if err != nil {
+				t.Fatal(err)
+			}
+			t.Cleanup(func() { b.Close() })
+			return b
+		},
+	}
+	for name, newBackend := range backends {
+		t.Run(name, func(t *testing.T) {
+			test(t, context.Background(), newBackend(t))
+		})
+	}
+}
+
+func createUser(t *testing.T, ctx context.Context, b db.Backend, name string) *user.User {
+	t.Helper()
+	u := &user.User{Name: name, Email: name + "@example.com"}
+	if err := b.Users().Create(ctx, u); err != nil {
+		t.Fatal(err)
+	}
+	return u
+}
+
+func createGroup(t *testing.T, ctx context.Context, b db.Backend, name string) *usergroup.UserGroup {
+	t.Helper()
+	ug := &usergroup.UserGroup{Name: name}
+	if err := b.UserGroups().Create(ctx, ug); err != nil {
+		t.Fatal(err)
+	}
+	return ug
+}
+
+func getGroup(t *testing.T, ctx context.Context, b db.Backend, id primitive.ObjectID) *usergroup.UserGroup {
+	t.Helper()
+	ug, err := b.UserGroups().GetById(ctx, id.Hex())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return ug
+}
+
+func TestUsers(t *testing.T) {
+	eachBackend(t, func(t *testing.T, ctx context.Context, b db.Backend) {
+		ann := createUser(t, ctx, b, "ann")
+		createUser(t, ctx, b, "bob")
+
+		update := &user.User{ID: ann.ID, Phone: "+49 30 1234", MetaData: map[string]any{"dept": "eng"}}
+		if err := b.Users().Update(ctx, update); err != nil {
+			t.Fatal(err)
+		}
+		got, err := b.Users().GetById(ctx, ann.ID.Hex())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got.Name != "ann" || got.Phone != "+49 30 1234" || got.MetaData["dept"] != "eng" {
+			t.Errorf("updated user = %+v", got)
+		}
+
+		users, next, err := b.Users().List(ctx, mongodb.ListOptions{
+			Sort:     []mongodb.SortField{{Key: mongodb.NameKey, Desc: true}},
+			PageSize: 1,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(users) != 1 || users[0].Name != "bob" || next == "" {
+			t.Fatalf("first page = %+v, next %q", users, next)
+		}
+		users, next, err = b.Users().List(ctx, mongodb.ListOptions{
+			Sort:      []mongodb.SortField{{Key: mongodb.NameKey, Desc: true}},
+			PageSize:  1,
+			PageToken: next,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(users) != 1 || users[0].Name != "ann" || next != "" {
+			t.Fatalf("last page = %+v, next %q", users, next)
+		}
+
+		if err := b.Users().Delete(ctx, ann.ID); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := b.Users().GetById(ctx, ann.ID.Hex()); !errors.Is(err, myerrors.ErrNotFound) {
+			t.Errorf("get deleted user: %v, want not found", err)
+		}
+		if err := b.Users().Delete(ctx, ann.ID); !errors.Is(err, myerrors.ErrNotFound) {
+			t.Errorf("delete deleted user: %v, want not found", err)
+		}
+	})
+}
+
+func TestMembership(t *testing.T) {
+	eachBackend(t, func(t *testing.T, ctx context.Context, b db.Backend) {
+		ann := createUser(t, ctx, b, "ann")
+		bob := createUser(t, ctx, b, "bob")
+		eng := createGroup(t, ctx, b, "eng")
+		ops := createGroup(t, ctx, b, "ops")
+		for _, m := range []struct{ group, user primitive.ObjectID }{{eng.ID, ann.ID}, {eng.ID, bob.ID}, {ops.ID, ann.ID}} {
+			if err := b.UserGroups().AddUser(ctx, m.group, m.user); err != nil {
+				t.Fatal(err)
+			}
+		}
+		u, err := b.Users().GetById(ctx, ann.ID.Hex())
+		if err != nil {
+			t.Fatal(err)
+		}
+		sort.Strings(u.UserGroupIds)
+		want := []string{eng.ID.Hex(), ops.ID.Hex()}
+		sort.Strings(want)
+		if !reflect.DeepEqual(u.UserGroupIds, want) {
+			t.Errorf("userGroupIds = %v, want %v", u.UserGroupIds, want)
+		}
+
+		if err := b.UserGroups().RemoveUser(ctx, eng.ID, bob.ID); err != nil {
+			t.Fatal(err)
+		}
+		if got := getGroup(t, ctx, b, eng.ID); !reflect.DeepEqual(got.UserIds, []string{ann.ID.Hex()}) {
+			t.Errorf("userIds after RemoveUser = %v", got.UserIds)
+		}
+
+		// the user groups of a deleted user no longer list it
+		if err := b.Users().Delete(ctx, ann.ID); err != nil {
+			t.Fatal(err)
+		}
+		for _, id := range []primitive.ObjectID{eng.ID, ops.ID} {
+			got := getGroup(t, ctx, b, id)
+			if len(got.UserIds) != 0 || len(got.Users) != 0 {
+				t.Errorf("group %s after deleting its member: userIds %v, users %v", got.Name, got.UserIds, got.Users)
+			}
+		}
+	})
+}
+
+func TestDeleteUserGroup(t *testing.T) {
+	eachBackend(t, func(t *testing.T, ctx context.Context, b db.Backend) {
+		ann := createUser(t, ctx, b, "ann")
+		eng := createGroup(t, ctx, b, "eng")
+		if err := b.UserGroups().AddUser(ctx, eng.ID, ann.ID); err != nil {
+			t.Fatal(err)
+		}
+		if err := b.Roles().Create(ctx, &access.Role{Name: "viewer", Permissions: []string{"groups:read"}}); err != nil {
+			t.Fatal(err)
+		}
+		if err := b.Access().Grant(ctx, ann.ID, eng.ID, "viewer"); err != nil {
+			t.Fatal(err)
+		}
+		if err := b.UserGroups().DeleteById(ctx, eng.ID); err != nil {
+			t.Fatal(err)
+		}
+		u, err := b.Users().GetById(ctx, ann.ID.Hex())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(u.UserGroupIds) != 0 || len(u.UsersGroups) != 0 {
+			t.Errorf("user after deleting its group: userGroupIds %v, usersGroups %v", u.UserGroupIds, u.UsersGroups)
+		}
+		accesses, err := b.Access().ListRolesForUser(ctx, ann.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(accesses) != 0 {
+			t.Errorf("access after deleting the group = %+v", accesses)
+		}
+	})
+}
+
+func TestNestedGroups(t *testing.T) {
+	eachBackend(t, func(t *testing.T, ctx context.Context, b db.Backend) {
+		ann := createUser(t, ctx, b, "ann")
+		org := createGroup(t, ctx, b, "org")
+		eng := createGroup(t, ctx, b, "eng")
+		team := createGroup(t, ctx, b, "team")
+		if err := b.UserGroups().AddUser(ctx, team.ID, ann.ID); err != nil {
+			t.Fatal(err)
+		}
+		if err := b.UserGroups().AddChild(ctx, org.ID, eng.ID); err != nil {
+			t.Fatal(err)
+		}
+		if err := b.UserGroups().AddChild(ctx, eng.ID, team.ID); err != nil {
+			t.Fatal(err)
+		}
+		for _, c := range []struct{ parent, child primitive.ObjectID }{{team.ID, org.ID}, {eng.ID, eng.ID}} {
+			if err := b.UserGroups().AddChild(ctx, c.parent, c.child); !errors.Is(err, myerrors.ErrGroupCycle) {
+				t.Errorf("AddChild making a cycle: %v, want ErrGroupCycle", err)
+			}
+		}
+
+		members, err := b.UserGroups().ResolveMembers(ctx, org.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(members) != 1 || members[0].ID != ann.ID.Hex() {
+			t.Errorf("transitive members of org = %+v", members)
+		}
+		groups, err := b.UserGroups().ResolveGroupsForUser(ctx, ann.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		names := []string{}
+		for _, g := range groups {
+			names = append(names, g.Name)
+		}
+		sort.Strings(names)
+		if !reflect.DeepEqual(names, []string{"eng", "org", "team"}) {
+			t.Errorf("groups of ann = %v", names)
+		}
+	})
+}
+
+func TestAccess(t *testing.T) {
+	eachBackend(t, func(t *testing.T, ctx context.Context, b db.Backend) {
+		ann := createUser(t, ctx, b, "ann")
+		eng := createGroup(t, ctx, b, "eng")
+		if err := b.Roles().Create(ctx, &access.Role{Name: "viewer", Permissions: []string{"groups:read"}}); err != nil {
+			t.Fatal(err)
+		}
+		if err := b.Roles().Create(ctx, &access.Role{Name: "editor", Permissions: []string{"groups:write"}, Inherits: []string{"viewer"}}); err != nil {
+			t.Fatal(err)
+		}
+		if err := b.Access().Grant(ctx, ann.ID, eng.ID, "undefined"); err == nil {
+			t.Error("granting an undefined role succeeded")
+		}
+		err := b.Access().Grant(ctx, primitive.NewObjectID(), eng.ID, "viewer")
+		if !errors.Is(err, myerrors.ErrNotFound) {
+			t.Errorf("granting to a missing user: %v", err)
+		}
+		err = b.Access().SetRoles(ctx, ann.ID, primitive.NewObjectID(), []string{"viewer"})
+		if !errors.Is(err, myerrors.ErrNotFound) {
+			t.Errorf("setting roles in a missing group: %v", err)
+		}
+		if err := b.Access().Grant(ctx, ann.ID, eng.ID, "editor"); err != nil {
+			t.Fatal(err)
+		}
+		permissions, err := b.Access().EffectivePermissions(ctx, ann.ID, eng.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		sort.Strings(permissions)
+		if !reflect.DeepEqual(permissions, []string{"groups:read", "groups:write"}) {
+			t.Errorf("permissions = %v", permissions)
+		}
+		if err := b.Access().Revoke(ctx, ann.ID, eng.ID, "editor"); err != nil {
+			t.Fatal(err)
+		}
+		ok, err := b.Access().HasRole(ctx, ann.ID, eng.ID, "editor")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if ok {
+			t.Error("ann still has the revoked role")
+		}
+	})
+}
+
+func TestListDynamic(t *testing.T) {
+	eachBackend(t, func(t *testing.T, ctx context.Context, b db.Backend) {
+		createGroup(t, ctx, b, "static")
+		sales := &usergroup.UserGroup{Name: "sales", Rule: `metaData.dept == "sales"`}
+		if err := b.UserGroups().Create(ctx, sales); err != nil {
+			t.Fatal(err)
+		}
+		groups, _, err := b.UserGroups().List(ctx, mongodb.ListOptions{Filter: mongodb.ListFilter{Dynamic: true}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(groups) != 1 || groups[0].ID != sales.ID {
+			t.Errorf("dynamic user groups = %+v", groups)
+		}
+		if _, _, err := b.Users().List(ctx, mongodb.ListOptions{Filter: mongodb.ListFilter{Dynamic: true}}); myerrors.CodeOf(err) != myerrors.InvalidArgument {
+			t.Errorf("listing dynamic users: %v, want an invalid argument", err)
+		}
+	})
+}
+
+func TestListNameFold(t *testing.T) {
+	eachBackend(t, func(t *testing.T, ctx context.Context, b db.Backend) {
+		ann := createUser(t, ctx, b, "Ann")
+		createUser(t, ctx, b, "anna")
+		users, _, err := b.Users().List(ctx, mongodb.ListOptions{Filter: mongodb.ListFilter{NameFold: "ANN"}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(users) != 1 || users[0].ID != ann.ID {
+			t.Errorf("users named ANN ignoring case = %+v", users)
+		}
+		opts := mongodb.ListOptions{Filter: mongodb.ListFilter{Name: "A", NameFold: "ann"}}
+		if _, _, err := b.Users().List(ctx, opts); myerrors.CodeOf(err) != myerrors.InvalidArgument {
+			t.Errorf("filtering by prefix and ignoring case: %v, want an invalid argument", err)
+		}
+	})
+}
+
+func TestSnapshots(t *testing.T) {
+	eachBackend(t, func(t *testing.T, ctx context.Context, b db.Backend) {
+		ann := createUser(t, ctx, b, "ann")
+		eng := createGroup(t, ctx, b, "eng")
+		if err := b.UserGroups().AddUser(ctx, eng.ID, ann.ID); err != nil {
+			t.Fatal(err)
+		}
+		if err := b.UserGroups().UpdateName(ctx, eng.ID, "engineering"); err != nil {
+			t.Fatal(err)
+		}
+		if err := b.Users().Update(ctx, &user.User{ID: ann.ID, Name: "annie", Phone: "555"}); err != nil {
+			t.Fatal(err)
+		}
+		users := getGroup(t, ctx, b, eng.ID).Users
+		if len(users) != 1 || users[0].Name != "annie" || users[0].Email != "ann@example.com" || users[0].Phone != "555" {
+			t.Errorf("members of eng = %+v", users)
+		}
+		u, err := b.Users().GetById(ctx, ann.ID.Hex())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(u.UsersGroups) != 1 || u.UsersGroups[0].Name != "engineering" {
+			t.Errorf("user groups of ann = %+v", u.UsersGroups)
+		}
+	})
+}
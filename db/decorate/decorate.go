@@ -0,0 +1,19 @@
+// Package decorate wraps a backend with the decorators the binaries serve it
+// through. It is not part of package db because the decorators import db.
+package decorate
+
+import (
+	"github.com/sr-codefreak/user-group/db"
+	"github.com/sr-codefreak/user-group/db/audited"
+	"github.com/sr-codefreak/user-group/db/dynamic"
+	"github.com/sr-codefreak/user-group/events"
+)
+
+// Backend returns b reconciling dynamic user groups, auditing and publishing
+// every mutation. The decorators run from the outside in, so the writes of
+// the reconciliations of dynamic user groups are audited and published like
+// the others, and the audit record and the events of a mutation are appended
+// in its transaction.
+func Backend(b db.Backend) db.Backend {
+	return dynamic.Backend(audited.Backend(events.Backend(b)))
+}
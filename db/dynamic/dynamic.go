@@ -0,0 +1,284 @@
+// Package dynamic keeps the members of dynamic user groups, the user groups
+// with a rule of package rule, in line with the users matching the rule.
+//
+// Wrap a backend with Backend to reconcile on every change of a user or of
+// a rule, or call the Reconciler after changing users by other means.
+//
+// A change of a user lists the dynamic user groups only, through the index
+// of their rule, and evaluates their rules against that user. A change of a
+// rule or a new dynamic user group reads every user, which Backend does in
+// the transaction of the change: keep rule changes to administrative paths.
+package dynamic
+
+import (
+	"context"
+	"errors"
+
+	"github.com/sr-codefreak/user-group/db"
+	"github.com/sr-codefreak/user-group/db/mongodb"
+	"github.com/sr-codefreak/user-group/db/mongodb/user"
+	"github.com/sr-codefreak/user-group/db/mongodb/usergroup"
+	"github.com/sr-codefreak/user-group/rule"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Reconciler adds and removes the members of the dynamic user groups of a backend
+type Reconciler struct {
+	backend db.Backend
+}
+
+// New returns the reconciler of backend
+func New(backend db.Backend) *Reconciler {
+	return &Reconciler{backend: backend}
+}
+
+// ReconcileUser adds the user to the dynamic user groups whose rule it
+// matches and removes it from the other dynamic user groups
+func (r *Reconciler) ReconcileUser(ctx context.Context, userId primitive.ObjectID) error {
+	return r.ReconcileUsers(ctx, []primitive.ObjectID{userId})
+}
+
+// ReconcileUsers reconciles the users like ReconcileUser. It reads the
+// users and lists the dynamic user groups once for all of them.
+func (r *Reconciler) ReconcileUsers(ctx context.Context, userIds []primitive.ObjectID) error {
+	if len(userIds) == 0 {
+		return nil
+	}
+	users := make([]*user.User, len(userIds))
+	for i, id := range userIds {
+		u, err := r.backend.Users().GetById(ctx, id.Hex())
+		if err != nil {
+			return err
+		}
+		users[i] = u
+	}
+	store := r.backend.UserGroups()
+	opts := mongodb.ListOptions{PageSize: mongodb.MaxPageSize, Filter: mongodb.ListFilter{Dynamic: true}}
+	for {
+		groups, next, err := store.List(ctx, opts)
+		if err != nil {
+			return err
+		}
+		for _, ug := range groups {
+			rl, err := rule.Parse(ug.Rule)
+			if err != nil {
+				return err
+			}
+			for _, u := range users {
+				member := contains(ug.UserIds, u.ID.Hex())
+				switch match := rl.Match(u); {
+				case match && !member:
+					err = store.AddUser(ctx, ug.ID, u.ID)
+				case !match && member:
+					err = store.RemoveUser(ctx, ug.ID, u.ID)
+				}
+				if err != nil {
+					return err
+				}
+			}
+		}
+		if next == "" {
+			return nil
+		}
+		opts.PageToken = next
+	}
+}
+
+// ReconcileGroup sets the members of the user group to the users matching
+// its rule, reading every user. Static user groups are left as they are.
+func (r *Reconciler) ReconcileGroup(ctx context.Context, id primitive.ObjectID) error {
+	store := r.backend.UserGroups()
+	ug, err := store.GetById(ctx, id.Hex())
+	if err != nil || ug.Rule == "" {
+		return err
+	}
+	rl, err := rule.Parse(ug.Rule)
+	if err != nil {
+		return err
+	}
+
+	matched := map[string]bool{}
+	err = eachUser(ctx, r.backend.Users(), func(u *user.User) error {
+		if !rl.Match(u) {
+			return nil
+		}
+		matched[u.ID.Hex()] = true
+		if contains(ug.UserIds, u.ID.Hex()) {
+			return nil
+		}
+		return store.AddUser(ctx, id, u.ID)
+	})
+	if err != nil {
+		return err
+	}
+	for _, userId := range ug.UserIds {
+		if matched[userId] {
+			continue
+		}
+		oid, err := primitive.ObjectIDFromHex(userId)
+		if err != nil {
+			return err
+		}
+		if err := store.RemoveUser(ctx, id, oid); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Preview returns up to limit users matching the rule src without changing
+// any user group, limit defaults to mongodb.DefaultPageSize
+func Preview(ctx context.Context, users user.UserStore, src string, limit int) ([]user.User, error) {
+	rl, err := rule.Parse(src)
+	if err != nil {
+		return nil, err
+	}
+	if limit <= 0 {
+		limit = mongodb.DefaultPageSize
+	}
+	matched := []user.User{}
+	err = eachUser(ctx, users, func(u *user.User) error {
+		if rl.Match(u) {
+			matched = append(matched, *u)
+		}
+		if len(matched) >= limit {
+			return errStop
+		}
+		return nil
+	})
+	if err != nil && err != errStop {
+		return nil, err
+	}
+	return matched, nil
+}
+
+// errStop ends eachUser early without error
+var errStop = errors.New("stop")
+
+// eachUser calls fn with every user of the store, page by page
+func eachUser(ctx context.Context, users user.UserStore, fn func(u *user.User) error) error {
+	opts := mongodb.ListOptions{PageSize: mongodb.MaxPageSize}
+	for {
+		page, next, err := users.List(ctx, opts)
+		if err != nil {
+			return err
+		}
+		for i := range page {
+			if err := fn(&page[i]); err != nil {
+				return err
+			}
+		}
+		if next == "" {
+			return nil
+		}
+		opts.PageToken = next
+	}
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+type backend struct {
+	db.Backend
+	r *Reconciler
+}
+
+// Backend wraps b so creating or updating a user reconciles the user and
+// creating a dynamic user group or setting a rule reconciles the user group.
+// The write and its reconciliation run in one transaction of b, a write
+// whose reconciliation fails is rolled back. The memory backend has no
+// transactions and keeps it.
+func Backend(b db.Backend) db.Backend {
+	return backend{Backend: b, r: New(b)}
+}
+
+func (b backend) Users() user.UserStore {
+	return userStore{UserStore: b.Backend.Users(), r: b.r}
+}
+
+func (b backend) UserGroups() usergroup.UserGroupStore {
+	return userGroupStore{UserGroupStore: b.Backend.UserGroups(), r: b.r}
+}
+
+// reconciled runs the write and then the reconciliation in one transaction
+func (r *Reconciler) reconciled(ctx context.Context, write func(ctx context.Context) error, reconcile func(ctx context.Context) error) error {
+	return r.backend.WithTransaction(ctx, func(ctx context.Context) error {
+		if err := write(ctx); err != nil {
+			return err
+		}
+		return reconcile(ctx)
+	})
+}
+
+type userStore struct {
+	user.UserStore
+	r *Reconciler
+}
+
+func (s userStore) Create(ctx context.Context, u *user.User) error {
+	return s.r.reconciled(ctx, func(ctx context.Context) error {
+		return s.UserStore.Create(ctx, u)
+	}, func(ctx context.Context) error {
+		return s.r.ReconcileUser(ctx, u.ID)
+	})
+}
+
+func (s userStore) CreateMany(ctx context.Context, users []*user.User) error {
+	return s.r.reconciled(ctx, func(ctx context.Context) error {
+		return s.UserStore.CreateMany(ctx, users)
+	}, func(ctx context.Context) error {
+		ids := make([]primitive.ObjectID, len(users))
+		for i, u := range users {
+			ids[i] = u.ID
+		}
+		return s.r.ReconcileUsers(ctx, ids)
+	})
+}
+
+func (s userStore) Update(ctx context.Context, u *user.User) error {
+	return s.r.reconciled(ctx, func(ctx context.Context) error {
+		return s.UserStore.Update(ctx, u)
+	}, func(ctx context.Context) error {
+		return s.r.ReconcileUser(ctx, u.ID)
+	})
+}
+
+type userGroupStore struct {
+	usergroup.UserGroupStore
+	r *Reconciler
+}
+
+func (s userGroupStore) Create(ctx context.Context, group *usergroup.UserGroup) error {
+	return s.r.reconciled(ctx, func(ctx context.Context) error {
+		return s.UserGroupStore.Create(ctx, group)
+	}, func(ctx context.Context) error {
+		return s.r.ReconcileGroup(ctx, group.ID)
+	})
+}
+
+func (s userGroupStore) CreateMany(ctx context.Context, groups []*usergroup.UserGroup) error {
+	return s.r.reconciled(ctx, func(ctx context.Context) error {
+		return s.UserGroupStore.CreateMany(ctx, groups)
+	}, func(ctx context.Context) error {
+		for _, group := range groups {
+			if err := s.r.ReconcileGroup(ctx, group.ID); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (s userGroupStore) SetRule(ctx context.Context, id primitive.ObjectID, rule string) error {
+	return s.r.reconciled(ctx, func(ctx context.Context) error {
+		return s.UserGroupStore.SetRule(ctx, id, rule)
+	}, func(ctx context.Context) error {
+		return s.r.ReconcileGroup(ctx, id)
+	})
+}
@@ -0,0 +1,123 @@
+package dynamic_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/sr-codefreak/user-group/db"
+	"github.com/sr-codefreak/user-group/db/dynamic"
+	"github.com/sr-codefreak/user-group/db/memory"
+	"github.com/sr-codefreak/user-group/db/mongodb"
+	"github.com/sr-codefreak/user-group/db/mongodb/user"
+	"github.com/sr-codefreak/user-group/db/mongodb/usergroup"
+	"github.com/sr-codefreak/user-group/db/sqlstore"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	_ "modernc.org/sqlite"
+)
+
+var errAddUser = errors.New("add user failed")
+
+// failingAddUser is a backend whose user groups reject new members
+type failingAddUser struct {
+	db.Backend
+}
+
+func (b failingAddUser) UserGroups() usergroup.UserGroupStore {
+	return failingAddUserStore{UserGroupStore: b.Backend.UserGroups()}
+}
+
+type failingAddUserStore struct {
+	usergroup.UserGroupStore
+}
+
+func (failingAddUserStore) AddUser(ctx context.Context, id primitive.ObjectID, userId primitive.ObjectID) error {
+	return errAddUser
+}
+
+func members(t *testing.T, b db.Backend, ug *usergroup.UserGroup) []string {
+	t.Helper()
+	got, err := b.UserGroups().GetById(context.Background(), ug.ID.Hex())
+	if err != nil {
+		t.Fatal(err)
+	}
+	names := []string{}
+	for _, u := range got.Users {
+		names = append(names, u.Name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func TestReconcile(t *testing.T) {
+	ctx := context.Background()
+	b := dynamic.Backend(memory.New())
+	static := &usergroup.UserGroup{Name: "static"}
+	sales := &usergroup.UserGroup{Name: "sales", Rule: `metaData.dept == "sales"`}
+	for _, ug := range []*usergroup.UserGroup{static, sales} {
+		if err := b.UserGroups().Create(ctx, ug); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	ann := &user.User{Name: "ann", Email: "ann@example.com", MetaData: map[string]any{"dept": "sales"}}
+	bob := &user.User{Name: "bob", Email: "bob@example.com", MetaData: map[string]any{"dept": "eng"}}
+	cid := &user.User{Name: "cid", Email: "cid@example.com", MetaData: map[string]any{"dept": "sales"}}
+	if err := b.Users().CreateMany(ctx, []*user.User{ann, bob, cid}); err != nil {
+		t.Fatal(err)
+	}
+	if got := members(t, b, sales); !reflect.DeepEqual(got, []string{"ann", "cid"}) {
+		t.Errorf("members of sales = %v", got)
+	}
+
+	// an update moves the user between dynamic user groups
+	if err := b.Users().Update(ctx, &user.User{ID: bob.ID, MetaData: map[string]any{"dept": "sales"}}); err != nil {
+		t.Fatal(err)
+	}
+	if err := b.Users().Update(ctx, &user.User{ID: ann.ID, MetaData: map[string]any{"dept": "eng"}}); err != nil {
+		t.Fatal(err)
+	}
+	if got := members(t, b, sales); !reflect.DeepEqual(got, []string{"bob", "cid"}) {
+		t.Errorf("members of sales after the updates = %v", got)
+	}
+
+	// a new rule replaces the members
+	if err := b.UserGroups().SetRule(ctx, sales.ID, `metaData.dept == "eng"`); err != nil {
+		t.Fatal(err)
+	}
+	if got := members(t, b, sales); !reflect.DeepEqual(got, []string{"ann"}) {
+		t.Errorf("members of sales after the rule change = %v", got)
+	}
+	if got := members(t, b, static); len(got) != 0 {
+		t.Errorf("members of the static user group = %v", got)
+	}
+}
+
+func TestRollback(t *testing.T) {
+	ctx := context.Background()
+	sql, err := sqlstore.Open(ctx, "sqlite", ":memory:")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { sql.Close() })
+	sales := &usergroup.UserGroup{Name: "sales", Rule: `metaData.dept == "sales"`}
+	if err := sql.UserGroups().Create(ctx, sales); err != nil {
+		t.Fatal(err)
+	}
+	b := dynamic.Backend(failingAddUser{Backend: sql})
+
+	// the users whose reconciliation fails are not created
+	ann := &user.User{Name: "ann", Email: "ann@example.com", MetaData: map[string]any{"dept": "sales"}}
+	if err := b.Users().CreateMany(ctx, []*user.User{ann}); !errors.Is(err, errAddUser) {
+		t.Fatalf("CreateMany: %v, want the reconciliation error", err)
+	}
+	users, _, err := sql.Users().List(ctx, mongodb.ListOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(users) != 0 {
+		t.Errorf("users of the rolled back batch = %+v", users)
+	}
+}
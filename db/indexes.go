@@ -0,0 +1,36 @@
+package db
+
+import (
+	"context"
+	"errors"
+
+	"github.com/sr-codefreak/user-group/db/mongodb"
+	"github.com/sr-codefreak/user-group/db/mongodb/access"
+	"github.com/sr-codefreak/user-group/db/mongodb/audit"
+	"github.com/sr-codefreak/user-group/db/mongodb/event"
+	"github.com/sr-codefreak/user-group/db/mongodb/user"
+	"github.com/sr-codefreak/user-group/db/mongodb/usergroup"
+	"github.com/sr-codefreak/user-group/db/mongodb/webhook"
+)
+
+// EnsureIndexes creates the indexes of the mongo stores using the client c.
+// It goes on when a store fails and returns the errors of all of them.
+func EnsureIndexes(ctx context.Context, c *mongodb.Client) error {
+	var errs []error
+	for _, s := range []interface {
+		EnsureIndexes(ctx context.Context) error
+	}{
+		user.NewStore(c),
+		usergroup.NewStore(c),
+		access.NewStore(c),
+		access.NewRoleStore(c),
+		audit.NewStore(c),
+		event.NewStore(c),
+		webhook.NewStore(c),
+	} {
+		if err := s.EnsureIndexes(ctx); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,153 @@
+package memory
+
+import (
+	"context"
+	"sort"
+
+	"github.com/sr-codefreak/user-group/db/mongodb/access"
+	"github.com/sr-codefreak/user-group/myerrors"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type accessStore struct {
+	b *Backend
+}
+
+// idFor identifies the access of the user for the user group in errors
+func idFor(userId primitive.ObjectID, userGroupId primitive.ObjectID) string {
+	return userId.Hex() + "/" + userGroupId.Hex()
+}
+
+func keyFor(userId primitive.ObjectID, userGroupId primitive.ObjectID) accessKey {
+	return accessKey{userId: userId.Hex(), userGroupId: userGroupId.Hex()}
+}
+
+// checkSubjects fails with a NotFound error unless the user and the user group exist
+func (b *Backend) checkSubjects(userId primitive.ObjectID, userGroupId primitive.ObjectID) error {
+	if _, ok := b.users[userId]; !ok {
+		return myerrors.Wrap(myerrors.ErrGetUserById, myerrors.KindUser, userId.Hex(), myerrors.ErrNotFound)
+	}
+	if _, ok := b.groups[userGroupId]; !ok {
+		return myerrors.Wrap(myerrors.ErrGetUserGroupById, myerrors.KindUserGroup, userGroupId.Hex(), myerrors.ErrNotFound)
+	}
+	return nil
+}
+
+// EnsureIndexes is a no-op, the map key already keeps (userId, userGroupId) unique
+func (accessStore) EnsureIndexes(ctx context.Context) error {
+	return nil
+}
+
+func (s accessStore) Grant(ctx context.Context, userId primitive.ObjectID, userGroupId primitive.ObjectID, roles ...string) error {
+	if len(roles) == 0 {
+		return nil
+	}
+	s.b.Lock()
+	defer s.b.Unlock()
+	if err := s.b.checkSubjects(userId, userGroupId); err != nil {
+		return myerrors.Wrap(myerrors.ErrGrantingAccess, myerrors.KindAccess, idFor(userId, userGroupId), err)
+	}
+	if err := s.b.checkDefined(roles); err != nil {
+		return myerrors.Wrap(myerrors.ErrGrantingAccess, myerrors.KindAccess, idFor(userId, userGroupId), err)
+	}
+	k := keyFor(userId, userGroupId)
+	a, ok := s.b.access[k]
+	if !ok {
+		a = &access.Access{
+			ID:          primitive.NewObjectID(),
+			UserId:      k.userId,
+			UserGroupId: k.userGroupId,
+		}
+		s.b.access[k] = a
+	}
+	for _, r := range roles {
+		if !contains(a.Roles, r) {
+			a.Roles = append(a.Roles, r)
+		}
+	}
+	return nil
+}
+
+func (s accessStore) Revoke(ctx context.Context, userId primitive.ObjectID, userGroupId primitive.ObjectID, roles ...string) error {
+	s.b.Lock()
+	defer s.b.Unlock()
+	k := keyFor(userId, userGroupId)
+	if len(roles) == 0 {
+		delete(s.b.access, k)
+		return nil
+	}
+	if a, ok := s.b.access[k]; ok {
+		for _, r := range roles {
+			a.Roles = remove(a.Roles, r)
+		}
+	}
+	return nil
+}
+
+func (s accessStore) SetRoles(ctx context.Context, userId primitive.ObjectID, userGroupId primitive.ObjectID, roles []string) error {
+	s.b.Lock()
+	defer s.b.Unlock()
+	if err := s.b.checkSubjects(userId, userGroupId); err != nil {
+		return myerrors.Wrap(myerrors.ErrSettingRoles, myerrors.KindAccess, idFor(userId, userGroupId), err)
+	}
+	if err := s.b.checkDefined(roles); err != nil {
+		return myerrors.Wrap(myerrors.ErrSettingRoles, myerrors.KindAccess, idFor(userId, userGroupId), err)
+	}
+	k := keyFor(userId, userGroupId)
+	a, ok := s.b.access[k]
+	if !ok {
+		a = &access.Access{
+			ID:          primitive.NewObjectID(),
+			UserId:      k.userId,
+			UserGroupId: k.userGroupId,
+		}
+		s.b.access[k] = a
+	}
+	a.Roles = append([]string{}, roles...)
+	return nil
+}
+
+func (s accessStore) ListRolesForUser(ctx context.Context, userId primitive.ObjectID) ([]access.Access, error) {
+	s.b.RLock()
+	defer s.b.RUnlock()
+	accesses := []access.Access{}
+	for k, a := range s.b.access {
+		if k.userId == userId.Hex() {
+			accesses = append(accesses, copyAccess(a))
+		}
+	}
+	sort.Slice(accesses, func(i, j int) bool {
+		return accesses[i].UserGroupId < accesses[j].UserGroupId
+	})
+	return accesses, nil
+}
+
+func (s accessStore) ListUsersWithRoleInGroup(ctx context.Context, userGroupId primitive.ObjectID, role string) ([]string, error) {
+	s.b.RLock()
+	defer s.b.RUnlock()
+	ids := []string{}
+	for k, a := range s.b.access {
+		if k.userGroupId == userGroupId.Hex() && contains(a.Roles, role) {
+			ids = append(ids, k.userId)
+		}
+	}
+	sort.Strings(ids)
+	return ids, nil
+}
+
+func (s accessStore) HasRole(ctx context.Context, userId primitive.ObjectID, userGroupId primitive.ObjectID, role string) (bool, error) {
+	s.b.RLock()
+	defer s.b.RUnlock()
+	a, ok := s.b.access[keyFor(userId, userGroupId)]
+	return ok && contains(a.Roles, role), nil
+}
+
+func (s accessStore) EffectivePermissions(ctx context.Context, userId primitive.ObjectID, userGroupId primitive.ObjectID) ([]string, error) {
+	s.b.RLock()
+	defer s.b.RUnlock()
+	a, ok := s.b.access[keyFor(userId, userGroupId)]
+	if !ok {
+		return []string{}, nil
+	}
+	return access.UnionPermissions(s.b.inherited(a.Roles)), nil
+}
@@ -0,0 +1,65 @@
+package memory
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/sr-codefreak/user-group/db/mongodb/audit"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type auditStore struct {
+	b *Backend
+}
+
+// copyRecord deep copies the before and after documents through JSON
+func copyRecord(r *audit.Record) audit.Record {
+	c := *r
+	for _, m := range []*map[string]any{&c.Before, &c.After} {
+		if *m == nil {
+			continue
+		}
+		data, _ := json.Marshal(*m)
+		*m = nil
+		_ = json.Unmarshal(data, m)
+	}
+	return c
+}
+
+// EnsureIndexes is a no-op, records are scanned
+func (auditStore) EnsureIndexes(ctx context.Context) error {
+	return nil
+}
+
+func (s auditStore) Append(ctx context.Context, r *audit.Record) error {
+	s.b.Lock()
+	defer s.b.Unlock()
+	if r.ID.IsZero() {
+		r.ID = primitive.NewObjectID()
+	}
+	if r.Time.IsZero() {
+		r.Time = time.Now().UTC()
+	}
+	s.b.audit = append(s.b.audit, copyRecord(r))
+	return nil
+}
+
+func (s auditStore) List(ctx context.Context, q audit.Query) ([]audit.Record, error) {
+	q = q.Normalize()
+	s.b.RLock()
+	defer s.b.RUnlock()
+	records := []audit.Record{}
+	// records are appended in time order, walk them newest first
+	for i := len(s.b.audit) - 1; i >= 0 && len(records) < q.Limit; i-- {
+		r := &s.b.audit[i]
+		if q.Kind != "" && r.Kind != q.Kind ||
+			q.EntityId != "" && r.EntityId != q.EntityId ||
+			!q.From.IsZero() && r.Time.Before(q.From) ||
+			!q.To.IsZero() && !r.Time.Before(q.To) {
+			continue
+		}
+		records = append(records, copyRecord(r))
+	}
+	return records, nil
+}
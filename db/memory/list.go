@@ -0,0 +1,79 @@
+package memory
+
+import (
+	"bytes"
+	"encoding/json"
+	"sort"
+	"strings"
+
+	"github.com/sr-codefreak/user-group/db/mongodb"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// position returns the values of the sort keys and the id of an entry
+type position[T any] func(T) ([]string, primitive.ObjectID)
+
+// listPage returns the page of opts of the entries, ordered like the mongodb stores
+func listPage[T any](opts mongodb.ListOptions, entries []T, pos position[T]) ([]T, string, error) {
+	after, err := opts.After()
+	if err != nil {
+		return nil, "", err
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		vi, idi := pos(entries[i])
+		vj, idj := pos(entries[j])
+		return compare(opts.Sort, vi, idi, vj, idj) < 0
+	})
+	if after != nil {
+		id, _ := primitive.ObjectIDFromHex(after.ID)
+		entries = entries[sort.Search(len(entries), func(i int) bool {
+			v, oid := pos(entries[i])
+			return compare(opts.Sort, v, oid, after.Values, id) > 0
+		}):]
+	}
+	if len(entries) > opts.Limit()+1 {
+		entries = entries[:opts.Limit()+1]
+	}
+	page, next := mongodb.Page(opts, entries, pos)
+	return page, next, nil
+}
+
+// compare orders the positions (a, aId) and (b, bId) by the sort keys and then by id
+func compare(sortFields []mongodb.SortField, a []string, aId primitive.ObjectID, b []string, bId primitive.ObjectID) int {
+	for i, f := range sortFields {
+		if c := strings.Compare(a[i], b[i]); c != 0 {
+			if f.Desc {
+				return -c
+			}
+			return c
+		}
+	}
+	return bytes.Compare(aId[:], bId[:])
+}
+
+// matches reports whether an entry with the values of the filterable keys
+// and metaData is selected by f
+func matches(f mongodb.ListFilter, values map[string]string, metaData map[string]any) bool {
+	for key, prefix := range map[string]string{mongodb.NameKey: f.Name, mongodb.EmailKey: f.Email, mongodb.PhoneKey: f.Phone} {
+		if !strings.HasPrefix(values[key], prefix) {
+			return false
+		}
+	}
+	if f.NameFold != "" && !strings.EqualFold(values[mongodb.NameKey], f.NameFold) {
+		return false
+	}
+	for key, want := range f.MetaData {
+		got, ok := metaData[key]
+		if !ok || !sameJSON(got, want) {
+			return false
+		}
+	}
+	return true
+}
+
+// sameJSON compares values by their JSON encoding, so 1 and 1.0 are equal
+func sameJSON(a any, b any) bool {
+	ja, errA := json.Marshal(a)
+	jb, errB := json.Marshal(b)
+	return errA == nil && errB == nil && bytes.Equal(ja, jb)
+}
@@ -0,0 +1,146 @@
+// Package memory implements the user, user group, access, role, audit, outbox
+// and webhook stores in memory. It mirrors the semantics of the mongodb stores
+// and is meant for tests and local development without a running MongoDB.
+package memory
+
+import (
+	"context"
+	"sync"
+
+	"github.com/sr-codefreak/user-group/db/mongodb/access"
+	"github.com/sr-codefreak/user-group/db/mongodb/audit"
+	"github.com/sr-codefreak/user-group/db/mongodb/event"
+	"github.com/sr-codefreak/user-group/db/mongodb/user"
+	"github.com/sr-codefreak/user-group/db/mongodb/usergroup"
+	"github.com/sr-codefreak/user-group/db/mongodb/webhook"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type accessKey struct {
+	userId      string
+	userGroupId string
+}
+
+// Backend holds the data of all stores behind a single lock
+type Backend struct {
+	sync.RWMutex
+	users      map[primitive.ObjectID]*user.User
+	groups     map[primitive.ObjectID]*usergroup.UserGroup
+	access     map[accessKey]*access.Access
+	roles      map[string]*access.Role
+	audit      []audit.Record
+	outbox     []event.Event
+	webhooks   map[primitive.ObjectID]*webhook.Subscription
+	deliveries map[primitive.ObjectID]*webhook.Delivery
+}
+
+// New returns an empty in-memory backend
+func New() *Backend {
+	return &Backend{
+		users:      map[primitive.ObjectID]*user.User{},
+		groups:     map[primitive.ObjectID]*usergroup.UserGroup{},
+		access:     map[accessKey]*access.Access{},
+		roles:      map[string]*access.Role{},
+		webhooks:   map[primitive.ObjectID]*webhook.Subscription{},
+		deliveries: map[primitive.ObjectID]*webhook.Delivery{},
+	}
+}
+
+func (b *Backend) Users() user.UserStore {
+	return userStore{b}
+}
+
+func (b *Backend) UserGroups() usergroup.UserGroupStore {
+	return userGroupStore{b}
+}
+
+func (b *Backend) Access() access.AccessStore {
+	return accessStore{b}
+}
+
+func (b *Backend) Roles() access.RoleStore {
+	return roleStore{b}
+}
+
+func (b *Backend) Audit() audit.AuditStore {
+	return auditStore{b}
+}
+
+func (b *Backend) Outbox() event.OutboxStore {
+	return outboxStore{b}
+}
+
+func (b *Backend) Webhooks() webhook.WebhookStore {
+	return webhookStore{b}
+}
+
+// HasTenant reports whether tenant is empty, the backend has no tenants
+func (b *Backend) HasTenant(tenant string) bool {
+	return tenant == ""
+}
+
+// WithTransaction runs fn. The stores take the lock themselves, so the
+// writes of fn are not atomic and the ones before a failure are kept.
+func (b *Backend) WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+func copyMetaData(m map[string]any) map[string]any {
+	if m == nil {
+		return nil
+	}
+	c := make(map[string]any, len(m))
+	for k, v := range m {
+		c[k] = v
+	}
+	return c
+}
+
+func copyUser(u *user.User) *user.User {
+	c := *u
+	c.MetaData = copyMetaData(u.MetaData)
+	c.UsersGroups = append([]user.UserGroupRef(nil), u.UsersGroups...)
+	c.UserGroupIds = append([]string(nil), u.UserGroupIds...)
+	return &c
+}
+
+func copyUserGroup(ug *usergroup.UserGroup) *usergroup.UserGroup {
+	c := *ug
+	c.MetaData = copyMetaData(ug.MetaData)
+	c.Users = append([]usergroup.UserRef(nil), ug.Users...)
+	c.UserIds = append([]string(nil), ug.UserIds...)
+	c.ParentIds = append([]primitive.ObjectID(nil), ug.ParentIds...)
+	return &c
+}
+
+func copyAccess(a *access.Access) access.Access {
+	c := *a
+	c.Roles = append([]string{}, a.Roles...)
+	return c
+}
+
+func copyRole(r *access.Role) access.Role {
+	c := *r
+	c.Permissions = append([]string{}, r.Permissions...)
+	c.Inherits = append([]string(nil), r.Inherits...)
+	return c
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func remove(values []string, value string) []string {
+	out := values[:0]
+	for _, v := range values {
+		if v != value {
+			out = append(out, v)
+		}
+	}
+	return out
+}
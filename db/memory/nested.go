@@ -0,0 +1,137 @@
+package memory
+
+import (
+	"context"
+
+	"github.com/sr-codefreak/user-group/db/mongodb/user"
+	"github.com/sr-codefreak/user-group/db/mongodb/usergroup"
+	"github.com/sr-codefreak/user-group/myerrors"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// AddChild makes childId a subgroup of id
+func (s userGroupStore) AddChild(ctx context.Context, id primitive.ObjectID, childId primitive.ObjectID) error {
+	s.b.Lock()
+	defer s.b.Unlock()
+	if _, ok := s.b.groups[id]; !ok {
+		return myerrors.Wrap(myerrors.ErrAddingChildGroup, myerrors.KindUserGroup, id.Hex(), myerrors.ErrNotFound)
+	}
+	child, ok := s.b.groups[childId]
+	if !ok {
+		return myerrors.Wrap(myerrors.ErrAddingChildGroup, myerrors.KindUserGroup, childId.Hex(), myerrors.ErrNotFound)
+	}
+	if id == childId || s.b.ancestors(id)[childId] {
+		return myerrors.Wrap(myerrors.ErrAddingChildGroup, myerrors.KindUserGroup, id.Hex(), myerrors.ErrGroupCycle)
+	}
+	if !containsID(child.ParentIds, id) {
+		child.ParentIds = append(child.ParentIds, id)
+	}
+	return nil
+}
+
+// RemoveChild removes childId from the subgroups of id
+func (s userGroupStore) RemoveChild(ctx context.Context, id primitive.ObjectID, childId primitive.ObjectID) error {
+	s.b.Lock()
+	defer s.b.Unlock()
+	if child, ok := s.b.groups[childId]; ok {
+		child.ParentIds = removeID(child.ParentIds, id)
+	}
+	return nil
+}
+
+// ResolveMembers collects the users of the user group and its descendants
+func (s userGroupStore) ResolveMembers(ctx context.Context, id primitive.ObjectID) ([]usergroup.UserRef, error) {
+	s.b.RLock()
+	defer s.b.RUnlock()
+	ug, ok := s.b.groups[id]
+	if !ok {
+		return nil, myerrors.Wrap(myerrors.ErrResolvingMembers, myerrors.KindUserGroup, id.Hex(), myerrors.ErrNotFound)
+	}
+	members := []usergroup.UserRef{}
+	seen := map[string]bool{}
+	queue := []*usergroup.UserGroup{ug}
+	visited := map[primitive.ObjectID]bool{id: true}
+	for len(queue) > 0 {
+		ug, queue = queue[0], queue[1:]
+		for _, u := range ug.Users {
+			if !seen[u.ID] {
+				seen[u.ID] = true
+				members = append(members, u)
+			}
+		}
+		for childId, child := range s.b.groups {
+			if !visited[childId] && containsID(child.ParentIds, ug.ID) {
+				visited[childId] = true
+				queue = append(queue, child)
+			}
+		}
+	}
+	return members, nil
+}
+
+// ResolveGroupsForUser collects the user groups containing the user and their ancestors
+func (s userGroupStore) ResolveGroupsForUser(ctx context.Context, userId primitive.ObjectID) ([]user.UserGroupRef, error) {
+	s.b.RLock()
+	defer s.b.RUnlock()
+	groups := []user.UserGroupRef{}
+	seen := map[primitive.ObjectID]bool{}
+	add := func(id primitive.ObjectID) {
+		if ug, ok := s.b.groups[id]; ok && !seen[id] {
+			seen[id] = true
+			groups = append(groups, user.UserGroupRef{ID: id.Hex(), Name: ug.Name})
+		}
+	}
+	direct := []primitive.ObjectID{}
+	for id, ug := range s.b.groups {
+		if contains(ug.UserIds, userId.Hex()) {
+			direct = append(direct, id)
+			add(id)
+		}
+	}
+	for _, id := range direct {
+		for ancestor := range s.b.ancestors(id) {
+			add(ancestor)
+		}
+	}
+	return groups, nil
+}
+
+// ancestors returns the ids of the user groups containing id, directly or not.
+// The caller holds the lock.
+func (b *Backend) ancestors(id primitive.ObjectID) map[primitive.ObjectID]bool {
+	found := map[primitive.ObjectID]bool{}
+	queue := []primitive.ObjectID{id}
+	for len(queue) > 0 {
+		ug, ok := b.groups[queue[0]]
+		queue = queue[1:]
+		if !ok {
+			continue
+		}
+		for _, parentId := range ug.ParentIds {
+			if !found[parentId] {
+				found[parentId] = true
+				queue = append(queue, parentId)
+			}
+		}
+	}
+	return found
+}
+
+func containsID(ids []primitive.ObjectID, id primitive.ObjectID) bool {
+	for _, v := range ids {
+		if v == id {
+			return true
+		}
+	}
+	return false
+}
+
+func removeID(ids []primitive.ObjectID, id primitive.ObjectID) []primitive.ObjectID {
+	out := ids[:0]
+	for _, v := range ids {
+		if v != id {
+			out = append(out, v)
+		}
+	}
+	return out
+}
@@ -0,0 +1,71 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"github.com/sr-codefreak/user-group/db/mongodb"
+	"github.com/sr-codefreak/user-group/db/mongodb/event"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// outboxStore keeps the events in append order
+type outboxStore struct {
+	b *Backend
+}
+
+// EnsureIndexes is a no-op, events are scanned
+func (outboxStore) EnsureIndexes(ctx context.Context) error {
+	return nil
+}
+
+func (s outboxStore) Append(ctx context.Context, events ...*event.Event) error {
+	s.b.Lock()
+	defer s.b.Unlock()
+	now := time.Now().UTC()
+	for _, e := range events {
+		if e.ID.IsZero() {
+			e.ID = primitive.NewObjectID()
+		}
+		if e.Time.IsZero() {
+			e.Time = now
+		}
+		c := *e
+		c.Roles = append([]string(nil), e.Roles...)
+		s.b.outbox = append(s.b.outbox, c)
+	}
+	return nil
+}
+
+func (s outboxStore) List(ctx context.Context, after primitive.ObjectID, limit int) ([]event.Event, error) {
+	limit = mongodb.ListOptions{PageSize: limit}.Limit()
+	s.b.RLock()
+	defer s.b.RUnlock()
+	events := []event.Event{}
+	for i := range s.b.outbox {
+		if len(events) >= limit {
+			break
+		}
+		e := s.b.outbox[i]
+		// ids grow with the append order, like the ORDER BY id of the other stores
+		if !after.IsZero() && e.ID.Hex() <= after.Hex() {
+			continue
+		}
+		e.Roles = append([]string(nil), e.Roles...)
+		events = append(events, e)
+	}
+	return events, nil
+}
+
+func (s outboxStore) Prune(ctx context.Context, before time.Time) error {
+	s.b.Lock()
+	defer s.b.Unlock()
+	kept := s.b.outbox[:0]
+	for _, e := range s.b.outbox {
+		if !e.Time.Before(before) {
+			kept = append(kept, e)
+		}
+	}
+	s.b.outbox = kept
+	return nil
+}
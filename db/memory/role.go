@@ -0,0 +1,145 @@
+package memory
+
+import (
+	"context"
+	"sort"
+
+	"github.com/sr-codefreak/user-group/db/mongodb/access"
+	"github.com/sr-codefreak/user-group/myerrors"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type roleStore struct {
+	b *Backend
+}
+
+// checkDefined fails with access.UndefinedRole for the first of names that is not a role
+func (b *Backend) checkDefined(names []string) error {
+	for _, name := range names {
+		if _, ok := b.roles[name]; !ok {
+			return access.UndefinedRole(name)
+		}
+	}
+	return nil
+}
+
+// inherited returns the roles named and all roles they inherit
+func (b *Backend) inherited(names []string) []access.Role {
+	roles := []access.Role{}
+	seen := map[string]bool{}
+	for len(names) > 0 {
+		name := names[0]
+		names = names[1:]
+		r, ok := b.roles[name]
+		if !ok || seen[name] {
+			continue
+		}
+		seen[name] = true
+		roles = append(roles, *r)
+		names = append(names, r.Inherits...)
+	}
+	return roles
+}
+
+// EnsureIndexes is a no-op, the map key already keeps the names unique
+func (roleStore) EnsureIndexes(ctx context.Context) error {
+	return nil
+}
+
+func (s roleStore) Create(ctx context.Context, r *access.Role) error {
+	if err := r.Check(); err != nil {
+		return myerrors.Wrap(myerrors.ErrCreatingRole, myerrors.KindRole, r.Name, err)
+	}
+	s.b.Lock()
+	defer s.b.Unlock()
+	if err := s.b.checkDefined(r.Inherits); err != nil {
+		return myerrors.Wrap(myerrors.ErrCreatingRole, myerrors.KindRole, r.Name, err)
+	}
+	if _, ok := s.b.roles[r.Name]; ok {
+		return myerrors.Wrap(myerrors.ErrCreatingRole, myerrors.KindRole, r.Name, myerrors.ErrAlreadyExists)
+	}
+	if r.ID.IsZero() {
+		r.ID = primitive.NewObjectID()
+	}
+	if r.Permissions == nil {
+		r.Permissions = []string{}
+	}
+	c := copyRole(r)
+	s.b.roles[r.Name] = &c
+	return nil
+}
+
+func (s roleStore) Update(ctx context.Context, r *access.Role) error {
+	if err := r.Check(); err != nil {
+		return myerrors.Wrap(myerrors.ErrUpdatingRole, myerrors.KindRole, r.Name, err)
+	}
+	s.b.Lock()
+	defer s.b.Unlock()
+	old, ok := s.b.roles[r.Name]
+	if !ok {
+		return myerrors.Wrap(myerrors.ErrUpdatingRole, myerrors.KindRole, r.Name, myerrors.ErrNotFound)
+	}
+	if err := s.b.checkDefined(r.Inherits); err != nil {
+		return myerrors.Wrap(myerrors.ErrUpdatingRole, myerrors.KindRole, r.Name, err)
+	}
+	for _, i := range s.b.inherited(r.Inherits) {
+		if i.Name == r.Name {
+			return myerrors.Wrap(myerrors.ErrUpdatingRole, myerrors.KindRole, r.Name, myerrors.ErrRoleCycle)
+		}
+	}
+	r.ID = old.ID
+	if r.Permissions == nil {
+		r.Permissions = []string{}
+	}
+	c := copyRole(r)
+	s.b.roles[r.Name] = &c
+	return nil
+}
+
+func (s roleStore) Delete(ctx context.Context, name string) error {
+	s.b.Lock()
+	defer s.b.Unlock()
+	if _, ok := s.b.roles[name]; !ok {
+		return myerrors.Wrap(myerrors.ErrDeleteRole, myerrors.KindRole, name, myerrors.ErrNotFound)
+	}
+	for _, r := range s.b.roles {
+		if contains(r.Inherits, name) {
+			return myerrors.Wrap(myerrors.ErrDeleteRole, myerrors.KindRole, name, myerrors.ErrRoleInherited)
+		}
+	}
+	delete(s.b.roles, name)
+	for _, a := range s.b.access {
+		a.Roles = remove(a.Roles, name)
+	}
+	return nil
+}
+
+func (s roleStore) Get(ctx context.Context, name string) (*access.Role, error) {
+	s.b.RLock()
+	defer s.b.RUnlock()
+	r, ok := s.b.roles[name]
+	if !ok {
+		return nil, myerrors.Wrap(myerrors.ErrGetRole, myerrors.KindRole, name, myerrors.ErrNotFound)
+	}
+	c := copyRole(r)
+	return &c, nil
+}
+
+func (s roleStore) List(ctx context.Context) ([]access.Role, error) {
+	s.b.RLock()
+	defer s.b.RUnlock()
+	roles := make([]access.Role, 0, len(s.b.roles))
+	for _, r := range s.b.roles {
+		roles = append(roles, copyRole(r))
+	}
+	sort.Slice(roles, func(i, j int) bool {
+		return roles[i].Name < roles[j].Name
+	})
+	return roles, nil
+}
+
+func (s roleStore) Permissions(ctx context.Context, names ...string) ([]string, error) {
+	s.b.RLock()
+	defer s.b.RUnlock()
+	return access.UnionPermissions(s.b.inherited(names)), nil
+}
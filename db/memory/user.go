@@ -0,0 +1,140 @@
+package memory
+
+import (
+	"context"
+
+	"github.com/sr-codefreak/user-group/db/mongodb"
+	"github.com/sr-codefreak/user-group/db/mongodb/user"
+	"github.com/sr-codefreak/user-group/myerrors"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type userStore struct {
+	b *Backend
+}
+
+// EnsureIndexes is a no-op, users are scanned
+func (userStore) EnsureIndexes(ctx context.Context) error {
+	return nil
+}
+
+func (s userStore) Create(ctx context.Context, u *user.User) error {
+	s.b.Lock()
+	defer s.b.Unlock()
+	if u.ID.IsZero() {
+		u.ID = primitive.NewObjectID()
+	}
+	if _, ok := s.b.users[u.ID]; ok {
+		return myerrors.Wrap(myerrors.ErrCreatingUser, myerrors.KindUser, u.ID.Hex(), myerrors.ErrAlreadyExists)
+	}
+	s.b.users[u.ID] = copyUser(u)
+	return nil
+}
+
+func (s userStore) CreateMany(ctx context.Context, users []*user.User) error {
+	s.b.Lock()
+	defer s.b.Unlock()
+	batch := map[primitive.ObjectID]bool{}
+	for _, u := range users {
+		if u.ID.IsZero() {
+			u.ID = primitive.NewObjectID()
+		}
+		if _, ok := s.b.users[u.ID]; ok || batch[u.ID] {
+			return myerrors.Wrap(myerrors.ErrCreatingUser, myerrors.KindUser, u.ID.Hex(), myerrors.ErrAlreadyExists)
+		}
+		batch[u.ID] = true
+	}
+	for _, u := range users {
+		s.b.users[u.ID] = copyUser(u)
+	}
+	return nil
+}
+
+func (s userStore) Update(ctx context.Context, u *user.User) error {
+	s.b.Lock()
+	defer s.b.Unlock()
+	stored, ok := s.b.users[u.ID]
+	if !ok {
+		return myerrors.Wrap(myerrors.ErrUpdatingUser, myerrors.KindUser, u.ID.Hex(), myerrors.ErrNotFound)
+	}
+	if len(u.Name) > 0 {
+		stored.Name = u.Name
+	}
+	if len(u.Email) > 0 {
+		stored.Email = u.Email
+	}
+	if len(u.Phone) > 0 {
+		stored.Phone = u.Phone
+	}
+	if u.MetaData != nil {
+		stored.MetaData = copyMetaData(u.MetaData)
+	}
+	// keep the snapshot embedded in the user groups in sync
+	for _, groupId := range stored.UserGroupIds {
+		oid, _ := primitive.ObjectIDFromHex(groupId)
+		if ug, ok := s.b.groups[oid]; ok {
+			for i := range ug.Users {
+				if ug.Users[i].ID == stored.ID.Hex() {
+					ug.Users[i].Name, ug.Users[i].Email, ug.Users[i].Phone = stored.Name, stored.Email, stored.Phone
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func (s userStore) GetById(ctx context.Context, id string) (*user.User, error) {
+	oid, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, myerrors.Wrap(myerrors.ErrGetUserById, myerrors.KindUser, id, myerrors.Invalid(err))
+	}
+	s.b.RLock()
+	defer s.b.RUnlock()
+	u, ok := s.b.users[oid]
+	if !ok {
+		return nil, myerrors.Wrap(myerrors.ErrGetUserById, myerrors.KindUser, id, myerrors.ErrNotFound)
+	}
+	return copyUser(u), nil
+}
+
+// Delete deletes the user, removes it from its user groups and deletes the
+// access rows granted to it
+func (s userStore) Delete(ctx context.Context, id primitive.ObjectID) error {
+	s.b.Lock()
+	defer s.b.Unlock()
+	if _, ok := s.b.users[id]; !ok {
+		return myerrors.Wrap(myerrors.ErrDeleteUser, myerrors.KindUser, id.Hex(), myerrors.ErrNotFound)
+	}
+	delete(s.b.users, id)
+	for _, ug := range s.b.groups {
+		removeUserFromGroup(ug, id.Hex())
+	}
+	for k := range s.b.access {
+		if k.userId == id.Hex() {
+			delete(s.b.access, k)
+		}
+	}
+	return nil
+}
+
+func (s userStore) List(ctx context.Context, opts mongodb.ListOptions) ([]user.User, string, error) {
+	if err := opts.Check(mongodb.NameKey, mongodb.EmailKey, mongodb.PhoneKey); err != nil {
+		return nil, "", myerrors.Wrap(myerrors.ErrListingUsers, myerrors.KindUser, "", err)
+	}
+	s.b.RLock()
+	users := []user.User{}
+	for _, u := range s.b.users {
+		values := map[string]string{mongodb.NameKey: u.Name, mongodb.EmailKey: u.Email, mongodb.PhoneKey: u.Phone}
+		if matches(opts.Filter, values, u.MetaData) {
+			users = append(users, *copyUser(u))
+		}
+	}
+	s.b.RUnlock()
+	users, next, err := listPage(opts, users, func(u user.User) ([]string, primitive.ObjectID) {
+		return u.SortValues(opts.Sort), u.ID
+	})
+	if err != nil {
+		return nil, "", myerrors.Wrap(myerrors.ErrListingUsers, myerrors.KindUser, "", err)
+	}
+	return users, next, nil
+}
@@ -0,0 +1,220 @@
+package memory
+
+import (
+	"context"
+
+	"github.com/sr-codefreak/user-group/db/mongodb"
+	"github.com/sr-codefreak/user-group/db/mongodb/user"
+	"github.com/sr-codefreak/user-group/db/mongodb/usergroup"
+	"github.com/sr-codefreak/user-group/myerrors"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type userGroupStore struct {
+	b *Backend
+}
+
+// EnsureIndexes is a no-op, user groups are scanned
+func (userGroupStore) EnsureIndexes(ctx context.Context) error {
+	return nil
+}
+
+func (s userGroupStore) Create(ctx context.Context, group *usergroup.UserGroup) error {
+	if err := group.CheckRule(); err != nil {
+		return myerrors.Wrap(myerrors.ErrCreatingUserGroup, myerrors.KindUserGroup, "", err)
+	}
+	s.b.Lock()
+	defer s.b.Unlock()
+	if group.ID.IsZero() {
+		group.ID = primitive.NewObjectID()
+	}
+	if _, ok := s.b.groups[group.ID]; ok {
+		return myerrors.Wrap(myerrors.ErrCreatingUserGroup, myerrors.KindUserGroup, group.ID.Hex(), myerrors.ErrAlreadyExists)
+	}
+	s.b.groups[group.ID] = copyUserGroup(group)
+	return nil
+}
+
+func (s userGroupStore) CreateMany(ctx context.Context, groups []*usergroup.UserGroup) error {
+	s.b.Lock()
+	defer s.b.Unlock()
+	batch := map[primitive.ObjectID]bool{}
+	for _, group := range groups {
+		if err := group.CheckRule(); err != nil {
+			return myerrors.Wrap(myerrors.ErrCreatingUserGroup, myerrors.KindUserGroup, "", err)
+		}
+		if group.ID.IsZero() {
+			group.ID = primitive.NewObjectID()
+		}
+		if _, ok := s.b.groups[group.ID]; ok || batch[group.ID] {
+			return myerrors.Wrap(myerrors.ErrCreatingUserGroup, myerrors.KindUserGroup, group.ID.Hex(), myerrors.ErrAlreadyExists)
+		}
+		batch[group.ID] = true
+	}
+	for _, group := range groups {
+		s.b.groups[group.ID] = copyUserGroup(group)
+	}
+	return nil
+}
+
+func (s userGroupStore) UpdateName(ctx context.Context, id primitive.ObjectID, name string) error {
+	s.b.Lock()
+	defer s.b.Unlock()
+	ug, ok := s.b.groups[id]
+	if !ok {
+		return myerrors.Wrap(myerrors.ErrUpdatingUserGroupName, myerrors.KindUserGroup, id.Hex(), myerrors.ErrNotFound)
+	}
+	ug.Name = name
+	for _, userId := range ug.UserIds {
+		oid, _ := primitive.ObjectIDFromHex(userId)
+		if u, ok := s.b.users[oid]; ok {
+			for i := range u.UsersGroups {
+				if u.UsersGroups[i].ID == id.Hex() {
+					u.UsersGroups[i].Name = name
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func (s userGroupStore) SetRule(ctx context.Context, id primitive.ObjectID, rule string) error {
+	if err := (usergroup.UserGroup{Rule: rule}).CheckRule(); err != nil {
+		return myerrors.Wrap(myerrors.ErrSettingRule, myerrors.KindUserGroup, id.Hex(), err)
+	}
+	s.b.Lock()
+	defer s.b.Unlock()
+	ug, ok := s.b.groups[id]
+	if !ok {
+		return myerrors.Wrap(myerrors.ErrSettingRule, myerrors.KindUserGroup, id.Hex(), myerrors.ErrNotFound)
+	}
+	ug.Rule = rule
+	return nil
+}
+
+// AddUser adds the user to the user group and the user group to the user
+func (s userGroupStore) AddUser(ctx context.Context, id primitive.ObjectID, userId primitive.ObjectID) error {
+	s.b.Lock()
+	defer s.b.Unlock()
+	ug, ok := s.b.groups[id]
+	if !ok {
+		return myerrors.Wrap(myerrors.ErrAddingUserToUserGroup, myerrors.KindUserGroup, id.Hex(), myerrors.ErrNotFound)
+	}
+	u, ok := s.b.users[userId]
+	if !ok {
+		return myerrors.Wrap(myerrors.ErrAddingUserToUserGroup, myerrors.KindUser, userId.Hex(), myerrors.ErrNotFound)
+	}
+	if !contains(ug.UserIds, userId.Hex()) {
+		ug.UserIds = append(ug.UserIds, userId.Hex())
+		ug.Users = append(ug.Users, usergroup.UserRef{
+			ID:    userId.Hex(),
+			Name:  u.Name,
+			Email: u.Email,
+			Phone: u.Phone,
+		})
+	}
+	if !contains(u.UserGroupIds, id.Hex()) {
+		u.UserGroupIds = append(u.UserGroupIds, id.Hex())
+		u.UsersGroups = append(u.UsersGroups, user.UserGroupRef{
+			ID:   id.Hex(),
+			Name: ug.Name,
+		})
+	}
+	return nil
+}
+
+// RemoveUser removes the user from the user group and the user group from the user
+func (s userGroupStore) RemoveUser(ctx context.Context, id primitive.ObjectID, userId primitive.ObjectID) error {
+	s.b.Lock()
+	defer s.b.Unlock()
+	if ug, ok := s.b.groups[id]; ok {
+		removeUserFromGroup(ug, userId.Hex())
+	}
+	if u, ok := s.b.users[userId]; ok {
+		removeGroupFromUser(u, id.Hex())
+	}
+	return nil
+}
+
+func (s userGroupStore) GetById(ctx context.Context, id string) (*usergroup.UserGroup, error) {
+	oid, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, myerrors.Wrap(myerrors.ErrGetUserGroupById, myerrors.KindUserGroup, id, myerrors.Invalid(err))
+	}
+	s.b.RLock()
+	defer s.b.RUnlock()
+	ug, ok := s.b.groups[oid]
+	if !ok {
+		return nil, myerrors.Wrap(myerrors.ErrGetUserGroupById, myerrors.KindUserGroup, id, myerrors.ErrNotFound)
+	}
+	return copyUserGroup(ug), nil
+}
+
+// DeleteById deletes the user group, removes it from the users it contains
+// and from the parents of its subgroups and deletes the access rows granted on it
+func (s userGroupStore) DeleteById(ctx context.Context, id primitive.ObjectID) error {
+	s.b.Lock()
+	defer s.b.Unlock()
+	if _, ok := s.b.groups[id]; !ok {
+		return myerrors.Wrap(myerrors.ErrDeleteUserGroup, myerrors.KindUserGroup, id.Hex(), myerrors.ErrNotFound)
+	}
+	delete(s.b.groups, id)
+	for _, u := range s.b.users {
+		removeGroupFromUser(u, id.Hex())
+	}
+	for _, ug := range s.b.groups {
+		ug.ParentIds = removeID(ug.ParentIds, id)
+	}
+	for k := range s.b.access {
+		if k.userGroupId == id.Hex() {
+			delete(s.b.access, k)
+		}
+	}
+	return nil
+}
+
+func removeUserFromGroup(ug *usergroup.UserGroup, userId string) {
+	ug.UserIds = remove(ug.UserIds, userId)
+	users := ug.Users[:0]
+	for _, u := range ug.Users {
+		if u.ID != userId {
+			users = append(users, u)
+		}
+	}
+	ug.Users = users
+}
+
+func removeGroupFromUser(u *user.User, userGroupId string) {
+	u.UserGroupIds = remove(u.UserGroupIds, userGroupId)
+	groups := u.UsersGroups[:0]
+	for _, g := range u.UsersGroups {
+		if g.ID != userGroupId {
+			groups = append(groups, g)
+		}
+	}
+	u.UsersGroups = groups
+}
+
+func (s userGroupStore) List(ctx context.Context, opts mongodb.ListOptions) ([]usergroup.UserGroup, string, error) {
+	if err := opts.Check(mongodb.NameKey, mongodb.RuleKey); err != nil {
+		return nil, "", myerrors.Wrap(myerrors.ErrListingUserGroups, myerrors.KindUserGroup, "", err)
+	}
+	s.b.RLock()
+	groups := []usergroup.UserGroup{}
+	for _, ug := range s.b.groups {
+		if opts.Filter.Dynamic && ug.Rule == "" {
+			continue
+		}
+		if matches(opts.Filter, map[string]string{mongodb.NameKey: ug.Name}, ug.MetaData) {
+			groups = append(groups, *copyUserGroup(ug))
+		}
+	}
+	s.b.RUnlock()
+	groups, next, err := listPage(opts, groups, func(ug usergroup.UserGroup) ([]string, primitive.ObjectID) {
+		return ug.SortValues(opts.Sort), ug.ID
+	})
+	if err != nil {
+		return nil, "", myerrors.Wrap(myerrors.ErrListingUserGroups, myerrors.KindUserGroup, "", err)
+	}
+	return groups, next, nil
+}
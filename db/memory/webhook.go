@@ -0,0 +1,189 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/sr-codefreak/user-group/db/mongodb"
+	"github.com/sr-codefreak/user-group/db/mongodb/webhook"
+	"github.com/sr-codefreak/user-group/myerrors"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type webhookStore struct {
+	b *Backend
+}
+
+func copySubscription(s *webhook.Subscription) webhook.Subscription {
+	c := *s
+	c.Types = append(c.Types[:0:0], s.Types...)
+	return c
+}
+
+func copyDelivery(d *webhook.Delivery) webhook.Delivery {
+	c := *d
+	c.Attempts = append([]webhook.Attempt{}, d.Attempts...)
+	return c
+}
+
+// EnsureIndexes is a no-op, subscriptions and deliveries are scanned
+func (webhookStore) EnsureIndexes(ctx context.Context) error {
+	return nil
+}
+
+func (s webhookStore) Create(ctx context.Context, sub *webhook.Subscription) error {
+	if err := sub.Check(); err != nil {
+		return myerrors.Wrap(myerrors.ErrCreatingWebhook, myerrors.KindWebhook, "", err)
+	}
+	s.b.Lock()
+	defer s.b.Unlock()
+	if sub.ID.IsZero() {
+		sub.ID = primitive.NewObjectID()
+	}
+	if _, ok := s.b.webhooks[sub.ID]; ok {
+		return myerrors.Wrap(myerrors.ErrCreatingWebhook, myerrors.KindWebhook, sub.ID.Hex(), myerrors.ErrAlreadyExists)
+	}
+	if sub.Created.IsZero() {
+		sub.Created = time.Now().UTC()
+	}
+	c := copySubscription(sub)
+	s.b.webhooks[sub.ID] = &c
+	return nil
+}
+
+func (s webhookStore) Update(ctx context.Context, sub *webhook.Subscription) error {
+	if err := sub.Check(); err != nil {
+		return myerrors.Wrap(myerrors.ErrUpdatingWebhook, myerrors.KindWebhook, sub.ID.Hex(), err)
+	}
+	s.b.Lock()
+	defer s.b.Unlock()
+	old, ok := s.b.webhooks[sub.ID]
+	if !ok {
+		return myerrors.Wrap(myerrors.ErrUpdatingWebhook, myerrors.KindWebhook, sub.ID.Hex(), myerrors.ErrNotFound)
+	}
+	c := copySubscription(sub)
+	c.Created = old.Created
+	if c.Secret == "" {
+		c.Secret = old.Secret
+	}
+	s.b.webhooks[sub.ID] = &c
+	return nil
+}
+
+func (s webhookStore) Delete(ctx context.Context, id primitive.ObjectID) error {
+	s.b.Lock()
+	defer s.b.Unlock()
+	if _, ok := s.b.webhooks[id]; !ok {
+		return myerrors.Wrap(myerrors.ErrDeleteWebhook, myerrors.KindWebhook, id.Hex(), myerrors.ErrNotFound)
+	}
+	delete(s.b.webhooks, id)
+	for deliveryId, d := range s.b.deliveries {
+		if d.SubscriptionId == id.Hex() {
+			delete(s.b.deliveries, deliveryId)
+		}
+	}
+	return nil
+}
+
+func (s webhookStore) Get(ctx context.Context, id string) (*webhook.Subscription, error) {
+	oid, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, myerrors.Wrap(myerrors.ErrGetWebhook, myerrors.KindWebhook, id, myerrors.Invalid(err))
+	}
+	s.b.RLock()
+	defer s.b.RUnlock()
+	sub, ok := s.b.webhooks[oid]
+	if !ok {
+		return nil, myerrors.Wrap(myerrors.ErrGetWebhook, myerrors.KindWebhook, id, myerrors.ErrNotFound)
+	}
+	c := copySubscription(sub)
+	return &c, nil
+}
+
+func (s webhookStore) List(ctx context.Context) ([]webhook.Subscription, error) {
+	s.b.RLock()
+	defer s.b.RUnlock()
+	subs := []webhook.Subscription{}
+	for _, sub := range s.b.webhooks {
+		subs = append(subs, copySubscription(sub))
+	}
+	sort.Slice(subs, func(i, j int) bool { return subs[i].ID.Hex() < subs[j].ID.Hex() })
+	return subs, nil
+}
+
+func (s webhookStore) Enqueue(ctx context.Context, d *webhook.Delivery) error {
+	s.b.Lock()
+	defer s.b.Unlock()
+	for _, other := range s.b.deliveries {
+		if other.SubscriptionId == d.SubscriptionId && other.EventId == d.EventId {
+			return myerrors.Wrap(myerrors.ErrEnqueuingDelivery, myerrors.KindWebhook, d.SubscriptionId, myerrors.ErrAlreadyExists)
+		}
+	}
+	if d.ID.IsZero() {
+		d.ID = primitive.NewObjectID()
+	}
+	if d.Created.IsZero() {
+		d.Created = time.Now().UTC()
+	}
+	if d.Attempts == nil {
+		d.Attempts = []webhook.Attempt{}
+	}
+	c := copyDelivery(d)
+	s.b.deliveries[d.ID] = &c
+	return nil
+}
+
+func (s webhookStore) UpdateDelivery(ctx context.Context, d *webhook.Delivery) error {
+	s.b.Lock()
+	defer s.b.Unlock()
+	old, ok := s.b.deliveries[d.ID]
+	if !ok {
+		return myerrors.Wrap(myerrors.ErrUpdatingDelivery, myerrors.KindWebhook, d.SubscriptionId, myerrors.ErrNotFound)
+	}
+	old.Status = d.Status
+	old.Attempts = append([]webhook.Attempt{}, d.Attempts...)
+	old.NextAttempt = d.NextAttempt
+	return nil
+}
+
+func (s webhookStore) ListDeliveries(ctx context.Context, q webhook.DeliveryQuery) ([]webhook.Delivery, error) {
+	q = q.Normalize()
+	s.b.RLock()
+	defer s.b.RUnlock()
+	deliveries := []webhook.Delivery{}
+	for _, d := range s.b.deliveries {
+		if q.SubscriptionId != "" && d.SubscriptionId != q.SubscriptionId ||
+			q.Status != "" && d.Status != q.Status {
+			continue
+		}
+		deliveries = append(deliveries, copyDelivery(d))
+	}
+	sort.Slice(deliveries, func(i, j int) bool { return deliveries[i].ID.Hex() > deliveries[j].ID.Hex() })
+	if len(deliveries) > q.Limit {
+		deliveries = deliveries[:q.Limit]
+	}
+	return deliveries, nil
+}
+
+func (s webhookStore) Claim(ctx context.Context, now time.Time, until time.Time, limit int) ([]webhook.Delivery, error) {
+	limit = mongodb.ListOptions{PageSize: limit}.Limit()
+	s.b.Lock()
+	defer s.b.Unlock()
+	due := []*webhook.Delivery{}
+	for _, d := range s.b.deliveries {
+		if d.Status == webhook.Pending && !d.NextAttempt.After(now) {
+			due = append(due, d)
+		}
+	}
+	sort.Slice(due, func(i, j int) bool { return due[i].NextAttempt.Before(due[j].NextAttempt) })
+	if len(due) > limit {
+		due = due[:limit]
+	}
+	deliveries := make([]webhook.Delivery, 0, len(due))
+	for _, d := range due {
+		d.NextAttempt = until
+		deliveries = append(deliveries, copyDelivery(d))
+	}
+	return deliveries, nil
+}
@@ -6,10 +6,10 @@ import (
 )
 
 type Access struct {
-	ID          primitive.ObjectID `bson:"_id,omitempty"`
-	UserId      string             `bson:"userId"`
-	UserGroupId string             `bson:"userGroupId"`
-	Roles       []string           `bson:"roles"`
+	ID          primitive.ObjectID `bson:"_id,omitempty" json:"_id,omitempty"`
+	UserId      string             `bson:"userId" json:"userId"`
+	UserGroupId string             `bson:"userGroupId" json:"userGroupId"`
+	Roles       []string           `bson:"roles" json:"roles"`
 }
 
 type AccessModel struct {
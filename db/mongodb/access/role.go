@@ -0,0 +1,94 @@
+package access
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+
+	"github.com/sr-codefreak/user-group/db/mongodb"
+	"github.com/sr-codefreak/user-group/myerrors"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Role is a named set of permissions that can be granted through Access.Roles.
+// A role has the permissions of the roles it inherits as well.
+type Role struct {
+	ID          primitive.ObjectID `bson:"_id,omitempty" json:"_id,omitempty"`
+	Name        string             `bson:"name" json:"name"`
+	Permissions []string           `bson:"permissions" json:"permissions"`
+	Inherits    []string           `bson:"inherits,omitempty" json:"inherits,omitempty"`
+}
+
+type RoleModel struct {
+	mongodb.UserGroup
+	IdKey          string
+	NameKey        string
+	PermissionsKey string
+	InheritsKey    string
+}
+
+var roleModel = &RoleModel{
+	IdKey:          "_id",
+	NameKey:        "name",
+	PermissionsKey: "permissions",
+	InheritsKey:    "inherits",
+}
+
+func GetRoleModel() *RoleModel {
+	return roleModel
+}
+
+func (r RoleModel) CollectionName() string {
+	return "roles"
+}
+
+var (
+	roleNamePattern   = regexp.MustCompile(`^[a-z][a-z0-9_.:-]*$`)
+	permissionPattern = regexp.MustCompile(`^[a-z*][a-z0-9_.:*-]*$`)
+
+	errUndefinedRole = errors.New("role is not defined")
+)
+
+// Check validates the names of the role, its permissions and inherited roles.
+// Names are lower case so "admin" and "Admin" cannot both exist.
+func (r *Role) Check() error {
+	if !roleNamePattern.MatchString(r.Name) {
+		return myerrors.New(myerrors.InvalidArgument, myerrors.KindRole, r.Name, fmt.Errorf("invalid role name %q", r.Name))
+	}
+	for _, p := range r.Permissions {
+		if !permissionPattern.MatchString(p) {
+			return myerrors.New(myerrors.InvalidArgument, myerrors.KindRole, r.Name, fmt.Errorf("invalid permission %q", p))
+		}
+	}
+	for _, name := range r.Inherits {
+		if name == r.Name {
+			return myerrors.ErrRoleCycle
+		}
+		if !roleNamePattern.MatchString(name) {
+			return myerrors.New(myerrors.InvalidArgument, myerrors.KindRole, r.Name, fmt.Errorf("invalid inherited role name %q", name))
+		}
+	}
+	return nil
+}
+
+// UndefinedRole returns the error of granting or inheriting the role name that does not exist
+func UndefinedRole(name string) error {
+	return myerrors.New(myerrors.InvalidArgument, myerrors.KindRole, name, errUndefinedRole)
+}
+
+// UnionPermissions returns the permissions of the roles sorted and each once
+func UnionPermissions(roles []Role) []string {
+	seen := map[string]bool{}
+	permissions := []string{}
+	for _, r := range roles {
+		for _, p := range r.Permissions {
+			if !seen[p] {
+				seen[p] = true
+				permissions = append(permissions, p)
+			}
+		}
+	}
+	sort.Strings(permissions)
+	return permissions
+}
@@ -0,0 +1,264 @@
+package access
+
+import (
+	"context"
+
+	"github.com/sr-codefreak/user-group/db/mongodb"
+	"github.com/sr-codefreak/user-group/myerrors"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// RoleStore manages the role definitions, roles are identified by their name.
+// Only defined roles can be granted through an AccessStore.
+type RoleStore interface {
+	EnsureIndexes(ctx context.Context) error
+	// Create inserts the role, the roles it inherits must be defined
+	Create(ctx context.Context, r *Role) error
+	// Update replaces the permissions and inherited roles of the role named r.Name.
+	// It fails with myerrors.ErrRoleCycle when the role would inherit itself.
+	Update(ctx context.Context, r *Role) error
+	// Delete deletes the role and revokes it from all users. It fails with
+	// myerrors.ErrRoleInherited while other roles inherit it.
+	Delete(ctx context.Context, name string) error
+	Get(ctx context.Context, name string) (*Role, error)
+	// List returns all roles ordered by name
+	List(ctx context.Context) ([]Role, error)
+	// Permissions returns the permissions of the roles and of all roles they
+	// inherit, sorted and each once. Undefined roles have no permissions.
+	Permissions(ctx context.Context, names ...string) ([]string, error)
+}
+
+type roleStore struct {
+	c *mongodb.Client
+}
+
+// NewRoleStore returns the role store using the mongo client c
+func NewRoleStore(c *mongodb.Client) RoleStore {
+	return roleStore{c: c}
+}
+
+var RlStore = NewRoleStore(mongodb.Default())
+
+// inheritedKey holds the result of the $graphLookup stage
+const inheritedKey = "inherited"
+
+func byName(name string) bson.D {
+	return bson.D{{Key: roleModel.NameKey, Value: name}}
+}
+
+// EnsureIndexes creates the unique name index
+func (s roleStore) EnsureIndexes(ctx context.Context) error {
+	_, err := s.c.CreateIndex(ctx, roleModel, mongo.IndexModel{
+		Keys:    bson.D{{Key: roleModel.NameKey, Value: 1}},
+		Options: options.Index().SetUnique(true),
+	})
+	if err != nil {
+		return myerrors.Wrap(myerrors.ErrCreatingIndex, myerrors.KindRole, "", err)
+	}
+	return nil
+}
+
+// checkDefined fails with UndefinedRole for the first of names that is not a role
+func checkDefined(ctx context.Context, c *mongodb.Client, names []string) error {
+	if len(names) == 0 {
+		return nil
+	}
+	filter := bson.D{{Key: roleModel.NameKey, Value: bson.D{{Key: "$in", Value: names}}}}
+	found, err := c.Distinct(ctx, roleModel, roleModel.NameKey, filter)
+	if err != nil {
+		return err
+	}
+	defined := map[string]bool{}
+	for _, name := range found {
+		if s, ok := name.(string); ok {
+			defined[s] = true
+		}
+	}
+	for _, name := range names {
+		if !defined[name] {
+			return UndefinedRole(name)
+		}
+	}
+	return nil
+}
+
+// inherited returns the roles named and all roles they inherit
+func (s roleStore) inherited(ctx context.Context, names []string) ([]Role, error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.D{{Key: roleModel.NameKey, Value: bson.D{{Key: "$in", Value: names}}}}}},
+		{{Key: "$graphLookup", Value: bson.D{
+			{Key: "from", Value: s.c.CollectionName(roleModel)},
+			{Key: "startWith", Value: "$" + roleModel.InheritsKey},
+			{Key: "connectFromField", Value: roleModel.InheritsKey},
+			{Key: "connectToField", Value: roleModel.NameKey},
+			{Key: "as", Value: inheritedKey},
+		}}},
+	}
+	cursor, err := s.c.Aggregate(ctx, roleModel, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	result := []struct {
+		Role      `bson:",inline"`
+		Inherited []Role `bson:"inherited"`
+	}{}
+	if err := cursor.All(ctx, &result); err != nil {
+		return nil, err
+	}
+	roles := []Role{}
+	seen := map[string]bool{}
+	add := func(r Role) {
+		if !seen[r.Name] {
+			seen[r.Name] = true
+			roles = append(roles, r)
+		}
+	}
+	for _, r := range result {
+		add(r.Role)
+		for _, i := range r.Inherited {
+			add(i)
+		}
+	}
+	return roles, nil
+}
+
+// Create inserts the role and sets r.ID
+func (s roleStore) Create(ctx context.Context, r *Role) error {
+	if err := r.Check(); err != nil {
+		return myerrors.Wrap(myerrors.ErrCreatingRole, myerrors.KindRole, r.Name, err)
+	}
+	if r.Permissions == nil {
+		r.Permissions = []string{}
+	}
+	err := s.c.WithTransaction(ctx, func(ctx mongodb.SessionContext) error {
+		if err := checkDefined(ctx, s.c, r.Inherits); err != nil {
+			return err
+		}
+		exists, err := s.c.FindOne(ctx, roleModel, &Role{}, byName(r.Name))
+		if err != nil {
+			return err
+		}
+		if exists {
+			return myerrors.ErrAlreadyExists
+		}
+		id, err := s.c.InsertOne(ctx, roleModel, r)
+		if err != nil {
+			return err
+		}
+		if oid, ok := id.(primitive.ObjectID); ok {
+			r.ID = oid
+		}
+		return nil
+	})
+	if err != nil {
+		return myerrors.Wrap(myerrors.ErrCreatingRole, myerrors.KindRole, r.Name, err)
+	}
+	return nil
+}
+
+func (s roleStore) Update(ctx context.Context, r *Role) error {
+	if err := r.Check(); err != nil {
+		return myerrors.Wrap(myerrors.ErrUpdatingRole, myerrors.KindRole, r.Name, err)
+	}
+	if r.Permissions == nil {
+		r.Permissions = []string{}
+	}
+	err := s.c.WithTransaction(ctx, func(ctx mongodb.SessionContext) error {
+		if err := checkDefined(ctx, s.c, r.Inherits); err != nil {
+			return err
+		}
+		if len(r.Inherits) > 0 {
+			inherited, err := s.inherited(ctx, r.Inherits)
+			if err != nil {
+				return err
+			}
+			for _, i := range inherited {
+				if i.Name == r.Name {
+					return myerrors.ErrRoleCycle
+				}
+			}
+		}
+		update := bson.D{
+			{Key: roleModel.PermissionsKey, Value: r.Permissions},
+			{Key: roleModel.InheritsKey, Value: append([]string{}, r.Inherits...)},
+		}
+		result, err := s.c.UpdateOne(ctx, roleModel, byName(r.Name), update)
+		if err != nil {
+			return err
+		}
+		if result.MatchedCount == 0 {
+			return myerrors.ErrNotFound
+		}
+		return nil
+	})
+	if err != nil {
+		return myerrors.Wrap(myerrors.ErrUpdatingRole, myerrors.KindRole, r.Name, err)
+	}
+	return nil
+}
+
+func (s roleStore) Delete(ctx context.Context, name string) error {
+	err := s.c.WithTransaction(ctx, func(ctx mongodb.SessionContext) error {
+		n, err := s.c.CountDocuments(ctx, roleModel, bson.D{{Key: roleModel.InheritsKey, Value: name}})
+		if err != nil {
+			return err
+		}
+		if n > 0 {
+			return myerrors.ErrRoleInherited
+		}
+		if err := s.c.DeleteOne(ctx, roleModel, byName(name)); err != nil {
+			return err
+		}
+		filter := bson.D{
+			{Key: accessModel.RolesKey, Value: name},
+		}
+		update := bson.D{
+			{Key: accessModel.RolesKey, Value: name},
+		}
+		_, err = s.c.PullFromArrayMany(ctx, accessModel, filter, update)
+		return err
+	})
+	if err != nil {
+		return myerrors.Wrap(myerrors.ErrDeleteRole, myerrors.KindRole, name, err)
+	}
+	return nil
+}
+
+func (s roleStore) Get(ctx context.Context, name string) (*Role, error) {
+	r := &Role{}
+	exists, err := s.c.FindOne(ctx, roleModel, r, byName(name))
+	if err != nil {
+		return nil, myerrors.Wrap(myerrors.ErrGetRole, myerrors.KindRole, name, err)
+	}
+	if !exists {
+		return nil, myerrors.Wrap(myerrors.ErrGetRole, myerrors.KindRole, name, myerrors.ErrNotFound)
+	}
+	return r, nil
+}
+
+func (s roleStore) List(ctx context.Context) ([]Role, error) {
+	opts := options.Find().SetSort(bson.D{{Key: roleModel.NameKey, Value: 1}})
+	cursor, err := s.c.Find(ctx, roleModel, bson.D{}, opts)
+	if err != nil {
+		return nil, myerrors.Wrap(myerrors.ErrListingRoles, myerrors.KindRole, "", err)
+	}
+	roles := []Role{}
+	if err := cursor.All(ctx, &roles); err != nil {
+		return nil, myerrors.Wrap(myerrors.ErrListingRoles, myerrors.KindRole, "", err)
+	}
+	return roles, nil
+}
+
+func (s roleStore) Permissions(ctx context.Context, names ...string) ([]string, error) {
+	if len(names) == 0 {
+		return []string{}, nil
+	}
+	roles, err := s.inherited(ctx, names)
+	if err != nil {
+		return nil, myerrors.Wrap(myerrors.ErrGetPermissions, myerrors.KindRole, "", err)
+	}
+	return UnionPermissions(roles), nil
+}
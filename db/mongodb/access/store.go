@@ -0,0 +1,233 @@
+package access
+
+import (
+	"context"
+
+	"github.com/sr-codefreak/user-group/db/mongodb"
+	"github.com/sr-codefreak/user-group/myerrors"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// AccessStore manages the roles a user has for a user group.
+// There is at most one access document per user and user group.
+// Only roles defined in the RoleStore can be granted.
+type AccessStore interface {
+	EnsureIndexes(ctx context.Context) error
+	// Grant adds the roles, it fails with an InvalidArgument error for undefined roles
+	// and with a NotFound error when the user or the user group does not exist
+	Grant(ctx context.Context, userId primitive.ObjectID, userGroupId primitive.ObjectID, roles ...string) error
+	Revoke(ctx context.Context, userId primitive.ObjectID, userGroupId primitive.ObjectID, roles ...string) error
+	// SetRoles replaces the roles, it fails like Grant
+	SetRoles(ctx context.Context, userId primitive.ObjectID, userGroupId primitive.ObjectID, roles []string) error
+	ListRolesForUser(ctx context.Context, userId primitive.ObjectID) ([]Access, error)
+	ListUsersWithRoleInGroup(ctx context.Context, userGroupId primitive.ObjectID, role string) ([]string, error)
+	HasRole(ctx context.Context, userId primitive.ObjectID, userGroupId primitive.ObjectID, role string) (bool, error)
+	// EffectivePermissions returns the permissions of the roles of the user for
+	// the user group including the inherited ones, sorted and each once
+	EffectivePermissions(ctx context.Context, userId primitive.ObjectID, userGroupId primitive.ObjectID) ([]string, error)
+}
+
+type accessStore struct {
+	c *mongodb.Client
+}
+
+// NewStore returns the access store using the mongo client c
+func NewStore(c *mongodb.Client) AccessStore {
+	return accessStore{c: c}
+}
+
+var AcStore = NewStore(mongodb.Default())
+
+func filterFor(userId primitive.ObjectID, userGroupId primitive.ObjectID) bson.D {
+	return bson.D{
+		{Key: accessModel.UserIdKey, Value: userId.Hex()},
+		{Key: accessModel.UserGroupIdKey, Value: userGroupId.Hex()},
+	}
+}
+
+// idFor identifies the access of the user for the user group in errors
+func idFor(userId primitive.ObjectID, userGroupId primitive.ObjectID) string {
+	return userId.Hex() + "/" + userGroupId.Hex()
+}
+
+// subjectModel is a collection the access documents refer to. Packages user
+// and usergroup import this one, so their models cannot be used here.
+type subjectModel struct {
+	mongodb.UserGroup
+	name string
+}
+
+func (m subjectModel) CollectionName() string {
+	return m.name
+}
+
+var (
+	userSubject      = &subjectModel{name: "user"}
+	userGroupSubject = &subjectModel{name: "userGroups"}
+)
+
+// checkSubjects fails with a NotFound error unless the user and the user group exist
+func checkSubjects(ctx context.Context, c *mongodb.Client, userId primitive.ObjectID, userGroupId primitive.ObjectID) error {
+	n, err := c.CountDocuments(ctx, userSubject, bson.D{{Key: "_id", Value: userId}})
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return myerrors.Wrap(myerrors.ErrGetUserById, myerrors.KindUser, userId.Hex(), myerrors.ErrNotFound)
+	}
+	n, err = c.CountDocuments(ctx, userGroupSubject, bson.D{{Key: "_id", Value: userGroupId}})
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return myerrors.Wrap(myerrors.ErrGetUserGroupById, myerrors.KindUserGroup, userGroupId.Hex(), myerrors.ErrNotFound)
+	}
+	return nil
+}
+
+// EnsureIndexes creates the unique (userId, userGroupId) index
+func (s accessStore) EnsureIndexes(ctx context.Context) error {
+	_, err := s.c.CreateIndex(ctx, accessModel, mongo.IndexModel{
+		Keys: bson.D{
+			{Key: accessModel.UserIdKey, Value: 1},
+			{Key: accessModel.UserGroupIdKey, Value: 1},
+		},
+		Options: options.Index().SetUnique(true),
+	})
+	if err != nil {
+		return myerrors.Wrap(myerrors.ErrCreatingIndex, myerrors.KindAccess, "", err)
+	}
+	return nil
+}
+
+// Grant adds the roles to the user for the user group,
+// creating the access document when there is none yet
+func (s accessStore) Grant(ctx context.Context, userId primitive.ObjectID, userGroupId primitive.ObjectID, roles ...string) error {
+	if len(roles) == 0 {
+		return nil
+	}
+	err := s.c.WithTransaction(ctx, func(ctx mongodb.SessionContext) error {
+		if err := checkSubjects(ctx, s.c, userId, userGroupId); err != nil {
+			return err
+		}
+		if err := checkDefined(ctx, s.c, roles); err != nil {
+			return err
+		}
+		update := bson.D{
+			{Key: accessModel.RolesKey, Value: bson.D{{Key: "$each", Value: roles}}},
+		}
+		_, err := s.c.AddToArray(ctx, accessModel, filterFor(userId, userGroupId), update, options.Update().SetUpsert(true))
+		return err
+	})
+	if err != nil {
+		return myerrors.Wrap(myerrors.ErrGrantingAccess, myerrors.KindAccess, idFor(userId, userGroupId), err)
+	}
+	return nil
+}
+
+// Revoke removes the roles from the user for the user group.
+// Without roles the whole access document is removed.
+func (s accessStore) Revoke(ctx context.Context, userId primitive.ObjectID, userGroupId primitive.ObjectID, roles ...string) error {
+	var err error
+	if len(roles) == 0 {
+		err = s.c.DeleteMany(ctx, accessModel, filterFor(userId, userGroupId))
+	} else {
+		update := bson.D{
+			{Key: accessModel.RolesKey, Value: bson.D{{Key: "$in", Value: roles}}},
+		}
+		_, err = s.c.PullFromArray(ctx, accessModel, filterFor(userId, userGroupId), update)
+	}
+	if err != nil {
+		return myerrors.Wrap(myerrors.ErrRevokingAccess, myerrors.KindAccess, idFor(userId, userGroupId), err)
+	}
+	return nil
+}
+
+// SetRoles replaces the roles of the user for the user group
+func (s accessStore) SetRoles(ctx context.Context, userId primitive.ObjectID, userGroupId primitive.ObjectID, roles []string) error {
+	if roles == nil {
+		roles = []string{}
+	}
+	err := s.c.WithTransaction(ctx, func(ctx mongodb.SessionContext) error {
+		if err := checkSubjects(ctx, s.c, userId, userGroupId); err != nil {
+			return err
+		}
+		if err := checkDefined(ctx, s.c, roles); err != nil {
+			return err
+		}
+		update := bson.D{
+			{Key: accessModel.RolesKey, Value: roles},
+		}
+		_, err := s.c.UpdateOne(ctx, accessModel, filterFor(userId, userGroupId), update, options.Update().SetUpsert(true))
+		return err
+	})
+	if err != nil {
+		return myerrors.Wrap(myerrors.ErrSettingRoles, myerrors.KindAccess, idFor(userId, userGroupId), err)
+	}
+	return nil
+}
+
+// ListRolesForUser returns the access documents of the user, one per user group
+func (s accessStore) ListRolesForUser(ctx context.Context, userId primitive.ObjectID) ([]Access, error) {
+	filter := bson.D{
+		{Key: accessModel.UserIdKey, Value: userId.Hex()},
+	}
+	cursor, err := s.c.Find(ctx, accessModel, filter)
+	if err != nil {
+		return nil, myerrors.Wrap(myerrors.ErrGetAccess, myerrors.KindAccess, "", err)
+	}
+	accesses := []Access{}
+	if err := cursor.All(ctx, &accesses); err != nil {
+		return nil, myerrors.Wrap(myerrors.ErrGetAccess, myerrors.KindAccess, "", err)
+	}
+	return accesses, nil
+}
+
+// ListUsersWithRoleInGroup returns the ids of the users having the role for the user group
+func (s accessStore) ListUsersWithRoleInGroup(ctx context.Context, userGroupId primitive.ObjectID, role string) ([]string, error) {
+	filter := bson.D{
+		{Key: accessModel.UserGroupIdKey, Value: userGroupId.Hex()},
+		{Key: accessModel.RolesKey, Value: role},
+	}
+	userIds, err := s.c.Distinct(ctx, accessModel, accessModel.UserIdKey, filter)
+	if err != nil {
+		return nil, myerrors.Wrap(myerrors.ErrGetAccess, myerrors.KindAccess, "", err)
+	}
+	ids := make([]string, 0, len(userIds))
+	for _, id := range userIds {
+		if s, ok := id.(string); ok {
+			ids = append(ids, s)
+		}
+	}
+	return ids, nil
+}
+
+// HasRole reports whether the user has the role for the user group
+func (s accessStore) HasRole(ctx context.Context, userId primitive.ObjectID, userGroupId primitive.ObjectID, role string) (bool, error) {
+	filter := append(filterFor(userId, userGroupId), bson.E{Key: accessModel.RolesKey, Value: role})
+	count, err := s.c.CountDocuments(ctx, accessModel, filter)
+	if err != nil {
+		return false, myerrors.Wrap(myerrors.ErrGetAccess, myerrors.KindAccess, idFor(userId, userGroupId), err)
+	}
+	return count > 0, nil
+}
+
+// EffectivePermissions expands the roles of the user for the user group through the role store
+func (s accessStore) EffectivePermissions(ctx context.Context, userId primitive.ObjectID, userGroupId primitive.ObjectID) ([]string, error) {
+	a := &Access{}
+	exists, err := s.c.FindOne(ctx, accessModel, a, filterFor(userId, userGroupId))
+	if err != nil {
+		return nil, myerrors.Wrap(myerrors.ErrGetPermissions, myerrors.KindAccess, idFor(userId, userGroupId), err)
+	}
+	if !exists {
+		return []string{}, nil
+	}
+	permissions, err := NewRoleStore(s.c).Permissions(ctx, a.Roles...)
+	if err != nil {
+		return nil, myerrors.Wrap(myerrors.ErrGetPermissions, myerrors.KindAccess, idFor(userId, userGroupId), err)
+	}
+	return permissions, nil
+}
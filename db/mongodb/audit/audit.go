@@ -0,0 +1,84 @@
+package audit
+
+import (
+	"context"
+	"time"
+
+	"github.com/sr-codefreak/user-group/db/mongodb"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Record is an immutable entry of the audit log. Before and After hold the
+// fields of the entity that the action changed, Before is empty for created
+// and After for deleted entities.
+type Record struct {
+	ID       primitive.ObjectID `bson:"_id,omitempty" json:"_id,omitempty"`
+	Time     time.Time          `bson:"time" json:"time"`
+	Actor    string             `bson:"actor" json:"actor"`
+	Action   string             `bson:"action" json:"action"`
+	Kind     string             `bson:"kind" json:"kind"`
+	EntityId string             `bson:"entityId" json:"entityId"`
+	Before   map[string]any     `bson:"before,omitempty" json:"before,omitempty"`
+	After    map[string]any     `bson:"after,omitempty" json:"after,omitempty"`
+}
+
+// Query selects the records of an entity in a time range, newest first.
+// Empty fields do not restrict the records; From is inclusive, To exclusive.
+type Query struct {
+	Kind     string
+	EntityId string
+	From     time.Time
+	To       time.Time
+	// Limit defaults to mongodb.DefaultPageSize and is capped at mongodb.MaxPageSize
+	Limit int
+}
+
+// Normalize returns q with the limit in the range of a page size
+func (q Query) Normalize() Query {
+	q.Limit = mongodb.ListOptions{PageSize: q.Limit}.Limit()
+	return q
+}
+
+type RecordModel struct {
+	mongodb.UserGroup
+	IdKey       string
+	TimeKey     string
+	ActorKey    string
+	ActionKey   string
+	KindKey     string
+	EntityIdKey string
+	BeforeKey   string
+	AfterKey    string
+}
+
+var recordModel = &RecordModel{
+	IdKey:       "_id",
+	TimeKey:     "time",
+	ActorKey:    "actor",
+	ActionKey:   "action",
+	KindKey:     "kind",
+	EntityIdKey: "entityId",
+	BeforeKey:   "before",
+	AfterKey:    "after",
+}
+
+func GetModel() *RecordModel {
+	return recordModel
+}
+
+func (r RecordModel) CollectionName() string {
+	return "audit"
+}
+
+type actorKey struct{}
+
+// WithActor returns a context recording actor as the author of the mutations made with it
+func WithActor(ctx context.Context, actor string) context.Context {
+	return context.WithValue(ctx, actorKey{}, actor)
+}
+
+// ActorFrom returns the actor set with WithActor, empty when there is none
+func ActorFrom(ctx context.Context) string {
+	actor, _ := ctx.Value(actorKey{}).(string)
+	return actor
+}
@@ -0,0 +1,100 @@
+package audit
+
+import (
+	"context"
+	"time"
+
+	"github.com/sr-codefreak/user-group/db/mongodb"
+	"github.com/sr-codefreak/user-group/myerrors"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// AuditStore appends to and queries the audit log. Records cannot be changed
+// or removed through the store.
+type AuditStore interface {
+	EnsureIndexes(ctx context.Context) error
+	// Append inserts the record and sets r.ID, and r.Time when it is zero
+	Append(ctx context.Context, r *Record) error
+	// List returns the records matching q, newest first
+	List(ctx context.Context, q Query) ([]Record, error)
+}
+
+type auditStore struct {
+	c *mongodb.Client
+}
+
+// NewStore returns the audit store using the mongo client c
+func NewStore(c *mongodb.Client) AuditStore {
+	return auditStore{c: c}
+}
+
+var AuStore = NewStore(mongodb.Default())
+
+// EnsureIndexes creates the (kind, entityId, time) and time indexes
+func (s auditStore) EnsureIndexes(ctx context.Context) error {
+	for _, keys := range []bson.D{
+		{
+			{Key: recordModel.KindKey, Value: 1},
+			{Key: recordModel.EntityIdKey, Value: 1},
+			{Key: recordModel.TimeKey, Value: -1},
+		},
+		{
+			{Key: recordModel.TimeKey, Value: -1},
+		},
+	} {
+		if _, err := s.c.CreateIndex(ctx, recordModel, mongo.IndexModel{Keys: keys}); err != nil {
+			return myerrors.Wrap(myerrors.ErrCreatingIndex, myerrors.KindAudit, "", err)
+		}
+	}
+	return nil
+}
+
+func (s auditStore) Append(ctx context.Context, r *Record) error {
+	if r.Time.IsZero() {
+		r.Time = time.Now().UTC()
+	}
+	id, err := s.c.InsertOne(ctx, recordModel, r)
+	if err != nil {
+		return myerrors.Wrap(myerrors.ErrAppendingAudit, myerrors.KindAudit, "", err)
+	}
+	if oid, ok := id.(primitive.ObjectID); ok {
+		r.ID = oid
+	}
+	return nil
+}
+
+func (s auditStore) List(ctx context.Context, q Query) ([]Record, error) {
+	q = q.Normalize()
+	filter := bson.D{}
+	if q.Kind != "" {
+		filter = append(filter, bson.E{Key: recordModel.KindKey, Value: q.Kind})
+	}
+	if q.EntityId != "" {
+		filter = append(filter, bson.E{Key: recordModel.EntityIdKey, Value: q.EntityId})
+	}
+	between := bson.D{}
+	if !q.From.IsZero() {
+		between = append(between, bson.E{Key: "$gte", Value: q.From})
+	}
+	if !q.To.IsZero() {
+		between = append(between, bson.E{Key: "$lt", Value: q.To})
+	}
+	if len(between) > 0 {
+		filter = append(filter, bson.E{Key: recordModel.TimeKey, Value: between})
+	}
+	opts := options.Find().
+		SetSort(bson.D{{Key: recordModel.TimeKey, Value: -1}, {Key: recordModel.IdKey, Value: -1}}).
+		SetLimit(int64(q.Limit))
+	cursor, err := s.c.Find(ctx, recordModel, filter, opts)
+	if err != nil {
+		return nil, myerrors.Wrap(myerrors.ErrListingAudit, myerrors.KindAudit, "", err)
+	}
+	records := []Record{}
+	if err := cursor.All(ctx, &records); err != nil {
+		return nil, myerrors.Wrap(myerrors.ErrListingAudit, myerrors.KindAudit, "", err)
+	}
+	return records, nil
+}
@@ -0,0 +1,330 @@
+package mongodb
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sr-codefreak/user-group/myerrors"
+	"github.com/sr-codefreak/user-group/utils/logger"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/mongo/readpref"
+	"go.mongodb.org/mongo-driver/x/mongo/driver/connstring"
+)
+
+var log = logger.GetLogger()
+
+// Config holds the settings of a Client.
+// Zero durations fall back to the defaults documented on each field.
+type Config struct {
+	// URI is the mongo connection string, see
+	// https://docs.mongodb.com/manual/reference/connection-string/
+	URI string
+	// Naming names the databases and collections of the models
+	Naming Naming
+	// Timeout is applied to operations whose context has no deadline,
+	// zero means 10 seconds and a negative value disables it
+	Timeout time.Duration
+	// ConnectTimeout bounds each connection attempt, default 10 seconds
+	ConnectTimeout time.Duration
+	// PingInterval is the time between two pings of a healthy connection, default 5 seconds
+	PingInterval time.Duration
+	// PingTimeout bounds each ping, default 3 seconds
+	PingTimeout time.Duration
+	// Backoff spaces the attempts while the server cannot be reached
+	Backoff Backoff
+}
+
+// Backoff is an exponential backoff with jitter.
+// The n-th retry waits Initial * Multiplier^n, capped at Max, and
+// randomly shortened or lengthened by up to Jitter of that delay.
+type Backoff struct {
+	// Initial is the delay before the first retry, default 1 second
+	Initial time.Duration
+	// Max caps the delay, default 30 seconds
+	Max time.Duration
+	// Multiplier grows the delay after each failed attempt, default 2
+	Multiplier float64
+	// Jitter is the fraction of the delay randomised, between 0 and 1, default 0.2
+	Jitter float64
+}
+
+func (cfg Config) withDefaults() Config {
+	if cfg.Timeout == 0 {
+		cfg.Timeout = 10 * time.Second
+	}
+	if cfg.ConnectTimeout <= 0 {
+		cfg.ConnectTimeout = 10 * time.Second
+	}
+	if cfg.PingInterval <= 0 {
+		cfg.PingInterval = 5 * time.Second
+	}
+	if cfg.PingTimeout <= 0 {
+		cfg.PingTimeout = 3 * time.Second
+	}
+	if cfg.Backoff.Initial <= 0 {
+		cfg.Backoff.Initial = 1 * time.Second
+	}
+	if cfg.Backoff.Max <= 0 {
+		cfg.Backoff.Max = 30 * time.Second
+	}
+	if cfg.Backoff.Multiplier < 1 {
+		cfg.Backoff.Multiplier = 2
+	}
+	if cfg.Backoff.Jitter <= 0 || cfg.Backoff.Jitter > 1 {
+		cfg.Backoff.Jitter = 0.2
+	}
+	return cfg
+}
+
+// Client is a mongo connection together with the goroutine monitoring it.
+// The stores are built from a Client, the package level helpers use the
+// Client returned by Default.
+type Client struct {
+	mu          sync.RWMutex
+	cfg         Config
+	timeout     time.Duration
+	c           *mongo.Client
+	isConnected bool
+
+	// stop is closed to end the monitor goroutine, which closes done on exit.
+	// stop is nil while no monitor is running, done is the one of the last
+	// monitor started.
+	stop chan struct{}
+	done chan struct{}
+
+	subsMu sync.Mutex
+	subs   map[chan ConnectionEvent]struct{}
+}
+
+// New returns a Client for cfg. It does not connect until Connect is called.
+func New(cfg Config) *Client {
+	cfg = cfg.withDefaults()
+	return &Client{
+		cfg:     cfg,
+		timeout: cfg.Timeout,
+		subs:    map[chan ConnectionEvent]struct{}{},
+	}
+}
+
+func (mc *Client) getIsConnected() bool {
+	mc.mu.RLock()
+	defer mc.mu.RUnlock()
+	return mc.isConnected
+}
+
+func (mc *Client) setIsConnected(conn bool) {
+	mc.mu.Lock()
+	defer mc.mu.Unlock()
+	mc.isConnected = conn
+}
+
+func (mc *Client) getClient() *mongo.Client {
+	mc.mu.RLock()
+	defer mc.mu.RUnlock()
+	return mc.c
+}
+
+func (mc *Client) setClient(c *mongo.Client) {
+	mc.mu.Lock()
+	defer mc.mu.Unlock()
+	mc.c = c
+}
+
+func (mc *Client) config() Config {
+	mc.mu.RLock()
+	defer mc.mu.RUnlock()
+	return mc.cfg
+}
+
+func (mc *Client) disconnect(ctx context.Context) error {
+	mc.mu.Lock()
+	defer mc.mu.Unlock()
+	var err error
+	if mc.c != nil {
+		err = mc.c.Disconnect(ctx)
+		if err != nil {
+			if err == mongo.ErrClientDisconnected {
+				log.Warnf("Mongo client already disconnected")
+			} else {
+				log.Errorf("error in disconnect: %s", err)
+			}
+		}
+		mc.c = nil
+	}
+	mc.isConnected = false
+	return err
+}
+
+// URI returns the connection string of the client
+func (mc *Client) URI() string {
+	return mc.config().URI
+}
+
+// DatabaseName returns the name of the database of the model for the
+// tenant of ctx, see Naming
+func (mc *Client) DatabaseName(ctx context.Context, m DatabaseNamer) string {
+	return mc.config().Naming.DatabaseName(ctx, m)
+}
+
+// HasTenant reports whether the client serves tenant, see Naming.HasTenant
+func (mc *Client) HasTenant(tenant string) bool {
+	return mc.config().Naming.HasTenant(tenant)
+}
+
+// CollectionName returns the name of the collection of the model, for
+// pipeline stages like $lookup naming another collection
+func (mc *Client) CollectionName(m CollectionNamer) string {
+	return mc.config().Naming.CollectionName(m)
+}
+
+// collection returns the collection of the model for the tenant of ctx
+func (mc *Client) collection(ctx context.Context, m collectionDatabaseNamer) *mongo.Collection {
+	naming := mc.config().Naming
+	return mc.getClient().Database(naming.DatabaseName(ctx, m)).Collection(naming.CollectionName(m))
+}
+
+func (mc *Client) setURI(uri string) {
+	mc.mu.Lock()
+	defer mc.mu.Unlock()
+	mc.cfg.URI = uri
+}
+
+// IsConnected reports whether the last ping of the monitor succeeded
+func (mc *Client) IsConnected() bool {
+	return mc.getIsConnected()
+}
+
+func (mc *Client) ping() error {
+	c := mc.getClient()
+	if c == nil {
+		return mongo.ErrClientDisconnected
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), mc.config().PingTimeout)
+	defer cancel()
+	return c.Ping(ctx, readpref.Primary())
+}
+
+func (mc *Client) connect() error {
+	if mc.getClient() != nil {
+		return nil
+	}
+	cfg := mc.config()
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.ConnectTimeout)
+	defer cancel()
+	c, err := mongo.Connect(ctx, options.Client().ApplyURI(cfg.URI))
+	if err != nil {
+		return err
+	}
+	mc.setClient(c)
+	return nil
+}
+
+func hosts(connectionURI string) string {
+	cs, err := connstring.ParseAndValidate(connectionURI)
+	if err != nil {
+		return ""
+	}
+	return strings.Join(cs.Hosts, ",")
+}
+
+// Connect starts the goroutine connecting and then monitoring the connection.
+// dbSigChan, when not nil, is signalled once the client is connected.
+// Calling Connect while the monitor runs only waits for the connection.
+// After Disconnect the new monitor starts once the stopped one has exited.
+func (mc *Client) Connect(dbSigChan chan struct{}) error {
+	mc.mu.Lock()
+	if mc.stop == nil {
+		prev := mc.done
+		mc.stop = make(chan struct{})
+		mc.done = make(chan struct{})
+		go mc.monitor(prev, mc.stop, mc.done)
+	}
+	done := mc.done
+	mc.mu.Unlock()
+
+	if dbSigChan != nil {
+		go mc.notifyConnected(dbSigChan, done)
+	}
+	return nil
+}
+
+// notifyConnected signals dbSigChan once connected, unless the monitor exits first
+func (mc *Client) notifyConnected(dbSigChan chan struct{}, done chan struct{}) {
+	events, unsubscribe := mc.Subscribe()
+	defer unsubscribe()
+	for !mc.getIsConnected() {
+		select {
+		case <-events:
+		case <-done:
+			return
+		}
+	}
+	select {
+	case dbSigChan <- struct{}{}:
+	case <-done:
+	}
+}
+
+// Disconnect stops the connection monitor and disconnects without waiting for it.
+// Returns myerrors.ErrNoMongoConnection as error when no mongo connection, the
+// monitor is stopped all the same
+func (mc *Client) Disconnect() error {
+	connected := mc.getIsConnected()
+	mc.stopMonitor()
+	if !connected {
+		return myerrors.ErrNoMongoConnection
+	}
+	log.Warnf("mongo connection marked for disconnection")
+	return nil
+}
+
+// Shutdown stops the connection monitor and blocks until it has exited and
+// the client has disconnected, or until ctx is done. It also waits for the
+// monitor stopped by a prior Disconnect.
+func (mc *Client) Shutdown(ctx context.Context) error {
+	done := mc.stopMonitor()
+	if done == nil {
+		return nil
+	}
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// stopMonitor signals the monitor to exit and returns the channel closed on
+// exit, which is kept until the next Connect. It is nil when no monitor was
+// started.
+func (mc *Client) stopMonitor() chan struct{} {
+	mc.mu.Lock()
+	defer mc.mu.Unlock()
+	if mc.stop != nil {
+		close(mc.stop)
+		mc.stop = nil
+	}
+	return mc.done
+}
+
+// Ping checks the primary of the connected server answers
+// Returns myerrors.ErrNoMongoConnection as error when no mongo connection
+func (mc *Client) Ping(ctx context.Context) error {
+	c, err := mc.GetClient()
+	if err != nil {
+		return err
+	}
+	return c.Ping(ctx, readpref.Primary())
+}
+
+// GetClient returns the connected mongo client
+// Returns myerrors.ErrNoMongoConnection as error when no mongo connection
+func (mc *Client) GetClient() (*mongo.Client, error) {
+	if !mc.getIsConnected() {
+		return nil, myerrors.ErrNoMongoConnection
+	}
+	return mc.getClient(), nil
+}
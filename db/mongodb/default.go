@@ -0,0 +1,172 @@
+package mongodb
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// The package level functions below keep the API from before Client existed.
+// They all use the default client set up with Connect.
+
+var defaultClient = New(Config{})
+
+// Default returns the client used by the package level functions
+func Default() *Client {
+	return defaultClient
+}
+
+// Connect connects the default client and keeps monitoring the connection.
+// More info on mongo connection string
+// https://docs.mongodb.com/manual/reference/connection-string/
+func Connect(connectionURI string, dbSigChan chan struct{}) error {
+	defaultClient.setURI(connectionURI)
+	return defaultClient.Connect(dbSigChan)
+}
+
+// Configure replaces the config of the default client, the URI is kept when
+// cfg has none. Call it before Connect.
+func Configure(cfg Config) {
+	defaultClient.mu.Lock()
+	defer defaultClient.mu.Unlock()
+	if cfg.URI == "" {
+		cfg.URI = defaultClient.cfg.URI
+	}
+	defaultClient.cfg = cfg.withDefaults()
+	defaultClient.timeout = defaultClient.cfg.Timeout
+}
+
+// Disconnect mongo client connection of the default client.
+// Returns myerrors.ErrNoMongoConnection as error when no mongo connection
+func Disconnect() error {
+	return defaultClient.Disconnect()
+}
+
+// Shutdown stops the default client and waits until it has disconnected
+func Shutdown(ctx context.Context) error {
+	return defaultClient.Shutdown(ctx)
+}
+
+// Subscribe subscribes to the connection state changes of the default client
+func Subscribe() (<-chan ConnectionEvent, func()) {
+	return defaultClient.Subscribe()
+}
+
+// GetClient returns the connected mongo client of the default client
+// Returns myerrors.ErrNoMongoConnection as error when no mongo connection
+func GetClient() (*mongo.Client, error) {
+	return defaultClient.GetClient()
+}
+
+// SetDefaultTimeout sets the timeout of the default client
+func SetDefaultTimeout(d time.Duration) {
+	defaultClient.SetDefaultTimeout(d)
+}
+
+// DefaultTimeout returns the timeout of the default client
+func DefaultTimeout() time.Duration {
+	return defaultClient.DefaultTimeout()
+}
+
+// WithTransaction runs Client.WithTransaction on the default client
+func WithTransaction(ctx context.Context, fn func(ctx SessionContext) error, opts ...*options.TransactionOptions) error {
+	return defaultClient.WithTransaction(ctx, fn, opts...)
+}
+
+// FindOne runs Client.FindOne on the default client
+func FindOne(ctx context.Context, m collectionDatabaseNamer, i interface{}, query bson.D, opts ...*options.FindOneOptions) (bool, error) {
+	return defaultClient.FindOne(ctx, m, i, query, opts...)
+}
+
+// FindOneWithModel runs Client.FindOneWithModel on the default client
+func FindOneWithModel(ctx context.Context, m collectionDatabaseNamer, i interface{}, d bson.D) (interface{}, bool, error) {
+	return defaultClient.FindOneWithModel(ctx, m, i, d)
+}
+
+// UpdateOne runs Client.UpdateOne on the default client
+func UpdateOne(ctx context.Context, m collectionDatabaseNamer, filter bson.D, update bson.D, opts ...*options.UpdateOptions) (*mongo.UpdateResult, error) {
+	return defaultClient.UpdateOne(ctx, m, filter, update, opts...)
+}
+
+// UpdateMany runs Client.UpdateMany on the default client
+func UpdateMany(ctx context.Context, m collectionDatabaseNamer, filter bson.D, update bson.D, opts ...*options.UpdateOptions) (*mongo.UpdateResult, error) {
+	return defaultClient.UpdateMany(ctx, m, filter, update, opts...)
+}
+
+// AddToArray runs Client.AddToArray on the default client
+func AddToArray(ctx context.Context, m collectionDatabaseNamer, filter bson.D, update bson.D, opts ...*options.UpdateOptions) (*mongo.UpdateResult, error) {
+	return defaultClient.AddToArray(ctx, m, filter, update, opts...)
+}
+
+// PullFromArray runs Client.PullFromArray on the default client
+func PullFromArray(ctx context.Context, m collectionDatabaseNamer, filter bson.D, update bson.D, opts ...*options.UpdateOptions) (*mongo.UpdateResult, error) {
+	return defaultClient.PullFromArray(ctx, m, filter, update, opts...)
+}
+
+// PullFromArrayMany runs Client.PullFromArrayMany on the default client
+func PullFromArrayMany(ctx context.Context, m collectionDatabaseNamer, filter bson.D, update bson.D, opts ...*options.UpdateOptions) (*mongo.UpdateResult, error) {
+	return defaultClient.PullFromArrayMany(ctx, m, filter, update, opts...)
+}
+
+// DeleteOne runs Client.DeleteOne on the default client
+func DeleteOne(ctx context.Context, m collectionDatabaseNamer, d bson.D) error {
+	return defaultClient.DeleteOne(ctx, m, d)
+}
+
+// DeleteMany runs Client.DeleteMany on the default client
+func DeleteMany(ctx context.Context, m collectionDatabaseNamer, d bson.D) error {
+	return defaultClient.DeleteMany(ctx, m, d)
+}
+
+// DeleteAll runs Client.DeleteAll on the default client
+func DeleteAll(ctx context.Context, m collectionDatabaseNamer) error {
+	return defaultClient.DeleteAll(ctx, m)
+}
+
+// InsertOne runs Client.InsertOne on the default client
+func InsertOne(ctx context.Context, m collectionDatabaseNamer, i interface{}) (interface{}, error) {
+	return defaultClient.InsertOne(ctx, m, i)
+}
+
+// InsertMany runs Client.InsertMany on the default client
+func InsertMany(ctx context.Context, m collectionDatabaseNamer, docs []interface{}) ([]interface{}, error) {
+	return defaultClient.InsertMany(ctx, m, docs)
+}
+
+// CountDocuments runs Client.CountDocuments on the default client
+func CountDocuments(ctx context.Context, m collectionDatabaseNamer, filter bson.D) (int64, error) {
+	return defaultClient.CountDocuments(ctx, m, filter)
+}
+
+// Find runs Client.Find on the default client
+func Find(ctx context.Context, m collectionDatabaseNamer, filter interface{}, opts ...*options.FindOptions) (*mongo.Cursor, error) {
+	return defaultClient.Find(ctx, m, filter, opts...)
+}
+
+// Aggregate runs Client.Aggregate on the default client
+func Aggregate(ctx context.Context, m collectionDatabaseNamer, d mongo.Pipeline) (*mongo.Cursor, error) {
+	return defaultClient.Aggregate(ctx, m, d)
+}
+
+// Watch runs Client.Watch on the default client
+func Watch(ctx context.Context, m collectionDatabaseNamer, d mongo.Pipeline, opts ...*options.ChangeStreamOptions) (*mongo.ChangeStream, error) {
+	return defaultClient.Watch(ctx, m, d, opts...)
+}
+
+// CreateIndex runs Client.CreateIndex on the default client
+func CreateIndex(ctx context.Context, m collectionDatabaseNamer, model mongo.IndexModel) (string, error) {
+	return defaultClient.CreateIndex(ctx, m, model)
+}
+
+// Distinct runs Client.Distinct on the default client
+func Distinct(ctx context.Context, m collectionDatabaseNamer, field string, filter interface{}) ([]interface{}, error) {
+	return defaultClient.Distinct(ctx, m, field, filter)
+}
+
+// UpdateWithUnsetKey runs Client.UpdateWithUnsetKey on the default client
+func UpdateWithUnsetKey(ctx context.Context, m collectionDatabaseNamer, filter bson.D, update bson.D, opts ...*options.UpdateOptions) (*mongo.UpdateResult, error) {
+	return defaultClient.UpdateWithUnsetKey(ctx, m, filter, update, opts...)
+}
@@ -0,0 +1,43 @@
+package mongodb
+
+import (
+	"context"
+	"errors"
+
+	"github.com/sr-codefreak/user-group/myerrors"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// writeConflictCode is the server error code of a write conflict in a transaction
+const writeConflictCode = 112
+
+// mapError classifies driver errors into a myerrors.Error so callers can
+// tell a missing document from a duplicate key or an unreachable server
+func mapError(err error) error {
+	if err == nil {
+		return nil
+	}
+	var e *myerrors.Error
+	if errors.As(err, &e) {
+		return err
+	}
+	code := myerrors.Unknown
+	var se mongo.ServerError
+	switch {
+	case errors.Is(err, mongo.ErrNoDocuments):
+		code = myerrors.NotFound
+	case mongo.IsDuplicateKeyError(err):
+		code = myerrors.AlreadyExists
+	case errors.As(err, &se) && (se.HasErrorCode(writeConflictCode) || se.HasErrorLabel("TransientTransactionError")):
+		code = myerrors.Conflict
+	case mongo.IsTimeout(err), mongo.IsNetworkError(err),
+		errors.Is(err, mongo.ErrClientDisconnected),
+		errors.Is(err, context.DeadlineExceeded):
+		code = myerrors.Unavailable
+	case errors.Is(err, mongo.ErrNilDocument), errors.Is(err, mongo.ErrEmptySlice):
+		code = myerrors.InvalidArgument
+	default:
+		return err
+	}
+	return &myerrors.Error{Code: code, Err: err}
+}
@@ -0,0 +1,84 @@
+package event
+
+import (
+	"time"
+
+	"github.com/sr-codefreak/user-group/db/mongodb"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Type names what happened to an entity
+type Type string
+
+const (
+	UserCreated     Type = "user.created"
+	UserUpdated     Type = "user.updated"
+	UserDeleted     Type = "user.deleted"
+	GroupCreated    Type = "userGroup.created"
+	GroupRenamed    Type = "userGroup.renamed"
+	GroupDeleted    Type = "userGroup.deleted"
+	RuleChanged     Type = "userGroup.ruleChanged"
+	MemberAdded     Type = "member.added"
+	MemberRemoved   Type = "member.removed"
+	SubgroupAdded   Type = "subgroup.added"
+	SubgroupRemoved Type = "subgroup.removed"
+	RoleGranted     Type = "role.granted"
+	RoleRevoked     Type = "role.revoked"
+	RolesSet        Type = "role.set"
+	RoleDefined     Type = "role.defined"
+	RoleUpdated     Type = "role.updated"
+	RoleDeleted     Type = "role.deleted"
+)
+
+// Event is an entry of the outbox. Only the fields concerning the type are set:
+// UserId for user and member events, UserGroupId for user group, member,
+// subgroup and access events, ChildGroupId for subgroup events, Name for the
+// name of a user, user group or role, Roles for access events and Rule for
+// RuleChanged.
+type Event struct {
+	ID           primitive.ObjectID `bson:"_id,omitempty" json:"_id,omitempty"`
+	Type         Type               `bson:"type" json:"type"`
+	Time         time.Time          `bson:"time" json:"time"`
+	Actor        string             `bson:"actor,omitempty" json:"actor,omitempty"`
+	UserId       string             `bson:"userId,omitempty" json:"userId,omitempty"`
+	UserGroupId  string             `bson:"userGroupId,omitempty" json:"userGroupId,omitempty"`
+	ChildGroupId string             `bson:"childGroupId,omitempty" json:"childGroupId,omitempty"`
+	Name         string             `bson:"name,omitempty" json:"name,omitempty"`
+	Roles        []string           `bson:"roles,omitempty" json:"roles,omitempty"`
+	Rule         string             `bson:"rule,omitempty" json:"rule,omitempty"`
+}
+
+type EventModel struct {
+	mongodb.UserGroup
+	IdKey           string
+	TypeKey         string
+	TimeKey         string
+	ActorKey        string
+	UserIdKey       string
+	UserGroupIdKey  string
+	ChildGroupIdKey string
+	NameKey         string
+	RolesKey        string
+	RuleKey         string
+}
+
+var eventModel = &EventModel{
+	IdKey:           "_id",
+	TypeKey:         "type",
+	TimeKey:         "time",
+	ActorKey:        "actor",
+	UserIdKey:       "userId",
+	UserGroupIdKey:  "userGroupId",
+	ChildGroupIdKey: "childGroupId",
+	NameKey:         "name",
+	RolesKey:        "roles",
+	RuleKey:         "rule",
+}
+
+func GetModel() *EventModel {
+	return eventModel
+}
+
+func (e EventModel) CollectionName() string {
+	return "outbox"
+}
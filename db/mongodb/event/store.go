@@ -0,0 +1,95 @@
+package event
+
+import (
+	"context"
+	"time"
+
+	"github.com/sr-codefreak/user-group/db/mongodb"
+	"github.com/sr-codefreak/user-group/myerrors"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// OutboxStore holds the events waiting to be consumed. Events are appended
+// in the transaction of the mutation they describe, so an event is stored
+// if and only if its mutation is.
+type OutboxStore interface {
+	EnsureIndexes(ctx context.Context) error
+	// Append inserts the events and sets their ID, and Time when it is zero
+	Append(ctx context.Context, events ...*Event) error
+	// List returns up to limit events appended after the event with id after,
+	// oldest first. A zero after lists from the oldest retained event.
+	List(ctx context.Context, after primitive.ObjectID, limit int) ([]Event, error)
+	// Prune removes the events appended before the time
+	Prune(ctx context.Context, before time.Time) error
+}
+
+type outboxStore struct {
+	c *mongodb.Client
+}
+
+// NewStore returns the outbox store using the mongo client c
+func NewStore(c *mongodb.Client) OutboxStore {
+	return outboxStore{c: c}
+}
+
+var EvStore = NewStore(mongodb.Default())
+
+// EnsureIndexes creates the time index used by Prune
+func (s outboxStore) EnsureIndexes(ctx context.Context) error {
+	keys := bson.D{{Key: eventModel.TimeKey, Value: 1}}
+	if _, err := s.c.CreateIndex(ctx, eventModel, mongo.IndexModel{Keys: keys}); err != nil {
+		return myerrors.Wrap(myerrors.ErrCreatingIndex, myerrors.KindEvent, "", err)
+	}
+	return nil
+}
+
+func (s outboxStore) Append(ctx context.Context, events ...*Event) error {
+	if len(events) == 0 {
+		return nil
+	}
+	now := time.Now().UTC()
+	docs := make([]interface{}, len(events))
+	for i, e := range events {
+		if e.ID.IsZero() {
+			e.ID = primitive.NewObjectID()
+		}
+		if e.Time.IsZero() {
+			e.Time = now
+		}
+		docs[i] = e
+	}
+	if _, err := s.c.InsertMany(ctx, eventModel, docs); err != nil {
+		return myerrors.Wrap(myerrors.ErrAppendingEvents, myerrors.KindEvent, "", err)
+	}
+	return nil
+}
+
+func (s outboxStore) List(ctx context.Context, after primitive.ObjectID, limit int) ([]Event, error) {
+	filter := bson.D{}
+	if !after.IsZero() {
+		filter = append(filter, bson.E{Key: eventModel.IdKey, Value: bson.D{{Key: "$gt", Value: after}}})
+	}
+	opts := options.Find().
+		SetSort(bson.D{{Key: eventModel.IdKey, Value: 1}}).
+		SetLimit(int64(mongodb.ListOptions{PageSize: limit}.Limit()))
+	cursor, err := s.c.Find(ctx, eventModel, filter, opts)
+	if err != nil {
+		return nil, myerrors.Wrap(myerrors.ErrListingEvents, myerrors.KindEvent, "", err)
+	}
+	events := []Event{}
+	if err := cursor.All(ctx, &events); err != nil {
+		return nil, myerrors.Wrap(myerrors.ErrListingEvents, myerrors.KindEvent, "", err)
+	}
+	return events, nil
+}
+
+func (s outboxStore) Prune(ctx context.Context, before time.Time) error {
+	filter := bson.D{{Key: eventModel.TimeKey, Value: bson.D{{Key: "$lt", Value: before}}}}
+	if err := s.c.DeleteMany(ctx, eventModel, filter); err != nil {
+		return myerrors.Wrap(myerrors.ErrPruningEvents, myerrors.KindEvent, "", err)
+	}
+	return nil
+}
@@ -0,0 +1,251 @@
+package mongodb
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/sr-codefreak/user-group/myerrors"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	// DefaultPageSize is the page size of a List call without PageSize
+	DefaultPageSize = 50
+	// MaxPageSize caps the PageSize of a List call
+	MaxPageSize = 1000
+)
+
+// Keys that can be filtered and sorted on, they are the same in every store
+const (
+	NameKey  = "name"
+	EmailKey = "email"
+	PhoneKey = "phone"
+	// RuleKey can only be filtered on, with ListFilter.Dynamic
+	RuleKey = "rule"
+)
+
+// SortField orders a List call by the value of Key
+type SortField struct {
+	Key  string `json:"k"`
+	Desc bool   `json:"d,omitempty"`
+}
+
+// ListFilter selects the entries of a List call.
+// Name, Email and Phone match the entries whose value starts with them,
+// NameFold the entries whose name equals it ignoring case, see NameCollation,
+// MetaData the entries whose metaData has the given value for each key,
+// Dynamic the user groups with a rule. Empty fields do not filter.
+type ListFilter struct {
+	Name     string
+	NameFold string
+	Email    string
+	Phone    string
+	MetaData map[string]any
+	Dynamic  bool
+}
+
+// NameCollation compares the names ignoring case, for ListFilter.NameFold
+// and the index serving it
+var NameCollation = &options.Collation{Locale: "en", Strength: 2}
+
+// ListOptions selects a page of a List call.
+// Entries are ordered by Sort and then by id, which keeps the order stable,
+// so pages can be continued with the NextPageToken of the previous page
+// without skipping over the entries before it.
+type ListOptions struct {
+	// PageSize is the maximum number of entries returned,
+	// DefaultPageSize when zero and at most MaxPageSize
+	PageSize int
+	// PageToken is the token returned by the previous page, empty for the first page.
+	// It is only valid with the same Sort as the previous page.
+	PageToken string
+	Sort      []SortField
+	Filter    ListFilter
+}
+
+var (
+	errInvalidPageToken = errors.New("invalid page token")
+	errPageTokenSort    = errors.New("page token was issued for another sort order")
+	errNegativePageSize = errors.New("page size must not be negative")
+)
+
+// Limit returns the normalised page size of o
+func (o ListOptions) Limit() int {
+	switch {
+	case o.PageSize <= 0:
+		return DefaultPageSize
+	case o.PageSize > MaxPageSize:
+		return MaxPageSize
+	}
+	return o.PageSize
+}
+
+// Check validates o for a store whose entries can be sorted and filtered by keys.
+// The returned error is an InvalidArgument myerrors.Error.
+func (o ListOptions) Check(keys ...string) error {
+	allowed := map[string]bool{}
+	for _, k := range keys {
+		allowed[k] = true
+	}
+	if o.PageSize < 0 {
+		return myerrors.Invalid(errNegativePageSize)
+	}
+	seen := map[string]bool{}
+	for _, f := range o.Sort {
+		if !allowed[f.Key] || f.Key == RuleKey {
+			return myerrors.Invalid(fmt.Errorf("cannot sort by %q", f.Key))
+		}
+		if seen[f.Key] {
+			return myerrors.Invalid(fmt.Errorf("sort key %q given twice", f.Key))
+		}
+		seen[f.Key] = true
+	}
+	for key, value := range map[string]string{NameKey: o.Filter.Name, EmailKey: o.Filter.Email, PhoneKey: o.Filter.Phone} {
+		if value != "" && !allowed[key] {
+			return myerrors.Invalid(fmt.Errorf("cannot filter by %q", key))
+		}
+	}
+	if o.Filter.NameFold != "" && !allowed[NameKey] {
+		return myerrors.Invalid(fmt.Errorf("cannot filter by %q", NameKey))
+	}
+	if o.Filter.NameFold != "" && o.Filter.Name != "" {
+		return myerrors.Invalid(errors.New("name cannot be filtered by prefix and ignoring case at once"))
+	}
+	if o.Filter.Dynamic && !allowed[RuleKey] {
+		return myerrors.Invalid(fmt.Errorf("cannot filter by %q", RuleKey))
+	}
+	for key := range o.Filter.MetaData {
+		if key == "" || strings.ContainsAny(key, ".$") {
+			return myerrors.Invalid(fmt.Errorf("invalid metaData key %q", key))
+		}
+	}
+	_, err := o.After()
+	return err
+}
+
+// PageToken is the decoded position of the last entry of a page
+type PageToken struct {
+	Sort   []SortField `json:"s,omitempty"`
+	Values []string    `json:"v,omitempty"`
+	ID     string      `json:"id"`
+}
+
+// Encode returns the opaque form of t handed out to callers
+func (t PageToken) Encode() string {
+	b, _ := json.Marshal(t)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// After decodes the PageToken of o, nil for the first page
+func (o ListOptions) After() (*PageToken, error) {
+	if o.PageToken == "" {
+		return nil, nil
+	}
+	b, err := base64.RawURLEncoding.DecodeString(o.PageToken)
+	if err != nil {
+		return nil, myerrors.Invalid(errInvalidPageToken)
+	}
+	t := &PageToken{}
+	if err := json.Unmarshal(b, t); err != nil || len(t.Values) != len(t.Sort) {
+		return nil, myerrors.Invalid(errInvalidPageToken)
+	}
+	if _, err := primitive.ObjectIDFromHex(t.ID); err != nil {
+		return nil, myerrors.Invalid(errInvalidPageToken)
+	}
+	if len(t.Sort) != len(o.Sort) {
+		return nil, myerrors.Invalid(errPageTokenSort)
+	}
+	for i := range t.Sort {
+		if t.Sort[i] != o.Sort[i] {
+			return nil, myerrors.Invalid(errPageTokenSort)
+		}
+	}
+	return t, nil
+}
+
+// Page cuts items, fetched with a limit of o.Limit()+1, to the page and returns
+// the token of the next page, empty when there are no more entries.
+// position returns the values of the sort keys of o and the id of an item.
+func Page[T any](o ListOptions, items []T, position func(T) ([]string, primitive.ObjectID)) ([]T, string) {
+	if len(items) <= o.Limit() {
+		return items, ""
+	}
+	items = items[:o.Limit()]
+	values, id := position(items[len(items)-1])
+	return items, PageToken{Sort: o.Sort, Values: values, ID: id.Hex()}.Encode()
+}
+
+// Query returns the filter and find options of the page of o on a collection
+// identified by idKey and holding its metadata under metaDataKey
+func (o ListOptions) Query(idKey string, metaDataKey string) (bson.D, *options.FindOptions, error) {
+	after, err := o.After()
+	if err != nil {
+		return nil, nil, err
+	}
+	filter := bson.D{}
+	for _, e := range []bson.E{{Key: NameKey, Value: o.Filter.Name}, {Key: EmailKey, Value: o.Filter.Email}, {Key: PhoneKey, Value: o.Filter.Phone}} {
+		if prefix := e.Value.(string); prefix != "" {
+			// an anchored regex without options can use the index on the key
+			filter = append(filter, bson.E{Key: e.Key, Value: primitive.Regex{Pattern: "^" + regexp.QuoteMeta(prefix)}})
+		}
+	}
+	if o.Filter.NameFold != "" {
+		filter = append(filter, bson.E{Key: NameKey, Value: o.Filter.NameFold})
+	}
+	for key, value := range o.Filter.MetaData {
+		filter = append(filter, bson.E{Key: metaDataKey + "." + key, Value: value})
+	}
+	if o.Filter.Dynamic {
+		// static user groups have no rule, see usergroup.UserGroupStore.SetRule
+		filter = append(filter, bson.E{Key: RuleKey, Value: bson.D{{Key: "$exists", Value: true}}})
+	}
+	if after != nil {
+		oid, _ := primitive.ObjectIDFromHex(after.ID)
+		filter = append(filter, bson.E{Key: "$or", Value: afterFilter(o.Sort, after.Values, idKey, oid)})
+	}
+
+	sort := bson.D{}
+	for _, f := range o.Sort {
+		sort = append(sort, bson.E{Key: f.Key, Value: direction(f.Desc)})
+	}
+	sort = append(sort, bson.E{Key: idKey, Value: 1})
+	opts := options.Find().SetSort(sort).SetLimit(int64(o.Limit() + 1))
+	if o.Filter.NameFold != "" {
+		opts.SetCollation(NameCollation)
+	}
+	return filter, opts, nil
+}
+
+func direction(desc bool) int {
+	if desc {
+		return -1
+	}
+	return 1
+}
+
+// afterFilter returns the alternatives of entries ordered after the position
+// (values, id): greater on the first key, or equal on it and greater on the
+// next key, and so on up to the id
+func afterFilter(sort []SortField, values []string, idKey string, id primitive.ObjectID) bson.A {
+	alternatives := bson.A{}
+	equal := bson.D{}
+	for i, f := range sort {
+		op := "$gt"
+		if f.Desc {
+			op = "$lt"
+		}
+		alt := append(bson.D{}, equal...)
+		alt = append(alt, bson.E{Key: f.Key, Value: bson.D{{Key: op, Value: values[i]}}})
+		alternatives = append(alternatives, alt)
+		equal = append(equal, bson.E{Key: f.Key, Value: values[i]})
+	}
+	alt := append(bson.D{}, equal...)
+	alt = append(alt, bson.E{Key: idKey, Value: bson.D{{Key: "$gt", Value: id}}})
+	return append(alternatives, alt)
+}
@@ -4,236 +4,14 @@ import (
 	"context"
 	"errors"
 	"net/http"
-	"sync"
-	"time"
 
 	"github.com/sr-codefreak/user-group/myerrors"
 	"github.com/sr-codefreak/user-group/utils/logger"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
-	"go.mongodb.org/mongo-driver/mongo/readpref"
-	"go.mongodb.org/mongo-driver/x/mongo/driver/connstring"
 )
 
-var log = logger.GetLogger()
-
-type mongoclient struct {
-	sync.RWMutex
-	c                      *mongo.Client
-	isConnected            bool
-	isDisconnecting        bool
-	shouldDisconnect       bool
-	isMonitoringConnection bool
-}
-
-func (mc *mongoclient) waitForDisconnecting() bool {
-	for mc._getIsDisconnecting() {
-		time.Sleep(1 * time.Second)
-	}
-	return true
-}
-
-func (mc *mongoclient) _getIsDisconnecting() bool {
-	return mc.isDisconnecting
-}
-
-func (mc *mongoclient) getIsConnected() bool {
-	mc.RLock()
-	defer mc.RUnlock()
-	return mc.waitForDisconnecting() && mc.isConnected
-}
-
-func (mc *mongoclient) setIsConnected(conn bool) {
-	if mc.getIsConnected() != conn {
-		mc.Lock()
-		defer mc.Unlock()
-		mc.isConnected = conn
-		if conn {
-			mc.isDisconnecting = false
-		}
-	}
-}
-
-func (mc *mongoclient) getIsMonitoringConnection() bool {
-	mc.Lock()
-	defer mc.Unlock()
-	return mc.waitForDisconnecting() && mc.isMonitoringConnection
-}
-
-func (mc *mongoclient) setIsMonitoringConnection(monitoring bool) {
-	if mc.getIsMonitoringConnection() != monitoring {
-		mc.Lock()
-		defer mc.Unlock()
-		mc.isMonitoringConnection = monitoring
-	}
-}
-
-func (mc *mongoclient) getShouldDisconnect() bool {
-	mc.RLock()
-	defer mc.RUnlock()
-	return mc.waitForDisconnecting() && mc.shouldDisconnect
-}
-
-func (mc *mongoclient) setShouldDisconnect(disconn bool) {
-	if mc.getShouldDisconnect() != disconn {
-		mc.Lock()
-		defer mc.Unlock()
-		mc.shouldDisconnect = disconn
-	}
-}
-
-func (mc *mongoclient) getClient() *mongo.Client {
-	mc.RLock()
-	defer mc.RUnlock()
-	return mc.c
-}
-
-func (mc *mongoclient) setClient(c *mongo.Client) {
-	mc.Lock()
-	defer mc.Unlock()
-	mc.c = c
-}
-
-func (mc *mongoclient) disconnect() error {
-	mc.Lock()
-	defer mc.Unlock()
-	mc.isDisconnecting = true
-	var err error
-	if mc.c != nil {
-		err = mc.c.Disconnect(context.Background())
-		if err != nil {
-			if err == mongo.ErrClientDisconnected {
-				log.Warnf("Mongo client already disconnected")
-			} else {
-				log.Errorf("error in disconnect: %s", err)
-			}
-		}
-		mc.c = nil
-	}
-	mc.isConnected = false
-	mc.isDisconnecting = false
-	return err
-}
-
-func (mc *mongoclient) markForDisconnect() {
-	mc.setShouldDisconnect(true)
-	log.Warnf("mongo connection marked for disconnection")
-}
-
-var client = mongoclient{
-	c:                      nil,
-	isConnected:            false,
-	isDisconnecting:        false,
-	shouldDisconnect:       false,
-	isMonitoringConnection: false,
-}
-
-// Connect connects and sets a connection.
-// More info on mongo connection string
-// https://docs.mongodb.com/manual/reference/connection-string/
-func Connect(connectionURI string, dbSigChan chan struct{}) error {
-	client.setShouldDisconnect(false)
-	notified := false
-	if !client.getIsMonitoringConnection() {
-		go func() {
-			for !client.getShouldDisconnect() {
-				isConnectedOld := client.getIsConnected()
-				if !client.getIsConnected() {
-					connect(connectionURI)
-				}
-				if client.getClient() != nil {
-					err := ping()
-					if err != nil {
-						log.Warnf("ping error: %s", err)
-						_ = client.disconnect()
-						if isConnectedOld != client.getIsConnected() {
-							//log only when connection status changes
-							log.Errorf("cannot create mongo session: %s\n", err)
-						}
-					} else {
-						client.setIsConnected(true)
-						if isConnectedOld != client.getIsConnected() {
-							//log only when connection status changes
-							cs, err := connstring.ParseAndValidate(connectionURI)
-							if err == nil {
-								var hostsStr string
-								for i, h := range cs.Hosts {
-									hostsStr += h
-									if i != (len(cs.Hosts) - 1) {
-										hostsStr += ","
-									}
-								}
-								log.Info("Connected to mongo at " + hostsStr)
-								if !notified && dbSigChan != nil {
-									dbSigChan <- struct{}{}
-									notified = true
-								}
-							}
-						}
-					}
-				} else {
-					client.setIsConnected(false)
-				}
-				time.Sleep(5 * time.Second)
-			}
-			_ = client.disconnect()
-			client.setIsMonitoringConnection(false)
-		}()
-		client.setIsMonitoringConnection(true)
-	} else {
-		for !client.getIsConnected() {
-			time.Sleep(1 * time.Second)
-		}
-		go func() {
-			if !notified && dbSigChan != nil {
-				dbSigChan <- struct{}{}
-				notified = true
-			}
-		}()
-	}
-	return nil
-}
-
-func ping() error {
-	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
-	defer cancel()
-	return client.c.Ping(ctx, readpref.Primary())
-}
-
-func connect(connectionURI string) error {
-	if !client.getIsConnected() {
-		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-		defer cancel()
-		c, err := mongo.Connect(ctx, options.Client().ApplyURI(connectionURI))
-		if err != nil {
-			client.setIsConnected(false)
-			return err
-		}
-		client.setClient(c)
-	}
-	return nil
-}
-
-// Disconnect mongo client connection.
-// Returns myerrors.ErrNoMongoConnection as error when no mongo connection
-func Disconnect() error {
-	if !client.getIsConnected() {
-		return myerrors.ErrNoMongoConnection
-	}
-	client.markForDisconnect()
-	return nil
-}
-
-// GetClient returns the connected mongo client
-// Returns myerrors.ErrNoMongoConnection as error when no mongo connection
-func GetClient() (*mongo.Client, error) {
-	if !client.getIsConnected() {
-		return nil, myerrors.ErrNoMongoConnection
-	}
-	return client.getClient(), nil
-}
-
 type JSONSerializer interface {
 	SerializeToJSON(w http.ResponseWriter) error
 }
@@ -259,36 +37,41 @@ type UserGroup struct {
 	DatabaseNamer
 }
 
+// DatabaseName is the database of the models unless Naming sets another
 func (a UserGroup) DatabaseName() string {
 	return "userGroup"
 }
 
 // FindOne finds one entry in a collection based on mongo query
 // Returns myerrors.myerrors.ErrNoMongoConnection as error when no mongo connection
-func FindOne(m collectionDatabaseNamer, i interface{}, query bson.D, opts ...*options.FindOneOptions) (bool, error) {
-	if !client.getIsConnected() {
+func (mc *Client) FindOne(ctx context.Context, m collectionDatabaseNamer, i interface{}, query bson.D, opts ...*options.FindOneOptions) (bool, error) {
+	if !mc.getIsConnected() {
 		return false, myerrors.ErrNoMongoConnection
 	}
-	c := client.getClient().Database(m.DatabaseName()).Collection(m.CollectionName())
-	result := c.FindOne(nil, query, opts...)
+	ctx, cancel := mc.withDefaultTimeout(ctx)
+	defer cancel()
+	c := mc.collection(ctx, m)
+	result := c.FindOne(ctx, query, opts...)
 	err := result.Decode(i)
 	if err != nil && err != mongo.ErrNoDocuments {
-		return false, err
+		return false, mapError(err)
 	}
 	if err == mongo.ErrNoDocuments {
 		return false, nil
 	}
 	return true, nil
 }
-func FindOneWithModel(m collectionDatabaseNamer, i interface{}, d bson.D) (interface{}, bool, error) {
-	if !client.getIsConnected() {
+func (mc *Client) FindOneWithModel(ctx context.Context, m collectionDatabaseNamer, i interface{}, d bson.D) (interface{}, bool, error) {
+	if !mc.getIsConnected() {
 		return i, false, myerrors.ErrNoMongoConnection
 	}
-	c := client.getClient().Database(m.DatabaseName()).Collection(m.CollectionName())
-	result := c.FindOne(nil, d)
+	ctx, cancel := mc.withDefaultTimeout(ctx)
+	defer cancel()
+	c := mc.collection(ctx, m)
+	result := c.FindOne(ctx, d)
 	err := result.Decode(i)
 	if err != nil && err != mongo.ErrNoDocuments {
-		return i, false, err
+		return i, false, mapError(err)
 	}
 	if err == mongo.ErrNoDocuments {
 		return i, false, nil
@@ -298,124 +81,192 @@ func FindOneWithModel(m collectionDatabaseNamer, i interface{}, d bson.D) (inter
 
 // UpdateOne finds one entry in a collection based on mongo query and updates it
 // Returns myerrors.ErrNoMongoConnection as error when no mongo connection
-func UpdateOne(m collectionDatabaseNamer, filter bson.D, update bson.D, opts ...*options.UpdateOptions) (*mongo.UpdateResult, error) {
-	if !client.getIsConnected() {
+func (mc *Client) UpdateOne(ctx context.Context, m collectionDatabaseNamer, filter bson.D, update bson.D, opts ...*options.UpdateOptions) (*mongo.UpdateResult, error) {
+	if !mc.getIsConnected() {
 		return nil, myerrors.ErrNoMongoConnection
 	}
-	c := client.getClient().Database(m.DatabaseName()).Collection(m.CollectionName())
-	result, err := c.UpdateOne(nil, filter, bson.D{{"$set", update}}, opts...)
+	ctx, cancel := mc.withDefaultTimeout(ctx)
+	defer cancel()
+	c := mc.collection(ctx, m)
+	result, err := c.UpdateOne(ctx, filter, bson.D{{Key: "$set", Value: update}}, opts...)
 	if err != nil {
-		return nil, err
+		return nil, mapError(err)
 	}
 	return result, nil
 }
 
 // UpdateMany finds all entry in a collection based on mongo query and updates it
 // Returns myerrors.ErrNoMongoConnection as error when no mongo connection
-func UpdateMany(m collectionDatabaseNamer, filter bson.D, update bson.D, opts ...*options.UpdateOptions) (*mongo.UpdateResult, error) {
-	if !client.getIsConnected() {
+func (mc *Client) UpdateMany(ctx context.Context, m collectionDatabaseNamer, filter bson.D, update bson.D, opts ...*options.UpdateOptions) (*mongo.UpdateResult, error) {
+	if !mc.getIsConnected() {
 		return nil, myerrors.ErrNoMongoConnection
 	}
-	c := client.getClient().Database(m.DatabaseName()).Collection(m.CollectionName())
-	result, err := c.UpdateMany(nil, filter, bson.D{{"$set", update}}, opts...)
+	ctx, cancel := mc.withDefaultTimeout(ctx)
+	defer cancel()
+	c := mc.collection(ctx, m)
+	result, err := c.UpdateMany(ctx, filter, bson.D{{Key: "$set", Value: update}}, opts...)
 	if err != nil {
-		return nil, err
+		return nil, mapError(err)
 	}
 	return result, nil
 }
 
-func AddToArray(m collectionDatabaseNamer, filter bson.D, update bson.D, opts ...*options.UpdateOptions) (*mongo.UpdateResult, error) {
-	if !client.getIsConnected() {
+func (mc *Client) AddToArray(ctx context.Context, m collectionDatabaseNamer, filter bson.D, update bson.D, opts ...*options.UpdateOptions) (*mongo.UpdateResult, error) {
+	if !mc.getIsConnected() {
 		return nil, myerrors.ErrNoMongoConnection
 	}
-	c := client.getClient().Database(m.DatabaseName()).Collection(m.CollectionName())
-	result, err := c.UpdateOne(nil, filter, bson.D{{"$addToSet", update}}, opts...)
+	ctx, cancel := mc.withDefaultTimeout(ctx)
+	defer cancel()
+	c := mc.collection(ctx, m)
+	result, err := c.UpdateOne(ctx, filter, bson.D{{Key: "$addToSet", Value: update}}, opts...)
 	if err != nil {
-		return nil, err
+		return nil, mapError(err)
+	}
+	return result, nil
+}
+
+// PullFromArray removes all matching values from the array fields given in update
+// Returns myerrors.ErrNoMongoConnection as error when no mongo connection
+func (mc *Client) PullFromArray(ctx context.Context, m collectionDatabaseNamer, filter bson.D, update bson.D, opts ...*options.UpdateOptions) (*mongo.UpdateResult, error) {
+	if !mc.getIsConnected() {
+		return nil, myerrors.ErrNoMongoConnection
+	}
+	ctx, cancel := mc.withDefaultTimeout(ctx)
+	defer cancel()
+	c := mc.collection(ctx, m)
+	result, err := c.UpdateOne(ctx, filter, bson.D{{Key: "$pull", Value: update}}, opts...)
+	if err != nil {
+		return nil, mapError(err)
+	}
+	return result, nil
+}
+
+// PullFromArrayMany removes all matching values from the array fields given in update
+// in every document matching filter
+// Returns myerrors.ErrNoMongoConnection as error when no mongo connection
+func (mc *Client) PullFromArrayMany(ctx context.Context, m collectionDatabaseNamer, filter bson.D, update bson.D, opts ...*options.UpdateOptions) (*mongo.UpdateResult, error) {
+	if !mc.getIsConnected() {
+		return nil, myerrors.ErrNoMongoConnection
+	}
+	ctx, cancel := mc.withDefaultTimeout(ctx)
+	defer cancel()
+	c := mc.collection(ctx, m)
+	result, err := c.UpdateMany(ctx, filter, bson.D{{Key: "$pull", Value: update}}, opts...)
+	if err != nil {
+		return nil, mapError(err)
+	}
+	return result, nil
+}
+
+// IncrementMany adds the values given in update to the number fields in every
+// document matching filter
+// Returns myerrors.ErrNoMongoConnection as error when no mongo connection
+func (mc *Client) IncrementMany(ctx context.Context, m collectionDatabaseNamer, filter bson.D, update bson.D, opts ...*options.UpdateOptions) (*mongo.UpdateResult, error) {
+	if !mc.getIsConnected() {
+		return nil, myerrors.ErrNoMongoConnection
+	}
+	ctx, cancel := mc.withDefaultTimeout(ctx)
+	defer cancel()
+	c := mc.collection(ctx, m)
+	result, err := c.UpdateMany(ctx, filter, bson.D{{Key: "$inc", Value: update}}, opts...)
+	if err != nil {
+		return nil, mapError(err)
 	}
 	return result, nil
 }
 
 // DeleteOne delete one entry in a collection based on mongo query
 // Returns myerrors.ErrNoMongoConnection as error when no mongo connection
-func DeleteOne(m collectionDatabaseNamer, d bson.D) error {
-	if !client.getIsConnected() {
+func (mc *Client) DeleteOne(ctx context.Context, m collectionDatabaseNamer, d bson.D) error {
+	if !mc.getIsConnected() {
 		return myerrors.ErrNoMongoConnection
 	}
-	c := client.getClient().Database(m.DatabaseName()).Collection(m.CollectionName())
-	dr, err := c.DeleteOne(nil, d)
+	ctx, cancel := mc.withDefaultTimeout(ctx)
+	defer cancel()
+	c := mc.collection(ctx, m)
+	dr, err := c.DeleteOne(ctx, d)
 	if err != nil {
-		return err
+		return mapError(err)
 	}
 	if dr.DeletedCount == 1 {
 		return nil
 	}
-	return errors.New("did not delete exactly one document")
+	return &myerrors.Error{Code: myerrors.NotFound, Err: errors.New("did not delete exactly one document")}
 }
 
 // DeleteMany delete many entries in a collection based on mongo query
 // Returns myerrors.ErrNoMongoConnection as error when no mongo connection
-func DeleteMany(m collectionDatabaseNamer, d bson.D) error {
-	if !client.getIsConnected() {
+func (mc *Client) DeleteMany(ctx context.Context, m collectionDatabaseNamer, d bson.D) error {
+	if !mc.getIsConnected() {
 		return myerrors.ErrNoMongoConnection
 	}
-	c := client.getClient().Database(m.DatabaseName()).Collection(m.CollectionName())
-	count, err := c.DeleteMany(nil, d)
+	ctx, cancel := mc.withDefaultTimeout(ctx)
+	defer cancel()
+	c := mc.collection(ctx, m)
+	count, err := c.DeleteMany(ctx, d)
 	if err != nil {
-		return err
+		return mapError(err)
 	}
 	logger.NewLogger().Println("deleted the documents", count.DeletedCount)
 	return nil
 }
 
-func DeleteAll(m collectionDatabaseNamer) error {
-	if !client.getIsConnected() {
+func (mc *Client) DeleteAll(ctx context.Context, m collectionDatabaseNamer) error {
+	if !mc.getIsConnected() {
 		return myerrors.ErrNoMongoConnection
 	}
-	c := client.getClient().Database(m.DatabaseName()).Collection(m.CollectionName())
-	err := c.Drop(nil)
+	ctx, cancel := mc.withDefaultTimeout(ctx)
+	defer cancel()
+	c := mc.collection(ctx, m)
+	err := c.Drop(ctx)
 	if err != nil {
-		return err
+		return mapError(err)
 	}
 	return nil
 }
 
 // InsertOne inserts one entry in a collection based on mongo query.
 // Returns myerrors.ErrNoMongoConnection as error when no mongo connection
-func InsertOne(m collectionDatabaseNamer, i interface{}) (interface{}, error) {
-	if !client.getIsConnected() {
+func (mc *Client) InsertOne(ctx context.Context, m collectionDatabaseNamer, i interface{}) (interface{}, error) {
+	if !mc.getIsConnected() {
 		return nil, myerrors.ErrNoMongoConnection
 	}
-	c := client.getClient().Database(m.DatabaseName()).Collection(m.CollectionName())
-	ir, err := c.InsertOne(nil, i)
+	ctx, cancel := mc.withDefaultTimeout(ctx)
+	defer cancel()
+	c := mc.collection(ctx, m)
+	ir, err := c.InsertOne(ctx, i)
 	if err != nil {
-		return nil, err
+		return nil, mapError(err)
 	}
 	return ir.InsertedID, nil
 }
 
 // InsertMany inserts many entry in a collection based on mongo query.
 // Returns myerrors.ErrNoMongoConnection as error when no mongo connection
-func InsertMany(m collectionDatabaseNamer, docs []interface{}) ([]interface{}, error) {
-	if !client.getIsConnected() {
+func (mc *Client) InsertMany(ctx context.Context, m collectionDatabaseNamer, docs []interface{}) ([]interface{}, error) {
+	if !mc.getIsConnected() {
 		return nil, myerrors.ErrNoMongoConnection
 	}
-	c := client.getClient().Database(m.DatabaseName()).Collection(m.CollectionName())
-	ir, err := c.InsertMany(nil, docs)
+	ctx, cancel := mc.withDefaultTimeout(ctx)
+	defer cancel()
+	c := mc.collection(ctx, m)
+	ir, err := c.InsertMany(ctx, docs)
 	if err != nil {
-		return nil, err
+		return nil, mapError(err)
 	}
 	return ir.InsertedIDs, nil
 }
 
-func CountDocuments(m collectionDatabaseNamer, filter bson.D) (int64, error) {
-	if !client.getIsConnected() {
+func (mc *Client) CountDocuments(ctx context.Context, m collectionDatabaseNamer, filter bson.D) (int64, error) {
+	if !mc.getIsConnected() {
 		return 0, myerrors.ErrNoMongoConnection
 	}
-	c := client.getClient().Database(m.DatabaseName()).Collection(m.CollectionName())
-	result, err := c.CountDocuments(nil, filter)
+	ctx, cancel := mc.withDefaultTimeout(ctx)
+	defer cancel()
+	c := mc.collection(ctx, m)
+	result, err := c.CountDocuments(ctx, filter)
 	if err != nil && err != mongo.ErrNoDocuments {
-		return 0, err
+		return 0, mapError(err)
 	}
 	if err == mongo.ErrNoDocuments {
 		return 0, nil
@@ -423,30 +274,64 @@ func CountDocuments(m collectionDatabaseNamer, filter bson.D) (int64, error) {
 	return result, nil
 }
 
-func Find(m collectionDatabaseNamer, filter interface{}, opts ...*options.FindOptions) (*mongo.Cursor, error) {
-	if !client.getIsConnected() {
+// Find finds all entries in a collection matching filter.
+// The returned cursor outlives the call, so ctx is not bounded by the default timeout.
+// Returns myerrors.ErrNoMongoConnection as error when no mongo connection
+func (mc *Client) Find(ctx context.Context, m collectionDatabaseNamer, filter interface{}, opts ...*options.FindOptions) (*mongo.Cursor, error) {
+	if !mc.getIsConnected() {
 		return nil, myerrors.ErrNoMongoConnection
 	}
-	c := client.getClient().Database(m.DatabaseName()).Collection(m.CollectionName())
-	cursor, err := c.Find(nil, filter, opts...)
+	c := mc.collection(ctx, m)
+	cursor, err := c.Find(ctx, filter, opts...)
 	if err != nil && err != mongo.ErrNoDocuments {
-		return nil, err
+		return nil, mapError(err)
 	}
 	return cursor, nil
 }
 
-func Aggregate(m collectionDatabaseNamer, d mongo.Pipeline) (*mongo.Cursor, error) {
-	if !client.isConnected {
+// Aggregate runs the pipeline on a collection.
+// The returned cursor outlives the call, so ctx is not bounded by the default timeout.
+// Returns myerrors.ErrNoMongoConnection as error when no mongo connection
+func (mc *Client) Aggregate(ctx context.Context, m collectionDatabaseNamer, d mongo.Pipeline) (*mongo.Cursor, error) {
+	if !mc.getIsConnected() {
 		return nil, myerrors.ErrNoMongoConnection
 	}
-	c := client.c.Database(m.DatabaseName()).Collection(m.CollectionName())
-	cursor, err := c.Aggregate(nil, d)
+	c := mc.collection(ctx, m)
+	cursor, err := c.Aggregate(ctx, d)
 	if err != nil && err != mongo.ErrNoDocuments {
-		return nil, err
+		return nil, mapError(err)
 	}
 	return cursor, nil
 }
 
+// Watch opens a change stream on a collection filtered by the pipeline.
+// The returned stream outlives the call, so ctx is not bounded by the default timeout.
+// Returns myerrors.ErrNoMongoConnection as error when no mongo connection
+func (mc *Client) Watch(ctx context.Context, m collectionDatabaseNamer, d mongo.Pipeline, opts ...*options.ChangeStreamOptions) (*mongo.ChangeStream, error) {
+	if !mc.getIsConnected() {
+		return nil, myerrors.ErrNoMongoConnection
+	}
+	c := mc.collection(ctx, m)
+	stream, err := c.Watch(ctx, d, opts...)
+	if err != nil {
+		return nil, mapError(err)
+	}
+	return stream, nil
+}
+
+// CreateIndex creates the index on the collection if it does not exist yet.
+// Returns myerrors.ErrNoMongoConnection as error when no mongo connection
+func (mc *Client) CreateIndex(ctx context.Context, m collectionDatabaseNamer, model mongo.IndexModel) (string, error) {
+	if !mc.getIsConnected() {
+		return "", myerrors.ErrNoMongoConnection
+	}
+	ctx, cancel := mc.withDefaultTimeout(ctx)
+	defer cancel()
+	c := mc.collection(ctx, m)
+	name, err := c.Indexes().CreateOne(ctx, model)
+	return name, mapError(err)
+}
+
 // // AuthenticateWithJWT authenticates user with jwt token
 // func AuthenticateWithJWT(id interface{}, r *http.Request) (*User, bool, error) {
 // 	_id := id.(string)
@@ -490,28 +375,32 @@ func GetBsonAForArray(arrayData interface{}) (bson.A, error) {
 	}
 	return out.Data, nil
 }
-func Distinct(m collectionDatabaseNamer, field string, filter interface{}) ([]interface{}, error) {
-	if !client.getIsConnected() {
+func (mc *Client) Distinct(ctx context.Context, m collectionDatabaseNamer, field string, filter interface{}) ([]interface{}, error) {
+	if !mc.getIsConnected() {
 		return nil, myerrors.ErrNoMongoConnection
 	}
-	c := client.getClient().Database(m.DatabaseName()).Collection(m.CollectionName())
-	data, err := c.Distinct(nil, field, filter)
+	ctx, cancel := mc.withDefaultTimeout(ctx)
+	defer cancel()
+	c := mc.collection(ctx, m)
+	data, err := c.Distinct(ctx, field, filter)
 	if err != nil && err != mongo.ErrNoDocuments {
-		return nil, err
+		return nil, mapError(err)
 	}
 	return data, nil
 }
 
 // Update with unset key removes the key and value on passing
 // update-obj with bson.D{{"$unset", bson.D{{"<key>", ""}}}}
-func UpdateWithUnsetKey(m collectionDatabaseNamer, filter bson.D, update bson.D, opts ...*options.UpdateOptions) (*mongo.UpdateResult, error) {
-	if !client.getIsConnected() {
+func (mc *Client) UpdateWithUnsetKey(ctx context.Context, m collectionDatabaseNamer, filter bson.D, update bson.D, opts ...*options.UpdateOptions) (*mongo.UpdateResult, error) {
+	if !mc.getIsConnected() {
 		return nil, myerrors.ErrNoMongoConnection
 	}
-	c := client.getClient().Database(m.DatabaseName()).Collection(m.CollectionName())
-	result, err := c.UpdateOne(nil, filter, update, opts...)
+	ctx, cancel := mc.withDefaultTimeout(ctx)
+	defer cancel()
+	c := mc.collection(ctx, m)
+	result, err := c.UpdateOne(ctx, filter, update, opts...)
 	if err != nil {
-		return nil, err
+		return nil, mapError(err)
 	}
 	return result, nil
 }
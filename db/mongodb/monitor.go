@@ -0,0 +1,149 @@
+package mongodb
+
+import (
+	"context"
+	"math"
+	"math/rand"
+	"time"
+)
+
+// ConnectionState is the state reported by a ConnectionEvent
+type ConnectionState int
+
+const (
+	// StateConnected is reported on the first successful ping
+	StateConnected ConnectionState = iota + 1
+	// StateLost is reported when a ping of a connected client fails
+	StateLost
+	// StateReconnected is reported on the first successful ping after StateLost
+	StateReconnected
+	// StateDisconnected is reported when the monitor stops
+	StateDisconnected
+)
+
+func (s ConnectionState) String() string {
+	switch s {
+	case StateConnected:
+		return "connected"
+	case StateLost:
+		return "lost"
+	case StateReconnected:
+		return "reconnected"
+	case StateDisconnected:
+		return "disconnected"
+	}
+	return "unknown"
+}
+
+// ConnectionEvent is a change of the connection state
+type ConnectionEvent struct {
+	State ConnectionState
+	Time  time.Time
+	// Err is the ping or connect error that caused StateLost
+	Err error
+}
+
+// Subscribe returns a channel receiving the connection state changes and a
+// function to stop the subscription. Events are dropped for subscribers
+// that do not keep up.
+func (mc *Client) Subscribe() (<-chan ConnectionEvent, func()) {
+	ch := make(chan ConnectionEvent, 16)
+	mc.subsMu.Lock()
+	mc.subs[ch] = struct{}{}
+	mc.subsMu.Unlock()
+	return ch, func() {
+		mc.subsMu.Lock()
+		defer mc.subsMu.Unlock()
+		delete(mc.subs, ch)
+	}
+}
+
+func (mc *Client) publish(state ConnectionState, err error) {
+	e := ConnectionEvent{State: state, Time: time.Now(), Err: err}
+	mc.subsMu.Lock()
+	defer mc.subsMu.Unlock()
+	for ch := range mc.subs {
+		select {
+		case ch <- e:
+		default:
+			log.Warnf("dropping mongo connection event %s for a slow subscriber", state)
+		}
+	}
+}
+
+// delay returns the wait before the retry following failures failed attempts
+func (b Backoff) delay(failures int) time.Duration {
+	d := float64(b.Initial) * math.Pow(b.Multiplier, float64(failures))
+	if d > float64(b.Max) {
+		d = float64(b.Max)
+	}
+	d += d * b.Jitter * (2*rand.Float64() - 1)
+	return time.Duration(d)
+}
+
+// monitor connects, pings the server every PingInterval and reconnects with
+// backoff while it cannot be reached, until stop is closed. It first waits
+// for the previous monitor to exit, when prev is not nil, as that one may
+// still be disconnecting the client.
+func (mc *Client) monitor(prev chan struct{}, stop chan struct{}, done chan struct{}) {
+	defer close(done)
+	if prev != nil {
+		<-prev
+		select {
+		case <-stop:
+			return
+		default:
+		}
+	}
+	wasConnected := false
+	everConnected := false
+	failures := 0
+	for {
+		cfg := mc.config()
+		err := mc.connect()
+		if err == nil {
+			err = mc.ping()
+		}
+
+		wait := cfg.PingInterval
+		if err == nil {
+			failures = 0
+			mc.setIsConnected(true)
+			if !wasConnected {
+				log.Info("Connected to mongo at " + hosts(cfg.URI))
+				if everConnected {
+					mc.publish(StateReconnected, nil)
+				} else {
+					mc.publish(StateConnected, nil)
+				}
+			}
+			wasConnected = true
+			everConnected = true
+		} else {
+			log.Warnf("ping error: %s", err)
+			ctx, cancel := context.WithTimeout(context.Background(), cfg.ConnectTimeout)
+			_ = mc.disconnect(ctx)
+			cancel()
+			if wasConnected {
+				//log only when connection status changes
+				log.Errorf("cannot create mongo session: %s", err)
+				mc.publish(StateLost, err)
+			}
+			wasConnected = false
+			wait = cfg.Backoff.delay(failures)
+			failures++
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-stop:
+			timer.Stop()
+			ctx, cancel := context.WithTimeout(context.Background(), cfg.ConnectTimeout)
+			_ = mc.disconnect(ctx)
+			cancel()
+			mc.publish(StateDisconnected, nil)
+			return
+		case <-timer.C:
+		}
+	}
+}
@@ -0,0 +1,81 @@
+package mongodb
+
+import "context"
+
+// Naming names the databases and collections of the models.
+//
+// The database of a model is the one of the tenant of the context when the
+// tenant has its own, else Database, else the DatabaseName of the model.
+// Prefix and Suffix are added to it, tenant databases included. The
+// collection of a model is the name Collections renames its CollectionName
+// to, else its CollectionName, with CollectionPrefix and CollectionSuffix
+// added.
+//
+// Staging and production share a cluster with the Prefix "staging_" on
+// staging, and a large customer gets its own database with
+//
+//	Naming{Tenants: map[string]string{"acme": "acme"}}
+//
+// when the operations on its data are made with WithTenant(ctx, "acme").
+type Naming struct {
+	// Database is the shared database
+	Database string
+	// Prefix and Suffix are added to the names of all the databases
+	Prefix string
+	Suffix string
+	// Tenants maps the tenants having their own database to its name, the
+	// other tenants use the shared database
+	Tenants map[string]string
+	// Collections renames collections, it maps the CollectionName of a
+	// model to the name used
+	Collections map[string]string
+	// CollectionPrefix and CollectionSuffix are added to the names of all
+	// the collections
+	CollectionPrefix string
+	CollectionSuffix string
+}
+
+// DatabaseName returns the name of the database of the model for the tenant of ctx
+func (n Naming) DatabaseName(ctx context.Context, m DatabaseNamer) string {
+	name, ok := n.Tenants[TenantFrom(ctx)]
+	if !ok {
+		name = n.Database
+	}
+	if name == "" {
+		name = m.DatabaseName()
+	}
+	return n.Prefix + name + n.Suffix
+}
+
+// HasTenant reports whether tenant is empty, the shared database, or has its
+// own database. The other tenants would silently use the shared database.
+func (n Naming) HasTenant(tenant string) bool {
+	if tenant == "" {
+		return true
+	}
+	_, ok := n.Tenants[tenant]
+	return ok
+}
+
+// CollectionName returns the name of the collection of the model
+func (n Naming) CollectionName(m CollectionNamer) string {
+	name, ok := n.Collections[m.CollectionName()]
+	if !ok {
+		name = m.CollectionName()
+	}
+	return n.CollectionPrefix + name + n.CollectionSuffix
+}
+
+type tenantKey struct{}
+
+// WithTenant returns a context whose operations use the database of tenant,
+// see Naming. An empty tenant is the shared database.
+func WithTenant(ctx context.Context, tenant string) context.Context {
+	return context.WithValue(ctx, tenantKey{}, tenant)
+}
+
+// TenantFrom returns the tenant set with WithTenant, empty when there is none
+func TenantFrom(ctx context.Context) string {
+	tenant, _ := ctx.Value(tenantKey{}).(string)
+	return tenant
+}
@@ -0,0 +1,37 @@
+package mongodb
+
+import (
+	"context"
+	"time"
+)
+
+// SetDefaultTimeout sets the timeout applied to operations whose context has no deadline.
+// A zero or negative duration disables the default timeout.
+func (mc *Client) SetDefaultTimeout(d time.Duration) {
+	mc.mu.Lock()
+	defer mc.mu.Unlock()
+	mc.timeout = d
+}
+
+// DefaultTimeout returns the timeout applied to operations whose context has no deadline
+func (mc *Client) DefaultTimeout() time.Duration {
+	mc.mu.RLock()
+	defer mc.mu.RUnlock()
+	return mc.timeout
+}
+
+// withDefaultTimeout bounds ctx by the default timeout unless it already has a deadline.
+// A nil ctx is treated as context.Background().
+func (mc *Client) withDefaultTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if _, ok := ctx.Deadline(); ok {
+		return ctx, func() {}
+	}
+	d := mc.DefaultTimeout()
+	if d <= 0 {
+		return ctx, func() {}
+	}
+	return context.WithTimeout(ctx, d)
+}
@@ -0,0 +1,40 @@
+package mongodb
+
+import (
+	"context"
+
+	"github.com/sr-codefreak/user-group/myerrors"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// SessionContext is the context handed to a WithTransaction callback.
+// Pass it to the helpers so they run inside the transaction.
+type SessionContext = mongo.SessionContext
+
+// WithTransaction runs fn inside a multi-document transaction.
+// The transaction is committed when fn returns nil and aborted otherwise,
+// transient transaction and commit errors are retried by the driver.
+// ctx bounds the whole transaction, the default timeout applies when it has no deadline.
+// When ctx already carries a session, fn joins its transaction.
+// Returns myerrors.ErrNoMongoConnection as error when no mongo connection
+func (mc *Client) WithTransaction(ctx context.Context, fn func(ctx SessionContext) error, opts ...*options.TransactionOptions) error {
+	if !mc.getIsConnected() {
+		return myerrors.ErrNoMongoConnection
+	}
+	if session := mongo.SessionFromContext(ctx); session != nil {
+		return fn(mongo.NewSessionContext(ctx, session))
+	}
+	session, err := mc.getClient().StartSession()
+	if err != nil {
+		return mapError(err)
+	}
+	ctx, cancel := mc.withDefaultTimeout(ctx)
+	defer cancel()
+	defer session.EndSession(ctx)
+
+	_, err = session.WithTransaction(ctx, func(sc mongo.SessionContext) (interface{}, error) {
+		return nil, fn(sc)
+	}, opts...)
+	return mapError(err)
+}
@@ -1,32 +1,92 @@
 package user
 
 import (
-	"errors"
+	"context"
 
 	"github.com/sr-codefreak/user-group/db/mongodb"
+	"github.com/sr-codefreak/user-group/db/mongodb/access"
 	"github.com/sr-codefreak/user-group/myerrors"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 type UserStore interface {
-	Create(u *User) error
-	Update(u *User) error
-	Delete(id primitive.ObjectID) error
-	GetById(id string) (*User, error)
+	EnsureIndexes(ctx context.Context) error
+	Create(ctx context.Context, u *User) error
+	// CreateMany inserts the users in one batch, all of them or none,
+	// and sets the IDs that are zero
+	CreateMany(ctx context.Context, users []*User) error
+	// Update sets the name, email and phone of the user that are not empty,
+	// in the snapshot embedded in its user groups too, and replaces the
+	// metaData when it is not nil
+	Update(ctx context.Context, u *User) error
+	Delete(ctx context.Context, id primitive.ObjectID) error
+	GetById(ctx context.Context, id string) (*User, error)
+	// List returns a page of the users and the token of the next page,
+	// empty on the last page. Users can be sorted and filtered by name, email and phone.
+	List(ctx context.Context, opts mongodb.ListOptions) ([]User, string, error)
 }
 
-type userStore struct{}
+type userStore struct {
+	c *mongodb.Client
+}
+
+// NewStore returns the user store using the mongo client c
+func NewStore(c *mongodb.Client) UserStore {
+	return userStore{c: c}
+}
+
+var UStore = NewStore(mongodb.Default())
+
+// EnsureIndexes creates the name index comparing the names ignoring case,
+// which serves ListFilter.NameFold
+func (s userStore) EnsureIndexes(ctx context.Context) error {
+	_, err := s.c.CreateIndex(ctx, userModel, mongo.IndexModel{
+		Keys:    bson.D{{Key: userModel.NameKey, Value: 1}},
+		Options: options.Index().SetCollation(mongodb.NameCollation),
+	})
+	if err != nil {
+		return myerrors.Wrap(myerrors.ErrCreatingIndex, myerrors.KindUser, "", err)
+	}
+	return nil
+}
+
+// Create inserts the user and sets u.ID when it was generated by the database
+func (s userStore) Create(ctx context.Context, u *User) error {
+	id, err := s.c.InsertOne(ctx, userModel, u)
+	if err != nil {
+		return myerrors.Wrap(myerrors.ErrCreatingUser, myerrors.KindUser, "", err)
+	}
+	if oid, ok := id.(primitive.ObjectID); ok {
+		u.ID = oid
+	}
+	return nil
+}
 
-func (userStore) Create(u *User) error {
-	_, err := mongodb.InsertOne(userModel, u)
+func (s userStore) CreateMany(ctx context.Context, users []*User) error {
+	if len(users) == 0 {
+		return nil
+	}
+	docs := make([]interface{}, len(users))
+	for i, u := range users {
+		if u.ID.IsZero() {
+			u.ID = primitive.NewObjectID()
+		}
+		docs[i] = u
+	}
+	err := s.c.WithTransaction(ctx, func(ctx mongodb.SessionContext) error {
+		_, err := s.c.InsertMany(ctx, userModel, docs)
+		return err
+	})
 	if err != nil {
-		return errors.Join(myerrors.ErrCreatingUser, err)
+		return myerrors.Wrap(myerrors.ErrCreatingUser, myerrors.KindUser, "", err)
 	}
 	return nil
 }
 
-func (userStore) Update(u *User) error {
+func (s userStore) Update(ctx context.Context, u *User) error {
 	filter := bson.D{
 		{Key: userModel.IdKey, Value: u.ID},
 	}
@@ -40,32 +100,128 @@ func (userStore) Update(u *User) error {
 	if len(u.Phone) > 0 {
 		update = append(update, bson.E{Key: userModel.PhoneKey, Value: u.Phone})
 	}
-	_, err := mongodb.UpdateOne(userModel, filter, update)
+	if u.MetaData != nil {
+		update = append(update, bson.E{Key: userModel.MetaDataKey, Value: u.MetaData})
+	}
+	if len(update) == 0 {
+		return nil
+	}
+	// the snapshot embedded in the user groups holds the name, email and phone
+	snapshot := bson.D{}
+	for _, e := range update {
+		if e.Key != userModel.MetaDataKey {
+			snapshot = append(snapshot, bson.E{Key: groupMemberModel.UsersKey + ".$[u]." + e.Key, Value: e.Value})
+		}
+	}
+	err := s.c.WithTransaction(ctx, func(ctx mongodb.SessionContext) error {
+		result, err := s.c.UpdateOne(ctx, userModel, filter, update)
+		if err != nil {
+			return err
+		}
+		if result.MatchedCount == 0 {
+			return myerrors.ErrNotFound
+		}
+		if len(snapshot) == 0 {
+			return nil
+		}
+		arrayFilters := options.ArrayFilters{Filters: []interface{}{
+			bson.D{{Key: "u." + userModel.IdKey, Value: u.ID.Hex()}},
+		}}
+		_, err = s.c.UpdateMany(ctx, groupMemberModel, bson.D{{Key: groupMemberModel.UserIdsKey, Value: u.ID.Hex()}},
+			snapshot, options.Update().SetArrayFilters(arrayFilters))
+		return err
+	})
 	if err != nil {
-		return errors.Join(myerrors.ErrUpdatingUser, err)
+		return myerrors.Wrap(myerrors.ErrUpdatingUser, myerrors.KindUser, u.ID.Hex(), err)
 	}
 	return nil
 }
 
-func (userStore) GetById(id string) (*User, error) {
+func (s userStore) GetById(ctx context.Context, id string) (*User, error) {
+	oid, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, myerrors.Wrap(myerrors.ErrGetUserById, myerrors.KindUser, id, myerrors.Invalid(err))
+	}
 	u := &User{}
 	query := bson.D{
-		bson.E{Key: userModel.IdKey, Value: id},
+		bson.E{Key: userModel.IdKey, Value: oid},
 	}
-	exists, err := mongodb.FindOne(userModel, u, query)
-	if !exists || err != nil {
-		return nil, errors.Join(myerrors.ErrGetUserGroupById, err)
+	exists, err := s.c.FindOne(ctx, userModel, u, query)
+	if err != nil {
+		return nil, myerrors.Wrap(myerrors.ErrGetUserById, myerrors.KindUser, id, err)
+	}
+	if !exists {
+		return nil, myerrors.Wrap(myerrors.ErrGetUserById, myerrors.KindUser, id, myerrors.ErrNotFound)
 	}
 	return u, nil
 }
 
-func (userStore) Delete(id primitive.ObjectID) error {
-	query := bson.D{
-		bson.E{Key: userModel.IdKey, Value: id},
-	}
-	err := mongodb.DeleteOne(userModel, query)
+// memberModel is the side of the user groups holding their members. Package
+// usergroup imports this one, so its UserGroupModel cannot be used here.
+type memberModel struct {
+	mongodb.UserGroup
+	UsersKey   string
+	UserIdsKey string
+}
+
+var groupMemberModel = &memberModel{
+	UsersKey:   "users",
+	UserIdsKey: "userIds",
+}
+
+func (m memberModel) CollectionName() string {
+	return "userGroups"
+}
+
+// Delete deletes the user, removes it from its user groups and deletes the
+// access rows granted to it
+func (s userStore) Delete(ctx context.Context, id primitive.ObjectID) error {
+	accessModel := access.GetModel()
+	err := s.c.WithTransaction(ctx, func(ctx mongodb.SessionContext) error {
+		query := bson.D{
+			bson.E{Key: userModel.IdKey, Value: id},
+		}
+		err := s.c.DeleteOne(ctx, userModel, query)
+		if err != nil {
+			return err
+		}
+		_, err = s.c.PullFromArrayMany(ctx, groupMemberModel, bson.D{
+			{Key: groupMemberModel.UserIdsKey, Value: id.Hex()},
+		}, bson.D{
+			{Key: groupMemberModel.UserIdsKey, Value: id.Hex()},
+			{Key: groupMemberModel.UsersKey, Value: bson.D{{Key: userModel.IdKey, Value: id.Hex()}}},
+		})
+		if err != nil {
+			return err
+		}
+		return s.c.DeleteMany(ctx, accessModel, bson.D{
+			{Key: accessModel.UserIdKey, Value: id.Hex()},
+		})
+	})
 	if err != nil {
-		return errors.Join(myerrors.ErrDeleteUserGroup, err)
+		return myerrors.Wrap(myerrors.ErrDeleteUser, myerrors.KindUser, id.Hex(), err)
 	}
 	return nil
 }
+
+func (s userStore) List(ctx context.Context, opts mongodb.ListOptions) ([]User, string, error) {
+	if err := opts.Check(mongodb.NameKey, mongodb.EmailKey, mongodb.PhoneKey); err != nil {
+		return nil, "", myerrors.Wrap(myerrors.ErrListingUsers, myerrors.KindUser, "", err)
+	}
+	filter, findOpts, err := opts.Query(userModel.IdKey, userModel.MetaDataKey)
+	if err != nil {
+		return nil, "", myerrors.Wrap(myerrors.ErrListingUsers, myerrors.KindUser, "", err)
+	}
+	cursor, err := s.c.Find(ctx, userModel, filter, findOpts)
+	if err != nil {
+		return nil, "", myerrors.Wrap(myerrors.ErrListingUsers, myerrors.KindUser, "", err)
+	}
+	users := []User{}
+	if err := cursor.All(ctx, &users); err != nil {
+		return nil, "", myerrors.Wrap(myerrors.ErrListingUsers, myerrors.KindUser, "", err)
+	}
+	users, next := mongodb.Page(opts, users, func(u User) ([]string, primitive.ObjectID) {
+		return u.SortValues(opts.Sort), u.ID
+	})
+	return users, next, nil
+}
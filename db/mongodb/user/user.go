@@ -6,16 +6,45 @@ import (
 )
 
 type User struct {
-	ID          primitive.ObjectID `bson:"_id,omitempty"`
-	Name        string             `bson:"name"`
-	Email       string             `bson:"email"`
-	Phone       string             `bson:"phone"`
-	MetaData    map[string]any     `bson:"metaData"`
-	UsersGroups []struct {
-		ID   string `bson:"_id"`
-		Name string `bson:"name"`
-	} `bson:"usersGroups"`
-	UserGroupIds []string `bson:"userGroupIds"`
+	ID           primitive.ObjectID `bson:"_id,omitempty" json:"_id,omitempty"`
+	Name         string             `bson:"name" json:"name"`
+	Email        string             `bson:"email" json:"email"`
+	Phone        string             `bson:"phone" json:"phone"`
+	MetaData     map[string]any     `bson:"metaData" json:"metaData"`
+	UsersGroups  []UserGroupRef     `bson:"usersGroups" json:"usersGroups"`
+	UserGroupIds []string           `bson:"userGroupIds" json:"userGroupIds"`
+}
+
+// UserGroupRef is the snapshot of a user group embedded in the user
+type UserGroupRef struct {
+	ID   string `bson:"_id" json:"_id"`
+	Name string `bson:"name" json:"name"`
+}
+
+// ActiveKey is the metaData key of the flag disabling the user when false,
+// set by package dirsync and the SCIM active attribute
+const ActiveKey = "active"
+
+// Active reports whether the user is not disabled, see ActiveKey
+func (u User) Active() bool {
+	active, ok := u.MetaData[ActiveKey].(bool)
+	return !ok || active
+}
+
+// SortValues returns the values of the user for the sort keys
+func (u User) SortValues(sort []mongodb.SortField) []string {
+	values := make([]string, len(sort))
+	for i, f := range sort {
+		switch f.Key {
+		case mongodb.NameKey:
+			values[i] = u.Name
+		case mongodb.EmailKey:
+			values[i] = u.Email
+		case mongodb.PhoneKey:
+			values[i] = u.Phone
+		}
+	}
+	return values
 }
 
 type UserModel struct {
@@ -0,0 +1,207 @@
+package usergroup
+
+import (
+	"context"
+
+	"github.com/sr-codefreak/user-group/db/mongodb"
+	"github.com/sr-codefreak/user-group/db/mongodb/user"
+	"github.com/sr-codefreak/user-group/myerrors"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// ancestorsKey and descendantsKey hold the result of the $graphLookup stages
+const (
+	ancestorsKey   = "ancestors"
+	descendantsKey = "descendants"
+)
+
+// ancestorsLookup follows the parentIds of the user groups up to the roots
+func (s userGroupStore) ancestorsLookup() bson.D {
+	return bson.D{{Key: "$graphLookup", Value: bson.D{
+		{Key: "from", Value: s.c.CollectionName(userGroupModel)},
+		{Key: "startWith", Value: "$" + userGroupModel.ParentIdsKey},
+		{Key: "connectFromField", Value: userGroupModel.ParentIdsKey},
+		{Key: "connectToField", Value: userGroupModel.IdKey},
+		{Key: "as", Value: ancestorsKey},
+	}}}
+}
+
+// descendantsLookup follows the user groups having the user group as parent down to the leaves
+func (s userGroupStore) descendantsLookup() bson.D {
+	return bson.D{{Key: "$graphLookup", Value: bson.D{
+		{Key: "from", Value: s.c.CollectionName(userGroupModel)},
+		{Key: "startWith", Value: "$" + userGroupModel.IdKey},
+		{Key: "connectFromField", Value: userGroupModel.IdKey},
+		{Key: "connectToField", Value: userGroupModel.ParentIdsKey},
+		{Key: "as", Value: descendantsKey},
+	}}}
+}
+
+// AddChild makes childId a subgroup of id
+func (s userGroupStore) AddChild(ctx context.Context, id primitive.ObjectID, childId primitive.ObjectID) error {
+	err := s.c.WithTransaction(ctx, func(ctx mongodb.SessionContext) error {
+		child := &UserGroup{}
+		exists, err := s.c.FindOne(ctx, userGroupModel, child, bson.D{{Key: userGroupModel.IdKey, Value: childId}})
+		if err != nil {
+			return err
+		}
+		if !exists {
+			return myerrors.Wrap(myerrors.ErrGetUserGroupById, myerrors.KindUserGroup, childId.Hex(), myerrors.ErrNotFound)
+		}
+		if id == childId {
+			return myerrors.ErrGroupCycle
+		}
+
+		pipeline := mongo.Pipeline{
+			{{Key: "$match", Value: bson.D{{Key: userGroupModel.IdKey, Value: id}}}},
+			s.ancestorsLookup(),
+			{{Key: "$project", Value: bson.D{{Key: ancestorsKey + "." + userGroupModel.IdKey, Value: 1}}}},
+		}
+		cursor, err := s.c.Aggregate(ctx, userGroupModel, pipeline)
+		if err != nil {
+			return err
+		}
+		result := []struct {
+			Ancestors []UserGroup `bson:"ancestors"`
+		}{}
+		if err := cursor.All(ctx, &result); err != nil {
+			return err
+		}
+		if len(result) == 0 {
+			return myerrors.Wrap(myerrors.ErrGetUserGroupById, myerrors.KindUserGroup, id.Hex(), myerrors.ErrNotFound)
+		}
+		checked := bson.A{id, childId}
+		for _, ancestor := range result[0].Ancestors {
+			if ancestor.ID == childId {
+				return myerrors.ErrGroupCycle
+			}
+			checked = append(checked, ancestor.ID)
+		}
+
+		// transactions only conflict on the documents they both write, so the
+		// ancestors read above are written too: two AddChild closing a cycle
+		// together, like AddChild(A, B) and AddChild(B, A), then write a
+		// common user group and one of them aborts
+		_, err = s.c.IncrementMany(ctx, userGroupModel, bson.D{
+			{Key: userGroupModel.IdKey, Value: bson.D{{Key: "$in", Value: checked}}},
+		}, bson.D{
+			{Key: userGroupModel.HierarchyVersionKey, Value: 1},
+		})
+		if err != nil {
+			return err
+		}
+
+		filter := bson.D{
+			{Key: userGroupModel.IdKey, Value: childId},
+		}
+		update := bson.D{
+			{Key: userGroupModel.ParentIdsKey, Value: id},
+		}
+		_, err = s.c.AddToArray(ctx, userGroupModel, filter, update)
+		return err
+	})
+	if err != nil {
+		return myerrors.Wrap(myerrors.ErrAddingChildGroup, myerrors.KindUserGroup, id.Hex(), err)
+	}
+	return nil
+}
+
+// RemoveChild removes childId from the subgroups of id
+func (s userGroupStore) RemoveChild(ctx context.Context, id primitive.ObjectID, childId primitive.ObjectID) error {
+	filter := bson.D{
+		{Key: userGroupModel.IdKey, Value: childId},
+	}
+	update := bson.D{
+		{Key: userGroupModel.ParentIdsKey, Value: id},
+	}
+	if _, err := s.c.PullFromArray(ctx, userGroupModel, filter, update); err != nil {
+		return myerrors.Wrap(myerrors.ErrRemovingChildGroup, myerrors.KindUserGroup, id.Hex(), err)
+	}
+	return nil
+}
+
+// ResolveMembers collects the embedded users of the user group and its descendants
+func (s userGroupStore) ResolveMembers(ctx context.Context, id primitive.ObjectID) ([]UserRef, error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.D{{Key: userGroupModel.IdKey, Value: id}}}},
+		s.descendantsLookup(),
+		{{Key: "$project", Value: bson.D{
+			{Key: userGroupModel.UsersKey, Value: 1},
+			{Key: descendantsKey + "." + userGroupModel.UsersKey, Value: 1},
+		}}},
+	}
+	cursor, err := s.c.Aggregate(ctx, userGroupModel, pipeline)
+	if err != nil {
+		return nil, myerrors.Wrap(myerrors.ErrResolvingMembers, myerrors.KindUserGroup, id.Hex(), err)
+	}
+	result := []struct {
+		Users       []UserRef   `bson:"users"`
+		Descendants []UserGroup `bson:"descendants"`
+	}{}
+	if err := cursor.All(ctx, &result); err != nil {
+		return nil, myerrors.Wrap(myerrors.ErrResolvingMembers, myerrors.KindUserGroup, id.Hex(), err)
+	}
+	if len(result) == 0 {
+		return nil, myerrors.Wrap(myerrors.ErrResolvingMembers, myerrors.KindUserGroup, id.Hex(), myerrors.ErrNotFound)
+	}
+
+	members := []UserRef{}
+	seen := map[string]bool{}
+	add := func(users []UserRef) {
+		for _, u := range users {
+			if !seen[u.ID] {
+				seen[u.ID] = true
+				members = append(members, u)
+			}
+		}
+	}
+	add(result[0].Users)
+	for _, ug := range result[0].Descendants {
+		add(ug.Users)
+	}
+	return members, nil
+}
+
+// ResolveGroupsForUser collects the user groups containing the user and their ancestors
+func (s userGroupStore) ResolveGroupsForUser(ctx context.Context, userId primitive.ObjectID) ([]user.UserGroupRef, error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.D{{Key: userGroupModel.UserIdsKey, Value: userId.Hex()}}}},
+		s.ancestorsLookup(),
+		{{Key: "$project", Value: bson.D{
+			{Key: userGroupModel.NameKey, Value: 1},
+			{Key: ancestorsKey + "." + userGroupModel.IdKey, Value: 1},
+			{Key: ancestorsKey + "." + userGroupModel.NameKey, Value: 1},
+		}}},
+	}
+	cursor, err := s.c.Aggregate(ctx, userGroupModel, pipeline)
+	if err != nil {
+		return nil, myerrors.Wrap(myerrors.ErrResolvingGroups, myerrors.KindUser, userId.Hex(), err)
+	}
+	result := []struct {
+		UserGroup `bson:",inline"`
+		Ancestors []UserGroup `bson:"ancestors"`
+	}{}
+	if err := cursor.All(ctx, &result); err != nil {
+		return nil, myerrors.Wrap(myerrors.ErrResolvingGroups, myerrors.KindUser, userId.Hex(), err)
+	}
+
+	groups := []user.UserGroupRef{}
+	seen := map[primitive.ObjectID]bool{}
+	add := func(ug UserGroup) {
+		if !seen[ug.ID] {
+			seen[ug.ID] = true
+			groups = append(groups, user.UserGroupRef{ID: ug.ID.Hex(), Name: ug.Name})
+		}
+	}
+	for _, r := range result {
+		add(r.UserGroup)
+	}
+	for _, r := range result {
+		for _, ancestor := range r.Ancestors {
+			add(ancestor)
+		}
+	}
+	return groups, nil
+}
@@ -1,88 +1,348 @@
 package usergroup
 
 import (
-	"errors"
+	"context"
 
 	"github.com/sr-codefreak/user-group/db/mongodb"
+	"github.com/sr-codefreak/user-group/db/mongodb/access"
+	"github.com/sr-codefreak/user-group/db/mongodb/user"
 	"github.com/sr-codefreak/user-group/myerrors"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 type UserGroupStore interface {
-	Create(group *UserGroup) error
-	UpdateNameUpdateName(id primitive.ObjectID, name string) error
-	AddUser(id primitive.ObjectID, userId primitive.ObjectID) error
-	RemoveUser(id primitive.ObjectID, userId primitive.ObjectID) error
-	DeleteById(ids primitive.ObjectID) error
-	GetById(id string) (*UserGroup, error)
+	EnsureIndexes(ctx context.Context) error
+	Create(ctx context.Context, group *UserGroup) error
+	// CreateMany inserts the user groups in one batch, all of them or none,
+	// and sets the IDs that are zero
+	CreateMany(ctx context.Context, groups []*UserGroup) error
+	// UpdateName renames the user group, in the snapshot embedded in its users too
+	UpdateName(ctx context.Context, id primitive.ObjectID, name string) error
+	AddUser(ctx context.Context, id primitive.ObjectID, userId primitive.ObjectID) error
+	RemoveUser(ctx context.Context, id primitive.ObjectID, userId primitive.ObjectID) error
+	DeleteById(ctx context.Context, ids primitive.ObjectID) error
+	GetById(ctx context.Context, id string) (*UserGroup, error)
+	// List returns a page of the user groups and the token of the next page,
+	// empty on the last page. User groups can be sorted and filtered by name.
+	List(ctx context.Context, opts mongodb.ListOptions) ([]UserGroup, string, error)
+	// AddChild makes childId a subgroup of id. It fails with myerrors.ErrGroupCycle
+	// when id is childId or already contained in it.
+	AddChild(ctx context.Context, id primitive.ObjectID, childId primitive.ObjectID) error
+	RemoveChild(ctx context.Context, id primitive.ObjectID, childId primitive.ObjectID) error
+	// ResolveMembers returns the users of the user group and of all its subgroups, each once
+	ResolveMembers(ctx context.Context, id primitive.ObjectID) ([]UserRef, error)
+	// ResolveGroupsForUser returns the user groups the user is a member of
+	// directly or through one of their subgroups, each once
+	ResolveGroupsForUser(ctx context.Context, userId primitive.ObjectID) ([]user.UserGroupRef, error)
+	// SetRule makes the user group dynamic with the membership rule of package rule,
+	// an empty rule makes it static again. The members are left as they are,
+	// package dynamic reconciles them with the rule.
+	SetRule(ctx context.Context, id primitive.ObjectID, rule string) error
 }
 
-type userGroupStore struct{}
+type userGroupStore struct {
+	c *mongodb.Client
+}
+
+// NewStore returns the user group store using the mongo client c
+func NewStore(c *mongodb.Client) UserGroupStore {
+	return userGroupStore{c: c}
+}
 
-var UgStore = userGroupStore{}
+var UgStore = NewStore(mongodb.Default())
 
-func (userGroupStore) Create(group *UserGroup) error {
-	_, err := mongodb.InsertOne(userGroupModel, group)
+// EnsureIndexes creates the partial rule index listing the dynamic user groups
+func (s userGroupStore) EnsureIndexes(ctx context.Context) error {
+	_, err := s.c.CreateIndex(ctx, userGroupModel, mongo.IndexModel{
+		Keys: bson.D{{Key: userGroupModel.RuleKey, Value: 1}},
+		Options: options.Index().SetPartialFilterExpression(bson.D{
+			{Key: userGroupModel.RuleKey, Value: bson.D{{Key: "$exists", Value: true}}},
+		}),
+	})
 	if err != nil {
-		return errors.Join(myerrors.ErrCreatingUserGroup, err)
+		return myerrors.Wrap(myerrors.ErrCreatingIndex, myerrors.KindUserGroup, "", err)
 	}
 	return nil
 }
 
-func (userGroupStore) UpdateName(id primitive.ObjectID, name string) error {
-	filter := bson.D{
-		{Key: userGroupModel.IdKey, Value: id},
+// Create inserts the user group and sets group.ID when it was generated by the database
+func (s userGroupStore) Create(ctx context.Context, group *UserGroup) error {
+	if err := group.CheckRule(); err != nil {
+		return myerrors.Wrap(myerrors.ErrCreatingUserGroup, myerrors.KindUserGroup, "", err)
 	}
-	update := bson.D{
-		bson.E{Key: userGroupModel.NameKey, Value: name},
+	id, err := s.c.InsertOne(ctx, userGroupModel, group)
+	if err != nil {
+		return myerrors.Wrap(myerrors.ErrCreatingUserGroup, myerrors.KindUserGroup, "", err)
+	}
+	if oid, ok := id.(primitive.ObjectID); ok {
+		group.ID = oid
+	}
+	return nil
+}
+
+func (s userGroupStore) CreateMany(ctx context.Context, groups []*UserGroup) error {
+	if len(groups) == 0 {
+		return nil
 	}
-	_, err := mongodb.UpdateOne(userGroupModel, filter, update)
+	docs := make([]interface{}, len(groups))
+	for i, group := range groups {
+		if err := group.CheckRule(); err != nil {
+			return myerrors.Wrap(myerrors.ErrCreatingUserGroup, myerrors.KindUserGroup, group.ID.Hex(), err)
+		}
+		if group.ID.IsZero() {
+			group.ID = primitive.NewObjectID()
+		}
+		docs[i] = group
+	}
+	err := s.c.WithTransaction(ctx, func(ctx mongodb.SessionContext) error {
+		_, err := s.c.InsertMany(ctx, userGroupModel, docs)
+		return err
+	})
+	if err != nil {
+		return myerrors.Wrap(myerrors.ErrCreatingUserGroup, myerrors.KindUserGroup, "", err)
+	}
+	return nil
+}
+
+// UpdateName renames the user group and its snapshot embedded in its users
+func (s userGroupStore) UpdateName(ctx context.Context, id primitive.ObjectID, name string) error {
+	userModel := user.GetUserGroupModel()
+	err := s.c.WithTransaction(ctx, func(ctx mongodb.SessionContext) error {
+		filter := bson.D{
+			{Key: userGroupModel.IdKey, Value: id},
+		}
+		update := bson.D{
+			bson.E{Key: userGroupModel.NameKey, Value: name},
+		}
+		result, err := s.c.UpdateOne(ctx, userGroupModel, filter, update)
+		if err != nil {
+			return err
+		}
+		if result.MatchedCount == 0 {
+			return myerrors.ErrNotFound
+		}
+		filter = bson.D{
+			{Key: userModel.UsgidsKey, Value: id.Hex()},
+		}
+		update = bson.D{
+			{Key: userModel.UserGroupsKey + ".$[g]." + userGroupModel.NameKey, Value: name},
+		}
+		arrayFilters := options.ArrayFilters{Filters: []interface{}{
+			bson.D{{Key: "g." + userGroupModel.IdKey, Value: id.Hex()}},
+		}}
+		_, err = s.c.UpdateMany(ctx, userModel, filter, update, options.Update().SetArrayFilters(arrayFilters))
+		return err
+	})
 	if err != nil {
-		return errors.Join(myerrors.ErrUpdatingUserGroupName, err)
+		return myerrors.Wrap(myerrors.ErrUpdatingUserGroupName, myerrors.KindUserGroup, id.Hex(), err)
 	}
 	return nil
 }
 
-func (userGroupStore) AddUser(id primitive.ObjectID, userId primitive.ObjectID) error {
+func (s userGroupStore) SetRule(ctx context.Context, id primitive.ObjectID, rule string) error {
+	if err := (UserGroup{Rule: rule}).CheckRule(); err != nil {
+		return myerrors.Wrap(myerrors.ErrSettingRule, myerrors.KindUserGroup, id.Hex(), err)
+	}
 	filter := bson.D{
 		{Key: userGroupModel.IdKey, Value: id},
 	}
 	update := bson.D{
-		// bson.E{Key: userGroupModel.NameKey, Value: name},
+		{Key: "$set", Value: bson.D{{Key: userGroupModel.RuleKey, Value: rule}}},
+	}
+	if rule == "" {
+		update = bson.D{
+			{Key: "$unset", Value: bson.D{{Key: userGroupModel.RuleKey, Value: ""}}},
+		}
+	}
+	result, err := s.c.UpdateWithUnsetKey(ctx, userGroupModel, filter, update)
+	if err != nil {
+		return myerrors.Wrap(myerrors.ErrSettingRule, myerrors.KindUserGroup, id.Hex(), err)
+	}
+	if result.MatchedCount == 0 {
+		return myerrors.Wrap(myerrors.ErrSettingRule, myerrors.KindUserGroup, id.Hex(), myerrors.ErrNotFound)
 	}
-	_, err := mongodb.UpdateOne(userGroupModel, filter, update)
+	return nil
+}
+
+// AddUser adds the user to the user group and the user group to the user,
+// keeping the ids and the embedded snapshots of both documents in sync
+func (s userGroupStore) AddUser(ctx context.Context, id primitive.ObjectID, userId primitive.ObjectID) error {
+	userModel := user.GetUserGroupModel()
+	err := s.c.WithTransaction(ctx, func(ctx mongodb.SessionContext) error {
+		ug := &UserGroup{}
+		exists, err := s.c.FindOne(ctx, userGroupModel, ug, bson.D{{Key: userGroupModel.IdKey, Value: id}})
+		if err != nil {
+			return err
+		}
+		if !exists {
+			return myerrors.Wrap(myerrors.ErrGetUserGroupById, myerrors.KindUserGroup, id.Hex(), myerrors.ErrNotFound)
+		}
+		u := &user.User{}
+		exists, err = s.c.FindOne(ctx, userModel, u, bson.D{{Key: userModel.IdKey, Value: userId}})
+		if err != nil {
+			return err
+		}
+		if !exists {
+			return myerrors.Wrap(myerrors.ErrGetUserById, myerrors.KindUser, userId.Hex(), myerrors.ErrNotFound)
+		}
+
+		filter := bson.D{
+			{Key: userGroupModel.IdKey, Value: id},
+			{Key: userGroupModel.UserIdsKey, Value: bson.D{{Key: "$ne", Value: userId.Hex()}}},
+		}
+		update := bson.D{
+			{Key: userGroupModel.UserIdsKey, Value: userId.Hex()},
+			{Key: userGroupModel.UsersKey, Value: bson.D{
+				{Key: userModel.IdKey, Value: userId.Hex()},
+				{Key: userModel.NameKey, Value: u.Name},
+				{Key: userModel.EmailKey, Value: u.Email},
+				{Key: userModel.PhoneKey, Value: u.Phone},
+			}},
+		}
+		_, err = s.c.AddToArray(ctx, userGroupModel, filter, update)
+		if err != nil {
+			return err
+		}
+
+		filter = bson.D{
+			{Key: userModel.IdKey, Value: userId},
+			{Key: userModel.UsgidsKey, Value: bson.D{{Key: "$ne", Value: id.Hex()}}},
+		}
+		update = bson.D{
+			{Key: userModel.UsgidsKey, Value: id.Hex()},
+			{Key: userModel.UserGroupsKey, Value: bson.D{
+				{Key: userGroupModel.IdKey, Value: id.Hex()},
+				{Key: userGroupModel.NameKey, Value: ug.Name},
+			}},
+		}
+		_, err = s.c.AddToArray(ctx, userModel, filter, update)
+		return err
+	})
 	if err != nil {
-		return errors.Join(myerrors.ErrUpdatingUserGroupName, err)
+		return myerrors.Wrap(myerrors.ErrAddingUserToUserGroup, myerrors.KindUserGroup, id.Hex(), err)
 	}
 	return nil
 }
 
-func (userGroupStore) GetById(id string) (*UserGroup, error) {
+func (s userGroupStore) GetById(ctx context.Context, id string) (*UserGroup, error) {
+	oid, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, myerrors.Wrap(myerrors.ErrGetUserGroupById, myerrors.KindUserGroup, id, myerrors.Invalid(err))
+	}
 	ug := &UserGroup{}
 	query := bson.D{
-		bson.E{Key: userGroupModel.IdKey, Value: id},
+		bson.E{Key: userGroupModel.IdKey, Value: oid},
+	}
+	exists, err := s.c.FindOne(ctx, userGroupModel, ug, query)
+	if err != nil {
+		return nil, myerrors.Wrap(myerrors.ErrGetUserGroupById, myerrors.KindUserGroup, id, err)
 	}
-	exists, err := mongodb.FindOne(userGroupModel, ug, query)
-	if !exists || err != nil {
-		return nil, errors.Join(myerrors.ErrGetUserGroupById, err)
+	if !exists {
+		return nil, myerrors.Wrap(myerrors.ErrGetUserGroupById, myerrors.KindUserGroup, id, myerrors.ErrNotFound)
 	}
 	return ug, nil
 }
 
-func (userGroupStore) DeleteByIds(id primitive.ObjectID) error {
+// DeleteById deletes the user group, removes it from the users it contains
+// and from the parents of its subgroups and deletes the access rows granted on it
+func (s userGroupStore) DeleteById(ctx context.Context, id primitive.ObjectID) error {
+	userModel := user.GetUserGroupModel()
+	accessModel := access.GetModel()
+	err := s.c.WithTransaction(ctx, func(ctx mongodb.SessionContext) error {
+		query := bson.D{
+			bson.E{Key: userGroupModel.IdKey, Value: id},
+		}
+		err := s.c.DeleteOne(ctx, userGroupModel, query)
+		if err != nil {
+			return err
+		}
 
-	query := bson.D{
-		bson.E{Key: userGroupModel.IdKey, Value: id},
-	}
-	err := mongodb.DeleteOne(userGroupModel, query)
+		filter := bson.D{
+			{Key: userModel.UsgidsKey, Value: id.Hex()},
+		}
+		update := bson.D{
+			{Key: userModel.UsgidsKey, Value: id.Hex()},
+			{Key: userModel.UserGroupsKey, Value: bson.D{{Key: userGroupModel.IdKey, Value: id.Hex()}}},
+		}
+		_, err = s.c.PullFromArrayMany(ctx, userModel, filter, update)
+		if err != nil {
+			return err
+		}
+
+		filter = bson.D{
+			{Key: userGroupModel.ParentIdsKey, Value: id},
+		}
+		update = bson.D{
+			{Key: userGroupModel.ParentIdsKey, Value: id},
+		}
+		_, err = s.c.PullFromArrayMany(ctx, userGroupModel, filter, update)
+		if err != nil {
+			return err
+		}
+
+		return s.c.DeleteMany(ctx, accessModel, bson.D{
+			{Key: accessModel.UserGroupIdKey, Value: id.Hex()},
+		})
+	})
 	if err != nil {
-		return errors.Join(myerrors.ErrDeleteUserGroup, err)
+		return myerrors.Wrap(myerrors.ErrDeleteUserGroup, myerrors.KindUserGroup, id.Hex(), err)
 	}
 	return nil
 }
 
-func (userGroupStore) RemoveUser(id primitive.ObjectID, userId primitive.ObjectID) error {
+// RemoveUser removes the user from the user group and the user group from the user
+func (s userGroupStore) RemoveUser(ctx context.Context, id primitive.ObjectID, userId primitive.ObjectID) error {
+	userModel := user.GetUserGroupModel()
+	err := s.c.WithTransaction(ctx, func(ctx mongodb.SessionContext) error {
+		filter := bson.D{
+			{Key: userGroupModel.IdKey, Value: id},
+		}
+		update := bson.D{
+			{Key: userGroupModel.UserIdsKey, Value: userId.Hex()},
+			{Key: userGroupModel.UsersKey, Value: bson.D{{Key: userModel.IdKey, Value: userId.Hex()}}},
+		}
+		_, err := s.c.PullFromArray(ctx, userGroupModel, filter, update)
+		if err != nil {
+			return err
+		}
 
+		filter = bson.D{
+			{Key: userModel.IdKey, Value: userId},
+		}
+		update = bson.D{
+			{Key: userModel.UsgidsKey, Value: id.Hex()},
+			{Key: userModel.UserGroupsKey, Value: bson.D{{Key: userGroupModel.IdKey, Value: id.Hex()}}},
+		}
+		_, err = s.c.PullFromArray(ctx, userModel, filter, update)
+		return err
+	})
+	if err != nil {
+		return myerrors.Wrap(myerrors.ErrRemovingUserFromUserGroup, myerrors.KindUserGroup, id.Hex(), err)
+	}
 	return nil
 }
+
+func (s userGroupStore) List(ctx context.Context, opts mongodb.ListOptions) ([]UserGroup, string, error) {
+	if err := opts.Check(mongodb.NameKey, mongodb.RuleKey); err != nil {
+		return nil, "", myerrors.Wrap(myerrors.ErrListingUserGroups, myerrors.KindUserGroup, "", err)
+	}
+	filter, findOpts, err := opts.Query(userGroupModel.IdKey, userGroupModel.MetaDataKey)
+	if err != nil {
+		return nil, "", myerrors.Wrap(myerrors.ErrListingUserGroups, myerrors.KindUserGroup, "", err)
+	}
+	cursor, err := s.c.Find(ctx, userGroupModel, filter, findOpts)
+	if err != nil {
+		return nil, "", myerrors.Wrap(myerrors.ErrListingUserGroups, myerrors.KindUserGroup, "", err)
+	}
+	groups := []UserGroup{}
+	if err := cursor.All(ctx, &groups); err != nil {
+		return nil, "", myerrors.Wrap(myerrors.ErrListingUserGroups, myerrors.KindUserGroup, "", err)
+	}
+	groups, next := mongodb.Page(opts, groups, func(ug UserGroup) ([]string, primitive.ObjectID) {
+		return ug.SortValues(opts.Sort), ug.ID
+	})
+	return groups, next, nil
+}
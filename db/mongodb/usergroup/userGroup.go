@@ -2,20 +2,49 @@ package usergroup
 
 import (
 	"github.com/sr-codefreak/user-group/db/mongodb"
+	"github.com/sr-codefreak/user-group/rule"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
 type UserGroup struct {
-	ID       primitive.ObjectID `bson:"_id,omitempty"`
-	Name     string             `bson:"name,omitempty"`
-	MetaData map[string]any     `bson:"metaData,omitempty"`
-	Users    []struct {
-		ID    string `bson:"_id,omitempty"`
-		Name  string `bson:"name,omitempty"`
-		Email string `bson:"email,omitempty"`
-		Phone string `bson:"phone,omitempty"`
-	} `bson:"users,omitempty"`
-	UserIds []string `bson:"userIds,omitempty"`
+	ID       primitive.ObjectID `bson:"_id,omitempty" json:"_id,omitempty"`
+	Name     string             `bson:"name,omitempty" json:"name,omitempty"`
+	MetaData map[string]any     `bson:"metaData,omitempty" json:"metaData,omitempty"`
+	Users    []UserRef          `bson:"users,omitempty" json:"users,omitempty"`
+	UserIds  []string           `bson:"userIds,omitempty" json:"userIds,omitempty"`
+	// ParentIds are the user groups containing this one, see UserGroupStore.AddChild
+	ParentIds []primitive.ObjectID `bson:"parentIds,omitempty" json:"parentIds,omitempty"`
+	// Rule makes the user group dynamic, its members are the users matching
+	// the rule, see package rule. Static user groups have no rule.
+	Rule string `bson:"rule,omitempty" json:"rule,omitempty"`
+}
+
+// UserRef is the snapshot of a user embedded in the user group
+type UserRef struct {
+	ID    string `bson:"_id,omitempty" json:"_id,omitempty"`
+	Name  string `bson:"name,omitempty" json:"name,omitempty"`
+	Email string `bson:"email,omitempty" json:"email,omitempty"`
+	Phone string `bson:"phone,omitempty" json:"phone,omitempty"`
+}
+
+// CheckRule validates the rule of the user group, static user groups are valid
+func (ug UserGroup) CheckRule() error {
+	if ug.Rule == "" {
+		return nil
+	}
+	_, err := rule.Parse(ug.Rule)
+	return err
+}
+
+// SortValues returns the values of the user group for the sort keys
+func (ug UserGroup) SortValues(sort []mongodb.SortField) []string {
+	values := make([]string, len(sort))
+	for i, f := range sort {
+		if f.Key == mongodb.NameKey {
+			values[i] = ug.Name
+		}
+	}
+	return values
 }
 
 func (u UserGroupModel) CollectionName() string {
@@ -24,19 +53,28 @@ func (u UserGroupModel) CollectionName() string {
 
 type UserGroupModel struct {
 	mongodb.UserGroup
-	IdKey       string
-	NameKey     string
-	UsersKey    string
-	UserIdsKey  string
-	MetaDataKey string
+	IdKey        string
+	NameKey      string
+	UsersKey     string
+	UserIdsKey   string
+	MetaDataKey  string
+	ParentIdsKey string
+	RuleKey      string
+	// HierarchyVersionKey is bumped by AddChild on the parent, its
+	// ancestors and the child
+	HierarchyVersionKey string
 }
 
 var userGroupModel = &UserGroupModel{
-	IdKey:       "_id",
-	NameKey:     "name",
-	UsersKey:    "users",
-	UserIdsKey:  "userIds",
-	MetaDataKey: "metaData",
+	IdKey:        "_id",
+	NameKey:      "name",
+	UsersKey:     "users",
+	UserIdsKey:   "userIds",
+	MetaDataKey:  "metaData",
+	ParentIdsKey: "parentIds",
+	RuleKey:      "rule",
+
+	HierarchyVersionKey: "hierarchyVersion",
 }
 
 func GetUserGroupModel() *UserGroupModel {
@@ -0,0 +1,253 @@
+package webhook
+
+import (
+	"context"
+	"time"
+
+	"github.com/sr-codefreak/user-group/db/mongodb"
+	"github.com/sr-codefreak/user-group/myerrors"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// WebhookStore manages the webhook subscriptions and their deliveries
+type WebhookStore interface {
+	EnsureIndexes(ctx context.Context) error
+	// Create inserts the subscription and sets s.ID, and s.Created when it is zero
+	Create(ctx context.Context, s *Subscription) error
+	// Update replaces the url, user group, types and disabled flag of the
+	// subscription s.ID, and the secret when s.Secret is not empty
+	Update(ctx context.Context, s *Subscription) error
+	// Delete deletes the subscription and its deliveries
+	Delete(ctx context.Context, id primitive.ObjectID) error
+	Get(ctx context.Context, id string) (*Subscription, error)
+	// List returns all subscriptions, oldest first
+	List(ctx context.Context) ([]Subscription, error)
+	// Enqueue inserts the delivery and sets d.ID. It fails with an
+	// AlreadyExists error when the event was enqueued for the subscription.
+	Enqueue(ctx context.Context, d *Delivery) error
+	// UpdateDelivery replaces the status, attempts and next attempt of the delivery d.ID
+	UpdateDelivery(ctx context.Context, d *Delivery) error
+	// ListDeliveries returns the deliveries matching q, newest first
+	ListDeliveries(ctx context.Context, q DeliveryQuery) ([]Delivery, error)
+	// Claim returns up to limit pending deliveries whose next attempt is not
+	// after now, the longest due first, and moves their next attempt to until
+	// so that the other dispatchers skip them until then
+	Claim(ctx context.Context, now time.Time, until time.Time, limit int) ([]Delivery, error)
+}
+
+type webhookStore struct {
+	c *mongodb.Client
+}
+
+// NewStore returns the webhook store using the mongo client c
+func NewStore(c *mongodb.Client) WebhookStore {
+	return webhookStore{c: c}
+}
+
+var WhStore = NewStore(mongodb.Default())
+
+// EnsureIndexes creates the unique (subscriptionId, eventId) index and the
+// (status, nextAttempt) index of the deliveries
+func (s webhookStore) EnsureIndexes(ctx context.Context) error {
+	for _, index := range []mongo.IndexModel{
+		{
+			Keys: bson.D{
+				{Key: deliveryModel.SubscriptionIdKey, Value: 1},
+				{Key: deliveryModel.EventIdKey, Value: 1},
+			},
+			Options: options.Index().SetUnique(true),
+		},
+		{
+			Keys: bson.D{
+				{Key: deliveryModel.StatusKey, Value: 1},
+				{Key: deliveryModel.NextAttemptKey, Value: 1},
+			},
+		},
+	} {
+		if _, err := s.c.CreateIndex(ctx, deliveryModel, index); err != nil {
+			return myerrors.Wrap(myerrors.ErrCreatingIndex, myerrors.KindWebhook, "", err)
+		}
+	}
+	return nil
+}
+
+func (s webhookStore) Create(ctx context.Context, sub *Subscription) error {
+	if err := sub.Check(); err != nil {
+		return myerrors.Wrap(myerrors.ErrCreatingWebhook, myerrors.KindWebhook, "", err)
+	}
+	if sub.Created.IsZero() {
+		sub.Created = time.Now().UTC()
+	}
+	id, err := s.c.InsertOne(ctx, subscriptionModel, sub)
+	if err != nil {
+		return myerrors.Wrap(myerrors.ErrCreatingWebhook, myerrors.KindWebhook, "", err)
+	}
+	if oid, ok := id.(primitive.ObjectID); ok {
+		sub.ID = oid
+	}
+	return nil
+}
+
+func (s webhookStore) Update(ctx context.Context, sub *Subscription) error {
+	if err := sub.Check(); err != nil {
+		return myerrors.Wrap(myerrors.ErrUpdatingWebhook, myerrors.KindWebhook, sub.ID.Hex(), err)
+	}
+	filter := bson.D{{Key: subscriptionModel.IdKey, Value: sub.ID}}
+	update := bson.D{
+		{Key: subscriptionModel.URLKey, Value: sub.URL},
+		{Key: subscriptionModel.UserGroupIdKey, Value: sub.UserGroupId},
+		{Key: subscriptionModel.TypesKey, Value: sub.Types},
+		{Key: subscriptionModel.DisabledKey, Value: sub.Disabled},
+	}
+	if sub.Secret != "" {
+		update = append(update, bson.E{Key: subscriptionModel.SecretKey, Value: sub.Secret})
+	}
+	result, err := s.c.UpdateOne(ctx, subscriptionModel, filter, update)
+	if err != nil {
+		return myerrors.Wrap(myerrors.ErrUpdatingWebhook, myerrors.KindWebhook, sub.ID.Hex(), err)
+	}
+	if result.MatchedCount == 0 {
+		return myerrors.Wrap(myerrors.ErrUpdatingWebhook, myerrors.KindWebhook, sub.ID.Hex(), myerrors.ErrNotFound)
+	}
+	return nil
+}
+
+func (s webhookStore) Delete(ctx context.Context, id primitive.ObjectID) error {
+	err := s.c.WithTransaction(ctx, func(ctx mongodb.SessionContext) error {
+		if err := s.c.DeleteOne(ctx, subscriptionModel, bson.D{{Key: subscriptionModel.IdKey, Value: id}}); err != nil {
+			return err
+		}
+		return s.c.DeleteMany(ctx, deliveryModel, bson.D{{Key: deliveryModel.SubscriptionIdKey, Value: id.Hex()}})
+	})
+	if err != nil {
+		return myerrors.Wrap(myerrors.ErrDeleteWebhook, myerrors.KindWebhook, id.Hex(), err)
+	}
+	return nil
+}
+
+func (s webhookStore) Get(ctx context.Context, id string) (*Subscription, error) {
+	oid, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, myerrors.Wrap(myerrors.ErrGetWebhook, myerrors.KindWebhook, id, myerrors.Invalid(err))
+	}
+	sub := &Subscription{}
+	exists, err := s.c.FindOne(ctx, subscriptionModel, sub, bson.D{{Key: subscriptionModel.IdKey, Value: oid}})
+	if err != nil {
+		return nil, myerrors.Wrap(myerrors.ErrGetWebhook, myerrors.KindWebhook, id, err)
+	}
+	if !exists {
+		return nil, myerrors.Wrap(myerrors.ErrGetWebhook, myerrors.KindWebhook, id, myerrors.ErrNotFound)
+	}
+	return sub, nil
+}
+
+func (s webhookStore) List(ctx context.Context) ([]Subscription, error) {
+	opts := options.Find().SetSort(bson.D{{Key: subscriptionModel.IdKey, Value: 1}})
+	cursor, err := s.c.Find(ctx, subscriptionModel, bson.D{}, opts)
+	if err != nil {
+		return nil, myerrors.Wrap(myerrors.ErrListingWebhooks, myerrors.KindWebhook, "", err)
+	}
+	subs := []Subscription{}
+	if err := cursor.All(ctx, &subs); err != nil {
+		return nil, myerrors.Wrap(myerrors.ErrListingWebhooks, myerrors.KindWebhook, "", err)
+	}
+	return subs, nil
+}
+
+func (s webhookStore) Enqueue(ctx context.Context, d *Delivery) error {
+	if d.Created.IsZero() {
+		d.Created = time.Now().UTC()
+	}
+	if d.Attempts == nil {
+		d.Attempts = []Attempt{}
+	}
+	id, err := s.c.InsertOne(ctx, deliveryModel, d)
+	if err != nil {
+		return myerrors.Wrap(myerrors.ErrEnqueuingDelivery, myerrors.KindWebhook, d.SubscriptionId, err)
+	}
+	if oid, ok := id.(primitive.ObjectID); ok {
+		d.ID = oid
+	}
+	return nil
+}
+
+func (s webhookStore) UpdateDelivery(ctx context.Context, d *Delivery) error {
+	filter := bson.D{{Key: deliveryModel.IdKey, Value: d.ID}}
+	update := bson.D{
+		{Key: deliveryModel.StatusKey, Value: d.Status},
+		{Key: deliveryModel.AttemptsKey, Value: d.Attempts},
+		{Key: deliveryModel.NextAttemptKey, Value: d.NextAttempt},
+	}
+	result, err := s.c.UpdateOne(ctx, deliveryModel, filter, update)
+	if err != nil {
+		return myerrors.Wrap(myerrors.ErrUpdatingDelivery, myerrors.KindWebhook, d.SubscriptionId, err)
+	}
+	if result.MatchedCount == 0 {
+		return myerrors.Wrap(myerrors.ErrUpdatingDelivery, myerrors.KindWebhook, d.SubscriptionId, myerrors.ErrNotFound)
+	}
+	return nil
+}
+
+func (s webhookStore) ListDeliveries(ctx context.Context, q DeliveryQuery) ([]Delivery, error) {
+	q = q.Normalize()
+	filter := bson.D{}
+	if q.SubscriptionId != "" {
+		filter = append(filter, bson.E{Key: deliveryModel.SubscriptionIdKey, Value: q.SubscriptionId})
+	}
+	if q.Status != "" {
+		filter = append(filter, bson.E{Key: deliveryModel.StatusKey, Value: q.Status})
+	}
+	opts := options.Find().
+		SetSort(bson.D{{Key: deliveryModel.IdKey, Value: -1}}).
+		SetLimit(int64(q.Limit))
+	return s.find(ctx, filter, opts)
+}
+
+// Claim moves the next attempt of each due delivery unless another
+// dispatcher has moved it since it was found
+func (s webhookStore) Claim(ctx context.Context, now time.Time, until time.Time, limit int) ([]Delivery, error) {
+	filter := bson.D{
+		{Key: deliveryModel.StatusKey, Value: Pending},
+		{Key: deliveryModel.NextAttemptKey, Value: bson.D{{Key: "$lte", Value: now}}},
+	}
+	opts := options.Find().
+		SetSort(bson.D{{Key: deliveryModel.NextAttemptKey, Value: 1}}).
+		SetLimit(int64(mongodb.ListOptions{PageSize: limit}.Limit()))
+	due, err := s.find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	claimed := []Delivery{}
+	for _, d := range due {
+		filter := bson.D{
+			{Key: deliveryModel.IdKey, Value: d.ID},
+			{Key: deliveryModel.StatusKey, Value: Pending},
+			{Key: deliveryModel.NextAttemptKey, Value: d.NextAttempt},
+		}
+		update := bson.D{{Key: deliveryModel.NextAttemptKey, Value: until}}
+		result, err := s.c.UpdateOne(ctx, deliveryModel, filter, update)
+		if err != nil {
+			return nil, myerrors.Wrap(myerrors.ErrUpdatingDelivery, myerrors.KindWebhook, d.SubscriptionId, err)
+		}
+		if result.ModifiedCount == 1 {
+			d.NextAttempt = until
+			claimed = append(claimed, d)
+		}
+	}
+	return claimed, nil
+}
+
+func (s webhookStore) find(ctx context.Context, filter bson.D, opts *options.FindOptions) ([]Delivery, error) {
+	cursor, err := s.c.Find(ctx, deliveryModel, filter, opts)
+	if err != nil {
+		return nil, myerrors.Wrap(myerrors.ErrListingDeliveries, myerrors.KindWebhook, "", err)
+	}
+	deliveries := []Delivery{}
+	if err := cursor.All(ctx, &deliveries); err != nil {
+		return nil, myerrors.Wrap(myerrors.ErrListingDeliveries, myerrors.KindWebhook, "", err)
+	}
+	return deliveries, nil
+}
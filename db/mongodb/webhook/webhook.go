@@ -0,0 +1,195 @@
+package webhook
+
+import (
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/sr-codefreak/user-group/db/mongodb"
+	"github.com/sr-codefreak/user-group/db/mongodb/event"
+	"github.com/sr-codefreak/user-group/myerrors"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// EventTypes are the membership and access events a subscription can receive
+var EventTypes = []event.Type{
+	event.MemberAdded,
+	event.MemberRemoved,
+	event.SubgroupAdded,
+	event.SubgroupRemoved,
+	event.RoleGranted,
+	event.RoleRevoked,
+	event.RolesSet,
+}
+
+// Subscription asks for the events of Types, all EventTypes when empty, to be
+// posted to URL. A subscription with a UserGroupId only receives the events
+// of that user group. The payloads are signed with Secret.
+type Subscription struct {
+	ID          primitive.ObjectID `bson:"_id,omitempty" json:"_id,omitempty"`
+	URL         string             `bson:"url" json:"url"`
+	Secret      string             `bson:"secret" json:"secret,omitempty"`
+	UserGroupId string             `bson:"userGroupId,omitempty" json:"userGroupId,omitempty"`
+	Types       []event.Type       `bson:"types,omitempty" json:"types,omitempty"`
+	Disabled    bool               `bson:"disabled" json:"disabled"`
+	Created     time.Time          `bson:"created" json:"created"`
+}
+
+// Check validates the URL, user group id and event types of the subscription
+func (s *Subscription) Check() error {
+	u, err := url.Parse(s.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return myerrors.Invalid(fmt.Errorf("invalid webhook url %q", s.URL))
+	}
+	if s.UserGroupId != "" {
+		if _, err := primitive.ObjectIDFromHex(s.UserGroupId); err != nil {
+			return myerrors.Invalid(fmt.Errorf("invalid user group id %q", s.UserGroupId))
+		}
+	}
+	for _, t := range s.Types {
+		if !isEventType(t) {
+			return myerrors.Invalid(fmt.Errorf("unsupported event type %q", t))
+		}
+	}
+	return nil
+}
+
+// Matches reports whether the subscription receives the event. Events older
+// than the subscription are not received.
+func (s *Subscription) Matches(e *event.Event) bool {
+	if s.Disabled || e.Time.Before(s.Created) {
+		return false
+	}
+	if s.UserGroupId != "" && s.UserGroupId != e.UserGroupId {
+		return false
+	}
+	if len(s.Types) == 0 {
+		return isEventType(e.Type)
+	}
+	for _, t := range s.Types {
+		if t == e.Type {
+			return true
+		}
+	}
+	return false
+}
+
+func isEventType(t event.Type) bool {
+	for _, et := range EventTypes {
+		if et == t {
+			return true
+		}
+	}
+	return false
+}
+
+// Status is the state of a delivery
+type Status string
+
+const (
+	// Pending deliveries are attempted at NextAttempt
+	Pending Status = "pending"
+	// Delivered deliveries got a 2xx response
+	Delivered Status = "delivered"
+	// Dead deliveries failed every attempt and are kept as dead letters
+	Dead Status = "dead"
+)
+
+// Attempt is the outcome of posting a delivery once. StatusCode is 0 when
+// no response was received.
+type Attempt struct {
+	Time       time.Time `bson:"time" json:"time"`
+	StatusCode int       `bson:"statusCode,omitempty" json:"statusCode,omitempty"`
+	Error      string    `bson:"error,omitempty" json:"error,omitempty"`
+}
+
+// Delivery is the payload of an event for a subscription along with the
+// history of its attempts. A subscription gets one delivery per event.
+type Delivery struct {
+	ID             primitive.ObjectID `bson:"_id,omitempty" json:"_id,omitempty"`
+	SubscriptionId string             `bson:"subscriptionId" json:"subscriptionId"`
+	EventId        string             `bson:"eventId" json:"eventId"`
+	EventType      event.Type         `bson:"eventType" json:"eventType"`
+	Payload        string             `bson:"payload" json:"payload"`
+	Status         Status             `bson:"status" json:"status"`
+	Attempts       []Attempt          `bson:"attempts" json:"attempts"`
+	NextAttempt    time.Time          `bson:"nextAttempt" json:"nextAttempt"`
+	Created        time.Time          `bson:"created" json:"created"`
+}
+
+// DeliveryQuery selects the deliveries of a subscription, newest first.
+// An empty Status selects every status.
+type DeliveryQuery struct {
+	SubscriptionId string
+	Status         Status
+	// Limit defaults to mongodb.DefaultPageSize and is capped at mongodb.MaxPageSize
+	Limit int
+}
+
+// Normalize returns q with the limit in the range of a page size
+func (q DeliveryQuery) Normalize() DeliveryQuery {
+	q.Limit = mongodb.ListOptions{PageSize: q.Limit}.Limit()
+	return q
+}
+
+type SubscriptionModel struct {
+	mongodb.UserGroup
+	IdKey          string
+	URLKey         string
+	SecretKey      string
+	UserGroupIdKey string
+	TypesKey       string
+	DisabledKey    string
+	CreatedKey     string
+}
+
+var subscriptionModel = &SubscriptionModel{
+	IdKey:          "_id",
+	URLKey:         "url",
+	SecretKey:      "secret",
+	UserGroupIdKey: "userGroupId",
+	TypesKey:       "types",
+	DisabledKey:    "disabled",
+	CreatedKey:     "created",
+}
+
+func GetModel() *SubscriptionModel {
+	return subscriptionModel
+}
+
+func (s SubscriptionModel) CollectionName() string {
+	return "webhooks"
+}
+
+type DeliveryModel struct {
+	mongodb.UserGroup
+	IdKey             string
+	SubscriptionIdKey string
+	EventIdKey        string
+	EventTypeKey      string
+	PayloadKey        string
+	StatusKey         string
+	AttemptsKey       string
+	NextAttemptKey    string
+	CreatedKey        string
+}
+
+var deliveryModel = &DeliveryModel{
+	IdKey:             "_id",
+	SubscriptionIdKey: "subscriptionId",
+	EventIdKey:        "eventId",
+	EventTypeKey:      "eventType",
+	PayloadKey:        "payload",
+	StatusKey:         "status",
+	AttemptsKey:       "attempts",
+	NextAttemptKey:    "nextAttempt",
+	CreatedKey:        "created",
+}
+
+func GetDeliveryModel() *DeliveryModel {
+	return deliveryModel
+}
+
+func (d DeliveryModel) CollectionName() string {
+	return "webhookDeliveries"
+}
@@ -0,0 +1,226 @@
+package sqlstore
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/sr-codefreak/user-group/db/mongodb/access"
+	"github.com/sr-codefreak/user-group/myerrors"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type accessStore struct {
+	b *Backend
+}
+
+// deleteAccess deletes the access rows matching where together with their roles
+func deleteAccess(ctx context.Context, b *Backend, tx *sql.Tx, where string, args ...any) error {
+	_, err := tx.ExecContext(ctx, b.rebind(`DELETE FROM access_roles WHERE access_id IN (SELECT id FROM access WHERE `+where+`)`), args...)
+	if err != nil {
+		return err
+	}
+	_, err = tx.ExecContext(ctx, b.rebind(`DELETE FROM access WHERE `+where), args...)
+	return err
+}
+
+// accessId returns the id of the access row of the user for the user group, creating it when missing
+func (s accessStore) accessId(ctx context.Context, tx *sql.Tx, userId primitive.ObjectID, userGroupId primitive.ObjectID) (string, error) {
+	_, err := tx.ExecContext(ctx, s.b.rebind(`INSERT INTO access (id, user_id, user_group_id) VALUES (?, ?, ?)
+		ON CONFLICT (user_id, user_group_id) DO NOTHING`), primitive.NewObjectID().Hex(), userId.Hex(), userGroupId.Hex())
+	if err != nil {
+		return "", err
+	}
+	var id string
+	err = tx.QueryRowContext(ctx, s.b.rebind(`SELECT id FROM access WHERE user_id = ? AND user_group_id = ?`),
+		userId.Hex(), userGroupId.Hex()).Scan(&id)
+	return id, err
+}
+
+func (s accessStore) insertRoles(ctx context.Context, tx *sql.Tx, id string, roles []string) error {
+	for _, r := range roles {
+		_, err := tx.ExecContext(ctx, s.b.rebind(`INSERT INTO access_roles (access_id, role) VALUES (?, ?) ON CONFLICT DO NOTHING`), id, r)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// idFor identifies the access of the user for the user group in errors
+func idFor(userId primitive.ObjectID, userGroupId primitive.ObjectID) string {
+	return userId.Hex() + "/" + userGroupId.Hex()
+}
+
+// checkSubjects fails with a NotFound error unless the user and the user group exist
+func checkSubjects(ctx context.Context, b *Backend, tx *sql.Tx, userId primitive.ObjectID, userGroupId primitive.ObjectID) error {
+	ok, err := exists(ctx, b, tx, "users", userId.Hex())
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return myerrors.Wrap(myerrors.ErrGetUserById, myerrors.KindUser, userId.Hex(), myerrors.ErrNotFound)
+	}
+	ok, err = exists(ctx, b, tx, "user_groups", userGroupId.Hex())
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return myerrors.Wrap(myerrors.ErrGetUserGroupById, myerrors.KindUserGroup, userGroupId.Hex(), myerrors.ErrNotFound)
+	}
+	return nil
+}
+
+// EnsureIndexes is a no-op, the unique (user_id, user_group_id) constraint is part of the migrations
+func (accessStore) EnsureIndexes(ctx context.Context) error {
+	return nil
+}
+
+func (s accessStore) Grant(ctx context.Context, userId primitive.ObjectID, userGroupId primitive.ObjectID, roles ...string) error {
+	if len(roles) == 0 {
+		return nil
+	}
+	err := s.b.withTx(ctx, func(tx *sql.Tx) error {
+		if err := checkSubjects(ctx, s.b, tx, userId, userGroupId); err != nil {
+			return err
+		}
+		if err := checkDefined(ctx, s.b, tx, roles); err != nil {
+			return err
+		}
+		id, err := s.accessId(ctx, tx, userId, userGroupId)
+		if err != nil {
+			return err
+		}
+		return s.insertRoles(ctx, tx, id, roles)
+	})
+	if err != nil {
+		return myerrors.Wrap(myerrors.ErrGrantingAccess, myerrors.KindAccess, idFor(userId, userGroupId), mapError(err))
+	}
+	return nil
+}
+
+// Revoke removes the roles from the user for the user group.
+// Without roles the whole access row is removed.
+func (s accessStore) Revoke(ctx context.Context, userId primitive.ObjectID, userGroupId primitive.ObjectID, roles ...string) error {
+	err := s.b.withTx(ctx, func(tx *sql.Tx) error {
+		if len(roles) == 0 {
+			return deleteAccess(ctx, s.b, tx, `user_id = ? AND user_group_id = ?`, userId.Hex(), userGroupId.Hex())
+		}
+		args := []any{userId.Hex(), userGroupId.Hex()}
+		for _, r := range roles {
+			args = append(args, r)
+		}
+		_, err := tx.ExecContext(ctx, s.b.rebind(`DELETE FROM access_roles
+			WHERE access_id IN (SELECT id FROM access WHERE user_id = ? AND user_group_id = ?)
+			AND role IN (`+placeholders(len(roles))+`)`), args...)
+		return err
+	})
+	if err != nil {
+		return myerrors.Wrap(myerrors.ErrRevokingAccess, myerrors.KindAccess, idFor(userId, userGroupId), mapError(err))
+	}
+	return nil
+}
+
+func (s accessStore) SetRoles(ctx context.Context, userId primitive.ObjectID, userGroupId primitive.ObjectID, roles []string) error {
+	err := s.b.withTx(ctx, func(tx *sql.Tx) error {
+		if err := checkSubjects(ctx, s.b, tx, userId, userGroupId); err != nil {
+			return err
+		}
+		if err := checkDefined(ctx, s.b, tx, roles); err != nil {
+			return err
+		}
+		id, err := s.accessId(ctx, tx, userId, userGroupId)
+		if err != nil {
+			return err
+		}
+		if _, err := tx.ExecContext(ctx, s.b.rebind(`DELETE FROM access_roles WHERE access_id = ?`), id); err != nil {
+			return err
+		}
+		return s.insertRoles(ctx, tx, id, roles)
+	})
+	if err != nil {
+		return myerrors.Wrap(myerrors.ErrSettingRoles, myerrors.KindAccess, idFor(userId, userGroupId), mapError(err))
+	}
+	return nil
+}
+
+func (s accessStore) ListRolesForUser(ctx context.Context, userId primitive.ObjectID) ([]access.Access, error) {
+	rows, err := s.b.conn(ctx).QueryContext(ctx, s.b.rebind(`SELECT a.id, a.user_group_id, r.role FROM access a
+		LEFT JOIN access_roles r ON r.access_id = a.id
+		WHERE a.user_id = ? ORDER BY a.user_group_id, r.role`), userId.Hex())
+	if err != nil {
+		return nil, myerrors.Wrap(myerrors.ErrGetAccess, myerrors.KindAccess, "", mapError(err))
+	}
+	defer rows.Close()
+	accesses := []access.Access{}
+	for rows.Next() {
+		var id, userGroupId string
+		var role sql.NullString
+		if err := rows.Scan(&id, &userGroupId, &role); err != nil {
+			return nil, myerrors.Wrap(myerrors.ErrGetAccess, myerrors.KindAccess, "", mapError(err))
+		}
+		if n := len(accesses); n == 0 || accesses[n-1].UserGroupId != userGroupId {
+			oid, _ := primitive.ObjectIDFromHex(id)
+			accesses = append(accesses, access.Access{
+				ID:          oid,
+				UserId:      userId.Hex(),
+				UserGroupId: userGroupId,
+				Roles:       []string{},
+			})
+		}
+		if role.Valid {
+			a := &accesses[len(accesses)-1]
+			a.Roles = append(a.Roles, role.String)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, myerrors.Wrap(myerrors.ErrGetAccess, myerrors.KindAccess, "", mapError(err))
+	}
+	return accesses, nil
+}
+
+func (s accessStore) ListUsersWithRoleInGroup(ctx context.Context, userGroupId primitive.ObjectID, role string) ([]string, error) {
+	rows, err := s.b.conn(ctx).QueryContext(ctx, s.b.rebind(`SELECT a.user_id FROM access a
+		JOIN access_roles r ON r.access_id = a.id
+		WHERE a.user_group_id = ? AND r.role = ? ORDER BY a.user_id`), userGroupId.Hex(), role)
+	if err != nil {
+		return nil, myerrors.Wrap(myerrors.ErrGetAccess, myerrors.KindAccess, "", mapError(err))
+	}
+	defer rows.Close()
+	ids := []string{}
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, myerrors.Wrap(myerrors.ErrGetAccess, myerrors.KindAccess, "", mapError(err))
+		}
+		ids = append(ids, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, myerrors.Wrap(myerrors.ErrGetAccess, myerrors.KindAccess, "", mapError(err))
+	}
+	return ids, nil
+}
+
+func (s accessStore) HasRole(ctx context.Context, userId primitive.ObjectID, userGroupId primitive.ObjectID, role string) (bool, error) {
+	var n int
+	err := s.b.conn(ctx).QueryRowContext(ctx, s.b.rebind(`SELECT COUNT(*) FROM access a
+		JOIN access_roles r ON r.access_id = a.id
+		WHERE a.user_id = ? AND a.user_group_id = ? AND r.role = ?`), userId.Hex(), userGroupId.Hex(), role).Scan(&n)
+	if err != nil {
+		return false, myerrors.Wrap(myerrors.ErrGetAccess, myerrors.KindAccess, idFor(userId, userGroupId), mapError(err))
+	}
+	return n > 0, nil
+}
+
+func (s accessStore) EffectivePermissions(ctx context.Context, userId primitive.ObjectID, userGroupId primitive.ObjectID) ([]string, error) {
+	var perms []string
+	err := s.b.withTx(ctx, func(tx *sql.Tx) (err error) {
+		perms, err = permissions(ctx, s.b, tx, `SELECT r.role FROM access a
+			JOIN access_roles r ON r.access_id = a.id
+			WHERE a.user_id = ? AND a.user_group_id = ?`, userId.Hex(), userGroupId.Hex())
+		return err
+	})
+	if err != nil {
+		return nil, myerrors.Wrap(myerrors.ErrGetPermissions, myerrors.KindAccess, idFor(userId, userGroupId), mapError(err))
+	}
+	return perms, nil
+}
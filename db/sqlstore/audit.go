@@ -0,0 +1,103 @@
+package sqlstore
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"time"
+
+	"github.com/sr-codefreak/user-group/db/mongodb/audit"
+	"github.com/sr-codefreak/user-group/myerrors"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// auditStore keeps the record times as microseconds since the epoch,
+// comparable the same way in every dialect
+type auditStore struct {
+	b *Backend
+}
+
+// EnsureIndexes is a no-op, the indexes are part of the migrations
+func (auditStore) EnsureIndexes(ctx context.Context) error {
+	return nil
+}
+
+func (s auditStore) Append(ctx context.Context, r *audit.Record) error {
+	if r.ID.IsZero() {
+		r.ID = primitive.NewObjectID()
+	}
+	if r.Time.IsZero() {
+		r.Time = time.Now().UTC()
+	}
+	before, err := encodeMetaData(r.Before)
+	if err != nil {
+		return myerrors.Wrap(myerrors.ErrAppendingAudit, myerrors.KindAudit, "", myerrors.Invalid(err))
+	}
+	after, err := encodeMetaData(r.After)
+	if err != nil {
+		return myerrors.Wrap(myerrors.ErrAppendingAudit, myerrors.KindAudit, "", myerrors.Invalid(err))
+	}
+	_, err = s.b.conn(ctx).ExecContext(ctx, s.b.rebind(`INSERT INTO audit (id, time_us, actor, action, kind, entity_id, before, after)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)`),
+		r.ID.Hex(), r.Time.UnixMicro(), r.Actor, r.Action, r.Kind, r.EntityId, before, after)
+	if err != nil {
+		return myerrors.Wrap(myerrors.ErrAppendingAudit, myerrors.KindAudit, "", mapError(err))
+	}
+	return nil
+}
+
+func (s auditStore) List(ctx context.Context, q audit.Query) ([]audit.Record, error) {
+	q = q.Normalize()
+	where := []string{}
+	args := []any{}
+	if q.Kind != "" {
+		where = append(where, `kind = ?`)
+		args = append(args, q.Kind)
+	}
+	if q.EntityId != "" {
+		where = append(where, `entity_id = ?`)
+		args = append(args, q.EntityId)
+	}
+	if !q.From.IsZero() {
+		where = append(where, `time_us >= ?`)
+		args = append(args, q.From.UnixMicro())
+	}
+	if !q.To.IsZero() {
+		where = append(where, `time_us < ?`)
+		args = append(args, q.To.UnixMicro())
+	}
+	clause := ""
+	if len(where) > 0 {
+		clause = ` WHERE ` + strings.Join(where, ` AND `)
+	}
+	args = append(args, q.Limit)
+	rows, err := s.b.conn(ctx).QueryContext(ctx, s.b.rebind(`SELECT id, time_us, actor, action, kind, entity_id, before, after
+		FROM audit`+clause+` ORDER BY time_us DESC, id DESC LIMIT ?`), args...)
+	if err != nil {
+		return nil, myerrors.Wrap(myerrors.ErrListingAudit, myerrors.KindAudit, "", mapError(err))
+	}
+	defer rows.Close()
+	records := []audit.Record{}
+	for rows.Next() {
+		var r audit.Record
+		var id string
+		var micros int64
+		var before, after sql.NullString
+		if err := rows.Scan(&id, &micros, &r.Actor, &r.Action, &r.Kind, &r.EntityId, &before, &after); err != nil {
+			return nil, myerrors.Wrap(myerrors.ErrListingAudit, myerrors.KindAudit, "", mapError(err))
+		}
+		r.ID, _ = primitive.ObjectIDFromHex(id)
+		r.Time = time.UnixMicro(micros).UTC()
+		if r.Before, err = decodeMetaData(before); err != nil {
+			return nil, myerrors.Wrap(myerrors.ErrListingAudit, myerrors.KindAudit, "", err)
+		}
+		if r.After, err = decodeMetaData(after); err != nil {
+			return nil, myerrors.Wrap(myerrors.ErrListingAudit, myerrors.KindAudit, "", err)
+		}
+		records = append(records, r)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, myerrors.Wrap(myerrors.ErrListingAudit, myerrors.KindAudit, "", mapError(err))
+	}
+	return records, nil
+}
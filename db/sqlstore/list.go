@@ -0,0 +1,95 @@
+package sqlstore
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/sr-codefreak/user-group/db/mongodb"
+)
+
+// listClause returns the WHERE, ORDER BY and LIMIT clauses selecting the page
+// of opts from a table whose sortable columns are named like the list keys.
+// It fetches one row more than the page to tell whether there is a next page.
+func (b *Backend) listClause(opts mongodb.ListOptions) (string, []any, error) {
+	after, err := opts.After()
+	if err != nil {
+		return "", nil, err
+	}
+	conds := []string{}
+	args := []any{}
+	for _, f := range []struct{ column, prefix string }{
+		{mongodb.NameKey, opts.Filter.Name},
+		{mongodb.EmailKey, opts.Filter.Email},
+		{mongodb.PhoneKey, opts.Filter.Phone},
+	} {
+		if f.prefix != "" {
+			// substr compares case-sensitively in both dialects, unlike sqlite's LIKE
+			conds = append(conds, `substr(`+f.column+`, 1, ?) = ?`)
+			args = append(args, utf8.RuneCountInString(f.prefix), f.prefix)
+		}
+	}
+	if opts.Filter.NameFold != "" {
+		// sqlite's lower only folds ASCII letters
+		conds = append(conds, `lower(`+mongodb.NameKey+`) = lower(?)`)
+		args = append(args, opts.Filter.NameFold)
+	}
+	for key, value := range opts.Filter.MetaData {
+		var buf bytes.Buffer
+		enc := json.NewEncoder(&buf)
+		enc.SetEscapeHTML(false)
+		if err := enc.Encode(value); err != nil {
+			return "", nil, err
+		}
+		if b.dialect == Postgres {
+			conds = append(conds, `meta_data -> ?::text = ?::jsonb`)
+			args = append(args, key, strings.TrimSpace(buf.String()))
+		} else {
+			conds = append(conds, `json_extract(meta_data, ?) = json_extract(?, '$')`)
+			args = append(args, `$."`+key+`"`, strings.TrimSpace(buf.String()))
+		}
+	}
+	if opts.Filter.Dynamic {
+		conds = append(conds, mongodb.RuleKey+` <> ''`)
+	}
+	if after != nil {
+		// rows ordered after the last row of the previous page: greater on the
+		// first key, or equal on it and greater on the next key, up to the id
+		alternatives := []string{}
+		equal := ""
+		equalArgs := []any{}
+		for i, f := range opts.Sort {
+			op := " > ?"
+			if f.Desc {
+				op = " < ?"
+			}
+			alternatives = append(alternatives, `(`+equal+f.Key+op+`)`)
+			args = append(args, equalArgs...)
+			args = append(args, after.Values[i])
+			equal += f.Key + ` = ? AND `
+			equalArgs = append(equalArgs, after.Values[i])
+		}
+		alternatives = append(alternatives, `(`+equal+`id > ?)`)
+		args = append(args, equalArgs...)
+		args = append(args, after.ID)
+		conds = append(conds, `(`+strings.Join(alternatives, ` OR `)+`)`)
+	}
+
+	clause := ""
+	if len(conds) > 0 {
+		clause = ` WHERE ` + strings.Join(conds, ` AND `)
+	}
+	order := []string{}
+	for _, f := range opts.Sort {
+		if f.Desc {
+			order = append(order, f.Key+` DESC`)
+		} else {
+			order = append(order, f.Key)
+		}
+	}
+	order = append(order, `id`)
+	clause += ` ORDER BY ` + strings.Join(order, `, `) + ` LIMIT ?`
+	args = append(args, opts.Limit()+1)
+	return clause, args, nil
+}
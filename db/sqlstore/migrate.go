@@ -0,0 +1,65 @@
+package sqlstore
+
+import (
+	"context"
+	"database/sql"
+	"embed"
+	"io/fs"
+	"path"
+	"sort"
+	"strings"
+)
+
+//go:embed migrations
+var migrations embed.FS
+
+// Migrate applies the embedded migrations of the dialect that were not applied yet.
+// Applied migrations are recorded by file name in the schema_migrations table,
+// each migration runs in its own transaction.
+func (b *Backend) Migrate(ctx context.Context) error {
+	_, err := b.db.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS schema_migrations (version TEXT PRIMARY KEY)`)
+	if err != nil {
+		return err
+	}
+
+	dir := path.Join("migrations", b.dialect.name)
+	entries, err := fs.ReadDir(migrations, dir)
+	if err != nil {
+		return err
+	}
+	names := []string{}
+	for _, e := range entries {
+		if !e.IsDir() && strings.HasSuffix(e.Name(), ".sql") {
+			names = append(names, e.Name())
+		}
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		script, err := fs.ReadFile(migrations, path.Join(dir, name))
+		if err != nil {
+			return err
+		}
+		err = b.withTx(ctx, func(tx *sql.Tx) error {
+			var applied int
+			err := tx.QueryRowContext(ctx, b.rebind(`SELECT COUNT(*) FROM schema_migrations WHERE version = ?`), name).Scan(&applied)
+			if err != nil || applied > 0 {
+				return err
+			}
+			for _, stmt := range strings.Split(string(script), ";") {
+				if strings.TrimSpace(stmt) == "" {
+					continue
+				}
+				if _, err := tx.ExecContext(ctx, stmt); err != nil {
+					return err
+				}
+			}
+			_, err = tx.ExecContext(ctx, b.rebind(`INSERT INTO schema_migrations (version) VALUES (?)`), name)
+			return err
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
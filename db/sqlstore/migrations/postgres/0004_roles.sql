@@ -0,0 +1,18 @@
+CREATE TABLE roles (
+    name TEXT PRIMARY KEY,
+    id   TEXT NOT NULL UNIQUE
+);
+
+CREATE TABLE role_permissions (
+    role_name  TEXT NOT NULL REFERENCES roles (name) ON DELETE CASCADE,
+    permission TEXT NOT NULL,
+    PRIMARY KEY (role_name, permission)
+);
+
+CREATE TABLE role_inherits (
+    role_name      TEXT NOT NULL REFERENCES roles (name) ON DELETE CASCADE,
+    inherited_name TEXT NOT NULL REFERENCES roles (name),
+    PRIMARY KEY (role_name, inherited_name)
+);
+
+CREATE INDEX role_inherits_inherited_name ON role_inherits (inherited_name);
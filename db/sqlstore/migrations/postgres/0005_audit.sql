@@ -0,0 +1,14 @@
+CREATE TABLE audit (
+    id        TEXT PRIMARY KEY,
+    time_us   BIGINT NOT NULL,
+    actor     TEXT NOT NULL DEFAULT '',
+    action    TEXT NOT NULL,
+    kind      TEXT NOT NULL,
+    entity_id TEXT NOT NULL DEFAULT '',
+    before    JSONB,
+    after     JSONB
+);
+
+CREATE INDEX audit_entity ON audit (kind, entity_id, time_us);
+
+CREATE INDEX audit_time_us ON audit (time_us);
@@ -0,0 +1,14 @@
+CREATE TABLE outbox (
+    id             TEXT PRIMARY KEY,
+    type           TEXT NOT NULL,
+    time_us        BIGINT NOT NULL,
+    actor          TEXT NOT NULL DEFAULT '',
+    user_id        TEXT NOT NULL DEFAULT '',
+    user_group_id  TEXT NOT NULL DEFAULT '',
+    child_group_id TEXT NOT NULL DEFAULT '',
+    name           TEXT NOT NULL DEFAULT '',
+    roles          JSONB,
+    rule           TEXT NOT NULL DEFAULT ''
+);
+
+CREATE INDEX outbox_time_us ON outbox (time_us);
@@ -0,0 +1,24 @@
+CREATE TABLE webhooks (
+    id            TEXT PRIMARY KEY,
+    url           TEXT NOT NULL,
+    secret        TEXT NOT NULL DEFAULT '',
+    user_group_id TEXT NOT NULL DEFAULT '',
+    types         JSONB,
+    disabled      BOOLEAN NOT NULL DEFAULT FALSE,
+    created_us    BIGINT NOT NULL
+);
+
+CREATE TABLE webhook_deliveries (
+    id              TEXT PRIMARY KEY,
+    subscription_id TEXT NOT NULL,
+    event_id        TEXT NOT NULL,
+    event_type      TEXT NOT NULL,
+    payload         TEXT NOT NULL,
+    status          TEXT NOT NULL,
+    attempts        JSONB NOT NULL,
+    next_attempt_us BIGINT NOT NULL,
+    created_us      BIGINT NOT NULL,
+    UNIQUE (subscription_id, event_id)
+);
+
+CREATE INDEX webhook_deliveries_due ON webhook_deliveries (status, next_attempt_us);
@@ -0,0 +1,3 @@
+ALTER TABLE outbox ADD COLUMN seq BIGSERIAL;
+
+CREATE UNIQUE INDEX outbox_seq ON outbox (seq);
@@ -0,0 +1,3 @@
+ALTER TABLE outbox ADD COLUMN txid BIGINT NOT NULL DEFAULT txid_current();
+
+CREATE INDEX outbox_txid_seq ON outbox (txid, seq);
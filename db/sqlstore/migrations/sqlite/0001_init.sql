@@ -0,0 +1,36 @@
+CREATE TABLE users (
+    id         TEXT PRIMARY KEY,
+    name       TEXT NOT NULL DEFAULT '',
+    email      TEXT NOT NULL DEFAULT '',
+    phone      TEXT NOT NULL DEFAULT '',
+    meta_data  TEXT
+);
+
+CREATE TABLE user_groups (
+    id         TEXT PRIMARY KEY,
+    name       TEXT NOT NULL DEFAULT '',
+    meta_data  TEXT
+);
+
+CREATE TABLE user_group_members (
+    user_group_id TEXT NOT NULL REFERENCES user_groups (id) ON DELETE CASCADE,
+    user_id       TEXT NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    PRIMARY KEY (user_group_id, user_id)
+);
+
+CREATE INDEX user_group_members_user_id ON user_group_members (user_id);
+
+CREATE TABLE access (
+    id            TEXT PRIMARY KEY,
+    user_id       TEXT NOT NULL,
+    user_group_id TEXT NOT NULL,
+    UNIQUE (user_id, user_group_id)
+);
+
+CREATE INDEX access_user_group_id ON access (user_group_id);
+
+CREATE TABLE access_roles (
+    access_id TEXT NOT NULL REFERENCES access (id) ON DELETE CASCADE,
+    role      TEXT NOT NULL,
+    PRIMARY KEY (access_id, role)
+);
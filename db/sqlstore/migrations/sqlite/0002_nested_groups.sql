@@ -0,0 +1,7 @@
+CREATE TABLE user_group_parents (
+    user_group_id TEXT NOT NULL REFERENCES user_groups (id) ON DELETE CASCADE,
+    parent_id     TEXT NOT NULL REFERENCES user_groups (id) ON DELETE CASCADE,
+    PRIMARY KEY (user_group_id, parent_id)
+);
+
+CREATE INDEX user_group_parents_parent_id ON user_group_parents (parent_id);
@@ -0,0 +1 @@
+ALTER TABLE user_groups ADD COLUMN rule TEXT NOT NULL DEFAULT '';
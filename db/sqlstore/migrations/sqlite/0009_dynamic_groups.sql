@@ -0,0 +1 @@
+CREATE INDEX user_groups_dynamic ON user_groups (id) WHERE rule <> '';
@@ -0,0 +1 @@
+CREATE INDEX users_name_fold ON users (lower(name));
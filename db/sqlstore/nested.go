@@ -0,0 +1,148 @@
+package sqlstore
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/sr-codefreak/user-group/db/mongodb/user"
+	"github.com/sr-codefreak/user-group/db/mongodb/usergroup"
+	"github.com/sr-codefreak/user-group/myerrors"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// AddChild makes childId a subgroup of id
+func (s userGroupStore) AddChild(ctx context.Context, id primitive.ObjectID, childId primitive.ObjectID) error {
+	err := s.b.withTx(ctx, func(tx *sql.Tx) error {
+		for _, groupId := range []primitive.ObjectID{id, childId} {
+			ok, err := exists(ctx, s.b, tx, "user_groups", groupId.Hex())
+			if err != nil {
+				return err
+			}
+			if !ok {
+				return myerrors.Wrap(myerrors.ErrGetUserGroupById, myerrors.KindUserGroup, groupId.Hex(), myerrors.ErrNotFound)
+			}
+		}
+		if id == childId {
+			return myerrors.ErrGroupCycle
+		}
+		// UNION instead of UNION ALL stops the recursion on rows already seen
+		var n int
+		err := tx.QueryRowContext(ctx, s.b.rebind(`WITH RECURSIVE ancestors (id) AS (
+				SELECT parent_id FROM user_group_parents WHERE user_group_id = ?
+				UNION
+				SELECT p.parent_id FROM user_group_parents p JOIN ancestors a ON p.user_group_id = a.id
+			)
+			SELECT COUNT(*) FROM ancestors WHERE id = ?`), id.Hex(), childId.Hex()).Scan(&n)
+		if err != nil {
+			return err
+		}
+		if n > 0 {
+			return myerrors.ErrGroupCycle
+		}
+		_, err = tx.ExecContext(ctx, s.b.rebind(`INSERT INTO user_group_parents (user_group_id, parent_id) VALUES (?, ?)
+			ON CONFLICT DO NOTHING`), childId.Hex(), id.Hex())
+		return err
+	})
+	if err != nil {
+		return myerrors.Wrap(myerrors.ErrAddingChildGroup, myerrors.KindUserGroup, id.Hex(), mapError(err))
+	}
+	return nil
+}
+
+// RemoveChild removes childId from the subgroups of id
+func (s userGroupStore) RemoveChild(ctx context.Context, id primitive.ObjectID, childId primitive.ObjectID) error {
+	_, err := s.b.conn(ctx).ExecContext(ctx, s.b.rebind(`DELETE FROM user_group_parents WHERE user_group_id = ? AND parent_id = ?`),
+		childId.Hex(), id.Hex())
+	if err != nil {
+		return myerrors.Wrap(myerrors.ErrRemovingChildGroup, myerrors.KindUserGroup, id.Hex(), mapError(err))
+	}
+	return nil
+}
+
+// ResolveMembers returns the users of the user group and its descendants ordered by id
+func (s userGroupStore) ResolveMembers(ctx context.Context, id primitive.ObjectID) ([]usergroup.UserRef, error) {
+	members := []usergroup.UserRef{}
+	err := s.b.withTx(ctx, func(tx *sql.Tx) error {
+		ok, err := exists(ctx, s.b, tx, "user_groups", id.Hex())
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return myerrors.ErrNotFound
+		}
+		rows, err := tx.QueryContext(ctx, s.b.rebind(`WITH RECURSIVE descendants (id) AS (
+				SELECT CAST(? AS TEXT)
+				UNION
+				SELECT p.user_group_id FROM user_group_parents p JOIN descendants d ON p.parent_id = d.id
+			)
+			SELECT DISTINCT u.id, u.name, u.email, u.phone FROM descendants d
+			JOIN user_group_members m ON m.user_group_id = d.id
+			JOIN users u ON u.id = m.user_id
+			ORDER BY u.id`), id.Hex())
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+		for rows.Next() {
+			u := usergroup.UserRef{}
+			if err := rows.Scan(&u.ID, &u.Name, &u.Email, &u.Phone); err != nil {
+				return err
+			}
+			members = append(members, u)
+		}
+		return rows.Err()
+	})
+	if err != nil {
+		return nil, myerrors.Wrap(myerrors.ErrResolvingMembers, myerrors.KindUserGroup, id.Hex(), mapError(err))
+	}
+	return members, nil
+}
+
+// ResolveGroupsForUser returns the user groups containing the user and their ancestors ordered by id
+func (s userGroupStore) ResolveGroupsForUser(ctx context.Context, userId primitive.ObjectID) ([]user.UserGroupRef, error) {
+	rows, err := s.b.conn(ctx).QueryContext(ctx, s.b.rebind(`WITH RECURSIVE member_of (id) AS (
+			SELECT user_group_id FROM user_group_members WHERE user_id = ?
+			UNION
+			SELECT p.parent_id FROM user_group_parents p JOIN member_of g ON p.user_group_id = g.id
+		)
+		SELECT ug.id, ug.name FROM member_of g JOIN user_groups ug ON ug.id = g.id ORDER BY ug.id`), userId.Hex())
+	if err != nil {
+		return nil, myerrors.Wrap(myerrors.ErrResolvingGroups, myerrors.KindUser, userId.Hex(), mapError(err))
+	}
+	defer rows.Close()
+	groups := []user.UserGroupRef{}
+	for rows.Next() {
+		g := user.UserGroupRef{}
+		if err := rows.Scan(&g.ID, &g.Name); err != nil {
+			return nil, myerrors.Wrap(myerrors.ErrResolvingGroups, myerrors.KindUser, userId.Hex(), mapError(err))
+		}
+		groups = append(groups, g)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, myerrors.Wrap(myerrors.ErrResolvingGroups, myerrors.KindUser, userId.Hex(), mapError(err))
+	}
+	return groups, nil
+}
+
+// parents returns the parent ids of the user groups ids by user group id
+func (b *Backend) parents(ctx context.Context, ids []any) (map[string][]primitive.ObjectID, error) {
+	rows, err := b.conn(ctx).QueryContext(ctx, b.rebind(`SELECT user_group_id, parent_id FROM user_group_parents
+		WHERE user_group_id IN (`+placeholders(len(ids))+`) ORDER BY parent_id`), ids...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	parents := map[string][]primitive.ObjectID{}
+	for rows.Next() {
+		var id, parentId string
+		if err := rows.Scan(&id, &parentId); err != nil {
+			return nil, err
+		}
+		oid, err := primitive.ObjectIDFromHex(parentId)
+		if err != nil {
+			return nil, err
+		}
+		parents[id] = append(parents[id], oid)
+	}
+	return parents, rows.Err()
+}
@@ -0,0 +1,121 @@
+package sqlstore
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/sr-codefreak/user-group/db/mongodb"
+	"github.com/sr-codefreak/user-group/db/mongodb/event"
+	"github.com/sr-codefreak/user-group/myerrors"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// outboxStore keeps the event times as microseconds since the epoch like
+// auditStore. List returns the events in an order later commits never
+// insert into, see Dialect.outboxTxid, so that events.Poll misses none. On
+// Postgres an event is listed only once the transactions started before its
+// own have finished.
+type outboxStore struct {
+	b *Backend
+}
+
+// EnsureIndexes is a no-op, the indexes are part of the migrations
+func (outboxStore) EnsureIndexes(ctx context.Context) error {
+	return nil
+}
+
+func (s outboxStore) Append(ctx context.Context, events ...*event.Event) error {
+	if len(events) == 0 {
+		return nil
+	}
+	return s.b.withTx(ctx, func(tx *sql.Tx) error {
+		now := time.Now().UTC()
+		for _, e := range events {
+			if e.ID.IsZero() {
+				e.ID = primitive.NewObjectID()
+			}
+			if e.Time.IsZero() {
+				e.Time = now
+			}
+			roles := sql.NullString{}
+			if e.Roles != nil {
+				data, err := json.Marshal(e.Roles)
+				if err != nil {
+					return myerrors.Wrap(myerrors.ErrAppendingEvents, myerrors.KindEvent, "", myerrors.Invalid(err))
+				}
+				roles = sql.NullString{String: string(data), Valid: true}
+			}
+			_, err := tx.ExecContext(ctx, s.b.rebind(`INSERT INTO outbox
+				(id, type, time_us, actor, user_id, user_group_id, child_group_id, name, roles, rule)
+				VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`),
+				e.ID.Hex(), string(e.Type), e.Time.UnixMicro(), e.Actor, e.UserId, e.UserGroupId,
+				e.ChildGroupId, e.Name, roles, e.Rule)
+			if err != nil {
+				return myerrors.Wrap(myerrors.ErrAppendingEvents, myerrors.KindEvent, "", mapError(err))
+			}
+		}
+		return nil
+	})
+}
+
+func (s outboxStore) List(ctx context.Context, after primitive.ObjectID, limit int) ([]event.Event, error) {
+	from := ""
+	if !after.IsZero() {
+		from = after.Hex()
+	}
+	// the events after a pruned event are all retained ones
+	seq := s.b.dialect.outboxSeq
+	where := seq + ` > COALESCE((SELECT ` + seq + ` FROM outbox WHERE id = ?), 0)`
+	order := seq
+	args := []any{from}
+	if txid := s.b.dialect.outboxTxid; txid != "" {
+		where = `(` + txid + `, ` + seq + `) > (COALESCE((SELECT ` + txid + ` FROM outbox WHERE id = ?), 0),
+			COALESCE((SELECT ` + seq + ` FROM outbox WHERE id = ?), 0))
+			AND ` + txid + ` < ` + s.b.dialect.finishedTxids
+		order = txid + `, ` + seq
+		args = append(args, from)
+	}
+	args = append(args, mongodb.ListOptions{PageSize: limit}.Limit())
+	rows, err := s.b.conn(ctx).QueryContext(ctx, s.b.rebind(`SELECT id, type, time_us, actor, user_id, user_group_id,
+		child_group_id, name, roles, rule FROM outbox
+		WHERE `+where+` ORDER BY `+order+` LIMIT ?`), args...)
+	if err != nil {
+		return nil, myerrors.Wrap(myerrors.ErrListingEvents, myerrors.KindEvent, "", mapError(err))
+	}
+	defer rows.Close()
+	events := []event.Event{}
+	for rows.Next() {
+		var e event.Event
+		var id, typ string
+		var micros int64
+		var roles sql.NullString
+		err := rows.Scan(&id, &typ, &micros, &e.Actor, &e.UserId, &e.UserGroupId,
+			&e.ChildGroupId, &e.Name, &roles, &e.Rule)
+		if err != nil {
+			return nil, myerrors.Wrap(myerrors.ErrListingEvents, myerrors.KindEvent, "", mapError(err))
+		}
+		e.ID, _ = primitive.ObjectIDFromHex(id)
+		e.Type = event.Type(typ)
+		e.Time = time.UnixMicro(micros).UTC()
+		if roles.Valid {
+			if err := json.Unmarshal([]byte(roles.String), &e.Roles); err != nil {
+				return nil, myerrors.Wrap(myerrors.ErrListingEvents, myerrors.KindEvent, "", err)
+			}
+		}
+		events = append(events, e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, myerrors.Wrap(myerrors.ErrListingEvents, myerrors.KindEvent, "", mapError(err))
+	}
+	return events, nil
+}
+
+func (s outboxStore) Prune(ctx context.Context, before time.Time) error {
+	_, err := s.b.conn(ctx).ExecContext(ctx, s.b.rebind(`DELETE FROM outbox WHERE time_us < ?`), before.UnixMicro())
+	if err != nil {
+		return myerrors.Wrap(myerrors.ErrPruningEvents, myerrors.KindEvent, "", mapError(err))
+	}
+	return nil
+}
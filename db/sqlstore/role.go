@@ -0,0 +1,298 @@
+package sqlstore
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/sr-codefreak/user-group/db/mongodb/access"
+	"github.com/sr-codefreak/user-group/myerrors"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type roleStore struct {
+	b *Backend
+}
+
+// withInherited returns the recursive CTE "inherited" of the roles selected
+// by start and all roles they inherit. UNION stops the recursion on rows already seen.
+func withInherited(start string) string {
+	return `WITH RECURSIVE inherited (name) AS (
+			` + start + `
+			UNION
+			SELECT i.inherited_name FROM role_inherits i JOIN inherited r ON i.role_name = r.name
+		)
+		`
+}
+
+// queryStrings returns the single text column of the rows of query
+func queryStrings(ctx context.Context, b *Backend, tx *sql.Tx, query string, args ...any) ([]string, error) {
+	rows, err := tx.QueryContext(ctx, b.rebind(query), args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	values := []string{}
+	for rows.Next() {
+		var v string
+		if err := rows.Scan(&v); err != nil {
+			return nil, err
+		}
+		values = append(values, v)
+	}
+	return values, rows.Err()
+}
+
+func stringArgs(values []string) []any {
+	args := make([]any, len(values))
+	for i, v := range values {
+		args[i] = v
+	}
+	return args
+}
+
+// checkDefined fails with access.UndefinedRole for the first of names that is not a role
+func checkDefined(ctx context.Context, b *Backend, tx *sql.Tx, names []string) error {
+	if len(names) == 0 {
+		return nil
+	}
+	found, err := queryStrings(ctx, b, tx, `SELECT name FROM roles WHERE name IN (`+placeholders(len(names))+`)`, stringArgs(names)...)
+	if err != nil {
+		return err
+	}
+	defined := map[string]bool{}
+	for _, name := range found {
+		defined[name] = true
+	}
+	for _, name := range names {
+		if !defined[name] {
+			return access.UndefinedRole(name)
+		}
+	}
+	return nil
+}
+
+// permissions returns the permissions of the roles selected by start and the roles they inherit
+func permissions(ctx context.Context, b *Backend, tx *sql.Tx, start string, args ...any) ([]string, error) {
+	return queryStrings(ctx, b, tx, withInherited(start)+`SELECT DISTINCT p.permission FROM inherited r
+		JOIN role_permissions p ON p.role_name = r.name ORDER BY p.permission`, args...)
+}
+
+func (s roleStore) insertDefinition(ctx context.Context, tx *sql.Tx, r *access.Role) error {
+	for _, p := range r.Permissions {
+		_, err := tx.ExecContext(ctx, s.b.rebind(`INSERT INTO role_permissions (role_name, permission) VALUES (?, ?) ON CONFLICT DO NOTHING`), r.Name, p)
+		if err != nil {
+			return err
+		}
+	}
+	for _, name := range r.Inherits {
+		_, err := tx.ExecContext(ctx, s.b.rebind(`INSERT INTO role_inherits (role_name, inherited_name) VALUES (?, ?) ON CONFLICT DO NOTHING`), r.Name, name)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// EnsureIndexes is a no-op, the name is the primary key of the roles table
+func (roleStore) EnsureIndexes(ctx context.Context) error {
+	return nil
+}
+
+func (s roleStore) Create(ctx context.Context, r *access.Role) error {
+	if err := r.Check(); err != nil {
+		return myerrors.Wrap(myerrors.ErrCreatingRole, myerrors.KindRole, r.Name, err)
+	}
+	if r.ID.IsZero() {
+		r.ID = primitive.NewObjectID()
+	}
+	if r.Permissions == nil {
+		r.Permissions = []string{}
+	}
+	err := s.b.withTx(ctx, func(tx *sql.Tx) error {
+		if err := checkDefined(ctx, s.b, tx, r.Inherits); err != nil {
+			return err
+		}
+		if _, err := tx.ExecContext(ctx, s.b.rebind(`INSERT INTO roles (name, id) VALUES (?, ?)`), r.Name, r.ID.Hex()); err != nil {
+			return err
+		}
+		return s.insertDefinition(ctx, tx, r)
+	})
+	if err != nil {
+		return myerrors.Wrap(myerrors.ErrCreatingRole, myerrors.KindRole, r.Name, mapError(err))
+	}
+	return nil
+}
+
+func (s roleStore) Update(ctx context.Context, r *access.Role) error {
+	if err := r.Check(); err != nil {
+		return myerrors.Wrap(myerrors.ErrUpdatingRole, myerrors.KindRole, r.Name, err)
+	}
+	if r.Permissions == nil {
+		r.Permissions = []string{}
+	}
+	err := s.b.withTx(ctx, func(tx *sql.Tx) error {
+		var id string
+		err := tx.QueryRowContext(ctx, s.b.rebind(`SELECT id FROM roles WHERE name = ?`), r.Name).Scan(&id)
+		if err != nil {
+			return err
+		}
+		r.ID, _ = primitive.ObjectIDFromHex(id)
+		if err := checkDefined(ctx, s.b, tx, r.Inherits); err != nil {
+			return err
+		}
+		if len(r.Inherits) > 0 {
+			var n int
+			args := append(stringArgs(r.Inherits), r.Name)
+			err := tx.QueryRowContext(ctx, s.b.rebind(withInherited(`SELECT name FROM roles WHERE name IN (`+placeholders(len(r.Inherits))+`)`)+
+				`SELECT COUNT(*) FROM inherited WHERE name = ?`), args...).Scan(&n)
+			if err != nil {
+				return err
+			}
+			if n > 0 {
+				return myerrors.ErrRoleCycle
+			}
+		}
+		for _, table := range []string{"role_permissions", "role_inherits"} {
+			if _, err := tx.ExecContext(ctx, s.b.rebind(`DELETE FROM `+table+` WHERE role_name = ?`), r.Name); err != nil {
+				return err
+			}
+		}
+		return s.insertDefinition(ctx, tx, r)
+	})
+	if err != nil {
+		return myerrors.Wrap(myerrors.ErrUpdatingRole, myerrors.KindRole, r.Name, mapError(err))
+	}
+	return nil
+}
+
+func (s roleStore) Delete(ctx context.Context, name string) error {
+	err := s.b.withTx(ctx, func(tx *sql.Tx) error {
+		var n int
+		err := tx.QueryRowContext(ctx, s.b.rebind(`SELECT COUNT(*) FROM role_inherits WHERE inherited_name = ?`), name).Scan(&n)
+		if err != nil {
+			return err
+		}
+		if n > 0 {
+			return myerrors.ErrRoleInherited
+		}
+		for _, table := range []string{"role_permissions", "role_inherits"} {
+			if _, err := tx.ExecContext(ctx, s.b.rebind(`DELETE FROM `+table+` WHERE role_name = ?`), name); err != nil {
+				return err
+			}
+		}
+		result, err := tx.ExecContext(ctx, s.b.rebind(`DELETE FROM roles WHERE name = ?`), name)
+		if err != nil {
+			return err
+		}
+		if n, err := result.RowsAffected(); err != nil || n == 0 {
+			if err == nil {
+				err = myerrors.ErrNotFound
+			}
+			return err
+		}
+		_, err = tx.ExecContext(ctx, s.b.rebind(`DELETE FROM access_roles WHERE role = ?`), name)
+		return err
+	})
+	if err != nil {
+		return myerrors.Wrap(myerrors.ErrDeleteRole, myerrors.KindRole, name, mapError(err))
+	}
+	return nil
+}
+
+// load returns the roles matching where, or all roles when where is empty, ordered by name
+func (s roleStore) load(ctx context.Context, tx *sql.Tx, where string, args ...any) ([]access.Role, error) {
+	if where != "" {
+		where = ` WHERE ` + where
+	}
+	rows, err := tx.QueryContext(ctx, s.b.rebind(`SELECT name, id FROM roles`+where+` ORDER BY name`), args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	roles := []access.Role{}
+	index := map[string]int{}
+	for rows.Next() {
+		var name, id string
+		if err := rows.Scan(&name, &id); err != nil {
+			return nil, err
+		}
+		oid, _ := primitive.ObjectIDFromHex(id)
+		index[name] = len(roles)
+		roles = append(roles, access.Role{ID: oid, Name: name, Permissions: []string{}})
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	for _, q := range []struct {
+		query string
+		add   func(r *access.Role, value string)
+	}{
+		{`SELECT role_name, permission FROM role_permissions ORDER BY role_name, permission`,
+			func(r *access.Role, p string) { r.Permissions = append(r.Permissions, p) }},
+		{`SELECT role_name, inherited_name FROM role_inherits ORDER BY role_name, inherited_name`,
+			func(r *access.Role, name string) { r.Inherits = append(r.Inherits, name) }},
+	} {
+		rows, err := tx.QueryContext(ctx, q.query)
+		if err != nil {
+			return nil, err
+		}
+		for rows.Next() {
+			var name, value string
+			if err := rows.Scan(&name, &value); err != nil {
+				rows.Close()
+				return nil, err
+			}
+			if i, ok := index[name]; ok {
+				q.add(&roles[i], value)
+			}
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return nil, err
+		}
+	}
+	return roles, nil
+}
+
+func (s roleStore) Get(ctx context.Context, name string) (*access.Role, error) {
+	var roles []access.Role
+	err := s.b.withTx(ctx, func(tx *sql.Tx) (err error) {
+		roles, err = s.load(ctx, tx, `name = ?`, name)
+		return err
+	})
+	if err == nil && len(roles) == 0 {
+		err = myerrors.ErrNotFound
+	}
+	if err != nil {
+		return nil, myerrors.Wrap(myerrors.ErrGetRole, myerrors.KindRole, name, mapError(err))
+	}
+	return &roles[0], nil
+}
+
+func (s roleStore) List(ctx context.Context) ([]access.Role, error) {
+	var roles []access.Role
+	err := s.b.withTx(ctx, func(tx *sql.Tx) (err error) {
+		roles, err = s.load(ctx, tx, "")
+		return err
+	})
+	if err != nil {
+		return nil, myerrors.Wrap(myerrors.ErrListingRoles, myerrors.KindRole, "", mapError(err))
+	}
+	return roles, nil
+}
+
+func (s roleStore) Permissions(ctx context.Context, names ...string) ([]string, error) {
+	if len(names) == 0 {
+		return []string{}, nil
+	}
+	var perms []string
+	err := s.b.withTx(ctx, func(tx *sql.Tx) (err error) {
+		perms, err = permissions(ctx, s.b, tx, `SELECT name FROM roles WHERE name IN (`+placeholders(len(names))+`)`, stringArgs(names)...)
+		return err
+	})
+	if err != nil {
+		return nil, myerrors.Wrap(myerrors.ErrGetPermissions, myerrors.KindRole, "", mapError(err))
+	}
+	return perms, nil
+}
@@ -0,0 +1,274 @@
+// Package sqlstore implements the user, user group, access, role, audit, outbox
+// and webhook stores on a relational database. PostgreSQL and SQLite are
+// supported; the database/sql driver is not imported here, so binaries pick
+// one by importing it, e.g.
+//
+//	import _ "github.com/lib/pq"   // driver "postgres"
+//	import _ "modernc.org/sqlite" // driver "sqlite"
+//
+// The embedded user and user group snapshots of the mongodb models are not
+// stored but derived from the user_group_members join table on read.
+package sqlstore
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/sr-codefreak/user-group/db/mongodb/access"
+	"github.com/sr-codefreak/user-group/db/mongodb/audit"
+	"github.com/sr-codefreak/user-group/db/mongodb/event"
+	"github.com/sr-codefreak/user-group/db/mongodb/user"
+	"github.com/sr-codefreak/user-group/db/mongodb/usergroup"
+	"github.com/sr-codefreak/user-group/db/mongodb/webhook"
+	"github.com/sr-codefreak/user-group/myerrors"
+)
+
+// Dialect describes the SQL flavour of the database
+type Dialect struct {
+	name     string
+	numbered bool
+	// outboxSeq is the column numbering the outbox rows in insertion order
+	outboxSeq string
+	// outboxTxid, when set, is the column holding the id of the transaction
+	// appending the row. The rows are then listed by transaction id once
+	// finishedTxids passes it, as the concurrent writers number their rows
+	// out of commit order
+	outboxTxid string
+	// finishedTxids is the expression every transaction id below which has
+	// finished
+	finishedTxids string
+}
+
+var (
+	Postgres = Dialect{
+		name:          "postgres",
+		numbered:      true,
+		outboxSeq:     "seq",
+		outboxTxid:    "txid",
+		finishedTxids: "txid_snapshot_xmin(txid_current_snapshot())",
+	}
+	// SQLite has a single writer at a time, the rowids of the outbox grow
+	// in commit order
+	SQLite = Dialect{name: "sqlite", outboxSeq: "rowid"}
+)
+
+var driverDialects = map[string]Dialect{
+	"postgres": Postgres,
+	"pgx":      Postgres,
+	"sqlite":   SQLite,
+	"sqlite3":  SQLite,
+}
+
+// Backend holds the stores sharing one database
+type Backend struct {
+	db      *sql.DB
+	dialect Dialect
+}
+
+// New returns the backend on an opened database.
+// Call Migrate before using the stores on a new database.
+func New(db *sql.DB, dialect Dialect) *Backend {
+	return &Backend{db: db, dialect: dialect}
+}
+
+// Open opens the database with the registered driver, picks the dialect from
+// the driver name and applies the migrations. The foreign keys are enforced
+// on sqlite, which leaves them off by default.
+func Open(ctx context.Context, driverName string, dataSourceName string) (*Backend, error) {
+	dialect, ok := driverDialects[driverName]
+	if !ok {
+		return nil, fmt.Errorf("unsupported sql driver %q", driverName)
+	}
+	if dialect == SQLite {
+		dataSourceName = foreignKeysOn(driverName, dataSourceName)
+	}
+	db, err := sql.Open(driverName, dataSourceName)
+	if err != nil {
+		return nil, err
+	}
+	if dialect == SQLite {
+		// sqlite allows a single writer, serialise the connections
+		db.SetMaxOpenConns(1)
+	}
+	b := New(db, dialect)
+	if err := b.Migrate(ctx); err != nil {
+		db.Close()
+		return nil, err
+	}
+	return b, nil
+}
+
+// foreignKeysOn adds the parameter enforcing the foreign keys to the sqlite
+// data source name, so that every connection the pool opens enforces them
+func foreignKeysOn(driverName, dataSourceName string) string {
+	param := "_pragma=foreign_keys(1)" // modernc.org/sqlite
+	if driverName == "sqlite3" {
+		param = "_foreign_keys=1" // github.com/mattn/go-sqlite3
+	}
+	if strings.Contains(dataSourceName, "?") {
+		return dataSourceName + "&" + param
+	}
+	return dataSourceName + "?" + param
+}
+
+// DB returns the underlying database
+func (b *Backend) DB() *sql.DB {
+	return b.db
+}
+
+// Close closes the underlying database
+func (b *Backend) Close() error {
+	return b.db.Close()
+}
+
+func (b *Backend) Users() user.UserStore {
+	return userStore{b}
+}
+
+func (b *Backend) UserGroups() usergroup.UserGroupStore {
+	return userGroupStore{b}
+}
+
+func (b *Backend) Access() access.AccessStore {
+	return accessStore{b}
+}
+
+func (b *Backend) Roles() access.RoleStore {
+	return roleStore{b}
+}
+
+func (b *Backend) Audit() audit.AuditStore {
+	return auditStore{b}
+}
+
+func (b *Backend) Outbox() event.OutboxStore {
+	return outboxStore{b}
+}
+
+func (b *Backend) Webhooks() webhook.WebhookStore {
+	return webhookStore{b}
+}
+
+// rebind rewrites the ? placeholders of query for the dialect
+func (b *Backend) rebind(query string) string {
+	if !b.dialect.numbered {
+		return query
+	}
+	var sb strings.Builder
+	n := 0
+	for _, r := range query {
+		if r == '?' {
+			n++
+			sb.WriteString("$" + strconv.Itoa(n))
+			continue
+		}
+		sb.WriteRune(r)
+	}
+	return sb.String()
+}
+
+// txKey is the context key of the transaction the stores run in, see transaction
+type txKey struct{}
+
+// querier runs statements on the database or in a transaction
+type querier interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+// conn returns the transaction of ctx, else the database
+func (b *Backend) conn(ctx context.Context) querier {
+	if tx, ok := ctx.Value(txKey{}).(*sql.Tx); ok {
+		return tx
+	}
+	return b.db
+}
+
+// HasTenant reports whether tenant is empty, the backend has no tenants
+func (b *Backend) HasTenant(tenant string) bool {
+	return tenant == ""
+}
+
+// WithTransaction runs fn with a context carrying a transaction the stores
+// join: the one of ctx when it has one, else a new one committed when fn
+// returns nil
+func (b *Backend) WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if _, ok := ctx.Value(txKey{}).(*sql.Tx); ok {
+		return fn(ctx)
+	}
+	tx, err := b.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	if err := fn(context.WithValue(ctx, txKey{}, tx)); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
+
+// withTx runs fn in the transaction of ctx, else in a new one committed when
+// it returns nil
+func (b *Backend) withTx(ctx context.Context, fn func(tx *sql.Tx) error) error {
+	return b.WithTransaction(ctx, func(ctx context.Context) error {
+		return fn(ctx.Value(txKey{}).(*sql.Tx))
+	})
+}
+
+// mapError classifies database errors into a myerrors.Error.
+// Unique violations are recognised by the messages of the sqlite and postgres drivers.
+func mapError(err error) error {
+	if err == nil {
+		return nil
+	}
+	var e *myerrors.Error
+	switch {
+	case errors.As(err, &e):
+		return err
+	case errors.Is(err, sql.ErrNoRows):
+		return &myerrors.Error{Code: myerrors.NotFound, Err: err}
+	case errors.Is(err, context.DeadlineExceeded), errors.Is(err, sql.ErrConnDone), errors.Is(err, driver.ErrBadConn):
+		return &myerrors.Error{Code: myerrors.Unavailable, Err: err}
+	}
+	msg := err.Error()
+	if strings.Contains(msg, "UNIQUE constraint failed") ||
+		strings.Contains(msg, "duplicate key value") ||
+		strings.Contains(msg, "SQLSTATE 23505") {
+		return &myerrors.Error{Code: myerrors.AlreadyExists, Err: err}
+	}
+	return err
+}
+
+// placeholders returns n comma separated ? placeholders
+func placeholders(n int) string {
+	return strings.TrimSuffix(strings.Repeat("?,", n), ",")
+}
+
+func encodeMetaData(m map[string]any) (sql.NullString, error) {
+	if m == nil {
+		return sql.NullString{}, nil
+	}
+	data, err := json.Marshal(m)
+	if err != nil {
+		return sql.NullString{}, err
+	}
+	return sql.NullString{String: string(data), Valid: true}, nil
+}
+
+func decodeMetaData(s sql.NullString) (map[string]any, error) {
+	if !s.Valid {
+		return nil, nil
+	}
+	m := map[string]any{}
+	if err := json.Unmarshal([]byte(s.String), &m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
@@ -0,0 +1,241 @@
+package sqlstore
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/sr-codefreak/user-group/db/mongodb"
+	"github.com/sr-codefreak/user-group/db/mongodb/user"
+	"github.com/sr-codefreak/user-group/myerrors"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type userStore struct {
+	b *Backend
+}
+
+// EnsureIndexes is a no-op, the indexes are part of the migrations
+func (userStore) EnsureIndexes(ctx context.Context) error {
+	return nil
+}
+
+func (s userStore) Create(ctx context.Context, u *user.User) error {
+	if u.ID.IsZero() {
+		u.ID = primitive.NewObjectID()
+	}
+	metaData, err := encodeMetaData(u.MetaData)
+	if err != nil {
+		return myerrors.Wrap(myerrors.ErrCreatingUser, myerrors.KindUser, u.ID.Hex(), mapError(err))
+	}
+	_, err = s.b.conn(ctx).ExecContext(ctx, s.b.rebind(`INSERT INTO users (id, name, email, phone, meta_data) VALUES (?, ?, ?, ?, ?)`),
+		u.ID.Hex(), u.Name, u.Email, u.Phone, metaData)
+	if err != nil {
+		return myerrors.Wrap(myerrors.ErrCreatingUser, myerrors.KindUser, u.ID.Hex(), mapError(err))
+	}
+	return nil
+}
+
+// CreateMany inserts the users in one transaction
+func (s userStore) CreateMany(ctx context.Context, users []*user.User) error {
+	metaData := make([]any, len(users))
+	for i, u := range users {
+		if u.ID.IsZero() {
+			u.ID = primitive.NewObjectID()
+		}
+		var err error
+		if metaData[i], err = encodeMetaData(u.MetaData); err != nil {
+			return myerrors.Wrap(myerrors.ErrCreatingUser, myerrors.KindUser, u.ID.Hex(), mapError(err))
+		}
+	}
+	var failed *user.User
+	err := s.b.withTx(ctx, func(tx *sql.Tx) error {
+		for i, u := range users {
+			failed = u
+			_, err := tx.ExecContext(ctx, s.b.rebind(`INSERT INTO users (id, name, email, phone, meta_data) VALUES (?, ?, ?, ?, ?)`),
+				u.ID.Hex(), u.Name, u.Email, u.Phone, metaData[i])
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return myerrors.Wrap(myerrors.ErrCreatingUser, myerrors.KindUser, failed.ID.Hex(), mapError(err))
+	}
+	return nil
+}
+
+func (s userStore) Update(ctx context.Context, u *user.User) error {
+	set := ""
+	args := []any{}
+	if len(u.Name) > 0 {
+		set += "name = ?, "
+		args = append(args, u.Name)
+	}
+	if len(u.Email) > 0 {
+		set += "email = ?, "
+		args = append(args, u.Email)
+	}
+	if len(u.Phone) > 0 {
+		set += "phone = ?, "
+		args = append(args, u.Phone)
+	}
+	if u.MetaData != nil {
+		metaData, err := encodeMetaData(u.MetaData)
+		if err != nil {
+			return myerrors.Wrap(myerrors.ErrUpdatingUser, myerrors.KindUser, u.ID.Hex(), myerrors.Invalid(err))
+		}
+		set += "meta_data = ?, "
+		args = append(args, metaData)
+	}
+	if len(args) == 0 {
+		return nil
+	}
+	args = append(args, u.ID.Hex())
+	result, err := s.b.conn(ctx).ExecContext(ctx, s.b.rebind(`UPDATE users SET `+set[:len(set)-2]+` WHERE id = ?`), args...)
+	if err != nil {
+		return myerrors.Wrap(myerrors.ErrUpdatingUser, myerrors.KindUser, u.ID.Hex(), mapError(err))
+	}
+	if n, err := result.RowsAffected(); err == nil && n == 0 {
+		return myerrors.Wrap(myerrors.ErrUpdatingUser, myerrors.KindUser, u.ID.Hex(), myerrors.ErrNotFound)
+	}
+	return nil
+}
+
+func (s userStore) GetById(ctx context.Context, id string) (*user.User, error) {
+	u := &user.User{}
+	oid, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, myerrors.Wrap(myerrors.ErrGetUserById, myerrors.KindUser, id, myerrors.Invalid(err))
+	}
+	u.ID = oid
+	var metaData sql.NullString
+	err = s.b.conn(ctx).QueryRowContext(ctx, s.b.rebind(`SELECT name, email, phone, meta_data FROM users WHERE id = ?`), id).
+		Scan(&u.Name, &u.Email, &u.Phone, &metaData)
+	if err == sql.ErrNoRows {
+		return nil, myerrors.Wrap(myerrors.ErrGetUserById, myerrors.KindUser, id, myerrors.ErrNotFound)
+	}
+	if err != nil {
+		return nil, myerrors.Wrap(myerrors.ErrGetUserById, myerrors.KindUser, id, mapError(err))
+	}
+	if u.MetaData, err = decodeMetaData(metaData); err != nil {
+		return nil, myerrors.Wrap(myerrors.ErrGetUserById, myerrors.KindUser, id, mapError(err))
+	}
+
+	rows, err := s.b.conn(ctx).QueryContext(ctx, s.b.rebind(`SELECT g.id, g.name FROM user_group_members m
+		JOIN user_groups g ON g.id = m.user_group_id
+		WHERE m.user_id = ? ORDER BY g.id`), id)
+	if err != nil {
+		return nil, myerrors.Wrap(myerrors.ErrGetUserById, myerrors.KindUser, id, mapError(err))
+	}
+	defer rows.Close()
+	for rows.Next() {
+		g := user.UserGroupRef{}
+		if err := rows.Scan(&g.ID, &g.Name); err != nil {
+			return nil, myerrors.Wrap(myerrors.ErrGetUserById, myerrors.KindUser, id, mapError(err))
+		}
+		u.UsersGroups = append(u.UsersGroups, g)
+		u.UserGroupIds = append(u.UserGroupIds, g.ID)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, myerrors.Wrap(myerrors.ErrGetUserById, myerrors.KindUser, id, mapError(err))
+	}
+	return u, nil
+}
+
+// Delete deletes the user, its memberships and the access rows granted to it
+func (s userStore) Delete(ctx context.Context, id primitive.ObjectID) error {
+	err := s.b.withTx(ctx, func(tx *sql.Tx) error {
+		result, err := tx.ExecContext(ctx, s.b.rebind(`DELETE FROM users WHERE id = ?`), id.Hex())
+		if err != nil {
+			return err
+		}
+		if n, err := result.RowsAffected(); err != nil {
+			return err
+		} else if n != 1 {
+			return myerrors.ErrNotFound
+		}
+		if _, err := tx.ExecContext(ctx, s.b.rebind(`DELETE FROM user_group_members WHERE user_id = ?`), id.Hex()); err != nil {
+			return err
+		}
+		return deleteAccess(ctx, s.b, tx, `user_id = ?`, id.Hex())
+	})
+	if err != nil {
+		return myerrors.Wrap(myerrors.ErrDeleteUser, myerrors.KindUser, id.Hex(), mapError(err))
+	}
+	return nil
+}
+
+func (s userStore) List(ctx context.Context, opts mongodb.ListOptions) ([]user.User, string, error) {
+	if err := opts.Check(mongodb.NameKey, mongodb.EmailKey, mongodb.PhoneKey); err != nil {
+		return nil, "", myerrors.Wrap(myerrors.ErrListingUsers, myerrors.KindUser, "", err)
+	}
+	clause, args, err := s.b.listClause(opts)
+	if err != nil {
+		return nil, "", myerrors.Wrap(myerrors.ErrListingUsers, myerrors.KindUser, "", mapError(err))
+	}
+	users, err := s.list(ctx, clause, args)
+	if err != nil {
+		return nil, "", myerrors.Wrap(myerrors.ErrListingUsers, myerrors.KindUser, "", mapError(err))
+	}
+	users, next := mongodb.Page(opts, users, func(u user.User) ([]string, primitive.ObjectID) {
+		return u.SortValues(opts.Sort), u.ID
+	})
+	return users, next, nil
+}
+
+// list returns the users selected by clause together with their user groups
+func (s userStore) list(ctx context.Context, clause string, args []any) ([]user.User, error) {
+	rows, err := s.b.conn(ctx).QueryContext(ctx, s.b.rebind(`SELECT id, name, email, phone, meta_data FROM users`+clause), args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	users := []user.User{}
+	index := map[string]int{}
+	for rows.Next() {
+		u := user.User{}
+		var id string
+		var metaData sql.NullString
+		if err := rows.Scan(&id, &u.Name, &u.Email, &u.Phone, &metaData); err != nil {
+			return nil, err
+		}
+		if u.ID, err = primitive.ObjectIDFromHex(id); err != nil {
+			return nil, err
+		}
+		if u.MetaData, err = decodeMetaData(metaData); err != nil {
+			return nil, err
+		}
+		index[id] = len(users)
+		users = append(users, u)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if len(users) == 0 {
+		return users, nil
+	}
+
+	ids := make([]any, 0, len(users))
+	for id := range index {
+		ids = append(ids, id)
+	}
+	groups, err := s.b.conn(ctx).QueryContext(ctx, s.b.rebind(`SELECT m.user_id, g.id, g.name FROM user_group_members m
+		JOIN user_groups g ON g.id = m.user_group_id
+		WHERE m.user_id IN (`+placeholders(len(ids))+`) ORDER BY g.id`), ids...)
+	if err != nil {
+		return nil, err
+	}
+	defer groups.Close()
+	for groups.Next() {
+		var userId string
+		g := user.UserGroupRef{}
+		if err := groups.Scan(&userId, &g.ID, &g.Name); err != nil {
+			return nil, err
+		}
+		u := &users[index[userId]]
+		u.UsersGroups = append(u.UsersGroups, g)
+		u.UserGroupIds = append(u.UserGroupIds, g.ID)
+	}
+	return users, groups.Err()
+}
@@ -0,0 +1,306 @@
+package sqlstore
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/sr-codefreak/user-group/db/mongodb"
+	"github.com/sr-codefreak/user-group/db/mongodb/usergroup"
+	"github.com/sr-codefreak/user-group/myerrors"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type userGroupStore struct {
+	b *Backend
+}
+
+// EnsureIndexes is a no-op, the indexes are part of the migrations
+func (userGroupStore) EnsureIndexes(ctx context.Context) error {
+	return nil
+}
+
+// Create inserts the user group together with the existing users listed in
+// group.UserIds and the existing parents listed in group.ParentIds
+func (s userGroupStore) Create(ctx context.Context, group *usergroup.UserGroup) error {
+	return s.CreateMany(ctx, []*usergroup.UserGroup{group})
+}
+
+// CreateMany inserts the user groups in one transaction like Create
+func (s userGroupStore) CreateMany(ctx context.Context, groups []*usergroup.UserGroup) error {
+	metaData := make([]any, len(groups))
+	for i, group := range groups {
+		if group.ID.IsZero() {
+			group.ID = primitive.NewObjectID()
+		}
+		if err := group.CheckRule(); err != nil {
+			return myerrors.Wrap(myerrors.ErrCreatingUserGroup, myerrors.KindUserGroup, group.ID.Hex(), err)
+		}
+		var err error
+		if metaData[i], err = encodeMetaData(group.MetaData); err != nil {
+			return myerrors.Wrap(myerrors.ErrCreatingUserGroup, myerrors.KindUserGroup, group.ID.Hex(), mapError(err))
+		}
+	}
+	var failed *usergroup.UserGroup
+	err := s.b.withTx(ctx, func(tx *sql.Tx) error {
+		for i, group := range groups {
+			failed = group
+			if err := s.insert(ctx, tx, group, metaData[i]); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return myerrors.Wrap(myerrors.ErrCreatingUserGroup, myerrors.KindUserGroup, failed.ID.Hex(), mapError(err))
+	}
+	return nil
+}
+
+func (s userGroupStore) insert(ctx context.Context, tx *sql.Tx, group *usergroup.UserGroup, metaData any) error {
+	_, err := tx.ExecContext(ctx, s.b.rebind(`INSERT INTO user_groups (id, name, meta_data, rule) VALUES (?, ?, ?, ?)`),
+		group.ID.Hex(), group.Name, metaData, group.Rule)
+	if err != nil {
+		return err
+	}
+	for _, userId := range group.UserIds {
+		_, err := tx.ExecContext(ctx, s.b.rebind(`INSERT INTO user_group_members (user_group_id, user_id)
+			SELECT ?, id FROM users WHERE id = ? ON CONFLICT DO NOTHING`), group.ID.Hex(), userId)
+		if err != nil {
+			return err
+		}
+	}
+	for _, parentId := range group.ParentIds {
+		_, err := tx.ExecContext(ctx, s.b.rebind(`INSERT INTO user_group_parents (user_group_id, parent_id)
+			SELECT ?, id FROM user_groups WHERE id = ? ON CONFLICT DO NOTHING`), group.ID.Hex(), parentId.Hex())
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s userGroupStore) UpdateName(ctx context.Context, id primitive.ObjectID, name string) error {
+	result, err := s.b.conn(ctx).ExecContext(ctx, s.b.rebind(`UPDATE user_groups SET name = ? WHERE id = ?`), name, id.Hex())
+	if err != nil {
+		return myerrors.Wrap(myerrors.ErrUpdatingUserGroupName, myerrors.KindUserGroup, id.Hex(), mapError(err))
+	}
+	if n, err := result.RowsAffected(); err == nil && n == 0 {
+		return myerrors.Wrap(myerrors.ErrUpdatingUserGroupName, myerrors.KindUserGroup, id.Hex(), myerrors.ErrNotFound)
+	}
+	return nil
+}
+
+func (s userGroupStore) SetRule(ctx context.Context, id primitive.ObjectID, rule string) error {
+	if err := (usergroup.UserGroup{Rule: rule}).CheckRule(); err != nil {
+		return myerrors.Wrap(myerrors.ErrSettingRule, myerrors.KindUserGroup, id.Hex(), err)
+	}
+	result, err := s.b.conn(ctx).ExecContext(ctx, s.b.rebind(`UPDATE user_groups SET rule = ? WHERE id = ?`), rule, id.Hex())
+	if err != nil {
+		return myerrors.Wrap(myerrors.ErrSettingRule, myerrors.KindUserGroup, id.Hex(), mapError(err))
+	}
+	if n, err := result.RowsAffected(); err == nil && n == 0 {
+		return myerrors.Wrap(myerrors.ErrSettingRule, myerrors.KindUserGroup, id.Hex(), myerrors.ErrNotFound)
+	}
+	return nil
+}
+
+func exists(ctx context.Context, b *Backend, tx *sql.Tx, table string, id string) (bool, error) {
+	var n int
+	err := tx.QueryRowContext(ctx, b.rebind(`SELECT COUNT(*) FROM `+table+` WHERE id = ?`), id).Scan(&n)
+	return n > 0, err
+}
+
+// AddUser adds the user to the user group
+func (s userGroupStore) AddUser(ctx context.Context, id primitive.ObjectID, userId primitive.ObjectID) error {
+	err := s.b.withTx(ctx, func(tx *sql.Tx) error {
+		ok, err := exists(ctx, s.b, tx, "user_groups", id.Hex())
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return myerrors.Wrap(myerrors.ErrGetUserGroupById, myerrors.KindUserGroup, id.Hex(), myerrors.ErrNotFound)
+		}
+		ok, err = exists(ctx, s.b, tx, "users", userId.Hex())
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return myerrors.Wrap(myerrors.ErrGetUserById, myerrors.KindUser, userId.Hex(), myerrors.ErrNotFound)
+		}
+		_, err = tx.ExecContext(ctx, s.b.rebind(`INSERT INTO user_group_members (user_group_id, user_id) VALUES (?, ?)
+			ON CONFLICT DO NOTHING`), id.Hex(), userId.Hex())
+		return err
+	})
+	if err != nil {
+		return myerrors.Wrap(myerrors.ErrAddingUserToUserGroup, myerrors.KindUserGroup, id.Hex(), mapError(err))
+	}
+	return nil
+}
+
+// RemoveUser removes the user from the user group
+func (s userGroupStore) RemoveUser(ctx context.Context, id primitive.ObjectID, userId primitive.ObjectID) error {
+	_, err := s.b.conn(ctx).ExecContext(ctx, s.b.rebind(`DELETE FROM user_group_members WHERE user_group_id = ? AND user_id = ?`),
+		id.Hex(), userId.Hex())
+	if err != nil {
+		return myerrors.Wrap(myerrors.ErrRemovingUserFromUserGroup, myerrors.KindUserGroup, id.Hex(), mapError(err))
+	}
+	return nil
+}
+
+func (s userGroupStore) GetById(ctx context.Context, id string) (*usergroup.UserGroup, error) {
+	ug := &usergroup.UserGroup{}
+	oid, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, myerrors.Wrap(myerrors.ErrGetUserGroupById, myerrors.KindUserGroup, id, myerrors.Invalid(err))
+	}
+	ug.ID = oid
+	var metaData sql.NullString
+	err = s.b.conn(ctx).QueryRowContext(ctx, s.b.rebind(`SELECT name, meta_data, rule FROM user_groups WHERE id = ?`), id).
+		Scan(&ug.Name, &metaData, &ug.Rule)
+	if err == sql.ErrNoRows {
+		return nil, myerrors.Wrap(myerrors.ErrGetUserGroupById, myerrors.KindUserGroup, id, myerrors.ErrNotFound)
+	}
+	if err != nil {
+		return nil, myerrors.Wrap(myerrors.ErrGetUserGroupById, myerrors.KindUserGroup, id, mapError(err))
+	}
+	if ug.MetaData, err = decodeMetaData(metaData); err != nil {
+		return nil, myerrors.Wrap(myerrors.ErrGetUserGroupById, myerrors.KindUserGroup, id, mapError(err))
+	}
+
+	rows, err := s.b.conn(ctx).QueryContext(ctx, s.b.rebind(`SELECT u.id, u.name, u.email, u.phone FROM user_group_members m
+		JOIN users u ON u.id = m.user_id
+		WHERE m.user_group_id = ? ORDER BY u.id`), id)
+	if err != nil {
+		return nil, myerrors.Wrap(myerrors.ErrGetUserGroupById, myerrors.KindUserGroup, id, mapError(err))
+	}
+	defer rows.Close()
+	for rows.Next() {
+		u := usergroup.UserRef{}
+		if err := rows.Scan(&u.ID, &u.Name, &u.Email, &u.Phone); err != nil {
+			return nil, myerrors.Wrap(myerrors.ErrGetUserGroupById, myerrors.KindUserGroup, id, mapError(err))
+		}
+		ug.Users = append(ug.Users, u)
+		ug.UserIds = append(ug.UserIds, u.ID)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, myerrors.Wrap(myerrors.ErrGetUserGroupById, myerrors.KindUserGroup, id, mapError(err))
+	}
+	parents, err := s.b.parents(ctx, []any{id})
+	if err != nil {
+		return nil, myerrors.Wrap(myerrors.ErrGetUserGroupById, myerrors.KindUserGroup, id, mapError(err))
+	}
+	ug.ParentIds = parents[id]
+	return ug, nil
+}
+
+// DeleteById deletes the user group, its memberships, its parent and child
+// relations and the access rows granted on it
+func (s userGroupStore) DeleteById(ctx context.Context, id primitive.ObjectID) error {
+	err := s.b.withTx(ctx, func(tx *sql.Tx) error {
+		result, err := tx.ExecContext(ctx, s.b.rebind(`DELETE FROM user_groups WHERE id = ?`), id.Hex())
+		if err != nil {
+			return err
+		}
+		if n, err := result.RowsAffected(); err != nil {
+			return err
+		} else if n != 1 {
+			return myerrors.ErrNotFound
+		}
+		if _, err := tx.ExecContext(ctx, s.b.rebind(`DELETE FROM user_group_members WHERE user_group_id = ?`), id.Hex()); err != nil {
+			return err
+		}
+		if _, err := tx.ExecContext(ctx, s.b.rebind(`DELETE FROM user_group_parents WHERE user_group_id = ? OR parent_id = ?`),
+			id.Hex(), id.Hex()); err != nil {
+			return err
+		}
+		return deleteAccess(ctx, s.b, tx, `user_group_id = ?`, id.Hex())
+	})
+	if err != nil {
+		return myerrors.Wrap(myerrors.ErrDeleteUserGroup, myerrors.KindUserGroup, id.Hex(), mapError(err))
+	}
+	return nil
+}
+
+func (s userGroupStore) List(ctx context.Context, opts mongodb.ListOptions) ([]usergroup.UserGroup, string, error) {
+	if err := opts.Check(mongodb.NameKey, mongodb.RuleKey); err != nil {
+		return nil, "", myerrors.Wrap(myerrors.ErrListingUserGroups, myerrors.KindUserGroup, "", err)
+	}
+	clause, args, err := s.b.listClause(opts)
+	if err != nil {
+		return nil, "", myerrors.Wrap(myerrors.ErrListingUserGroups, myerrors.KindUserGroup, "", mapError(err))
+	}
+	groups, err := s.list(ctx, clause, args)
+	if err != nil {
+		return nil, "", myerrors.Wrap(myerrors.ErrListingUserGroups, myerrors.KindUserGroup, "", mapError(err))
+	}
+	groups, next := mongodb.Page(opts, groups, func(ug usergroup.UserGroup) ([]string, primitive.ObjectID) {
+		return ug.SortValues(opts.Sort), ug.ID
+	})
+	return groups, next, nil
+}
+
+// list returns the user groups selected by clause together with their members
+func (s userGroupStore) list(ctx context.Context, clause string, args []any) ([]usergroup.UserGroup, error) {
+	rows, err := s.b.conn(ctx).QueryContext(ctx, s.b.rebind(`SELECT id, name, meta_data, rule FROM user_groups`+clause), args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	groups := []usergroup.UserGroup{}
+	index := map[string]int{}
+	for rows.Next() {
+		ug := usergroup.UserGroup{}
+		var id string
+		var metaData sql.NullString
+		if err := rows.Scan(&id, &ug.Name, &metaData, &ug.Rule); err != nil {
+			return nil, err
+		}
+		if ug.ID, err = primitive.ObjectIDFromHex(id); err != nil {
+			return nil, err
+		}
+		if ug.MetaData, err = decodeMetaData(metaData); err != nil {
+			return nil, err
+		}
+		index[id] = len(groups)
+		groups = append(groups, ug)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if len(groups) == 0 {
+		return groups, nil
+	}
+
+	ids := make([]any, 0, len(groups))
+	for id := range index {
+		ids = append(ids, id)
+	}
+	members, err := s.b.conn(ctx).QueryContext(ctx, s.b.rebind(`SELECT m.user_group_id, u.id, u.name, u.email, u.phone FROM user_group_members m
+		JOIN users u ON u.id = m.user_id
+		WHERE m.user_group_id IN (`+placeholders(len(ids))+`) ORDER BY u.id`), ids...)
+	if err != nil {
+		return nil, err
+	}
+	defer members.Close()
+	for members.Next() {
+		var groupId string
+		u := usergroup.UserRef{}
+		if err := members.Scan(&groupId, &u.ID, &u.Name, &u.Email, &u.Phone); err != nil {
+			return nil, err
+		}
+		ug := &groups[index[groupId]]
+		ug.Users = append(ug.Users, u)
+		ug.UserIds = append(ug.UserIds, u.ID)
+	}
+	if err := members.Err(); err != nil {
+		return nil, err
+	}
+	parents, err := s.b.parents(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	for id, i := range index {
+		groups[i].ParentIds = parents[id]
+	}
+	return groups, nil
+}
@@ -0,0 +1,274 @@
+package sqlstore
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/sr-codefreak/user-group/db/mongodb"
+	"github.com/sr-codefreak/user-group/db/mongodb/event"
+	"github.com/sr-codefreak/user-group/db/mongodb/webhook"
+	"github.com/sr-codefreak/user-group/myerrors"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// webhookStore keeps the event types and the attempts of a delivery as JSON
+// and the times as microseconds since the epoch like auditStore
+type webhookStore struct {
+	b *Backend
+}
+
+// EnsureIndexes is a no-op, the indexes are part of the migrations
+func (webhookStore) EnsureIndexes(ctx context.Context) error {
+	return nil
+}
+
+func encodeTypes(types []event.Type) (sql.NullString, error) {
+	if len(types) == 0 {
+		return sql.NullString{}, nil
+	}
+	data, err := json.Marshal(types)
+	if err != nil {
+		return sql.NullString{}, err
+	}
+	return sql.NullString{String: string(data), Valid: true}, nil
+}
+
+func (s webhookStore) Create(ctx context.Context, sub *webhook.Subscription) error {
+	if err := sub.Check(); err != nil {
+		return myerrors.Wrap(myerrors.ErrCreatingWebhook, myerrors.KindWebhook, "", err)
+	}
+	types, err := encodeTypes(sub.Types)
+	if err != nil {
+		return myerrors.Wrap(myerrors.ErrCreatingWebhook, myerrors.KindWebhook, "", myerrors.Invalid(err))
+	}
+	if sub.ID.IsZero() {
+		sub.ID = primitive.NewObjectID()
+	}
+	if sub.Created.IsZero() {
+		sub.Created = time.Now().UTC()
+	}
+	_, err = s.b.conn(ctx).ExecContext(ctx, s.b.rebind(`INSERT INTO webhooks (id, url, secret, user_group_id, types, disabled, created_us)
+		VALUES (?, ?, ?, ?, ?, ?, ?)`),
+		sub.ID.Hex(), sub.URL, sub.Secret, sub.UserGroupId, types, sub.Disabled, sub.Created.UnixMicro())
+	if err != nil {
+		return myerrors.Wrap(myerrors.ErrCreatingWebhook, myerrors.KindWebhook, sub.ID.Hex(), mapError(err))
+	}
+	return nil
+}
+
+func (s webhookStore) Update(ctx context.Context, sub *webhook.Subscription) error {
+	if err := sub.Check(); err != nil {
+		return myerrors.Wrap(myerrors.ErrUpdatingWebhook, myerrors.KindWebhook, sub.ID.Hex(), err)
+	}
+	types, err := encodeTypes(sub.Types)
+	if err != nil {
+		return myerrors.Wrap(myerrors.ErrUpdatingWebhook, myerrors.KindWebhook, sub.ID.Hex(), myerrors.Invalid(err))
+	}
+	query := `UPDATE webhooks SET url = ?, user_group_id = ?, types = ?, disabled = ?`
+	args := []any{sub.URL, sub.UserGroupId, types, sub.Disabled}
+	if sub.Secret != "" {
+		query += `, secret = ?`
+		args = append(args, sub.Secret)
+	}
+	result, err := s.b.conn(ctx).ExecContext(ctx, s.b.rebind(query+` WHERE id = ?`), append(args, sub.ID.Hex())...)
+	if err != nil {
+		return myerrors.Wrap(myerrors.ErrUpdatingWebhook, myerrors.KindWebhook, sub.ID.Hex(), mapError(err))
+	}
+	if n, err := result.RowsAffected(); err == nil && n == 0 {
+		return myerrors.Wrap(myerrors.ErrUpdatingWebhook, myerrors.KindWebhook, sub.ID.Hex(), myerrors.ErrNotFound)
+	}
+	return nil
+}
+
+func (s webhookStore) Delete(ctx context.Context, id primitive.ObjectID) error {
+	err := s.b.withTx(ctx, func(tx *sql.Tx) error {
+		result, err := tx.ExecContext(ctx, s.b.rebind(`DELETE FROM webhooks WHERE id = ?`), id.Hex())
+		if err != nil {
+			return err
+		}
+		if n, err := result.RowsAffected(); err == nil && n == 0 {
+			return myerrors.ErrNotFound
+		}
+		_, err = tx.ExecContext(ctx, s.b.rebind(`DELETE FROM webhook_deliveries WHERE subscription_id = ?`), id.Hex())
+		return err
+	})
+	if err != nil {
+		return myerrors.Wrap(myerrors.ErrDeleteWebhook, myerrors.KindWebhook, id.Hex(), mapError(err))
+	}
+	return nil
+}
+
+const subscriptionColumns = `id, url, secret, user_group_id, types, disabled, created_us`
+
+func scanSubscription(row interface{ Scan(...any) error }) (*webhook.Subscription, error) {
+	sub := &webhook.Subscription{}
+	var id string
+	var types sql.NullString
+	var micros int64
+	if err := row.Scan(&id, &sub.URL, &sub.Secret, &sub.UserGroupId, &types, &sub.Disabled, &micros); err != nil {
+		return nil, err
+	}
+	sub.ID, _ = primitive.ObjectIDFromHex(id)
+	sub.Created = time.UnixMicro(micros).UTC()
+	if types.Valid {
+		if err := json.Unmarshal([]byte(types.String), &sub.Types); err != nil {
+			return nil, err
+		}
+	}
+	return sub, nil
+}
+
+func (s webhookStore) Get(ctx context.Context, id string) (*webhook.Subscription, error) {
+	if _, err := primitive.ObjectIDFromHex(id); err != nil {
+		return nil, myerrors.Wrap(myerrors.ErrGetWebhook, myerrors.KindWebhook, id, myerrors.Invalid(err))
+	}
+	row := s.b.conn(ctx).QueryRowContext(ctx, s.b.rebind(`SELECT `+subscriptionColumns+` FROM webhooks WHERE id = ?`), id)
+	sub, err := scanSubscription(row)
+	if err != nil {
+		return nil, myerrors.Wrap(myerrors.ErrGetWebhook, myerrors.KindWebhook, id, mapError(err))
+	}
+	return sub, nil
+}
+
+func (s webhookStore) List(ctx context.Context) ([]webhook.Subscription, error) {
+	rows, err := s.b.conn(ctx).QueryContext(ctx, `SELECT `+subscriptionColumns+` FROM webhooks ORDER BY id`)
+	if err != nil {
+		return nil, myerrors.Wrap(myerrors.ErrListingWebhooks, myerrors.KindWebhook, "", mapError(err))
+	}
+	defer rows.Close()
+	subs := []webhook.Subscription{}
+	for rows.Next() {
+		sub, err := scanSubscription(rows)
+		if err != nil {
+			return nil, myerrors.Wrap(myerrors.ErrListingWebhooks, myerrors.KindWebhook, "", mapError(err))
+		}
+		subs = append(subs, *sub)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, myerrors.Wrap(myerrors.ErrListingWebhooks, myerrors.KindWebhook, "", mapError(err))
+	}
+	return subs, nil
+}
+
+func (s webhookStore) Enqueue(ctx context.Context, d *webhook.Delivery) error {
+	if d.ID.IsZero() {
+		d.ID = primitive.NewObjectID()
+	}
+	if d.Created.IsZero() {
+		d.Created = time.Now().UTC()
+	}
+	if d.Attempts == nil {
+		d.Attempts = []webhook.Attempt{}
+	}
+	attempts, err := json.Marshal(d.Attempts)
+	if err != nil {
+		return myerrors.Wrap(myerrors.ErrEnqueuingDelivery, myerrors.KindWebhook, d.SubscriptionId, myerrors.Invalid(err))
+	}
+	_, err = s.b.conn(ctx).ExecContext(ctx, s.b.rebind(`INSERT INTO webhook_deliveries
+		(id, subscription_id, event_id, event_type, payload, status, attempts, next_attempt_us, created_us)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`),
+		d.ID.Hex(), d.SubscriptionId, d.EventId, string(d.EventType), d.Payload, string(d.Status),
+		string(attempts), d.NextAttempt.UnixMicro(), d.Created.UnixMicro())
+	if err != nil {
+		return myerrors.Wrap(myerrors.ErrEnqueuingDelivery, myerrors.KindWebhook, d.SubscriptionId, mapError(err))
+	}
+	return nil
+}
+
+func (s webhookStore) UpdateDelivery(ctx context.Context, d *webhook.Delivery) error {
+	attempts, err := json.Marshal(d.Attempts)
+	if err != nil {
+		return myerrors.Wrap(myerrors.ErrUpdatingDelivery, myerrors.KindWebhook, d.SubscriptionId, myerrors.Invalid(err))
+	}
+	result, err := s.b.conn(ctx).ExecContext(ctx, s.b.rebind(`UPDATE webhook_deliveries
+		SET status = ?, attempts = ?, next_attempt_us = ? WHERE id = ?`),
+		string(d.Status), string(attempts), d.NextAttempt.UnixMicro(), d.ID.Hex())
+	if err != nil {
+		return myerrors.Wrap(myerrors.ErrUpdatingDelivery, myerrors.KindWebhook, d.SubscriptionId, mapError(err))
+	}
+	if n, err := result.RowsAffected(); err == nil && n == 0 {
+		return myerrors.Wrap(myerrors.ErrUpdatingDelivery, myerrors.KindWebhook, d.SubscriptionId, myerrors.ErrNotFound)
+	}
+	return nil
+}
+
+func (s webhookStore) ListDeliveries(ctx context.Context, q webhook.DeliveryQuery) ([]webhook.Delivery, error) {
+	q = q.Normalize()
+	where := []string{}
+	args := []any{}
+	if q.SubscriptionId != "" {
+		where = append(where, `subscription_id = ?`)
+		args = append(args, q.SubscriptionId)
+	}
+	if q.Status != "" {
+		where = append(where, `status = ?`)
+		args = append(args, string(q.Status))
+	}
+	clause := ""
+	if len(where) > 0 {
+		clause = ` WHERE ` + strings.Join(where, ` AND `)
+	}
+	return s.query(ctx, clause+` ORDER BY id DESC LIMIT ?`, append(args, q.Limit)...)
+}
+
+// Claim moves the next attempt of each due delivery unless another
+// dispatcher has moved it since it was found
+func (s webhookStore) Claim(ctx context.Context, now time.Time, until time.Time, limit int) ([]webhook.Delivery, error) {
+	due, err := s.query(ctx, ` WHERE status = ? AND next_attempt_us <= ? ORDER BY next_attempt_us LIMIT ?`,
+		string(webhook.Pending), now.UnixMicro(), mongodb.ListOptions{PageSize: limit}.Limit())
+	if err != nil {
+		return nil, err
+	}
+	claimed := []webhook.Delivery{}
+	for _, d := range due {
+		result, err := s.b.conn(ctx).ExecContext(ctx, s.b.rebind(`UPDATE webhook_deliveries SET next_attempt_us = ?
+			WHERE id = ? AND status = ? AND next_attempt_us = ?`),
+			until.UnixMicro(), d.ID.Hex(), string(webhook.Pending), d.NextAttempt.UnixMicro())
+		if err != nil {
+			return nil, myerrors.Wrap(myerrors.ErrUpdatingDelivery, myerrors.KindWebhook, d.SubscriptionId, mapError(err))
+		}
+		if n, err := result.RowsAffected(); err != nil {
+			return nil, myerrors.Wrap(myerrors.ErrUpdatingDelivery, myerrors.KindWebhook, d.SubscriptionId, mapError(err))
+		} else if n == 1 {
+			d.NextAttempt = until.UTC()
+			claimed = append(claimed, d)
+		}
+	}
+	return claimed, nil
+}
+
+// query returns the deliveries selected by the clause following FROM
+func (s webhookStore) query(ctx context.Context, clause string, args ...any) ([]webhook.Delivery, error) {
+	rows, err := s.b.conn(ctx).QueryContext(ctx, s.b.rebind(`SELECT id, subscription_id, event_id, event_type, payload,
+		status, attempts, next_attempt_us, created_us FROM webhook_deliveries`+clause), args...)
+	if err != nil {
+		return nil, myerrors.Wrap(myerrors.ErrListingDeliveries, myerrors.KindWebhook, "", mapError(err))
+	}
+	defer rows.Close()
+	deliveries := []webhook.Delivery{}
+	for rows.Next() {
+		var d webhook.Delivery
+		var id, eventType, status, attempts string
+		var next, created int64
+		err := rows.Scan(&id, &d.SubscriptionId, &d.EventId, &eventType, &d.Payload, &status, &attempts, &next, &created)
+		if err != nil {
+			return nil, myerrors.Wrap(myerrors.ErrListingDeliveries, myerrors.KindWebhook, "", mapError(err))
+		}
+		d.ID, _ = primitive.ObjectIDFromHex(id)
+		d.EventType = event.Type(eventType)
+		d.Status = webhook.Status(status)
+		d.NextAttempt = time.UnixMicro(next).UTC()
+		d.Created = time.UnixMicro(created).UTC()
+		if err := json.Unmarshal([]byte(attempts), &d.Attempts); err != nil {
+			return nil, myerrors.Wrap(myerrors.ErrListingDeliveries, myerrors.KindWebhook, "", err)
+		}
+		deliveries = append(deliveries, d)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, myerrors.Wrap(myerrors.ErrListingDeliveries, myerrors.KindWebhook, "", mapError(err))
+	}
+	return deliveries, nil
+}
@@ -0,0 +1,238 @@
+// Package dirsync synchronises the users and user groups of a backend with
+// a directory such as LDAP or Active Directory, the source of truth for
+// people and teams.
+//
+// A Syncer maps the user and group entries of a Directory to users and user
+// groups with the attribute mapping of a Config, creates and updates them and
+// reconciles the members of the groups with their member attribute. Member
+// groups become subgroups. The synced users and user groups carry their
+// entry in their metaData:
+//
+//	source      Config.Name of the directory
+//	externalId  the Mapping.ID attribute, which identifies the entry across renames
+//	dn          the distinguished name of the entry
+//	active      false when the user is disabled, see user.ActiveKey
+//
+// Users and user groups without source are left alone, so the directory can
+// feed a backend that is also managed through the APIs.
+//
+// A full sync soft-disables the users whose entry is gone by setting active
+// to false, and removes the members of the groups whose entry is gone;
+// nothing is deleted. An incremental sync only reads the entries modified
+// since the previous sync and cannot see deletions, Run alternates both.
+// A dry run returns the Report of the changes without making them.
+//
+//	dir := dirsync.NewLDAP(dirsync.LDAPConfig{URL: "ldaps://dc.example.com", ...})
+//	s := dirsync.New(backend, dir, dirsync.ActiveDirectory("dc=example,dc=com"))
+//	report, err := s.Sync(ctx, dirsync.Options{DryRun: true})
+//	fmt.Print(report)
+//
+// The Stub directory serves entries from memory for tests and dry runs.
+package dirsync
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Entry is an entry of the directory
+type Entry struct {
+	DN         string
+	Attributes map[string][]string
+	// Modified is the time of the last modification, modifyTimestamp in LDAP
+	Modified time.Time
+}
+
+// Get returns the first value of the attribute, attribute names are case insensitive
+func (e Entry) Get(attr string) string {
+	if values := e.Values(attr); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+// Values returns the values of the attribute
+func (e Entry) Values(attr string) []string {
+	if values, ok := e.Attributes[attr]; ok {
+		return values
+	}
+	for name, values := range e.Attributes {
+		if strings.EqualFold(name, attr) {
+			return values
+		}
+	}
+	return nil
+}
+
+// SearchRequest selects the entries of a Search
+type SearchRequest struct {
+	// BaseDN is the subtree searched
+	BaseDN string
+	// Filter is an LDAP filter such as (objectClass=person)
+	Filter string
+	// Attributes are the attributes returned
+	Attributes []string
+	// ModifiedSince restricts the search to the entries modified since, when not zero
+	ModifiedSince time.Time
+}
+
+// Directory is a directory of users and groups
+type Directory interface {
+	Search(ctx context.Context, req SearchRequest) ([]Entry, error)
+}
+
+// Mapping maps the attributes of an entry. MetaData maps metaData keys
+// to attributes, multi-valued attributes are stored as lists.
+type Mapping struct {
+	BaseDN   string            `json:"baseDN"`
+	Filter   string            `json:"filter"`
+	ID       string            `json:"id"`
+	Name     string            `json:"name"`
+	MetaData map[string]string `json:"metaData"`
+}
+
+// UserMapping maps the user entries
+type UserMapping struct {
+	Mapping
+	Email string `json:"email"`
+	Phone string `json:"phone"`
+	// Disabled is the attribute marking disabled users, TRUE or 1.
+	// For userAccountControl the ACCOUNTDISABLE flag marks them.
+	Disabled string `json:"disabled"`
+}
+
+// GroupMapping maps the group entries
+type GroupMapping struct {
+	Mapping
+	// Member is the attribute listing the members
+	Member string `json:"member"`
+	// MemberRef is the user attribute the member values hold,
+	// empty when they are distinguished names, e.g. uid for memberUid
+	MemberRef string `json:"memberRef"`
+}
+
+// Config describes the directory and how its entries map to users and user groups
+type Config struct {
+	// Name is the source of the synced users and user groups, defaults to ldap
+	Name   string       `json:"name"`
+	Users  UserMapping  `json:"users"`
+	Groups GroupMapping `json:"groups"`
+}
+
+// OpenLDAP returns the Config of an OpenLDAP directory with inetOrgPerson
+// users and groupOfNames groups below base
+func OpenLDAP(base string) Config {
+	return Config{
+		Name: "ldap",
+		Users: UserMapping{
+			Mapping: Mapping{BaseDN: base, Filter: "(objectClass=inetOrgPerson)", ID: "entryUUID", Name: "uid"},
+			Email:   "mail",
+			Phone:   "telephoneNumber",
+		},
+		Groups: GroupMapping{
+			Mapping: Mapping{BaseDN: base, Filter: "(objectClass=groupOfNames)", ID: "entryUUID", Name: "cn"},
+			Member:  "member",
+		},
+	}
+}
+
+// ActiveDirectory returns the Config of an Active Directory domain below base
+func ActiveDirectory(base string) Config {
+	return Config{
+		Name: "ad",
+		Users: UserMapping{
+			Mapping:  Mapping{BaseDN: base, Filter: "(&(objectCategory=person)(objectClass=user))", ID: "objectGUID", Name: "sAMAccountName"},
+			Email:    "mail",
+			Phone:    "telephoneNumber",
+			Disabled: "userAccountControl",
+		},
+		Groups: GroupMapping{
+			Mapping: Mapping{BaseDN: base, Filter: "(objectClass=group)", ID: "objectGUID", Name: "cn"},
+			Member:  "member",
+		},
+	}
+}
+
+// withDefaults fills the empty fields with those of OpenLDAP
+func (c Config) withDefaults() Config {
+	def := OpenLDAP("")
+	if c.Name == "" {
+		c.Name = def.Name
+	}
+	for _, m := range []struct{ c, def *Mapping }{{&c.Users.Mapping, &def.Users.Mapping}, {&c.Groups.Mapping, &def.Groups.Mapping}} {
+		if m.c.Filter == "" {
+			m.c.Filter = m.def.Filter
+		}
+		if m.c.ID == "" {
+			m.c.ID = m.def.ID
+		}
+		if m.c.Name == "" {
+			m.c.Name = m.def.Name
+		}
+	}
+	if c.Users.Email == "" {
+		c.Users.Email = def.Users.Email
+	}
+	if c.Users.Phone == "" {
+		c.Users.Phone = def.Users.Phone
+	}
+	if c.Groups.Member == "" {
+		c.Groups.Member = def.Groups.Member
+	}
+	return c
+}
+
+// attributes returns the attributes to read for the mapping
+func (m Mapping) attributes(extra ...string) []string {
+	attrs := append([]string{m.ID, m.Name}, extra...)
+	for _, attr := range m.MetaData {
+		attrs = append(attrs, attr)
+	}
+	return attrs
+}
+
+// userAccountControl flag of disabled accounts
+const accountDisable = 0x2
+
+// disabled reports whether the user entry is disabled
+func (m UserMapping) disabled(e Entry) bool {
+	if m.Disabled == "" {
+		return false
+	}
+	value := e.Get(m.Disabled)
+	if strings.EqualFold(m.Disabled, "userAccountControl") {
+		flags, err := strconv.ParseInt(value, 10, 64)
+		return err == nil && flags&accountDisable != 0
+	}
+	return strings.EqualFold(value, "TRUE") || value == "1"
+}
+
+// metaData returns the mapped attributes of the entry
+func (m Mapping) metaData(e Entry) map[string]any {
+	metaData := map[string]any{}
+	for key, attr := range m.MetaData {
+		switch values := e.Values(attr); len(values) {
+		case 0:
+		case 1:
+			metaData[key] = values[0]
+		default:
+			list := make([]any, len(values))
+			for i, v := range values {
+				list[i] = v
+			}
+			metaData[key] = list
+		}
+	}
+	return metaData
+}
+
+// normalizeDN returns the DN in the form used to compare DNs
+func normalizeDN(dn string) string {
+	parts := strings.Split(dn, ",")
+	for i, p := range parts {
+		parts[i] = strings.ToLower(strings.TrimSpace(p))
+	}
+	return strings.Join(parts, ",")
+}
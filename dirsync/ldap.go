@@ -0,0 +1,152 @@
+package dirsync
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/hex"
+	"strings"
+	"time"
+
+	"github.com/go-ldap/ldap/v3"
+	"github.com/sr-codefreak/user-group/myerrors"
+)
+
+// LDAPConfig is the connection to an LDAP server
+type LDAPConfig struct {
+	// URL is the ldap:// or ldaps:// URL of the server
+	URL          string `json:"url"`
+	BindDN       string `json:"bindDN"`
+	BindPassword string `json:"bindPassword"`
+	// StartTLS upgrades ldap:// connections to TLS
+	StartTLS           bool `json:"startTLS"`
+	InsecureSkipVerify bool `json:"insecureSkipVerify"`
+	// PageSize is the number of entries read per request, defaults to 500
+	PageSize uint32 `json:"pageSize"`
+}
+
+// ldapTimeout bounds every request to the server
+const ldapTimeout = 30 * time.Second
+
+// binaryAttributes are returned hex encoded
+var binaryAttributes = []string{"objectGUID", "objectSid"}
+
+// LDAP is the Directory of an LDAP server, every Search runs on a new connection
+type LDAP struct {
+	cfg LDAPConfig
+}
+
+// NewLDAP returns the directory of the server
+func NewLDAP(cfg LDAPConfig) *LDAP {
+	if cfg.PageSize == 0 {
+		cfg.PageSize = 500
+	}
+	return &LDAP{cfg: cfg}
+}
+
+func (d *LDAP) dial() (*ldap.Conn, error) {
+	tlsConfig := &tls.Config{InsecureSkipVerify: d.cfg.InsecureSkipVerify}
+	conn, err := ldap.DialURL(d.cfg.URL, ldap.DialWithTLSConfig(tlsConfig))
+	if err != nil {
+		return nil, myerrors.New(myerrors.Unavailable, myerrors.KindDirectory, d.cfg.URL, err)
+	}
+	conn.SetTimeout(ldapTimeout)
+	if d.cfg.StartTLS {
+		if err := conn.StartTLS(tlsConfig); err != nil {
+			conn.Close()
+			return nil, myerrors.New(myerrors.Unavailable, myerrors.KindDirectory, d.cfg.URL, err)
+		}
+	}
+	if d.cfg.BindDN != "" {
+		if err := conn.Bind(d.cfg.BindDN, d.cfg.BindPassword); err != nil {
+			conn.Close()
+			return nil, myerrors.Wrap(myerrors.ErrConnectingDirectory, myerrors.KindDirectory, d.cfg.URL, err)
+		}
+	}
+	return conn, nil
+}
+
+// Check connects and binds to the server
+func (d *LDAP) Check(ctx context.Context) error {
+	conn, err := d.dial()
+	if err != nil {
+		return err
+	}
+	conn.Close()
+	return nil
+}
+
+// Search reads the entries page by page. ModifiedSince is added to the
+// filter as a condition on modifyTimestamp.
+func (d *LDAP) Search(ctx context.Context, req SearchRequest) ([]Entry, error) {
+	conn, err := d.dial()
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+	// closing the connection aborts the search when ctx is done
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-ctx.Done():
+			conn.Close()
+		case <-done:
+		}
+	}()
+	filter := req.Filter
+	if filter == "" {
+		filter = "(objectClass=*)"
+	}
+	if !req.ModifiedSince.IsZero() {
+		filter = "(&" + filter + "(modifyTimestamp>=" + req.ModifiedSince.UTC().Format(generalizedTime) + "))"
+	}
+	attrs := append([]string{"modifyTimestamp"}, req.Attributes...)
+	res, err := conn.SearchWithPaging(ldap.NewSearchRequest(
+		req.BaseDN, ldap.ScopeWholeSubtree, ldap.NeverDerefAliases, 0, 0, false,
+		filter, attrs, nil,
+	), d.cfg.PageSize)
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		return nil, err
+	}
+	entries := make([]Entry, 0, len(res.Entries))
+	for _, le := range res.Entries {
+		e := Entry{DN: le.DN, Attributes: map[string][]string{}}
+		for _, a := range le.Attributes {
+			values := a.Values
+			if isBinary(a.Name) {
+				values = make([]string, len(a.ByteValues))
+				for i, b := range a.ByteValues {
+					values[i] = hex.EncodeToString(b)
+				}
+			}
+			e.Attributes[a.Name] = values
+		}
+		e.Modified = parseGeneralizedTime(e.Get("modifyTimestamp"))
+		entries = append(entries, e)
+	}
+	return entries, nil
+}
+
+func isBinary(attr string) bool {
+	for _, b := range binaryAttributes {
+		if strings.EqualFold(attr, b) {
+			return true
+		}
+	}
+	return false
+}
+
+// generalizedTime is the layout of LDAP timestamps in UTC
+const generalizedTime = "20060102150405Z"
+
+// parseGeneralizedTime parses a UTC timestamp, Active Directory adds tenths of seconds
+func parseGeneralizedTime(s string) time.Time {
+	if i := strings.IndexByte(s, '.'); i >= 0 {
+		s = s[:i] + "Z"
+	}
+	t, _ := time.Parse(generalizedTime, s)
+	return t
+}
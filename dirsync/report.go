@@ -0,0 +1,129 @@
+package dirsync
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Op is the kind of a Change
+type Op string
+
+const (
+	CreateUser     Op = "createUser"
+	UpdateUser     Op = "updateUser"
+	DisableUser    Op = "disableUser"
+	CreateGroup    Op = "createGroup"
+	RenameGroup    Op = "renameGroup"
+	AddMember      Op = "addMember"
+	RemoveMember   Op = "removeMember"
+	AddSubgroup    Op = "addSubgroup"
+	RemoveSubgroup Op = "removeSubgroup"
+)
+
+// Change is a change made by a sync, or planned by a dry run
+type Change struct {
+	Op Op `json:"op"`
+	// ExternalID identifies the entry of the user or user group
+	ExternalID string `json:"externalId,omitempty"`
+	// ID is the id of the user or user group, empty when it is created
+	ID   string `json:"id,omitempty"`
+	Name string `json:"name,omitempty"`
+	// Member is the user or subgroup added or removed, its externalId when
+	// it has one and else its id
+	Member string `json:"member,omitempty"`
+	// Error is the failure of the change, the sync goes on with the next one
+	Error string `json:"error,omitempty"`
+
+	apply func(ctx context.Context, st *state) error
+}
+
+func (c Change) String() string {
+	s := string(c.Op) + " "
+	switch {
+	case c.Name != "":
+		s += c.Name
+	case c.ExternalID != "":
+		s += c.ExternalID
+	default:
+		s += c.ID
+	}
+	if c.Member != "" {
+		s += " " + c.Member
+	}
+	if c.Error != "" {
+		s += ": " + c.Error
+	}
+	return s
+}
+
+// Report is the outcome of a sync
+type Report struct {
+	Directory string    `json:"directory"`
+	Started   time.Time `json:"started"`
+	Finished  time.Time `json:"finished"`
+	// Since is the time the entries of an incremental sync were modified since
+	Since  time.Time `json:"since,omitempty"`
+	DryRun bool      `json:"dryRun,omitempty"`
+	// Changes are in the order they were made
+	Changes []Change `json:"changes"`
+	// Skipped are the entries that could not be mapped, with the reason
+	Skipped []string `json:"skipped,omitempty"`
+}
+
+// Incremental reports whether only the modified entries were synced
+func (r *Report) Incremental() bool {
+	return !r.Since.IsZero()
+}
+
+// Failed returns the number of changes that failed
+func (r *Report) Failed() int {
+	n := 0
+	for _, c := range r.Changes {
+		if c.Error != "" {
+			n++
+		}
+	}
+	return n
+}
+
+// Counts returns the number of changes per op
+func (r *Report) Counts() map[Op]int {
+	counts := map[Op]int{}
+	for _, c := range r.Changes {
+		counts[c.Op]++
+	}
+	return counts
+}
+
+// String returns a summary of the report followed by the changes, one per line
+func (r *Report) String() string {
+	var sb strings.Builder
+	kind := "full"
+	if r.Incremental() {
+		kind = "incremental since " + r.Since.Format(time.RFC3339)
+	}
+	if r.DryRun {
+		kind += " dry run"
+	}
+	fmt.Fprintf(&sb, "%s sync of %s at %s: %d changes, %d failed, %d skipped\n",
+		kind, r.Directory, r.Started.Format(time.RFC3339), len(r.Changes), r.Failed(), len(r.Skipped))
+	counts := r.Counts()
+	ops := make([]string, 0, len(counts))
+	for op := range counts {
+		ops = append(ops, string(op))
+	}
+	sort.Strings(ops)
+	for _, op := range ops {
+		fmt.Fprintf(&sb, "  %-15s %d\n", op, counts[Op(op)])
+	}
+	for _, c := range r.Changes {
+		fmt.Fprintf(&sb, "%s\n", c)
+	}
+	for _, s := range r.Skipped {
+		fmt.Fprintf(&sb, "skipped %s\n", s)
+	}
+	return sb.String()
+}
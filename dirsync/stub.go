@@ -0,0 +1,179 @@
+package dirsync
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Stub is a Directory serving entries from memory, for tests and for
+// trying out a Config. Its filters support equality, presence and
+// substrings joined with &, | and !.
+type Stub struct {
+	mu      sync.Mutex
+	entries map[string]Entry
+}
+
+// NewStub returns the stub directory holding the entries
+func NewStub(entries ...Entry) *Stub {
+	s := &Stub{entries: map[string]Entry{}}
+	for _, e := range entries {
+		s.Put(e)
+	}
+	return s
+}
+
+// Put adds or replaces the entry, its Modified time is set to now when zero
+func (s *Stub) Put(e Entry) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if e.Modified.IsZero() {
+		e.Modified = time.Now()
+	}
+	attrs := make(map[string][]string, len(e.Attributes))
+	for k, v := range e.Attributes {
+		attrs[k] = append([]string(nil), v...)
+	}
+	e.Attributes = attrs
+	s.entries[normalizeDN(e.DN)] = e
+}
+
+// Delete removes the entry
+func (s *Stub) Delete(dn string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.entries, normalizeDN(dn))
+}
+
+// Search returns the entries below the base DN matching the filter in the
+// order of their DNs, with all their attributes
+func (s *Stub) Search(ctx context.Context, req SearchRequest) ([]Entry, error) {
+	f, err := parseLDAPFilter(req.Filter)
+	if err != nil {
+		return nil, err
+	}
+	base := normalizeDN(req.BaseDN)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var entries []Entry
+	for dn, e := range s.entries {
+		if base != "" && dn != base && !strings.HasSuffix(dn, ","+base) {
+			continue
+		}
+		if e.Modified.Before(req.ModifiedSince) || !f(e) {
+			continue
+		}
+		entries = append(entries, e)
+	}
+	sort.Slice(entries, func(i, j int) bool { return normalizeDN(entries[i].DN) < normalizeDN(entries[j].DN) })
+	return entries, nil
+}
+
+// ldapFilter reports whether an entry matches, see RFC 4515
+type ldapFilter func(e Entry) bool
+
+func parseLDAPFilter(s string) (ldapFilter, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return func(Entry) bool { return true }, nil
+	}
+	f, rest, err := parseLDAPItem(s)
+	if err != nil {
+		return nil, fmt.Errorf("invalid filter %q: %w", s, err)
+	}
+	if rest != "" {
+		return nil, fmt.Errorf("invalid filter %q: unexpected %q", s, rest)
+	}
+	return f, nil
+}
+
+// parseLDAPItem parses the parenthesized filter at the start of s and returns the rest
+func parseLDAPItem(s string) (ldapFilter, string, error) {
+	if !strings.HasPrefix(s, "(") {
+		return nil, "", fmt.Errorf("expected ( at %q", s)
+	}
+	s = s[1:]
+	if s == "" {
+		return nil, "", fmt.Errorf("unterminated filter")
+	}
+	switch s[0] {
+	case '&', '|':
+		op := s[0]
+		var fs []ldapFilter
+		s = s[1:]
+		for strings.HasPrefix(s, "(") {
+			f, rest, err := parseLDAPItem(s)
+			if err != nil {
+				return nil, "", err
+			}
+			fs, s = append(fs, f), rest
+		}
+		if !strings.HasPrefix(s, ")") {
+			return nil, "", fmt.Errorf("expected ) at %q", s)
+		}
+		return func(e Entry) bool {
+			for _, f := range fs {
+				if f(e) != (op == '&') {
+					return op != '&'
+				}
+			}
+			return op == '&'
+		}, s[1:], nil
+	case '!':
+		f, rest, err := parseLDAPItem(s[1:])
+		if err != nil {
+			return nil, "", err
+		}
+		if !strings.HasPrefix(rest, ")") {
+			return nil, "", fmt.Errorf("expected ) at %q", rest)
+		}
+		return func(e Entry) bool { return !f(e) }, rest[1:], nil
+	}
+	end := strings.Index(s, ")")
+	if end < 0 {
+		return nil, "", fmt.Errorf("unterminated filter")
+	}
+	attr, value, ok := strings.Cut(s[:end], "=")
+	if !ok || attr == "" {
+		return nil, "", fmt.Errorf("expected attr=value at %q", s[:end])
+	}
+	return matchValue(attr, value), s[end+1:], nil
+}
+
+// matchValue matches the entries with a value of the attribute equal to
+// value, case insensitively, where * stands for any substring
+func matchValue(attr, value string) ldapFilter {
+	parts := strings.Split(strings.ToLower(value), "*")
+	return func(e Entry) bool {
+		if strings.EqualFold(attr, "objectClass") && value == "*" {
+			return true
+		}
+		for _, v := range e.Values(attr) {
+			if matchParts(strings.ToLower(v), parts) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+func matchParts(v string, parts []string) bool {
+	if len(parts) == 1 {
+		return v == parts[0]
+	}
+	if !strings.HasPrefix(v, parts[0]) {
+		return false
+	}
+	v = v[len(parts[0]):]
+	for _, p := range parts[1 : len(parts)-1] {
+		i := strings.Index(v, p)
+		if i < 0 {
+			return false
+		}
+		v = v[i+len(p):]
+	}
+	return strings.HasSuffix(v, parts[len(parts)-1])
+}
@@ -0,0 +1,524 @@
+package dirsync
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/sr-codefreak/user-group/db"
+	"github.com/sr-codefreak/user-group/db/mongodb"
+	"github.com/sr-codefreak/user-group/db/mongodb/audit"
+	"github.com/sr-codefreak/user-group/db/mongodb/user"
+	"github.com/sr-codefreak/user-group/db/mongodb/usergroup"
+	"github.com/sr-codefreak/user-group/myerrors"
+	"github.com/sr-codefreak/user-group/utils/logger"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+var log = logger.GetLogger()
+
+// The metaData keys of the synced users and user groups
+const (
+	SourceKey     = "source"
+	ExternalIDKey = "externalId"
+	DNKey         = "dn"
+)
+
+// clockSkew is subtracted from the start of a sync to get the Since of the
+// next incremental one, so clocks of the directory running behind do not
+// hide modifications
+const clockSkew = time.Minute
+
+// Syncer syncs the entries of a Directory into a backend
+type Syncer struct {
+	backend db.Backend
+	dir     Directory
+	cfg     Config
+}
+
+// New returns the Syncer of the directory, the empty fields of cfg default to OpenLDAP
+func New(backend db.Backend, dir Directory, cfg Config) *Syncer {
+	return &Syncer{backend: backend, dir: dir, cfg: cfg.withDefaults()}
+}
+
+// Options select the kind of a sync
+type Options struct {
+	// Since makes the sync incremental, only the entries modified since are read
+	Since time.Time
+	// DryRun plans the changes without making them
+	DryRun bool
+}
+
+// state holds the users and user groups of the source by externalId.
+// Planned creations are added to it, their ID is set once they are made.
+type state struct {
+	backend db.Backend
+	users   map[string]*user.User
+	groups  map[string]*usergroup.UserGroup
+	// userByDN and groupByDN map the normalized DNs to externalIds
+	userByDN  map[string]string
+	groupByDN map[string]string
+	// userByRef maps the GroupMapping.MemberRef values to externalIds
+	userByRef map[string]string
+	// userByID and groupByID map the ids to externalIds
+	userByID  map[string]string
+	groupByID map[string]string
+	// children maps the externalIds of the groups to those of their subgroups
+	children map[string][]string
+}
+
+// Sync reads the directory and makes the changes bringing the users and user
+// groups of the source in line with it. It fails when the directory or the
+// backend cannot be read, the failures of single changes are in the Report.
+func (s *Syncer) Sync(ctx context.Context, opts Options) (*Report, error) {
+	ctx = audit.WithActor(ctx, "dirsync:"+s.cfg.Name)
+	report := &Report{Directory: s.cfg.Name, Started: time.Now(), Since: opts.Since, DryRun: opts.DryRun}
+	st, err := s.load(ctx)
+	if err != nil {
+		return nil, err
+	}
+	users := s.cfg.Users
+	extra := []string{users.Email, users.Phone}
+	if users.Disabled != "" {
+		extra = append(extra, users.Disabled)
+	}
+	if s.cfg.Groups.MemberRef != "" {
+		extra = append(extra, s.cfg.Groups.MemberRef)
+	}
+	userEntries, err := s.search(ctx, users.Mapping, opts.Since, extra...)
+	if err != nil {
+		return nil, err
+	}
+	groupEntries, err := s.search(ctx, s.cfg.Groups.Mapping, opts.Since, s.cfg.Groups.Member)
+	if err != nil {
+		return nil, err
+	}
+	p := &planner{cfg: s.cfg, st: st, report: report, full: opts.Since.IsZero()}
+	p.planUsers(userEntries)
+	p.planGroups(groupEntries)
+	if !opts.DryRun {
+		for i := range report.Changes {
+			if err := report.Changes[i].apply(ctx, st); err != nil {
+				report.Changes[i].Error = err.Error()
+			}
+		}
+	}
+	report.Finished = time.Now()
+	return report, nil
+}
+
+func (s *Syncer) search(ctx context.Context, m Mapping, since time.Time, extra ...string) ([]Entry, error) {
+	entries, err := s.dir.Search(ctx, SearchRequest{
+		BaseDN:        m.BaseDN,
+		Filter:        m.Filter,
+		Attributes:    m.attributes(extra...),
+		ModifiedSince: since,
+	})
+	if err != nil {
+		return nil, myerrors.Wrap(myerrors.ErrSearchingDirectory, myerrors.KindDirectory, s.cfg.Name, err)
+	}
+	return entries, nil
+}
+
+// load reads the users and user groups of the source
+func (s *Syncer) load(ctx context.Context) (*state, error) {
+	st := &state{
+		backend:   s.backend,
+		users:     map[string]*user.User{},
+		groups:    map[string]*usergroup.UserGroup{},
+		userByDN:  map[string]string{},
+		groupByDN: map[string]string{},
+		userByRef: map[string]string{},
+		userByID:  map[string]string{},
+		groupByID: map[string]string{},
+		children:  map[string][]string{},
+	}
+	opts := mongodb.ListOptions{
+		PageSize: mongodb.MaxPageSize,
+		Filter:   mongodb.ListFilter{MetaData: map[string]any{SourceKey: s.cfg.Name}},
+	}
+	for {
+		page, next, err := s.backend.Users().List(ctx, opts)
+		if err != nil {
+			return nil, err
+		}
+		for i := range page {
+			u := &page[i]
+			ext, _ := u.MetaData[ExternalIDKey].(string)
+			if ext == "" {
+				continue
+			}
+			st.users[ext] = u
+			st.userByID[u.ID.Hex()] = ext
+			if dn, ok := u.MetaData[DNKey].(string); ok {
+				st.userByDN[normalizeDN(dn)] = ext
+			}
+			if ref := s.memberRef(u); ref != "" {
+				st.userByRef[ref] = ext
+			}
+		}
+		if next == "" {
+			break
+		}
+		opts.PageToken = next
+	}
+	opts.PageToken = ""
+	var groups []*usergroup.UserGroup
+	for {
+		page, next, err := s.backend.UserGroups().List(ctx, opts)
+		if err != nil {
+			return nil, err
+		}
+		for i := range page {
+			ug := &page[i]
+			ext, _ := ug.MetaData[ExternalIDKey].(string)
+			if ext == "" {
+				continue
+			}
+			st.groups[ext] = ug
+			st.groupByID[ug.ID.Hex()] = ext
+			if dn, ok := ug.MetaData[DNKey].(string); ok {
+				st.groupByDN[normalizeDN(dn)] = ext
+			}
+			groups = append(groups, ug)
+		}
+		if next == "" {
+			break
+		}
+		opts.PageToken = next
+	}
+	for _, ug := range groups {
+		for _, parentId := range ug.ParentIds {
+			if parent, ok := st.groupByID[parentId.Hex()]; ok {
+				st.children[parent] = append(st.children[parent], st.groupByID[ug.ID.Hex()])
+			}
+		}
+	}
+	return st, nil
+}
+
+// memberRef returns the GroupMapping.MemberRef value of the stored user,
+// which is its name or one of its metaData
+func (s *Syncer) memberRef(u *user.User) string {
+	ref := s.cfg.Groups.MemberRef
+	if ref == "" {
+		return ""
+	}
+	if strings.EqualFold(ref, s.cfg.Users.Name) {
+		return u.Name
+	}
+	for key, attr := range s.cfg.Users.MetaData {
+		if strings.EqualFold(ref, attr) {
+			v, _ := u.MetaData[key].(string)
+			return v
+		}
+	}
+	return ""
+}
+
+type planner struct {
+	cfg    Config
+	st     *state
+	report *Report
+	full   bool
+}
+
+func (p *planner) add(c Change) {
+	p.report.Changes = append(p.report.Changes, c)
+}
+
+func (p *planner) skip(dn string, format string, args ...any) {
+	p.report.Skipped = append(p.report.Skipped, dn+": "+fmt.Sprintf(format, args...))
+}
+
+// metaData returns the metaData of the synced entry
+func (p *planner) metaData(m Mapping, e Entry, ext string) map[string]any {
+	metaData := m.metaData(e)
+	metaData[SourceKey] = p.cfg.Name
+	metaData[ExternalIDKey] = ext
+	metaData[DNKey] = e.DN
+	return metaData
+}
+
+func (p *planner) planUsers(entries []Entry) {
+	m := p.cfg.Users
+	seen := map[string]bool{}
+	for _, e := range entries {
+		ext, name := e.Get(m.ID), e.Get(m.Name)
+		if ext == "" || name == "" {
+			p.skip(e.DN, "missing %s or %s", m.ID, m.Name)
+			continue
+		}
+		if seen[ext] {
+			p.skip(e.DN, "duplicate %s %s", m.ID, ext)
+			continue
+		}
+		seen[ext] = true
+		p.st.userByDN[normalizeDN(e.DN)] = ext
+		if ref := p.cfg.Groups.MemberRef; ref != "" {
+			p.st.userByRef[e.Get(ref)] = ext
+		}
+		want := &user.User{
+			Name:     name,
+			Email:    e.Get(m.Email),
+			Phone:    e.Get(m.Phone),
+			MetaData: p.metaData(m.Mapping, e, ext),
+		}
+		want.MetaData[user.ActiveKey] = !m.disabled(e)
+		cur, ok := p.st.users[ext]
+		if !ok {
+			p.st.users[ext] = want
+			p.add(Change{Op: CreateUser, ExternalID: ext, Name: name, apply: func(ctx context.Context, st *state) error {
+				return st.backend.Users().Create(ctx, want)
+			}})
+			continue
+		}
+		if !userChanged(cur, want) {
+			continue
+		}
+		want.ID = cur.ID
+		p.add(Change{Op: UpdateUser, ExternalID: ext, ID: cur.ID.Hex(), Name: name, apply: func(ctx context.Context, st *state) error {
+			return st.backend.Users().Update(ctx, want)
+		}})
+	}
+	if !p.full {
+		return
+	}
+	for _, ext := range sortedKeys(p.st.users) {
+		cur := p.st.users[ext]
+		if seen[ext] || !cur.Active() {
+			continue
+		}
+		disabled := &user.User{ID: cur.ID, MetaData: map[string]any{}}
+		for k, v := range cur.MetaData {
+			disabled.MetaData[k] = v
+		}
+		disabled.MetaData[user.ActiveKey] = false
+		p.add(Change{Op: DisableUser, ExternalID: ext, ID: cur.ID.Hex(), Name: cur.Name, apply: func(ctx context.Context, st *state) error {
+			return st.backend.Users().Update(ctx, disabled)
+		}})
+	}
+}
+
+// userChanged reports whether updating cur to want changes it,
+// the user store keeps the email and phone when they are empty
+func userChanged(cur *user.User, want *user.User) bool {
+	return cur.Name != want.Name ||
+		(want.Email != "" && cur.Email != want.Email) ||
+		(want.Phone != "" && cur.Phone != want.Phone) ||
+		!sameJSON(cur.MetaData, want.MetaData)
+}
+
+func (p *planner) planGroups(entries []Entry) {
+	m := p.cfg.Groups
+	var valid []Entry
+	seen := map[string]bool{}
+	for _, e := range entries {
+		ext, name := e.Get(m.ID), e.Get(m.Name)
+		if ext == "" || name == "" {
+			p.skip(e.DN, "missing %s or %s", m.ID, m.Name)
+			continue
+		}
+		if seen[ext] {
+			p.skip(e.DN, "duplicate %s %s", m.ID, ext)
+			continue
+		}
+		seen[ext] = true
+		p.st.groupByDN[normalizeDN(e.DN)] = ext
+		valid = append(valid, e)
+	}
+	// the groups are created first, so they can be subgroups of each other
+	for _, e := range valid {
+		ext, name := e.Get(m.ID), e.Get(m.Name)
+		cur, ok := p.st.groups[ext]
+		if !ok {
+			want := &usergroup.UserGroup{Name: name, MetaData: p.metaData(m.Mapping, e, ext)}
+			p.st.groups[ext] = want
+			p.add(Change{Op: CreateGroup, ExternalID: ext, Name: name, apply: func(ctx context.Context, st *state) error {
+				return st.backend.UserGroups().Create(ctx, want)
+			}})
+			continue
+		}
+		if cur.Name != name {
+			id := cur.ID
+			p.add(Change{Op: RenameGroup, ExternalID: ext, ID: id.Hex(), Name: name, apply: func(ctx context.Context, st *state) error {
+				return st.backend.UserGroups().UpdateName(ctx, id, name)
+			}})
+		}
+	}
+	for _, e := range valid {
+		ext := e.Get(m.ID)
+		if p.st.groups[ext].Rule != "" {
+			p.skip(e.DN, "members of the dynamic group follow its rule")
+			continue
+		}
+		users, subgroups := p.members(e, ext)
+		p.reconcile(ext, users, subgroups)
+	}
+	if !p.full {
+		return
+	}
+	for _, ext := range sortedKeys(p.st.groups) {
+		if !seen[ext] && p.st.groups[ext].Rule == "" {
+			p.reconcile(ext, nil, nil)
+		}
+	}
+}
+
+// members returns the externalIds of the users and groups in the member
+// attribute of the group entry, members outside of the synced entries are left out
+func (p *planner) members(e Entry, ext string) ([]string, []string) {
+	var users, subgroups []string
+	for _, v := range e.Values(p.cfg.Groups.Member) {
+		if p.cfg.Groups.MemberRef != "" {
+			if u, ok := p.st.userByRef[v]; ok {
+				users = append(users, u)
+			}
+			continue
+		}
+		dn := normalizeDN(v)
+		if u, ok := p.st.userByDN[dn]; ok {
+			users = append(users, u)
+		} else if g, ok := p.st.groupByDN[dn]; ok && g != ext {
+			subgroups = append(subgroups, g)
+		}
+	}
+	return users, subgroups
+}
+
+// reconcile plans the changes making users and subgroups the members of the group
+func (p *planner) reconcile(ext string, users []string, subgroups []string) {
+	ug := p.st.groups[ext]
+	wantUsers := toSet(users)
+	for _, id := range ug.UserIds {
+		member, ok := p.st.userByID[id]
+		if ok && wantUsers[member] {
+			delete(wantUsers, member)
+			continue
+		}
+		if !ok {
+			member = id
+		}
+		userId := id
+		p.add(Change{Op: RemoveMember, ExternalID: ext, ID: ug.ID.Hex(), Name: ug.Name, Member: member, apply: func(ctx context.Context, st *state) error {
+			oid, err := primitive.ObjectIDFromHex(userId)
+			if err != nil {
+				return err
+			}
+			return st.backend.UserGroups().RemoveUser(ctx, st.groups[ext].ID, oid)
+		}})
+	}
+	for _, member := range users {
+		if !wantUsers[member] {
+			continue
+		}
+		delete(wantUsers, member)
+		member := member
+		p.add(Change{Op: AddMember, ExternalID: ext, ID: idHex(ug.ID), Name: ug.Name, Member: member, apply: func(ctx context.Context, st *state) error {
+			gid, uid := st.groups[ext].ID, st.users[member].ID
+			if gid.IsZero() || uid.IsZero() {
+				return errNotCreated
+			}
+			return st.backend.UserGroups().AddUser(ctx, gid, uid)
+		}})
+	}
+	wantGroups := toSet(subgroups)
+	for _, child := range p.st.children[ext] {
+		if wantGroups[child] {
+			delete(wantGroups, child)
+			continue
+		}
+		child := child
+		p.add(Change{Op: RemoveSubgroup, ExternalID: ext, ID: ug.ID.Hex(), Name: ug.Name, Member: child, apply: func(ctx context.Context, st *state) error {
+			return st.backend.UserGroups().RemoveChild(ctx, st.groups[ext].ID, st.groups[child].ID)
+		}})
+	}
+	for _, child := range subgroups {
+		if !wantGroups[child] {
+			continue
+		}
+		delete(wantGroups, child)
+		child := child
+		p.add(Change{Op: AddSubgroup, ExternalID: ext, ID: idHex(ug.ID), Name: ug.Name, Member: child, apply: func(ctx context.Context, st *state) error {
+			gid, cid := st.groups[ext].ID, st.groups[child].ID
+			if gid.IsZero() || cid.IsZero() {
+				return errNotCreated
+			}
+			return st.backend.UserGroups().AddChild(ctx, gid, cid)
+		}})
+	}
+}
+
+// errNotCreated fails the changes of users and user groups whose creation failed
+var errNotCreated = errors.New("user or user group was not created")
+
+func idHex(id primitive.ObjectID) string {
+	if id.IsZero() {
+		return ""
+	}
+	return id.Hex()
+}
+
+func toSet(values []string) map[string]bool {
+	set := make(map[string]bool, len(values))
+	for _, v := range values {
+		set[v] = true
+	}
+	return set
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// sameJSON compares values by their JSON encoding, so values read back
+// from the store equal those mapped from the directory
+func sameJSON(a any, b any) bool {
+	ja, errA := json.Marshal(a)
+	jb, errB := json.Marshal(b)
+	return errA == nil && errB == nil && bytes.Equal(ja, jb)
+}
+
+// Run syncs the directory every interval until ctx is done and returns its
+// error. The first sync is a full one, and so is the first sync after every
+// fullInterval; those in between are incremental. The reports are logged.
+func (s *Syncer) Run(ctx context.Context, interval time.Duration, fullInterval time.Duration) error {
+	var since, lastFull time.Time
+	for {
+		opts := Options{}
+		if !lastFull.IsZero() && time.Since(lastFull) < fullInterval {
+			opts.Since = since
+		}
+		report, err := s.Sync(ctx, opts)
+		if err != nil {
+			log.Errorf("dirsync: %s: %s", s.cfg.Name, err)
+		} else {
+			summary, _, _ := strings.Cut(report.String(), "\n")
+			log.Infof("dirsync: %s", summary)
+			for _, c := range report.Changes {
+				if c.Error != "" {
+					log.Warnf("dirsync: %s", c)
+				}
+			}
+			since = report.Started.Add(-clockSkew)
+			if !report.Incremental() {
+				lastFull = report.Started
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(interval):
+		}
+	}
+}
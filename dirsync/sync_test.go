@@ -0,0 +1,167 @@
+package dirsync_test
+
+import (
+	"context"
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/sr-codefreak/user-group/db"
+	"github.com/sr-codefreak/user-group/db/memory"
+	"github.com/sr-codefreak/user-group/db/mongodb"
+	"github.com/sr-codefreak/user-group/db/mongodb/user"
+	"github.com/sr-codefreak/user-group/db/mongodb/usergroup"
+	"github.com/sr-codefreak/user-group/dirsync"
+)
+
+const base = "dc=example,dc=com"
+
+func person(uid, uuid, mail string, modified time.Time) dirsync.Entry {
+	return dirsync.Entry{
+		DN: "uid=" + uid + ",ou=people," + base,
+		Attributes: map[string][]string{
+			"objectClass": {"inetOrgPerson"},
+			"entryUUID":   {uuid},
+			"uid":         {uid},
+			"mail":        {mail},
+		},
+		Modified: modified,
+	}
+}
+
+func group(cn, uuid string, members []string, modified time.Time) dirsync.Entry {
+	return dirsync.Entry{
+		DN: "cn=" + cn + ",ou=groups," + base,
+		Attributes: map[string][]string{
+			"objectClass": {"groupOfNames"},
+			"entryUUID":   {uuid},
+			"cn":          {cn},
+			"member":      members,
+		},
+		Modified: modified,
+	}
+}
+
+// sync runs a sync and fails the test on errors and failed changes
+func sync(t *testing.T, s *dirsync.Syncer, opts dirsync.Options) *dirsync.Report {
+	t.Helper()
+	report, err := s.Sync(context.Background(), opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.Failed() > 0 {
+		t.Fatalf("failed changes:\n%s", report)
+	}
+	return report
+}
+
+func wantCounts(t *testing.T, report *dirsync.Report, want map[dirsync.Op]int) {
+	t.Helper()
+	if got := report.Counts(); !reflect.DeepEqual(got, want) {
+		t.Errorf("changes %v, want %v\n%s", got, want, report)
+	}
+}
+
+func usersByName(t *testing.T, b db.Backend) map[string]user.User {
+	t.Helper()
+	users, _, err := b.Users().List(context.Background(), mongodb.ListOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	byName := map[string]user.User{}
+	for _, u := range users {
+		byName[u.Name] = u
+	}
+	return byName
+}
+
+func groupsByName(t *testing.T, b db.Backend) map[string]usergroup.UserGroup {
+	t.Helper()
+	groups, _, err := b.UserGroups().List(context.Background(), mongodb.ListOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	byName := map[string]usergroup.UserGroup{}
+	for _, g := range groups {
+		byName[g.Name] = g
+	}
+	return byName
+}
+
+// resolved returns the sorted names of the transitive members of the group
+func resolved(t *testing.T, b db.Backend, g usergroup.UserGroup) []string {
+	t.Helper()
+	members, err := b.UserGroups().ResolveMembers(context.Background(), g.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	names := []string{}
+	for _, m := range members {
+		names = append(names, m.Name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func TestSync(t *testing.T) {
+	before := time.Now().Add(-time.Hour)
+	ann := person("ann", "u-ann", "ann@example.com", before)
+	bob := person("bob", "u-bob", "bob@example.com", before)
+	dir := dirsync.NewStub(
+		ann, bob,
+		group("team", "g-team", []string{bob.DN}, before),
+		group("eng", "g-eng", []string{ann.DN, "cn=team,ou=groups," + base}, before),
+	)
+	b := memory.New()
+	s := dirsync.New(b, dir, dirsync.OpenLDAP(base))
+
+	// a dry run plans the changes of the full sync without making them
+	report := sync(t, s, dirsync.Options{DryRun: true})
+	full := map[dirsync.Op]int{dirsync.CreateUser: 2, dirsync.CreateGroup: 2, dirsync.AddMember: 2, dirsync.AddSubgroup: 1}
+	wantCounts(t, report, full)
+	if users := usersByName(t, b); len(users) != 0 {
+		t.Errorf("users after a dry run = %v", users)
+	}
+
+	wantCounts(t, sync(t, s, dirsync.Options{}), full)
+	users, groups := usersByName(t, b), groupsByName(t, b)
+	if u := users["ann"]; u.Email != "ann@example.com" || u.MetaData[dirsync.ExternalIDKey] != "u-ann" || u.MetaData[user.ActiveKey] != true {
+		t.Errorf("ann = %+v", u)
+	}
+	if got := resolved(t, b, groups["eng"]); !reflect.DeepEqual(got, []string{"ann", "bob"}) {
+		t.Errorf("members of eng = %v", got)
+	}
+	if team := groups["team"]; len(team.ParentIds) != 1 || team.ParentIds[0] != groups["eng"].ID {
+		t.Errorf("parents of team = %v", team.ParentIds)
+	}
+	// the directory did not change
+	wantCounts(t, sync(t, s, dirsync.Options{}), map[dirsync.Op]int{})
+
+	// an incremental sync reads the modified entries and cannot see deletions
+	since := time.Now()
+	ann.Attributes["mail"] = []string{"ann@example.org"}
+	ann.Modified = since.Add(time.Second)
+	dir.Put(ann)
+	dir.Delete(bob.DN)
+	wantCounts(t, sync(t, s, dirsync.Options{Since: since}), map[dirsync.Op]int{dirsync.UpdateUser: 1})
+	users = usersByName(t, b)
+	if users["ann"].Email != "ann@example.org" {
+		t.Errorf("email of ann = %q", users["ann"].Email)
+	}
+	if users["bob"].MetaData[user.ActiveKey] != true {
+		t.Errorf("bob disabled by an incremental sync")
+	}
+
+	// the full sync disables the user whose entry is gone, and removes it
+	// from the group the directory removed it from
+	dir.Put(group("team", "g-team", nil, time.Now()))
+	wantCounts(t, sync(t, s, dirsync.Options{}), map[dirsync.Op]int{dirsync.DisableUser: 1, dirsync.RemoveMember: 1})
+	users, groups = usersByName(t, b), groupsByName(t, b)
+	if u, ok := users["bob"]; !ok || u.MetaData[user.ActiveKey] != false {
+		t.Errorf("bob after the full sync = %+v, %v", u, ok)
+	}
+	if got := resolved(t, b, groups["eng"]); !reflect.DeepEqual(got, []string{"ann"}) {
+		t.Errorf("members of eng = %v", got)
+	}
+}
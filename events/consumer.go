@@ -0,0 +1,81 @@
+package events
+
+import (
+	"context"
+
+	"github.com/sr-codefreak/user-group/db/mongodb/event"
+)
+
+// Handler handles an event, an error stops the Consumer before the event is checkpointed
+type Handler func(ctx context.Context, e event.Event) error
+
+// Source opens streams of the events of an outbox
+type Source interface {
+	// Open returns the stream of the events after the position token,
+	// an empty token starts at the default position of the source
+	Open(ctx context.Context, token string) (Stream, error)
+}
+
+// Stream returns the events in the order they were appended
+type Stream interface {
+	// Next blocks until the next event and returns it with the token
+	// of the position after it
+	Next(ctx context.Context) (event.Event, string, error)
+	Close(ctx context.Context) error
+}
+
+// Consumer delivers the events of a source to the handlers registered for their type
+type Consumer struct {
+	source   Source
+	handlers map[event.Type][]Handler
+	all      []Handler
+}
+
+// NewConsumer returns a consumer of the events of source without handlers
+func NewConsumer(source Source) *Consumer {
+	return &Consumer{source: source, handlers: map[event.Type][]Handler{}}
+}
+
+// Handle registers h for the events of type t. Register the handlers before calling Run.
+func (c *Consumer) Handle(t event.Type, h Handler) {
+	c.handlers[t] = append(c.handlers[t], h)
+}
+
+// HandleAll registers h for the events of every type
+func (c *Consumer) HandleAll(h Handler) {
+	c.all = append(c.all, h)
+}
+
+// Run delivers the events after the position token until ctx is done, returning
+// ctx.Err(), or a handler fails. The handlers of an event run in the order they were
+// registered, then checkpoint, when not nil, is called with the token to
+// resume after the event. Events are delivered at least once: a handler may
+// see an event again when Run is resumed from an earlier token.
+func (c *Consumer) Run(ctx context.Context, token string, checkpoint func(ctx context.Context, token string) error) error {
+	stream, err := c.source.Open(ctx, token)
+	if err != nil {
+		return err
+	}
+	defer stream.Close(context.Background())
+	for {
+		e, next, err := stream.Next(ctx)
+		if err != nil {
+			return err
+		}
+		for _, h := range c.handlers[e.Type] {
+			if err := h(ctx, e); err != nil {
+				return err
+			}
+		}
+		for _, h := range c.all {
+			if err := h(ctx, e); err != nil {
+				return err
+			}
+		}
+		if checkpoint != nil {
+			if err := checkpoint(ctx, next); err != nil {
+				return err
+			}
+		}
+	}
+}
@@ -0,0 +1,342 @@
+// Package events publishes the changes made through a backend as typed
+// events, package event of the mongodb models, and delivers them to
+// handlers registered in Go.
+//
+// Wrap a backend with Backend so every successful mutation appends its events
+// to the outbox of the backend in the same transaction as the mutation:
+//
+//	b := events.Backend(backend)
+//
+// and consume them with a Consumer reading the outbox through a Source,
+// ChangeStream on MongoDB or Poll on any backend:
+//
+//	c := events.NewConsumer(events.ChangeStream(client))
+//	c.Handle(event.MemberAdded, func(ctx context.Context, e event.Event) error {
+//		return welcome(ctx, e.UserId, e.UserGroupId)
+//	})
+//	err := c.Run(ctx, token, saveToken)
+//
+// The outbox is transactional on MongoDB, which needs a replica set for
+// transactions and change streams, and on the sql backend. The memory backend
+// appends the events after the mutation.
+package events
+
+import (
+	"context"
+	"errors"
+
+	"github.com/sr-codefreak/user-group/db"
+	"github.com/sr-codefreak/user-group/db/mongodb/access"
+	"github.com/sr-codefreak/user-group/db/mongodb/audit"
+	"github.com/sr-codefreak/user-group/db/mongodb/event"
+	"github.com/sr-codefreak/user-group/db/mongodb/user"
+	"github.com/sr-codefreak/user-group/db/mongodb/usergroup"
+	"github.com/sr-codefreak/user-group/myerrors"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type backend struct {
+	db.Backend
+}
+
+// Backend wraps b so every successful mutation appends its events to b.Outbox()
+func Backend(b db.Backend) db.Backend {
+	return backend{Backend: b}
+}
+
+func (b backend) Users() user.UserStore {
+	return userStore{UserStore: b.Backend.Users(), b: b.Backend}
+}
+
+func (b backend) UserGroups() usergroup.UserGroupStore {
+	return userGroupStore{UserGroupStore: b.Backend.UserGroups(), b: b.Backend}
+}
+
+func (b backend) Access() access.AccessStore {
+	return accessStore{AccessStore: b.Backend.Access(), b: b.Backend}
+}
+
+func (b backend) Roles() access.RoleStore {
+	return roleStore{RoleStore: b.Backend.Roles(), b: b.Backend}
+}
+
+// publish runs the mutation and appends the events it returns in one transaction
+// of the backend. The events get the actor set with audit.WithActor.
+func publish(ctx context.Context, b db.Backend, run func(ctx context.Context) ([]*event.Event, error)) error {
+	return b.WithTransaction(ctx, func(ctx context.Context) error {
+		events, err := run(ctx)
+		if err != nil {
+			return err
+		}
+		for _, e := range events {
+			e.Actor = audit.ActorFrom(ctx)
+		}
+		return b.Outbox().Append(ctx, events...)
+	})
+}
+
+type userStore struct {
+	user.UserStore
+	b db.Backend
+}
+
+func (s userStore) Create(ctx context.Context, u *user.User) error {
+	return publish(ctx, s.b, func(ctx context.Context) ([]*event.Event, error) {
+		if err := s.UserStore.Create(ctx, u); err != nil {
+			return nil, err
+		}
+		return []*event.Event{{Type: event.UserCreated, UserId: u.ID.Hex(), Name: u.Name}}, nil
+	})
+}
+
+func (s userStore) CreateMany(ctx context.Context, users []*user.User) error {
+	return publish(ctx, s.b, func(ctx context.Context) ([]*event.Event, error) {
+		if err := s.UserStore.CreateMany(ctx, users); err != nil {
+			return nil, err
+		}
+		events := make([]*event.Event, len(users))
+		for i, u := range users {
+			events[i] = &event.Event{Type: event.UserCreated, UserId: u.ID.Hex(), Name: u.Name}
+		}
+		return events, nil
+	})
+}
+
+func (s userStore) Update(ctx context.Context, u *user.User) error {
+	return publish(ctx, s.b, func(ctx context.Context) ([]*event.Event, error) {
+		if err := s.UserStore.Update(ctx, u); err != nil {
+			return nil, err
+		}
+		return []*event.Event{{Type: event.UserUpdated, UserId: u.ID.Hex(), Name: u.Name}}, nil
+	})
+}
+
+// Delete publishes a MemberRemoved event for every user group the user was in
+// before the UserDeleted event
+func (s userStore) Delete(ctx context.Context, id primitive.ObjectID) error {
+	return publish(ctx, s.b, func(ctx context.Context) ([]*event.Event, error) {
+		// a missing user is reported by Delete
+		u, err := s.UserStore.GetById(ctx, id.Hex())
+		if err != nil && !errors.Is(err, myerrors.ErrNotFound) {
+			return nil, err
+		}
+		if err := s.UserStore.Delete(ctx, id); err != nil {
+			return nil, err
+		}
+		events := []*event.Event{}
+		for _, groupId := range u.UserGroupIds {
+			events = append(events, &event.Event{Type: event.MemberRemoved, UserId: id.Hex(), UserGroupId: groupId})
+		}
+		return append(events, &event.Event{Type: event.UserDeleted, UserId: id.Hex(), Name: u.Name}), nil
+	})
+}
+
+type userGroupStore struct {
+	usergroup.UserGroupStore
+	b db.Backend
+}
+
+// change publishes the event e when run succeeds
+func (s userGroupStore) change(ctx context.Context, e *event.Event, run func(ctx context.Context) error) error {
+	return publish(ctx, s.b, func(ctx context.Context) ([]*event.Event, error) {
+		if err := run(ctx); err != nil {
+			return nil, err
+		}
+		return []*event.Event{e}, nil
+	})
+}
+
+// Create publishes a MemberAdded event for every user the user group is created with
+func (s userGroupStore) Create(ctx context.Context, group *usergroup.UserGroup) error {
+	return publish(ctx, s.b, func(ctx context.Context) ([]*event.Event, error) {
+		if err := s.UserGroupStore.Create(ctx, group); err != nil {
+			return nil, err
+		}
+		id := group.ID.Hex()
+		events := []*event.Event{{Type: event.GroupCreated, UserGroupId: id, Name: group.Name, Rule: group.Rule}}
+		for _, userId := range group.UserIds {
+			events = append(events, &event.Event{Type: event.MemberAdded, UserId: userId, UserGroupId: id})
+		}
+		return events, nil
+	})
+}
+
+// CreateMany publishes the events of Create for every user group
+func (s userGroupStore) CreateMany(ctx context.Context, groups []*usergroup.UserGroup) error {
+	return publish(ctx, s.b, func(ctx context.Context) ([]*event.Event, error) {
+		if err := s.UserGroupStore.CreateMany(ctx, groups); err != nil {
+			return nil, err
+		}
+		var events []*event.Event
+		for _, group := range groups {
+			id := group.ID.Hex()
+			events = append(events, &event.Event{Type: event.GroupCreated, UserGroupId: id, Name: group.Name, Rule: group.Rule})
+			for _, userId := range group.UserIds {
+				events = append(events, &event.Event{Type: event.MemberAdded, UserId: userId, UserGroupId: id})
+			}
+		}
+		return events, nil
+	})
+}
+
+func (s userGroupStore) UpdateName(ctx context.Context, id primitive.ObjectID, name string) error {
+	e := &event.Event{Type: event.GroupRenamed, UserGroupId: id.Hex(), Name: name}
+	return s.change(ctx, e, func(ctx context.Context) error {
+		return s.UserGroupStore.UpdateName(ctx, id, name)
+	})
+}
+
+// membership publishes e when run changes the membership of the user of e:
+// adding a member or removing a user that is not one leaves the user group
+// as it is and publishes nothing. member tells whether run adds the user.
+func (s userGroupStore) membership(ctx context.Context, e *event.Event, id primitive.ObjectID, member bool, run func(ctx context.Context) error) error {
+	return publish(ctx, s.b, func(ctx context.Context) ([]*event.Event, error) {
+		// a missing user group is reported by run
+		group, err := s.UserGroupStore.GetById(ctx, id.Hex())
+		if err != nil && !errors.Is(err, myerrors.ErrNotFound) {
+			return nil, err
+		}
+		if err := run(ctx); err != nil {
+			return nil, err
+		}
+		if group != nil && contains(group.UserIds, e.UserId) == member {
+			return nil, nil
+		}
+		return []*event.Event{e}, nil
+	})
+}
+
+func (s userGroupStore) AddUser(ctx context.Context, id primitive.ObjectID, userId primitive.ObjectID) error {
+	e := &event.Event{Type: event.MemberAdded, UserId: userId.Hex(), UserGroupId: id.Hex()}
+	return s.membership(ctx, e, id, true, func(ctx context.Context) error {
+		return s.UserGroupStore.AddUser(ctx, id, userId)
+	})
+}
+
+func (s userGroupStore) RemoveUser(ctx context.Context, id primitive.ObjectID, userId primitive.ObjectID) error {
+	e := &event.Event{Type: event.MemberRemoved, UserId: userId.Hex(), UserGroupId: id.Hex()}
+	return s.membership(ctx, e, id, false, func(ctx context.Context) error {
+		return s.UserGroupStore.RemoveUser(ctx, id, userId)
+	})
+}
+
+// DeleteById publishes a MemberRemoved event for every member of the user
+// group before the GroupDeleted event
+func (s userGroupStore) DeleteById(ctx context.Context, id primitive.ObjectID) error {
+	return publish(ctx, s.b, func(ctx context.Context) ([]*event.Event, error) {
+		// a missing user group is reported by DeleteById
+		group, err := s.UserGroupStore.GetById(ctx, id.Hex())
+		if err != nil && !errors.Is(err, myerrors.ErrNotFound) {
+			return nil, err
+		}
+		if err := s.UserGroupStore.DeleteById(ctx, id); err != nil {
+			return nil, err
+		}
+		events := []*event.Event{}
+		for _, userId := range group.UserIds {
+			events = append(events, &event.Event{Type: event.MemberRemoved, UserId: userId, UserGroupId: id.Hex()})
+		}
+		return append(events, &event.Event{Type: event.GroupDeleted, UserGroupId: id.Hex(), Name: group.Name}), nil
+	})
+}
+
+func (s userGroupStore) AddChild(ctx context.Context, id primitive.ObjectID, childId primitive.ObjectID) error {
+	e := &event.Event{Type: event.SubgroupAdded, UserGroupId: id.Hex(), ChildGroupId: childId.Hex()}
+	return s.change(ctx, e, func(ctx context.Context) error {
+		return s.UserGroupStore.AddChild(ctx, id, childId)
+	})
+}
+
+func (s userGroupStore) RemoveChild(ctx context.Context, id primitive.ObjectID, childId primitive.ObjectID) error {
+	e := &event.Event{Type: event.SubgroupRemoved, UserGroupId: id.Hex(), ChildGroupId: childId.Hex()}
+	return s.change(ctx, e, func(ctx context.Context) error {
+		return s.UserGroupStore.RemoveChild(ctx, id, childId)
+	})
+}
+
+func (s userGroupStore) SetRule(ctx context.Context, id primitive.ObjectID, rule string) error {
+	e := &event.Event{Type: event.RuleChanged, UserGroupId: id.Hex(), Rule: rule}
+	return s.change(ctx, e, func(ctx context.Context) error {
+		return s.UserGroupStore.SetRule(ctx, id, rule)
+	})
+}
+
+type accessStore struct {
+	access.AccessStore
+	b db.Backend
+}
+
+// change publishes an event of type t with the roles when run succeeds
+func (s accessStore) change(ctx context.Context, t event.Type, userId primitive.ObjectID, userGroupId primitive.ObjectID, roles []string, run func(ctx context.Context) error) error {
+	return publish(ctx, s.b, func(ctx context.Context) ([]*event.Event, error) {
+		if err := run(ctx); err != nil {
+			return nil, err
+		}
+		return []*event.Event{{
+			Type:        t,
+			UserId:      userId.Hex(),
+			UserGroupId: userGroupId.Hex(),
+			Roles:       append([]string{}, roles...),
+		}}, nil
+	})
+}
+
+func (s accessStore) Grant(ctx context.Context, userId primitive.ObjectID, userGroupId primitive.ObjectID, roles ...string) error {
+	return s.change(ctx, event.RoleGranted, userId, userGroupId, roles, func(ctx context.Context) error {
+		return s.AccessStore.Grant(ctx, userId, userGroupId, roles...)
+	})
+}
+
+func (s accessStore) Revoke(ctx context.Context, userId primitive.ObjectID, userGroupId primitive.ObjectID, roles ...string) error {
+	return s.change(ctx, event.RoleRevoked, userId, userGroupId, roles, func(ctx context.Context) error {
+		return s.AccessStore.Revoke(ctx, userId, userGroupId, roles...)
+	})
+}
+
+func (s accessStore) SetRoles(ctx context.Context, userId primitive.ObjectID, userGroupId primitive.ObjectID, roles []string) error {
+	return s.change(ctx, event.RolesSet, userId, userGroupId, roles, func(ctx context.Context) error {
+		return s.AccessStore.SetRoles(ctx, userId, userGroupId, roles)
+	})
+}
+
+type roleStore struct {
+	access.RoleStore
+	b db.Backend
+}
+
+// change publishes an event of type t for the role name when run succeeds
+func (s roleStore) change(ctx context.Context, t event.Type, name string, run func(ctx context.Context) error) error {
+	return publish(ctx, s.b, func(ctx context.Context) ([]*event.Event, error) {
+		if err := run(ctx); err != nil {
+			return nil, err
+		}
+		return []*event.Event{{Type: t, Name: name}}, nil
+	})
+}
+
+func (s roleStore) Create(ctx context.Context, r *access.Role) error {
+	return s.change(ctx, event.RoleDefined, r.Name, func(ctx context.Context) error {
+		return s.RoleStore.Create(ctx, r)
+	})
+}
+
+func (s roleStore) Update(ctx context.Context, r *access.Role) error {
+	return s.change(ctx, event.RoleUpdated, r.Name, func(ctx context.Context) error {
+		return s.RoleStore.Update(ctx, r)
+	})
+}
+
+func (s roleStore) Delete(ctx context.Context, name string) error {
+	return s.change(ctx, event.RoleDeleted, name, func(ctx context.Context) error {
+		return s.RoleStore.Delete(ctx, name)
+	})
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,52 @@
+package events_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sr-codefreak/user-group/db/memory"
+	"github.com/sr-codefreak/user-group/db/mongodb/event"
+	"github.com/sr-codefreak/user-group/db/mongodb/user"
+	"github.com/sr-codefreak/user-group/db/mongodb/usergroup"
+	"github.com/sr-codefreak/user-group/events"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestMembershipEvents(t *testing.T) {
+	ctx := context.Background()
+	mem := memory.New()
+	b := events.Backend(mem)
+	u := &user.User{Name: "ann", Email: "ann@example.com"}
+	if err := mem.Users().Create(ctx, u); err != nil {
+		t.Fatal(err)
+	}
+	ug := &usergroup.UserGroup{Name: "eng"}
+	if err := mem.UserGroups().Create(ctx, ug); err != nil {
+		t.Fatal(err)
+	}
+
+	// retries that leave the membership as it is publish nothing
+	for i := 0; i < 2; i++ {
+		if err := b.UserGroups().AddUser(ctx, ug.ID, u.ID); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for i := 0; i < 2; i++ {
+		if err := b.UserGroups().RemoveUser(ctx, ug.ID, u.ID); err != nil {
+			t.Fatal(err)
+		}
+	}
+	got, err := mem.Outbox().List(ctx, primitive.NilObjectID, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []event.Type{event.MemberAdded, event.MemberRemoved}
+	if len(got) != len(want) {
+		t.Fatalf("events = %+v, want %v", got, want)
+	}
+	for i, e := range got {
+		if e.Type != want[i] || e.UserId != u.ID.Hex() || e.UserGroupId != ug.ID.Hex() {
+			t.Errorf("event %d = %+v, want %s", i, e, want[i])
+		}
+	}
+}
@@ -0,0 +1,188 @@
+package events
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"time"
+
+	"github.com/sr-codefreak/user-group/db/mongodb"
+	"github.com/sr-codefreak/user-group/db/mongodb/event"
+	"github.com/sr-codefreak/user-group/myerrors"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type changeStreamSource struct {
+	c *mongodb.Client
+}
+
+// ChangeStream returns the source watching the inserts into the outbox
+// collection with a MongoDB change stream. Its tokens are the resume tokens
+// of the change stream, an empty token starts at the events appended after
+// Open. The tokens stay valid as long as the oplog holds their position.
+func ChangeStream(c *mongodb.Client) Source {
+	return changeStreamSource{c: c}
+}
+
+func (s changeStreamSource) Open(ctx context.Context, token string) (Stream, error) {
+	opts := options.ChangeStream()
+	if token != "" {
+		resume, err := base64.RawURLEncoding.DecodeString(token)
+		if err != nil {
+			return nil, myerrors.Wrap(myerrors.ErrWatchingEvents, myerrors.KindEvent, "", myerrors.Invalid(fmt.Errorf("invalid token: %w", err)))
+		}
+		opts.SetResumeAfter(bson.Raw(resume))
+	}
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.D{{Key: "operationType", Value: "insert"}}}},
+	}
+	stream, err := s.c.Watch(ctx, event.GetModel(), pipeline, opts)
+	if err != nil {
+		return nil, myerrors.Wrap(myerrors.ErrWatchingEvents, myerrors.KindEvent, "", err)
+	}
+	return changeStream{stream: stream}, nil
+}
+
+type changeStream struct {
+	stream *mongo.ChangeStream
+}
+
+func (s changeStream) Next(ctx context.Context) (event.Event, string, error) {
+	if !s.stream.Next(ctx) {
+		if err := s.stream.Err(); err != nil {
+			return event.Event{}, "", myerrors.Wrap(myerrors.ErrWatchingEvents, myerrors.KindEvent, "", err)
+		}
+		return event.Event{}, "", ctx.Err()
+	}
+	change := struct {
+		FullDocument event.Event `bson:"fullDocument"`
+	}{}
+	if err := s.stream.Decode(&change); err != nil {
+		return event.Event{}, "", myerrors.Wrap(myerrors.ErrWatchingEvents, myerrors.KindEvent, "", err)
+	}
+	return change.FullDocument, base64.RawURLEncoding.EncodeToString(s.stream.ResumeToken()), nil
+}
+
+func (s changeStream) Close(ctx context.Context) error {
+	return s.stream.Close(ctx)
+}
+
+type pollSource struct {
+	store    event.OutboxStore
+	interval time.Duration
+}
+
+// Poll returns the source listing the events of the outbox store every
+// interval while there are no new ones. Its tokens are the ids of the events,
+// an empty token starts at the oldest event the outbox retains.
+//
+// Poll relies on the store listing the events in commit order, as the memory
+// and sql backends do. The ids of MongoDB are not: an event committed after
+// the one of the token but with a smaller id is skipped, use ChangeStream
+// there.
+func Poll(store event.OutboxStore, interval time.Duration) Source {
+	return pollSource{store: store, interval: interval}
+}
+
+func (s pollSource) Open(ctx context.Context, token string) (Stream, error) {
+	after := primitive.NilObjectID
+	if token != "" {
+		var err error
+		if after, err = primitive.ObjectIDFromHex(token); err != nil {
+			return nil, myerrors.Wrap(myerrors.ErrWatchingEvents, myerrors.KindEvent, "", myerrors.Invalid(fmt.Errorf("invalid token: %w", err)))
+		}
+	}
+	return &pollStream{source: s, after: after}, nil
+}
+
+type pollStream struct {
+	source  pollSource
+	after   primitive.ObjectID
+	pending []event.Event
+}
+
+func (s *pollStream) Next(ctx context.Context) (event.Event, string, error) {
+	for len(s.pending) == 0 {
+		events, err := s.source.store.List(ctx, s.after, mongodb.MaxPageSize)
+		if err != nil {
+			return event.Event{}, "", err
+		}
+		if len(events) > 0 {
+			s.pending = events
+			break
+		}
+		select {
+		case <-ctx.Done():
+			return event.Event{}, "", ctx.Err()
+		case <-time.After(s.source.interval):
+		}
+	}
+	e := s.pending[0]
+	s.pending = s.pending[1:]
+	s.after = e.ID
+	return e, e.ID.Hex(), nil
+}
+
+func (s *pollStream) Close(ctx context.Context) error {
+	return nil
+}
+
+type catchUpSource struct {
+	store event.OutboxStore
+	live  Source
+}
+
+// CatchUp returns the source that, opened with an empty token, returns the
+// events the outbox store retains before those of live, which is opened
+// first: an event committed while the retained ones are listed is returned
+// by live if the listing misses it. Events may be returned twice; the
+// retained ones come with an empty token, so resuming from them replays the
+// outbox again. The other tokens are tokens of live.
+//
+//	events.CatchUp(event.NewStore(client), events.ChangeStream(client))
+//
+// replays the outbox on start without missing the events appended meanwhile.
+func CatchUp(store event.OutboxStore, live Source) Source {
+	return catchUpSource{store: store, live: live}
+}
+
+func (s catchUpSource) Open(ctx context.Context, token string) (Stream, error) {
+	stream, err := s.live.Open(ctx, token)
+	if err != nil || token != "" {
+		return stream, err
+	}
+	return &catchUpStream{store: s.store, live: stream}, nil
+}
+
+type catchUpStream struct {
+	store   event.OutboxStore
+	live    Stream
+	after   primitive.ObjectID
+	pending []event.Event
+	caught  bool
+}
+
+func (s *catchUpStream) Next(ctx context.Context) (event.Event, string, error) {
+	if !s.caught && len(s.pending) == 0 {
+		events, err := s.store.List(ctx, s.after, mongodb.MaxPageSize)
+		if err != nil {
+			return event.Event{}, "", err
+		}
+		s.pending = events
+		s.caught = len(events) == 0
+	}
+	if s.caught {
+		return s.live.Next(ctx)
+	}
+	e := s.pending[0]
+	s.pending = s.pending[1:]
+	s.after = e.ID
+	return e, "", nil
+}
+
+func (s *catchUpStream) Close(ctx context.Context) error {
+	return s.live.Close(ctx)
+}
@@ -3,13 +3,78 @@ package myerrors
 import "errors"
 
 // ErrNoMongoConnection is returned when there is no mongo connection
-var ErrNoMongoConnection = errors.New("mongo client is not connected")
+var ErrNoMongoConnection = &Error{Code: Unavailable, Err: errors.New("mongo client is not connected")}
 var ErrCreatingUserGroup = errors.New("error creating user group")
 var ErrUpdatingUserGroupName = errors.New("error updating user group name")
-var ErrGetUserGroupById = errors.New("error getting user by Id")
+var ErrGetUserGroupById = errors.New("error getting user group by Id")
 var ErrDeleteUserGroup = errors.New("error deleting user group")
+var ErrAddingUserToUserGroup = errors.New("error adding user to user group")
+var ErrRemovingUserFromUserGroup = errors.New("error removing user from user group")
+var ErrListingUserGroups = errors.New("error listing user groups")
+var ErrAddingChildGroup = errors.New("error adding child user group")
+var ErrRemovingChildGroup = errors.New("error removing child user group")
+var ErrResolvingMembers = errors.New("error resolving members of user group")
+var ErrResolvingGroups = errors.New("error resolving user groups of user")
+var ErrSettingRule = errors.New("error setting rule of user group")
+
+// ErrGroupCycle is returned when a user group would end up containing itself
+var ErrGroupCycle = &Error{Code: InvalidArgument, Err: errors.New("user group would contain itself")}
 
 var (
 	ErrCreatingUser = errors.New("error creating user")
 	ErrUpdatingUser = errors.New("error updating user")
+	ErrGetUserById  = errors.New("error getting user by Id")
+	ErrDeleteUser   = errors.New("error deleting user")
+	ErrListingUsers = errors.New("error listing users")
+)
+
+var (
+	ErrGrantingAccess = errors.New("error granting access")
+	ErrRevokingAccess = errors.New("error revoking access")
+	ErrSettingRoles   = errors.New("error setting roles")
+	ErrGetAccess      = errors.New("error getting access")
+	ErrCreatingIndex  = errors.New("error creating index")
 )
+
+var (
+	ErrCreatingRole   = errors.New("error creating role")
+	ErrUpdatingRole   = errors.New("error updating role")
+	ErrDeleteRole     = errors.New("error deleting role")
+	ErrGetRole        = errors.New("error getting role")
+	ErrListingRoles   = errors.New("error listing roles")
+	ErrGetPermissions = errors.New("error getting permissions")
+)
+
+var (
+	ErrAppendingAudit = errors.New("error appending audit record")
+	ErrListingAudit   = errors.New("error listing audit records")
+)
+
+var (
+	ErrAppendingEvents = errors.New("error appending events")
+	ErrListingEvents   = errors.New("error listing events")
+	ErrPruningEvents   = errors.New("error pruning events")
+	ErrWatchingEvents  = errors.New("error watching events")
+)
+
+var (
+	ErrCreatingWebhook   = errors.New("error creating webhook")
+	ErrUpdatingWebhook   = errors.New("error updating webhook")
+	ErrDeleteWebhook     = errors.New("error deleting webhook")
+	ErrGetWebhook        = errors.New("error getting webhook")
+	ErrListingWebhooks   = errors.New("error listing webhooks")
+	ErrEnqueuingDelivery = errors.New("error enqueuing webhook delivery")
+	ErrUpdatingDelivery  = errors.New("error updating webhook delivery")
+	ErrListingDeliveries = errors.New("error listing webhook deliveries")
+)
+
+var (
+	ErrConnectingDirectory = errors.New("error connecting to directory")
+	ErrSearchingDirectory  = errors.New("error searching directory")
+)
+
+// ErrRoleCycle is returned when a role would end up inheriting itself
+var ErrRoleCycle = &Error{Code: InvalidArgument, Err: errors.New("role would inherit itself")}
+
+// ErrRoleInherited is returned when deleting a role other roles still inherit
+var ErrRoleInherited = &Error{Code: InvalidArgument, Err: errors.New("role is inherited by other roles")}
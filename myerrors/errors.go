@@ -0,0 +1,136 @@
+package myerrors
+
+import (
+	"errors"
+	"strings"
+)
+
+// Code classifies an Error
+type Code int
+
+const (
+	Unknown Code = iota
+	NotFound
+	AlreadyExists
+	InvalidArgument
+	Unavailable
+	Conflict
+)
+
+func (c Code) String() string {
+	switch c {
+	case NotFound:
+		return "not found"
+	case AlreadyExists:
+		return "already exists"
+	case InvalidArgument:
+		return "invalid argument"
+	case Unavailable:
+		return "unavailable"
+	case Conflict:
+		return "conflict"
+	}
+	return "unknown"
+}
+
+// Kind is the kind of entity an Error is about
+type Kind string
+
+const (
+	KindUser      Kind = "user"
+	KindUserGroup Kind = "userGroup"
+	KindAccess    Kind = "access"
+	KindRole      Kind = "role"
+	KindAudit     Kind = "audit"
+	KindEvent     Kind = "event"
+	KindWebhook   Kind = "webhook"
+	KindDirectory Kind = "directory"
+)
+
+// Error is the error returned by the stores.
+// Use errors.Is with ErrNotFound, ErrAlreadyExists, ... to test the code
+// and errors.As to read the entity kind and id.
+type Error struct {
+	Code Code
+	Kind Kind
+	ID   string
+	Err  error
+}
+
+func (e *Error) Error() string {
+	var sb strings.Builder
+	if e.Kind != "" {
+		sb.WriteString(string(e.Kind))
+		if e.ID != "" {
+			sb.WriteString(" " + e.ID)
+		}
+		sb.WriteString(": ")
+	}
+	sb.WriteString(e.Code.String())
+	if e.Err != nil {
+		sb.WriteString(": " + e.Err.Error())
+	}
+	return sb.String()
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Is reports whether target is a code sentinel such as ErrNotFound matching e.
+// A target *Error without Err matches on its code, and on its kind and id when set.
+func (e *Error) Is(target error) bool {
+	t, ok := target.(*Error)
+	if !ok || t.Err != nil {
+		return false
+	}
+	return t.Code == e.Code &&
+		(t.Kind == "" || t.Kind == e.Kind) &&
+		(t.ID == "" || t.ID == e.ID)
+}
+
+// Sentinels to test the code of an error with errors.Is
+var (
+	ErrNotFound        = &Error{Code: NotFound}
+	ErrAlreadyExists   = &Error{Code: AlreadyExists}
+	ErrInvalidArgument = &Error{Code: InvalidArgument}
+	ErrUnavailable     = &Error{Code: Unavailable}
+	ErrConflict        = &Error{Code: Conflict}
+)
+
+// New returns an Error about the entity
+func New(code Code, kind Kind, id string, err error) *Error {
+	return &Error{Code: code, Kind: kind, ID: id, Err: err}
+}
+
+// CodeOf returns the code of the first Error in err's tree, Unknown when there is none
+func CodeOf(err error) Code {
+	var e *Error
+	if errors.As(err, &e) {
+		return e.Code
+	}
+	return Unknown
+}
+
+// Wrap annotates err, the failure of the operation op, with the entity it is about.
+// The code is taken over from err, and so are the kind and id when err is
+// already about a more specific entity. Wrap returns nil when err is nil.
+func Wrap(op error, kind Kind, id string, err error) error {
+	if err == nil {
+		return nil
+	}
+	if t, ok := err.(*Error); ok && t.Err == nil {
+		// a bare code sentinel adds nothing to the message
+		return &Error{Code: t.Code, Kind: kind, ID: id, Err: op}
+	}
+	var e *Error
+	if errors.As(err, &e) && e.Kind != "" {
+		kind, id = e.Kind, e.ID
+	}
+	return &Error{Code: CodeOf(err), Kind: kind, ID: id, Err: errors.Join(op, err)}
+}
+
+// Invalid marks err as an InvalidArgument error
+func Invalid(err error) error {
+	return &Error{Code: InvalidArgument, Err: err}
+}
@@ -0,0 +1,225 @@
+package rule
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/sr-codefreak/user-group/db/mongodb/user"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type node interface {
+	eval(u *user.User) bool
+}
+
+type andNode struct{ left, right node }
+
+func (n andNode) eval(u *user.User) bool { return n.left.eval(u) && n.right.eval(u) }
+
+type orNode struct{ left, right node }
+
+func (n orNode) eval(u *user.User) bool { return n.left.eval(u) || n.right.eval(u) }
+
+type notNode struct{ n node }
+
+func (n notNode) eval(u *user.User) bool { return !n.n.eval(u) }
+
+// field is a user field, path holds the keys below metaData
+type field struct {
+	name string
+	path []string
+}
+
+func parseField(s string) (field, error) {
+	switch s {
+	case "id", "name", "email", "phone":
+		return field{name: s}, nil
+	}
+	if key, ok := strings.CutPrefix(s, "metaData."); ok {
+		path := strings.Split(key, ".")
+		for _, k := range path {
+			if k == "" {
+				return field{}, fmt.Errorf("invalid field %q", s)
+			}
+		}
+		return field{name: "metaData", path: path}, nil
+	}
+	return field{}, fmt.Errorf("unknown field %q", s)
+}
+
+// value returns the value of the field of u, false when it is missing
+func (f field) value(u *user.User) (any, bool) {
+	switch f.name {
+	case "id":
+		return u.ID.Hex(), true
+	case "name":
+		return u.Name, true
+	case "email":
+		return u.Email, true
+	case "phone":
+		return u.Phone, true
+	}
+	var v any = u.MetaData
+	for _, key := range f.path {
+		m, ok := asMap(v)
+		if !ok {
+			return nil, false
+		}
+		if v, ok = m[key]; !ok {
+			return nil, false
+		}
+	}
+	return normalize(v), true
+}
+
+// asMap returns the document v as map, mongo decodes nested documents as primitive.M or D
+func asMap(v any) (map[string]any, bool) {
+	switch m := v.(type) {
+	case map[string]any:
+		return m, true
+	case primitive.M:
+		return m, true
+	case primitive.D:
+		doc := make(map[string]any, len(m))
+		for _, e := range m {
+			doc[e.Key] = e.Value
+		}
+		return doc, true
+	}
+	return nil, false
+}
+
+// normalize converts the numbers and arrays decoded from bson or json
+// to float64 and []any so they compare with the rule values
+func normalize(v any) any {
+	switch n := v.(type) {
+	case int:
+		return float64(n)
+	case int32:
+		return float64(n)
+	case int64:
+		return float64(n)
+	case float32:
+		return float64(n)
+	case primitive.A:
+		return []any(n)
+	}
+	return v
+}
+
+type compareNode struct {
+	field field
+	op    string
+	value any
+}
+
+func (n compareNode) eval(u *user.User) bool {
+	v, ok := n.field.value(u)
+	if !ok {
+		switch n.op {
+		case "==":
+			return n.value == nil
+		case "!=":
+			return n.value != nil
+		}
+		return false
+	}
+	switch n.op {
+	case "==":
+		return equal(v, n.value)
+	case "!=":
+		return !equal(v, n.value)
+	case "<", "<=", ">", ">=":
+		c, ok := compare(v, n.value)
+		if !ok {
+			return false
+		}
+		switch n.op {
+		case "<":
+			return c < 0
+		case "<=":
+			return c <= 0
+		case ">":
+			return c > 0
+		}
+		return c >= 0
+	case "contains":
+		if list, ok := v.([]any); ok {
+			for _, e := range list {
+				if equal(normalize(e), n.value) {
+					return true
+				}
+			}
+			return false
+		}
+		s, sok := v.(string)
+		sub, subok := n.value.(string)
+		return sok && subok && strings.Contains(s, sub)
+	case "startswith", "endswith":
+		s, sok := v.(string)
+		affix, aok := n.value.(string)
+		if !sok || !aok {
+			return false
+		}
+		if n.op == "startswith" {
+			return strings.HasPrefix(s, affix)
+		}
+		return strings.HasSuffix(s, affix)
+	}
+	return false
+}
+
+type inNode struct {
+	field  field
+	values []any
+}
+
+func (n inNode) eval(u *user.User) bool {
+	v, ok := n.field.value(u)
+	if !ok {
+		return false
+	}
+	for _, value := range n.values {
+		if equal(v, value) {
+			return true
+		}
+	}
+	return false
+}
+
+func equal(a any, b any) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	switch a.(type) {
+	case string, float64, bool:
+		// values of different types are not equal
+		return a == b
+	}
+	return false
+}
+
+// compare orders two numbers or two strings, ok is false for other values
+func compare(a any, b any) (int, bool) {
+	switch x := a.(type) {
+	case float64:
+		y, ok := b.(float64)
+		if !ok {
+			return 0, false
+		}
+		switch {
+		case x < y:
+			return -1, true
+		case x > y:
+			return 1, true
+		}
+		return 0, true
+	case string:
+		y, ok := b.(string)
+		if !ok {
+			return 0, false
+		}
+		return strings.Compare(x, y), true
+	}
+	return 0, false
+}
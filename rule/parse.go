@@ -0,0 +1,297 @@
+package rule
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+type tokenKind int
+
+const (
+	tokEOF tokenKind = iota
+	tokIdent
+	tokString
+	tokNumber
+	tokOp
+	tokLParen
+	tokRParen
+	tokLBracket
+	tokRBracket
+	tokComma
+)
+
+type token struct {
+	kind tokenKind
+	text string
+	pos  int
+}
+
+func (t token) String() string {
+	if t.kind == tokEOF {
+		return "end of rule"
+	}
+	return fmt.Sprintf("%q at %d", t.text, t.pos)
+}
+
+type lexer struct {
+	src string
+	pos int
+}
+
+func isIdentRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' || r == '.' || r == '-'
+}
+
+// peek returns the rune at the position and its width in bytes, 0 at the end
+func (l *lexer) peek() (rune, int) {
+	return utf8.DecodeRuneInString(l.src[l.pos:])
+}
+
+func (l *lexer) next() (token, error) {
+	for r, w := l.peek(); w > 0 && unicode.IsSpace(r); r, w = l.peek() {
+		l.pos += w
+	}
+	start := l.pos
+	if l.pos >= len(l.src) {
+		return token{kind: tokEOF, pos: start}, nil
+	}
+	c, _ := l.peek()
+	switch {
+	case c == '(':
+		l.pos++
+		return token{kind: tokLParen, text: "(", pos: start}, nil
+	case c == ')':
+		l.pos++
+		return token{kind: tokRParen, text: ")", pos: start}, nil
+	case c == '[':
+		l.pos++
+		return token{kind: tokLBracket, text: "[", pos: start}, nil
+	case c == ']':
+		l.pos++
+		return token{kind: tokRBracket, text: "]", pos: start}, nil
+	case c == ',':
+		l.pos++
+		return token{kind: tokComma, text: ",", pos: start}, nil
+	case c == '"':
+		l.pos++
+		for l.pos < len(l.src) && l.src[l.pos] != '"' {
+			if l.src[l.pos] == '\\' {
+				l.pos++
+			}
+			l.pos++
+		}
+		if l.pos >= len(l.src) {
+			return token{}, fmt.Errorf("unterminated string at %d", start)
+		}
+		l.pos++
+		s, err := strconv.Unquote(l.src[start:l.pos])
+		if err != nil {
+			return token{}, fmt.Errorf("invalid string at %d: %w", start, err)
+		}
+		return token{kind: tokString, text: s, pos: start}, nil
+	case strings.ContainsRune("=!<>", c):
+		l.pos++
+		if l.pos < len(l.src) && l.src[l.pos] == '=' {
+			l.pos++
+		}
+		op := l.src[start:l.pos]
+		if op == "=" || op == "!" {
+			return token{}, fmt.Errorf("unknown operator %q at %d", op, start)
+		}
+		return token{kind: tokOp, text: op, pos: start}, nil
+	case c == '-' || c == '+' || (c >= '0' && c <= '9'):
+		l.pos++
+		for l.pos < len(l.src) && strings.ContainsRune("0123456789.eE+-", rune(l.src[l.pos])) {
+			l.pos++
+		}
+		return token{kind: tokNumber, text: l.src[start:l.pos], pos: start}, nil
+	case isIdentRune(c):
+		for r, w := l.peek(); w > 0 && isIdentRune(r); r, w = l.peek() {
+			l.pos += w
+		}
+		return token{kind: tokIdent, text: l.src[start:l.pos], pos: start}, nil
+	}
+	return token{}, fmt.Errorf("unexpected %q at %d", c, start)
+}
+
+// parser is a recursive descent parser of
+//
+//	or         = and { "OR" and }
+//	and        = not { "AND" not }
+//	not        = "NOT" not | "(" or ")" | comparison
+//	comparison = field op value | field "in" "[" [ value { "," value } ] "]"
+type parser struct {
+	lex lexer
+	tok token
+	err error
+}
+
+func (p *parser) next() {
+	if p.err != nil {
+		return
+	}
+	p.tok, p.err = p.lex.next()
+}
+
+func (p *parser) errorf(format string, args ...any) error {
+	if p.err != nil {
+		return p.err
+	}
+	return fmt.Errorf(format, args...)
+}
+
+func (p *parser) keyword(kw string) bool {
+	return p.err == nil && p.tok.kind == tokIdent && strings.EqualFold(p.tok.text, kw)
+}
+
+func (p *parser) parseOr() (node, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.keyword("or") {
+		p.next()
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = orNode{left, right}
+	}
+	return left, p.err
+}
+
+func (p *parser) parseAnd() (node, error) {
+	left, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+	for p.keyword("and") {
+		p.next()
+		right, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		left = andNode{left, right}
+	}
+	return left, p.err
+}
+
+func (p *parser) parseNot() (node, error) {
+	switch {
+	case p.keyword("not"):
+		p.next()
+		n, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		return notNode{n}, nil
+	case p.err == nil && p.tok.kind == tokLParen:
+		p.next()
+		n, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if p.tok.kind != tokRParen {
+			return nil, p.errorf("expected ) instead of %s", p.tok)
+		}
+		p.next()
+		return n, p.err
+	}
+	return p.parseComparison()
+}
+
+var wordOps = map[string]bool{"contains": true, "startswith": true, "endswith": true, "in": true}
+
+func (p *parser) parseComparison() (node, error) {
+	if p.err != nil {
+		return nil, p.err
+	}
+	if p.tok.kind != tokIdent {
+		return nil, p.errorf("expected a field instead of %s", p.tok)
+	}
+	f, err := parseField(p.tok.text)
+	if err != nil {
+		return nil, err
+	}
+	p.next()
+
+	var op string
+	switch {
+	case p.err != nil:
+		return nil, p.err
+	case p.tok.kind == tokOp:
+		op = p.tok.text
+	case p.tok.kind == tokIdent && wordOps[strings.ToLower(p.tok.text)]:
+		op = strings.ToLower(p.tok.text)
+	default:
+		return nil, p.errorf("expected an operator instead of %s", p.tok)
+	}
+	p.next()
+
+	if op == "in" {
+		values, err := p.parseList()
+		if err != nil {
+			return nil, err
+		}
+		return inNode{field: f, values: values}, nil
+	}
+	value, err := p.parseValue()
+	if err != nil {
+		return nil, err
+	}
+	return compareNode{field: f, op: op, value: value}, nil
+}
+
+func (p *parser) parseList() ([]any, error) {
+	if p.err != nil || p.tok.kind != tokLBracket {
+		return nil, p.errorf("expected [ instead of %s", p.tok)
+	}
+	p.next()
+	values := []any{}
+	for p.err == nil && p.tok.kind != tokRBracket {
+		if len(values) > 0 {
+			if p.tok.kind != tokComma {
+				return nil, p.errorf("expected , instead of %s", p.tok)
+			}
+			p.next()
+		}
+		v, err := p.parseValue()
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, v)
+	}
+	if p.err != nil {
+		return nil, p.err
+	}
+	p.next()
+	return values, p.err
+}
+
+func (p *parser) parseValue() (any, error) {
+	if p.err != nil {
+		return nil, p.err
+	}
+	tok := p.tok
+	p.next()
+	switch {
+	case tok.kind == tokString:
+		return tok.text, p.err
+	case tok.kind == tokNumber:
+		f, err := strconv.ParseFloat(tok.text, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid number %s", tok)
+		}
+		return f, p.err
+	case tok.kind == tokIdent && strings.EqualFold(tok.text, "true"):
+		return true, p.err
+	case tok.kind == tokIdent && strings.EqualFold(tok.text, "false"):
+		return false, p.err
+	case tok.kind == tokIdent && strings.EqualFold(tok.text, "null"):
+		return nil, p.err
+	}
+	return nil, fmt.Errorf("expected a value instead of %s", tok)
+}
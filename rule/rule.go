@@ -0,0 +1,72 @@
+// Package rule parses and evaluates the membership rules of dynamic user groups.
+//
+// A rule is a predicate over the fields of a user.User:
+//
+//	metaData.department == "sales" AND email endswith "@corp.com"
+//	NOT (metaData.level < 3 OR metaData.tags contains "intern")
+//	phone startswith "+49" or name in ["ada", "grace"]
+//
+// The fields are id, name, email, phone and metaData.<key>, nested keys are
+// separated by dots. Values are double quoted strings, numbers, true, false,
+// null and lists of them in brackets. The operators are ==, !=, <, <=, >, >=,
+// contains, startswith, endswith and in, combined with AND, OR, NOT and
+// parentheses. Keywords are case insensitive. A comparison with a missing
+// field is false, except for != and == null.
+package rule
+
+import (
+	"fmt"
+
+	"github.com/sr-codefreak/user-group/db/mongodb/user"
+	"github.com/sr-codefreak/user-group/myerrors"
+)
+
+// Rule is a parsed membership rule
+type Rule struct {
+	src  string
+	root node
+}
+
+// Parse parses src. The returned error is an InvalidArgument myerrors.Error.
+func Parse(src string) (*Rule, error) {
+	p := &parser{lex: lexer{src: src}}
+	p.next()
+	root, err := p.parseOr()
+	if err == nil && p.tok.kind != tokEOF {
+		err = p.errorf("unexpected %s", p.tok)
+	}
+	if err != nil {
+		return nil, myerrors.Invalid(fmt.Errorf("rule %q: %w", src, err))
+	}
+	return &Rule{src: src, root: root}, nil
+}
+
+// MustParse is like Parse but panics on invalid rules
+func MustParse(src string) *Rule {
+	r, err := Parse(src)
+	if err != nil {
+		panic(err)
+	}
+	return r
+}
+
+// String returns the source of the rule
+func (r *Rule) String() string {
+	return r.src
+}
+
+// Match reports whether the user satisfies the rule
+func (r *Rule) Match(u *user.User) bool {
+	return r.root.eval(u)
+}
+
+// Filter returns the users satisfying the rule
+func (r *Rule) Filter(users []user.User) []user.User {
+	matched := []user.User{}
+	for i := range users {
+		if r.Match(&users[i]) {
+			matched = append(matched, users[i])
+		}
+	}
+	return matched
+}
@@ -0,0 +1,156 @@
+package rule_test
+
+import (
+	"testing"
+
+	"github.com/sr-codefreak/user-group/db/mongodb/user"
+	"github.com/sr-codefreak/user-group/myerrors"
+	"github.com/sr-codefreak/user-group/rule"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestUnicode(t *testing.T) {
+	u := &user.User{Name: "Zoë", MetaData: map[string]any{"größe": 180.0, "städte": []any{"Köln", "Zürich"}}}
+	for _, src := range []string{
+		`metaData.größe >= 180`,
+		`metaData.städte contains "Zürich"`,
+		"name ==　\"Zoë\"",
+		`name endswith "ë"`,
+	} {
+		r, err := rule.Parse(src)
+		if err != nil {
+			t.Errorf("Parse(%q): %v", src, err)
+			continue
+		}
+		if !r.Match(u) {
+			t.Errorf("%q does not match %+v", src, u)
+		}
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	for _, src := range []string{
+		``,
+		`name`,
+		`name ==`,
+		`name = "ann"`,
+		`name ! "ann"`,
+		`name like "ann"`,
+		`title == "ann"`,
+		`metaData. == 1`,
+		`metaData.a..b == 1`,
+		`name == "ann`,
+		`name == "\q"`,
+		`name == ann`,
+		`name == 1e`,
+		`name == @`,
+		`(name == "ann"`,
+		`name == "ann")`,
+		`name == "ann" extra`,
+		`name == "ann" AND`,
+		`NOT`,
+		`name in "ann"`,
+		`name in ["ann" "bob"]`,
+		`name in ["ann",`,
+	} {
+		if _, err := rule.Parse(src); myerrors.CodeOf(err) != myerrors.InvalidArgument {
+			t.Errorf("Parse(%q) = %v, want an invalid argument", src, err)
+		}
+	}
+}
+
+func TestMatch(t *testing.T) {
+	id := primitive.NewObjectID()
+	u := &user.User{
+		ID:    id,
+		Name:  "ann",
+		Email: "ann@corp.com",
+		Phone: "+49 30 1234",
+		MetaData: map[string]any{
+			"dept":    "sales",
+			"level":   3,
+			"score":   int64(7),
+			"ratio":   0.5,
+			"active":  true,
+			"tags":    primitive.A{"lead", "remote"},
+			"manager": primitive.M{"name": "bob"},
+			"office":  primitive.D{{Key: "city", Value: "Berlin"}},
+		},
+	}
+	for _, c := range []struct {
+		src  string
+		want bool
+	}{
+		// fields
+		{`id == "` + id.Hex() + `"`, true},
+		{`name == "ann"`, true},
+		{`name == "Ann"`, false},
+		{`name != "ann"`, false},
+		{`phone startswith "+49"`, true},
+		{`metaData.manager.name == "bob"`, true},
+		{`metaData.office.city == "Berlin"`, true},
+		// numbers decoded as int, int64 and float64 compare as numbers
+		{`metaData.level == 3`, true},
+		{`metaData.level == 3.0`, true},
+		{`metaData.level == "3"`, false},
+		{`metaData.score == 7`, true},
+		{`metaData.ratio == 5e-1`, true},
+		{`metaData.level < 4`, true},
+		{`metaData.level <= 3`, true},
+		{`metaData.level > 3`, false},
+		{`metaData.level >= 3`, true},
+		{`metaData.level > -1`, true},
+		{`metaData.dept < "t"`, true},
+		{`metaData.dept < 4`, false},
+		{`metaData.active == true`, true},
+		{`metaData.active == FALSE`, false},
+		// string and list operators
+		{`email endswith "@corp.com"`, true},
+		{`email startswith "ann@"`, true},
+		{`email contains "corp"`, true},
+		{`email contains 1`, false},
+		{`metaData.level startswith "3"`, false},
+		{`metaData.tags contains "lead"`, true},
+		{`metaData.tags contains "office"`, false},
+		{`metaData.dept in ["ops", "sales"]`, true},
+		{`metaData.dept in []`, false},
+		{`metaData.level in [1, 2, 3]`, true},
+		// missing fields
+		{`metaData.missing == null`, true},
+		{`metaData.missing != "x"`, true},
+		{`metaData.missing == "x"`, false},
+		{`metaData.missing < 1`, false},
+		{`metaData.missing in ["x"]`, false},
+		{`metaData.dept.sub == null`, true},
+		{`metaData.dept != null`, true},
+		// precedence: NOT before AND before OR
+		{`name == "ann" OR name == "bob" AND email == "x"`, true},
+		{`(name == "ann" OR name == "bob") AND email == "x"`, false},
+		{`NOT name == "ann" AND name == "bob"`, false},
+		{`NOT (name == "ann" AND name == "bob")`, true},
+		{`not not name == "ann"`, true},
+		{`name == "bob" and email == "x" or name == "ann"`, true},
+		{`NOT metaData.level IN [3] OR metaData.dept == "sales"`, true},
+	} {
+		r, err := rule.Parse(c.src)
+		if err != nil {
+			t.Errorf("Parse(%q): %v", c.src, err)
+			continue
+		}
+		if got := r.Match(u); got != c.want {
+			t.Errorf("%s: Match = %v, want %v", c.src, got, c.want)
+		}
+	}
+}
+
+func TestFilter(t *testing.T) {
+	users := []user.User{{Name: "ann"}, {Name: "bob"}, {Name: "cid"}}
+	r := rule.MustParse(`name in ["ann", "cid"]`)
+	got := r.Filter(users)
+	if len(got) != 2 || got[0].Name != "ann" || got[1].Name != "cid" {
+		t.Errorf("Filter = %+v", got)
+	}
+	if r.String() != `name in ["ann", "cid"]` {
+		t.Errorf("String = %q", r.String())
+	}
+}
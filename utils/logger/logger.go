@@ -1,6 +1,7 @@
 package logger
 
 import (
+	"fmt"
 	"io"
 	"os"
 	"sync"
@@ -10,19 +11,30 @@ import (
 
 type myLogger struct {
 	sync.Mutex
-	l *logrus.Logger
+	l    *logrus.Logger
+	file *lazyFile
 }
 
 var lo = myLogger{}
 
-func NewLogger() *logrus.Logger {
+// DefaultFile is the file written besides stdout until Configure is called
+const DefaultFile = "log.json"
 
-	logFile, err := os.Create("log.json")
-	multi := io.MultiWriter(os.Stdout)
-	if err == nil {
-		multi = io.MultiWriter(logFile, os.Stdout)
-	}
+// Config configures the logger, see Configure
+type Config struct {
+	// Level is the logrus level: panic, fatal, error, warn, info, debug or
+	// trace. Empty means info.
+	Level string
+	// File is the file the logs are appended to besides Console, they are
+	// only written to Console when empty
+	File string
+	// Console is written to besides File, nil means os.Stdout
+	Console io.Writer
+	// Format is text or json, empty means text
+	Format string
+}
 
+func NewLogger() *logrus.Logger {
 	if lo.l == nil {
 		lo.Lock()
 		defer lo.Unlock()
@@ -31,7 +43,8 @@ func NewLogger() *logrus.Logger {
 			l := &logrus.Logger{}
 			l.SetFormatter(&logrus.TextFormatter{})
 
-			l.SetOutput(multi)
+			lo.file = &lazyFile{path: DefaultFile}
+			l.SetOutput(io.MultiWriter(lo.file, os.Stdout))
 			l.SetLevel(logrus.InfoLevel)
 			lo.l = l
 		}
@@ -45,3 +58,78 @@ func GetLogger() *logrus.Logger {
 	}
 	return lo.l
 }
+
+// Configure sets the level, file and format of the logger returned by
+// GetLogger. The previous file is closed.
+func Configure(cfg Config) error {
+	level := logrus.InfoLevel
+	if cfg.Level != "" {
+		var err error
+		if level, err = logrus.ParseLevel(cfg.Level); err != nil {
+			return err
+		}
+	}
+	var formatter logrus.Formatter
+	switch cfg.Format {
+	case "", "text":
+		formatter = &logrus.TextFormatter{}
+	case "json":
+		formatter = &logrus.JSONFormatter{}
+	default:
+		return fmt.Errorf("unknown log format %q, expected text or json", cfg.Format)
+	}
+	console := cfg.Console
+	if console == nil {
+		console = os.Stdout
+	}
+	l := GetLogger()
+	lo.Lock()
+	defer lo.Unlock()
+	old := lo.file
+	if cfg.File == "" {
+		lo.file = nil
+		l.SetOutput(console)
+	} else {
+		lo.file = &lazyFile{path: cfg.File}
+		l.SetOutput(io.MultiWriter(lo.file, console))
+	}
+	l.SetFormatter(formatter)
+	l.SetLevel(level)
+	if old != nil {
+		return old.Close()
+	}
+	return nil
+}
+
+// lazyFile creates the file on the first write, so that no file is left
+// behind by a logger configured before logging anything
+type lazyFile struct {
+	mu   sync.Mutex
+	path string
+	f    *os.File
+	err  error
+}
+
+func (lf *lazyFile) Write(p []byte) (int, error) {
+	lf.mu.Lock()
+	defer lf.mu.Unlock()
+	if lf.f == nil && lf.err == nil {
+		lf.f, lf.err = os.OpenFile(lf.path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0o644)
+	}
+	if lf.err != nil {
+		// the logs still go to stdout
+		return len(p), nil
+	}
+	return lf.f.Write(p)
+}
+
+func (lf *lazyFile) Close() error {
+	lf.mu.Lock()
+	defer lf.mu.Unlock()
+	var err error
+	if lf.f != nil {
+		err = lf.f.Close()
+	}
+	lf.f, lf.err = nil, os.ErrClosed
+	return err
+}
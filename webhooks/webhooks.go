@@ -0,0 +1,313 @@
+// Package webhooks posts the membership and access events of package events
+// to the webhook subscriptions of a backend.
+//
+// A Dispatcher enqueues a delivery per event and matching subscription when
+// registered with an events.Consumer, and Run posts the pending deliveries:
+//
+//	d := webhooks.New(backend.Webhooks(), webhooks.Config{})
+//	c := events.NewConsumer(events.Poll(backend.Outbox(), time.Second))
+//	d.Register(c)
+//	go c.Run(ctx, "", nil)
+//	err := d.Run(ctx)
+//
+// A delivery is a POST of the JSON event with the headers
+//
+//	X-Webhook-Delivery   id of the delivery, the same for every attempt
+//	X-Webhook-Event      type of the event
+//	X-Webhook-Timestamp  unix seconds of the attempt
+//	X-Webhook-Signature  sha256=<hex HMAC-SHA256 of "<timestamp>.<body>" keyed with the secret>
+//
+// Receivers check the signature with Verify. A 2xx response marks the
+// delivery delivered; other responses and errors are retried with exponential
+// backoff until Config.MaxAttempts, then the delivery is kept as Dead.
+// Deliveries are unique per subscription and event, so replaying the outbox
+// does not post an event twice, and a dispatcher claims the deliveries it
+// posts, so that every replica of a service can run one.
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/sr-codefreak/user-group/db/mongodb/event"
+	"github.com/sr-codefreak/user-group/db/mongodb/webhook"
+	"github.com/sr-codefreak/user-group/events"
+	"github.com/sr-codefreak/user-group/myerrors"
+	"github.com/sr-codefreak/user-group/utils/logger"
+)
+
+var log = logger.GetLogger()
+
+// The headers of a delivery
+const (
+	DeliveryHeader  = "X-Webhook-Delivery"
+	EventHeader     = "X-Webhook-Event"
+	TimestampHeader = "X-Webhook-Timestamp"
+	SignatureHeader = "X-Webhook-Signature"
+)
+
+// Config tunes the delivery of a Dispatcher, zero fields take their defaults
+type Config struct {
+	// Client posts the deliveries, defaults to a client with a 10s timeout
+	Client *http.Client
+	// MaxAttempts before a delivery is dead, defaults to 8
+	MaxAttempts int
+	// Backoff is the delay before the first retry, doubled for every
+	// further retry up to MaxBackoff. Defaults to 30s and 1h.
+	Backoff    time.Duration
+	MaxBackoff time.Duration
+	// PollInterval is the time between two lookups of due deliveries, defaults to 1s
+	PollInterval time.Duration
+	// Lease is the time the deliveries claimed by a dispatcher are kept from
+	// the others, it must exceed the time to post claimBatch of them.
+	// Defaults to 5m.
+	Lease time.Duration
+}
+
+// claimBatch is the number of due deliveries claimed at a time
+const claimBatch = 10
+
+func (c Config) withDefaults() Config {
+	if c.Client == nil {
+		c.Client = &http.Client{Timeout: 10 * time.Second}
+	}
+	if c.MaxAttempts <= 0 {
+		c.MaxAttempts = 8
+	}
+	if c.Backoff <= 0 {
+		c.Backoff = 30 * time.Second
+	}
+	if c.MaxBackoff <= 0 {
+		c.MaxBackoff = time.Hour
+	}
+	if c.PollInterval <= 0 {
+		c.PollInterval = time.Second
+	}
+	if c.Lease <= 0 {
+		c.Lease = 5 * time.Minute
+	}
+	return c
+}
+
+// Dispatcher enqueues and posts the deliveries of the subscriptions of a store
+type Dispatcher struct {
+	store webhook.WebhookStore
+	cfg   Config
+	wake  chan struct{}
+}
+
+// New returns the dispatcher of the subscriptions of store
+func New(store webhook.WebhookStore, cfg Config) *Dispatcher {
+	return &Dispatcher{store: store, cfg: cfg.withDefaults(), wake: make(chan struct{}, 1)}
+}
+
+// Register makes the consumer enqueue its events with the dispatcher
+func (d *Dispatcher) Register(c *events.Consumer) {
+	c.HandleAll(d.Enqueue)
+}
+
+// Enqueue adds a pending delivery of the event for every subscription matching it.
+// Events already enqueued for a subscription are skipped.
+func (d *Dispatcher) Enqueue(ctx context.Context, e event.Event) error {
+	subs, err := d.store.List(ctx)
+	if err != nil {
+		return err
+	}
+	var payload []byte
+	enqueued := false
+	for i := range subs {
+		if !subs[i].Matches(&e) {
+			continue
+		}
+		if payload == nil {
+			if payload, err = json.Marshal(e); err != nil {
+				return myerrors.Wrap(myerrors.ErrEnqueuingDelivery, myerrors.KindWebhook, subs[i].ID.Hex(), err)
+			}
+		}
+		err := d.store.Enqueue(ctx, &webhook.Delivery{
+			SubscriptionId: subs[i].ID.Hex(),
+			EventId:        e.ID.Hex(),
+			EventType:      e.Type,
+			Payload:        string(payload),
+			Status:         webhook.Pending,
+			NextAttempt:    time.Now().UTC(),
+		})
+		if errors.Is(err, myerrors.ErrAlreadyExists) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		enqueued = true
+	}
+	if enqueued {
+		select {
+		case d.wake <- struct{}{}:
+		default:
+		}
+	}
+	return nil
+}
+
+// Run posts the due deliveries until ctx is done and returns ctx.Err().
+// Several dispatchers may run on a store, each delivery is claimed by one of
+// them for Config.Lease while it is posted.
+func (d *Dispatcher) Run(ctx context.Context) error {
+	ticker := time.NewTicker(d.cfg.PollInterval)
+	defer ticker.Stop()
+	for {
+		if _, err := d.DeliverDue(ctx); err != nil && ctx.Err() == nil {
+			log.Errorf("delivering webhooks: %s", err)
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		case <-d.wake:
+		}
+	}
+}
+
+// DeliverDue claims and attempts the deliveries due now once and returns how
+// many it attempted
+func (d *Dispatcher) DeliverDue(ctx context.Context) (int, error) {
+	attempted := 0
+	for {
+		now := time.Now().UTC()
+		due, err := d.store.Claim(ctx, now, now.Add(d.cfg.Lease), claimBatch)
+		if err != nil || len(due) == 0 {
+			return attempted, err
+		}
+		subs := map[string]*webhook.Subscription{}
+		for i := range due {
+			sub, ok := subs[due[i].SubscriptionId]
+			if !ok {
+				sub, err = d.store.Get(ctx, due[i].SubscriptionId)
+				if err != nil && !errors.Is(err, myerrors.ErrNotFound) {
+					return attempted, err
+				}
+				subs[due[i].SubscriptionId] = sub
+			}
+			if err := d.deliver(ctx, sub, &due[i]); err != nil {
+				return attempted, err
+			}
+			attempted++
+		}
+		if len(due) < claimBatch {
+			return attempted, nil
+		}
+	}
+}
+
+// deliver posts the delivery once and records the attempt, sub is nil when
+// the subscription was deleted
+func (d *Dispatcher) deliver(ctx context.Context, sub *webhook.Subscription, delivery *webhook.Delivery) error {
+	attempt := webhook.Attempt{Time: time.Now().UTC()}
+	switch {
+	case sub == nil:
+		attempt.Error = "subscription deleted"
+	case sub.Disabled:
+		attempt.Error = "subscription disabled"
+	default:
+		attempt.StatusCode, attempt.Error = d.post(ctx, sub, delivery, attempt.Time)
+	}
+	if ctx.Err() != nil {
+		// shutting down, the delivery is attempted again once its claim expires
+		return ctx.Err()
+	}
+	delivery.Attempts = append(delivery.Attempts, attempt)
+	switch {
+	case attempt.Error == "":
+		delivery.Status = webhook.Delivered
+	case sub == nil || sub.Disabled || len(delivery.Attempts) >= d.cfg.MaxAttempts:
+		delivery.Status = webhook.Dead
+	default:
+		delivery.NextAttempt = attempt.Time.Add(d.backoff(len(delivery.Attempts)))
+	}
+	return d.store.UpdateDelivery(ctx, delivery)
+}
+
+// backoff returns the delay after the failed attempt n, counted from 1
+func (d *Dispatcher) backoff(n int) time.Duration {
+	delay := d.cfg.Backoff
+	for i := 1; i < n && delay < d.cfg.MaxBackoff; i++ {
+		delay *= 2
+	}
+	if delay > d.cfg.MaxBackoff {
+		delay = d.cfg.MaxBackoff
+	}
+	return delay
+}
+
+// post sends the delivery and returns the response status and the error
+// message of a failed attempt, empty on success
+func (d *Dispatcher) post(ctx context.Context, sub *webhook.Subscription, delivery *webhook.Delivery, now time.Time) (int, string) {
+	body := []byte(delivery.Payload)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, sub.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, err.Error()
+	}
+	timestamp := now.Unix()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(DeliveryHeader, delivery.ID.Hex())
+	req.Header.Set(EventHeader, string(delivery.EventType))
+	req.Header.Set(TimestampHeader, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(SignatureHeader, Sign(sub.Secret, timestamp, body))
+	resp, err := d.cfg.Client.Do(req)
+	if err != nil {
+		return 0, err.Error()
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp.StatusCode, fmt.Sprintf("unexpected status %s", resp.Status)
+	}
+	return resp.StatusCode, ""
+}
+
+// Sign returns the value of the X-Webhook-Signature header of the body sent at timestamp
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify reports whether the signature and timestamp headers of a delivery
+// match the body and the timestamp is at most tolerance away from now.
+// A zero tolerance does not check the timestamp.
+func Verify(secret string, header http.Header, body []byte, tolerance time.Duration) bool {
+	timestamp, err := strconv.ParseInt(header.Get(TimestampHeader), 10, 64)
+	if err != nil {
+		return false
+	}
+	if tolerance > 0 {
+		skew := time.Since(time.Unix(timestamp, 0))
+		if skew > tolerance || skew < -tolerance {
+			return false
+		}
+	}
+	expected := Sign(secret, timestamp, body)
+	return hmac.Equal([]byte(expected), []byte(header.Get(SignatureHeader)))
+}
+
+// NewSecret returns a random secret to sign the deliveries of a subscription
+func NewSecret() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
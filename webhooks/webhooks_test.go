@@ -0,0 +1,234 @@
+package webhooks_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/sr-codefreak/user-group/db/memory"
+	"github.com/sr-codefreak/user-group/db/mongodb/event"
+	"github.com/sr-codefreak/user-group/db/mongodb/webhook"
+	"github.com/sr-codefreak/user-group/webhooks"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const secret = "s3cret"
+
+// receiver records the events it accepts, it answers status to the others
+type receiver struct {
+	t      *testing.T
+	status int
+
+	mu       sync.Mutex
+	received []event.Event
+	calls    int
+}
+
+func (rc *receiver) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		rc.t.Error(err)
+	}
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	rc.calls++
+	if !webhooks.Verify(secret, r.Header, body, time.Minute) {
+		rc.t.Errorf("delivery %s has an invalid signature", r.Header.Get(webhooks.DeliveryHeader))
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+	if rc.status != 0 {
+		w.WriteHeader(rc.status)
+		return
+	}
+	var e event.Event
+	if err := json.Unmarshal(body, &e); err != nil {
+		rc.t.Error(err)
+	}
+	if got := r.Header.Get(webhooks.EventHeader); got != string(e.Type) {
+		rc.t.Errorf("event header %q, want %q", got, e.Type)
+	}
+	rc.received = append(rc.received, e)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// subscribe returns the webhook store with a subscription to the receiver
+func subscribe(t *testing.T, rc *receiver) (webhook.WebhookStore, *webhook.Subscription) {
+	srv := httptest.NewServer(rc)
+	t.Cleanup(srv.Close)
+	store := memory.New().Webhooks()
+	sub := &webhook.Subscription{URL: srv.URL, Secret: secret}
+	if err := store.Create(context.Background(), sub); err != nil {
+		t.Fatal(err)
+	}
+	return store, sub
+}
+
+func deliveries(t *testing.T, store webhook.WebhookStore, sub *webhook.Subscription) []webhook.Delivery {
+	t.Helper()
+	ds, err := store.ListDeliveries(context.Background(), webhook.DeliveryQuery{SubscriptionId: sub.ID.Hex()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return ds
+}
+
+func memberAdded() event.Event {
+	return event.Event{
+		ID:          primitive.NewObjectID(),
+		Type:        event.MemberAdded,
+		Time:        time.Now().UTC(),
+		UserId:      primitive.NewObjectID().Hex(),
+		UserGroupId: primitive.NewObjectID().Hex(),
+	}
+}
+
+func TestDeliver(t *testing.T) {
+	ctx := context.Background()
+	rc := &receiver{t: t}
+	store, sub := subscribe(t, rc)
+	d := webhooks.New(store, webhooks.Config{})
+
+	e := memberAdded()
+	// a replayed event is enqueued once
+	for i := 0; i < 2; i++ {
+		if err := d.Enqueue(ctx, e); err != nil {
+			t.Fatal(err)
+		}
+	}
+	n, err := d.DeliverDue(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != 1 {
+		t.Errorf("attempted %d deliveries, want 1", n)
+	}
+	if len(rc.received) != 1 || rc.received[0].ID != e.ID || rc.received[0].UserId != e.UserId {
+		t.Errorf("received %+v", rc.received)
+	}
+	ds := deliveries(t, store, sub)
+	if len(ds) != 1 || ds[0].Status != webhook.Delivered || len(ds[0].Attempts) != 1 || ds[0].Attempts[0].StatusCode != http.StatusNoContent {
+		t.Errorf("deliveries = %+v", ds)
+	}
+}
+
+func TestConcurrentDispatchers(t *testing.T) {
+	ctx := context.Background()
+	rc := &receiver{t: t}
+	store, _ := subscribe(t, rc)
+	dispatchers := []*webhooks.Dispatcher{
+		webhooks.New(store, webhooks.Config{}),
+		webhooks.New(store, webhooks.Config{}),
+	}
+	const events = 25
+	for i := 0; i < events; i++ {
+		if err := dispatchers[0].Enqueue(ctx, memberAdded()); err != nil {
+			t.Fatal(err)
+		}
+	}
+	var wg sync.WaitGroup
+	attempted := make([]int, len(dispatchers))
+	for i, d := range dispatchers {
+		wg.Add(1)
+		go func(i int, d *webhooks.Dispatcher) {
+			defer wg.Done()
+			n, err := d.DeliverDue(ctx)
+			if err != nil {
+				t.Error(err)
+			}
+			attempted[i] = n
+		}(i, d)
+	}
+	wg.Wait()
+	if attempted[0]+attempted[1] != events {
+		t.Errorf("attempted %v deliveries, want %d in all", attempted, events)
+	}
+	if rc.calls != events {
+		t.Errorf("received %d posts, want %d", rc.calls, events)
+	}
+}
+
+func TestVerify(t *testing.T) {
+	body := []byte(`{"type":"member.added"}`)
+	now := time.Now().Unix()
+	for _, c := range []struct {
+		name      string
+		secret    string
+		timestamp int64
+		body      []byte
+		want      bool
+	}{
+		{"valid", secret, now, body, true},
+		{"other secret", "other", now, body, false},
+		{"altered body", secret, now, []byte(`{"type":"member.removed"}`), false},
+		{"stale", secret, now - 3600, body, false},
+	} {
+		header := http.Header{}
+		header.Set(webhooks.TimestampHeader, strconv.FormatInt(c.timestamp, 10))
+		header.Set(webhooks.SignatureHeader, webhooks.Sign(c.secret, c.timestamp, c.body))
+		if got := webhooks.Verify(secret, header, body, time.Minute); got != c.want {
+			t.Errorf("%s: Verify = %v, want %v", c.name, got, c.want)
+		}
+	}
+}
+
+func TestBackoffAndDeadLetter(t *testing.T) {
+	ctx := context.Background()
+	rc := &receiver{t: t, status: http.StatusServiceUnavailable}
+	store, sub := subscribe(t, rc)
+	d := webhooks.New(store, webhooks.Config{MaxAttempts: 3, Backoff: 100 * time.Millisecond, MaxBackoff: 150 * time.Millisecond})
+	if err := d.Enqueue(ctx, memberAdded()); err != nil {
+		t.Fatal(err)
+	}
+
+	// the delay doubles from Backoff up to MaxBackoff
+	wantDelays := []time.Duration{100 * time.Millisecond, 150 * time.Millisecond}
+	for attempt := 1; attempt <= 3; attempt++ {
+		n, err := d.DeliverDue(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if n != 1 {
+			t.Fatalf("attempt %d: attempted %d deliveries, want 1", attempt, n)
+		}
+		ds := deliveries(t, store, sub)
+		if len(ds) != 1 || len(ds[0].Attempts) != attempt {
+			t.Fatalf("attempt %d: deliveries = %+v", attempt, ds)
+		}
+		last := ds[0].Attempts[attempt-1]
+		if last.StatusCode != http.StatusServiceUnavailable || last.Error == "" {
+			t.Errorf("attempt %d = %+v", attempt, last)
+		}
+		if attempt == 3 {
+			if ds[0].Status != webhook.Dead {
+				t.Errorf("status after %d attempts = %s, want dead", attempt, ds[0].Status)
+			}
+			break
+		}
+		if ds[0].Status != webhook.Pending {
+			t.Errorf("status after attempt %d = %s, want pending", attempt, ds[0].Status)
+		}
+		if delay := ds[0].NextAttempt.Sub(last.Time); delay != wantDelays[attempt-1] {
+			t.Errorf("delay after attempt %d = %s, want %s", attempt, delay, wantDelays[attempt-1])
+		}
+		// not due before the delay
+		if n, err := d.DeliverDue(ctx); err != nil || n != 0 {
+			t.Errorf("attempted %d deliveries before the delay, %v", n, err)
+		}
+		time.Sleep(time.Until(ds[0].NextAttempt))
+	}
+
+	// a dead delivery is not attempted again
+	if n, err := d.DeliverDue(ctx); err != nil || n != 0 {
+		t.Errorf("attempted %d dead deliveries, %v", n, err)
+	}
+	if rc.calls != 3 {
+		t.Errorf("receiver called %d times, want 3", rc.calls)
+	}
+}